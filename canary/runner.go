@@ -51,7 +51,11 @@ func NewCanaryRunner(cfg *Config) (Runnable, error) {
 		cfg.Cadence.HostNameAndPort = ServiceHostPort
 	}
 
-	connection, err := rpc.Dial(cfg.Cadence.HostNameAndPort)
+	transportCredentials, err := rpc.NewClientCredentials(cfg.Cadence.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TLS config: %v", err)
+	}
+	connection, err := rpc.Dial(cfg.Cadence.HostNameAndPort, transportCredentials)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection: %v", err)
 	}
@@ -59,6 +63,7 @@ func NewCanaryRunner(cfg *Config) (Runnable, error) {
 		logger,
 		metricsScope,
 		cfg.Cadence.HostNameAndPort,
+		rpc.NewSDKGRPCDialer(transportCredentials),
 		workflowservice.NewWorkflowServiceClient(connection),
 	)
 
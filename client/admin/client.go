@@ -283,6 +283,125 @@ func (c *clientImpl) RefreshWorkflowTasks(
 	return client.RefreshWorkflowTasks(ctx, request, opts...)
 }
 
+func (c *clientImpl) DescribeLogLevels(
+	ctx context.Context,
+	request *adminservice.DescribeLogLevelsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeLogLevelsResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeLogLevels(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateLogLevel(
+	ctx context.Context,
+	request *adminservice.UpdateLogLevelRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateLogLevelResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateLogLevel(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeNamespaceQuotas(
+	ctx context.Context,
+	request *adminservice.DescribeNamespaceQuotasRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeNamespaceQuotasResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeNamespaceQuotas(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateNamespaceQuotas(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceQuotasRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateNamespaceQuotasResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateNamespaceQuotas(ctx, request, opts...)
+}
+
+func (c *clientImpl) CaptureProfile(
+	ctx context.Context,
+	request *adminservice.CaptureProfileRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.CaptureProfileResponse, error) {
+	var client adminservice.AdminServiceClient
+	var err error
+	if request.GetService() == common.FrontendServiceName && request.GetHostAddress() != "" {
+		client, err = c.getClientForHost(request.GetHostAddress())
+	} else {
+		client, err = c.getRandomClient()
+	}
+	if err != nil {
+		return nil, err
+	}
+	// the call lasts for the duration of the profile on top of the usual timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout+time.Duration(request.GetDurationInSeconds())*time.Second)
+	defer cancel()
+	return client.CaptureProfile(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetHostDiagnostics(
+	ctx context.Context,
+	request *adminservice.GetHostDiagnosticsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetHostDiagnosticsResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetHostDiagnostics(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeShardQueues(
+	ctx context.Context,
+	request *adminservice.DescribeShardQueuesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeShardQueuesResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeShardQueues(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeTaskList(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskListResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeTaskList(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
@@ -297,3 +416,12 @@ func (c *clientImpl) getRandomClient() (adminservice.AdminServiceClient, error)
 
 	return client.(adminservice.AdminServiceClient), nil
 }
+
+func (c *clientImpl) getClientForHost(hostAddress string) (adminservice.AdminServiceClient, error) {
+	client, err := c.clients.GetClientForClientKey(hostAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.(adminservice.AdminServiceClient), nil
+}
@@ -327,3 +327,139 @@ func (c *metricClient) RefreshWorkflowTasks(
 	}
 	return resp, err
 }
+
+func (c *metricClient) DescribeLogLevels(
+	ctx context.Context,
+	request *adminservice.DescribeLogLevelsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeLogLevelsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeLogLevelsScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeLogLevelsScope, metrics.ClientLatency)
+	resp, err := c.client.DescribeLogLevels(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeLogLevelsScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UpdateLogLevel(
+	ctx context.Context,
+	request *adminservice.UpdateLogLevelRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateLogLevelResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUpdateLogLevelScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUpdateLogLevelScope, metrics.ClientLatency)
+	resp, err := c.client.UpdateLogLevel(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUpdateLogLevelScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DescribeNamespaceQuotas(
+	ctx context.Context,
+	request *adminservice.DescribeNamespaceQuotasRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeNamespaceQuotasResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeNamespaceQuotasScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeNamespaceQuotasScope, metrics.ClientLatency)
+	resp, err := c.client.DescribeNamespaceQuotas(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeNamespaceQuotasScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UpdateNamespaceQuotas(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceQuotasRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateNamespaceQuotasResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUpdateNamespaceQuotasScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUpdateNamespaceQuotasScope, metrics.ClientLatency)
+	resp, err := c.client.UpdateNamespaceQuotas(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUpdateNamespaceQuotasScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) CaptureProfile(
+	ctx context.Context,
+	request *adminservice.CaptureProfileRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.CaptureProfileResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientCaptureProfileScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientCaptureProfileScope, metrics.ClientLatency)
+	resp, err := c.client.CaptureProfile(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientCaptureProfileScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetHostDiagnostics(
+	ctx context.Context,
+	request *adminservice.GetHostDiagnosticsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetHostDiagnosticsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientGetHostDiagnosticsScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientGetHostDiagnosticsScope, metrics.ClientLatency)
+	resp, err := c.client.GetHostDiagnostics(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientGetHostDiagnosticsScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DescribeShardQueues(
+	ctx context.Context,
+	request *adminservice.DescribeShardQueuesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeShardQueuesResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeShardQueuesScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeShardQueuesScope, metrics.ClientLatency)
+	resp, err := c.client.DescribeShardQueues(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeShardQueuesScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DescribeTaskList(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskListResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeTaskListScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeTaskListScope, metrics.ClientLatency)
+	resp, err := c.client.DescribeTaskList(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeTaskListScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
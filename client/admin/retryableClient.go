@@ -301,3 +301,131 @@ func (c *retryableClient) RefreshWorkflowTasks(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) DescribeLogLevels(
+	ctx context.Context,
+	request *adminservice.DescribeLogLevelsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeLogLevelsResponse, error) {
+
+	var resp *adminservice.DescribeLogLevelsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeLogLevels(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateLogLevel(
+	ctx context.Context,
+	request *adminservice.UpdateLogLevelRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateLogLevelResponse, error) {
+
+	var resp *adminservice.UpdateLogLevelResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateLogLevel(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeNamespaceQuotas(
+	ctx context.Context,
+	request *adminservice.DescribeNamespaceQuotasRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeNamespaceQuotasResponse, error) {
+
+	var resp *adminservice.DescribeNamespaceQuotasResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeNamespaceQuotas(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateNamespaceQuotas(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceQuotasRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateNamespaceQuotasResponse, error) {
+
+	var resp *adminservice.UpdateNamespaceQuotasResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateNamespaceQuotas(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) CaptureProfile(
+	ctx context.Context,
+	request *adminservice.CaptureProfileRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.CaptureProfileResponse, error) {
+
+	var resp *adminservice.CaptureProfileResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.CaptureProfile(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetHostDiagnostics(
+	ctx context.Context,
+	request *adminservice.GetHostDiagnosticsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetHostDiagnosticsResponse, error) {
+
+	var resp *adminservice.GetHostDiagnosticsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetHostDiagnostics(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeShardQueues(
+	ctx context.Context,
+	request *adminservice.DescribeShardQueuesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeShardQueuesResponse, error) {
+
+	var resp *adminservice.DescribeShardQueuesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeShardQueues(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeTaskList(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskListResponse, error) {
+
+	var resp *adminservice.DescribeTaskListResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeTaskList(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
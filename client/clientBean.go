@@ -51,6 +51,8 @@ type (
 		SetRemoteAdminClient(cluster string, client admin.Client)
 		GetRemoteFrontendClient(cluster string) frontend.Client
 		SetRemoteFrontendClient(cluster string, client frontend.Client)
+		GetFrontendAdminClient() admin.Client
+		SetFrontendAdminClient(client admin.Client)
 	}
 
 	clientBeanImpl struct {
@@ -60,6 +62,7 @@ type (
 		matchingClient        atomic.Value
 		remoteAdminClients    map[string]admin.Client
 		remoteFrontendClients map[string]frontend.Client
+		frontendAdminClient   admin.Client
 		factory               Factory
 	}
 )
@@ -72,6 +75,11 @@ func NewClientBean(factory Factory, clusterMetadata cluster.Metadata) (Bean, err
 		return nil, err
 	}
 
+	frontendAdminClient, err := factory.NewFrontendAdminClientWithTimeout(admin.DefaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+
 	remoteAdminClients := map[string]admin.Client{}
 	remoteFrontendClients := map[string]frontend.Client{}
 
@@ -107,6 +115,7 @@ func NewClientBean(factory Factory, clusterMetadata cluster.Metadata) (Bean, err
 		historyClient:         historyClient,
 		remoteAdminClients:    remoteAdminClients,
 		remoteFrontendClients: remoteFrontendClients,
+		frontendAdminClient:   frontendAdminClient,
 	}, nil
 }
 
@@ -181,6 +190,16 @@ func (h *clientBeanImpl) SetRemoteFrontendClient(
 	h.remoteFrontendClients[cluster] = client
 }
 
+func (h *clientBeanImpl) GetFrontendAdminClient() admin.Client {
+	return h.frontendAdminClient
+}
+
+func (h *clientBeanImpl) SetFrontendAdminClient(
+	client admin.Client,
+) {
+	h.frontendAdminClient = client
+}
+
 func (h *clientBeanImpl) lazyInitMatchingClient(namespaceIDToName NamespaceIDToNameFunc) (matching.Client, error) {
 	h.Lock()
 	defer h.Unlock()
@@ -191,3 +191,29 @@ func (mr *MockBeanMockRecorder) SetRemoteFrontendClient(cluster, client interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRemoteFrontendClient", reflect.TypeOf((*MockBean)(nil).SetRemoteFrontendClient), cluster, client)
 }
+
+// GetFrontendAdminClient mocks base method.
+func (m *MockBean) GetFrontendAdminClient() admin.Client {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFrontendAdminClient")
+	ret0, _ := ret[0].(admin.Client)
+	return ret0
+}
+
+// GetFrontendAdminClient indicates an expected call of GetFrontendAdminClient.
+func (mr *MockBeanMockRecorder) GetFrontendAdminClient() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFrontendAdminClient", reflect.TypeOf((*MockBean)(nil).GetFrontendAdminClient))
+}
+
+// SetFrontendAdminClient mocks base method.
+func (m *MockBean) SetFrontendAdminClient(client admin.Client) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetFrontendAdminClient", client)
+}
+
+// SetFrontendAdminClient indicates an expected call of SetFrontendAdminClient.
+func (mr *MockBeanMockRecorder) SetFrontendAdminClient(client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFrontendAdminClient", reflect.TypeOf((*MockBean)(nil).SetFrontendAdminClient), client)
+}
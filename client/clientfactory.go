@@ -58,6 +58,7 @@ type (
 		NewMatchingClientWithTimeout(namespaceIDToName NamespaceIDToNameFunc, timeout time.Duration, longPollTimeout time.Duration) (matching.Client, error)
 		NewFrontendClientWithTimeout(rpcAddress string, timeout time.Duration, longPollTimeout time.Duration) (frontend.Client, error)
 		NewAdminClientWithTimeout(rpcAddress string, timeout time.Duration) (admin.Client, error)
+		NewFrontendAdminClientWithTimeout(timeout time.Duration) (admin.Client, error)
 	}
 
 	// NamespaceIDToNameFunc maps a namespaceID to namespace name. Returns error when mapping is not possible.
@@ -177,7 +178,7 @@ func (cf *rpcClientFactory) NewFrontendClientWithTimeout(
 	}
 
 	clientProvider := func(clientKey string) (interface{}, error) {
-		connection := cf.rpcFactory.CreateGRPCConnection(rpcAddress)
+		connection := cf.rpcFactory.CreateFrontendGRPCConnection(rpcAddress)
 		return workflowservice.NewWorkflowServiceClient(connection), nil
 	}
 
@@ -197,7 +198,37 @@ func (cf *rpcClientFactory) NewAdminClientWithTimeout(
 	}
 
 	clientProvider := func(clientKey string) (interface{}, error) {
-		connection := cf.rpcFactory.CreateGRPCConnection(rpcAddress)
+		connection := cf.rpcFactory.CreateFrontendGRPCConnection(rpcAddress)
+		return adminservice.NewAdminServiceClient(connection), nil
+	}
+
+	client := admin.NewClient(timeout, common.NewClientCache(keyResolver, clientProvider))
+	if cf.metricsClient != nil {
+		client = admin.NewMetricClient(client, cf.metricsClient)
+	}
+	return client, nil
+}
+
+// NewFrontendAdminClientWithTimeout creates an admin client for the frontend hosts of the
+// current cluster, which are resolved through the frontend membership ring
+func (cf *rpcClientFactory) NewFrontendAdminClientWithTimeout(
+	timeout time.Duration,
+) (admin.Client, error) {
+	resolver, err := cf.monitor.GetResolver(common.FrontendServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	keyResolver := func(key string) (string, error) {
+		host, err := resolver.Lookup(key)
+		if err != nil {
+			return "", err
+		}
+		return host.GetAddress(), nil
+	}
+
+	clientProvider := func(clientKey string) (interface{}, error) {
+		connection := cf.rpcFactory.CreateFrontendGRPCConnection(clientKey)
 		return adminservice.NewAdminServiceClient(connection), nil
 	}
 
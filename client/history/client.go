@@ -29,6 +29,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/opentracing/opentracing-go"
 	"go.temporal.io/temporal-proto/serviceerror"
 	"google.golang.org/grpc"
 
@@ -180,6 +181,85 @@ func (c *clientImpl) DescribeHistoryHost(
 	return response, nil
 }
 
+func (c *clientImpl) CaptureProfile(
+	ctx context.Context,
+	request *historyservice.CaptureProfileRequest,
+	opts ...grpc.CallOption) (*historyservice.CaptureProfileResponse, error) {
+
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	client := ret.(historyservice.HistoryServiceClient)
+
+	// the call lasts for the duration of the profile on top of the usual timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout+time.Duration(request.GetDurationInSeconds())*time.Second)
+	defer cancel()
+	return client.CaptureProfile(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetHostDiagnostics(
+	ctx context.Context,
+	request *historyservice.GetHostDiagnosticsRequest,
+	opts ...grpc.CallOption) (*historyservice.GetHostDiagnosticsResponse, error) {
+
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	client := ret.(historyservice.HistoryServiceClient)
+
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetHostDiagnostics(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateLogLevel(
+	ctx context.Context,
+	request *historyservice.UpdateLogLevelRequest,
+	opts ...grpc.CallOption) (*historyservice.UpdateLogLevelResponse, error) {
+
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	client := ret.(historyservice.HistoryServiceClient)
+
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateLogLevel(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeLogLevels(
+	ctx context.Context,
+	request *historyservice.DescribeLogLevelsRequest,
+	opts ...grpc.CallOption) (*historyservice.DescribeLogLevelsResponse, error) {
+
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	client := ret.(historyservice.HistoryServiceClient)
+
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeLogLevels(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeShardQueues(
+	ctx context.Context,
+	request *historyservice.DescribeShardQueuesRequest,
+	opts ...grpc.CallOption) (*historyservice.DescribeShardQueuesResponse, error) {
+
+	client, err := c.getClientForShardID(int(request.GetShardId()))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeShardQueues(ctx, request, opts...)
+}
+
 func (c *clientImpl) RemoveTask(
 	ctx context.Context,
 	request *historyservice.RemoveTaskRequest,
@@ -1063,6 +1143,9 @@ redirectLoop:
 		if err != nil {
 			if s, ok := err.(*serviceerror.ShardOwnershipLost); ok {
 				// TODO: consider emitting a metric for number of redirects
+				if span := opentracing.SpanFromContext(ctx); span != nil {
+					span.LogKV("event", "shard ownership lost", "owner", s.Owner)
+				}
 				ret, err := c.clients.GetClientForClientKey(s.Owner)
 				if err != nil {
 					return err
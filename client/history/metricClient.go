@@ -74,6 +74,51 @@ func (c *metricClient) DescribeHistoryHost(
 	return resp, err
 }
 
+func (c *metricClient) CaptureProfile(
+	context context.Context,
+	request *historyservice.CaptureProfileRequest,
+	opts ...grpc.CallOption) (*historyservice.CaptureProfileResponse, error) {
+	resp, err := c.client.CaptureProfile(context, request, opts...)
+
+	return resp, err
+}
+
+func (c *metricClient) GetHostDiagnostics(
+	context context.Context,
+	request *historyservice.GetHostDiagnosticsRequest,
+	opts ...grpc.CallOption) (*historyservice.GetHostDiagnosticsResponse, error) {
+	resp, err := c.client.GetHostDiagnostics(context, request, opts...)
+
+	return resp, err
+}
+
+func (c *metricClient) UpdateLogLevel(
+	context context.Context,
+	request *historyservice.UpdateLogLevelRequest,
+	opts ...grpc.CallOption) (*historyservice.UpdateLogLevelResponse, error) {
+	resp, err := c.client.UpdateLogLevel(context, request, opts...)
+
+	return resp, err
+}
+
+func (c *metricClient) DescribeLogLevels(
+	context context.Context,
+	request *historyservice.DescribeLogLevelsRequest,
+	opts ...grpc.CallOption) (*historyservice.DescribeLogLevelsResponse, error) {
+	resp, err := c.client.DescribeLogLevels(context, request, opts...)
+
+	return resp, err
+}
+
+func (c *metricClient) DescribeShardQueues(
+	context context.Context,
+	request *historyservice.DescribeShardQueuesRequest,
+	opts ...grpc.CallOption) (*historyservice.DescribeShardQueuesResponse, error) {
+	resp, err := c.client.DescribeShardQueues(context, request, opts...)
+
+	return resp, err
+}
+
 func (c *metricClient) RemoveTask(
 	context context.Context,
 	request *historyservice.RemoveTaskRequest,
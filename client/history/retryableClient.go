@@ -82,6 +82,86 @@ func (c *retryableClient) DescribeHistoryHost(
 	return resp, err
 }
 
+func (c *retryableClient) CaptureProfile(
+	ctx context.Context,
+	request *historyservice.CaptureProfileRequest,
+	opts ...grpc.CallOption) (*historyservice.CaptureProfileResponse, error) {
+
+	var resp *historyservice.CaptureProfileResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.CaptureProfile(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetHostDiagnostics(
+	ctx context.Context,
+	request *historyservice.GetHostDiagnosticsRequest,
+	opts ...grpc.CallOption) (*historyservice.GetHostDiagnosticsResponse, error) {
+
+	var resp *historyservice.GetHostDiagnosticsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetHostDiagnostics(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateLogLevel(
+	ctx context.Context,
+	request *historyservice.UpdateLogLevelRequest,
+	opts ...grpc.CallOption) (*historyservice.UpdateLogLevelResponse, error) {
+
+	var resp *historyservice.UpdateLogLevelResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateLogLevel(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeLogLevels(
+	ctx context.Context,
+	request *historyservice.DescribeLogLevelsRequest,
+	opts ...grpc.CallOption) (*historyservice.DescribeLogLevelsResponse, error) {
+
+	var resp *historyservice.DescribeLogLevelsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeLogLevels(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeShardQueues(
+	ctx context.Context,
+	request *historyservice.DescribeShardQueuesRequest,
+	opts ...grpc.CallOption) (*historyservice.DescribeShardQueuesResponse, error) {
+
+	var resp *historyservice.DescribeShardQueuesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeShardQueues(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) CloseShard(
 	ctx context.Context,
 	request *historyservice.CloseShardRequest,
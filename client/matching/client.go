@@ -28,6 +28,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/opentracing/opentracing-go"
 	"google.golang.org/grpc"
 
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
@@ -77,6 +78,7 @@ func (c *clientImpl) AddActivityTask(
 		request.GetForwardedFrom(),
 	)
 	request.TaskList.Name = partition
+	logPartition(ctx, partition)
 	client, err := c.getClientForTasklist(partition)
 	if err != nil {
 		return nil, err
@@ -97,6 +99,7 @@ func (c *clientImpl) AddDecisionTask(
 		request.GetForwardedFrom(),
 	)
 	request.TaskList.Name = partition
+	logPartition(ctx, partition)
 	client, err := c.getClientForTasklist(request.TaskList.GetName())
 	if err != nil {
 		return nil, err
@@ -117,6 +120,7 @@ func (c *clientImpl) PollForActivityTask(
 		request.GetForwardedFrom(),
 	)
 	request.PollRequest.TaskList.Name = partition
+	logPartition(ctx, partition)
 	client, err := c.getClientForTasklist(request.PollRequest.TaskList.GetName())
 	if err != nil {
 		return nil, err
@@ -137,6 +141,7 @@ func (c *clientImpl) PollForDecisionTask(
 		request.GetForwardedFrom(),
 	)
 	request.PollRequest.TaskList.Name = partition
+	logPartition(ctx, partition)
 	client, err := c.getClientForTasklist(request.PollRequest.TaskList.GetName())
 	if err != nil {
 		return nil, err
@@ -154,6 +159,7 @@ func (c *clientImpl) QueryWorkflow(ctx context.Context, request *matchingservice
 		request.GetForwardedFrom(),
 	)
 	request.TaskList.Name = partition
+	logPartition(ctx, partition)
 	client, err := c.getClientForTasklist(request.TaskList.GetName())
 	if err != nil {
 		return nil, err
@@ -183,6 +189,16 @@ func (c *clientImpl) CancelOutstandingPoll(ctx context.Context, request *matchin
 	return client.CancelOutstandingPoll(ctx, request, opts...)
 }
 
+func (c *clientImpl) ReleaseInFlightTask(ctx context.Context, request *matchingservice.ReleaseInFlightTaskRequest, opts ...grpc.CallOption) (*matchingservice.ReleaseInFlightTaskResponse, error) {
+	client, err := c.getClientForTasklist(request.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ReleaseInFlightTask(ctx, request, opts...)
+}
+
 func (c *clientImpl) DescribeTaskList(ctx context.Context, request *matchingservice.DescribeTaskListRequest, opts ...grpc.CallOption) (*matchingservice.DescribeTaskListResponse, error) {
 	client, err := c.getClientForTasklist(request.DescRequest.TaskList.GetName())
 	if err != nil {
@@ -203,6 +219,62 @@ func (c *clientImpl) ListTaskListPartitions(ctx context.Context, request *matchi
 	return client.ListTaskListPartitions(ctx, request, opts...)
 }
 
+func (c *clientImpl) CaptureProfile(ctx context.Context, request *matchingservice.CaptureProfileRequest, opts ...grpc.CallOption) (*matchingservice.CaptureProfileResponse, error) {
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	client := ret.(matchingservice.MatchingServiceClient)
+
+	// the call lasts for the duration of the profile on top of the usual timeout
+	ctx, cancel := context.WithTimeout(ctx, c.timeout+time.Duration(request.GetDurationInSeconds())*time.Second)
+	defer cancel()
+	return client.CaptureProfile(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetHostDiagnostics(ctx context.Context, request *matchingservice.GetHostDiagnosticsRequest, opts ...grpc.CallOption) (*matchingservice.GetHostDiagnosticsResponse, error) {
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	client := ret.(matchingservice.MatchingServiceClient)
+
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetHostDiagnostics(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateLogLevel(ctx context.Context, request *matchingservice.UpdateLogLevelRequest, opts ...grpc.CallOption) (*matchingservice.UpdateLogLevelResponse, error) {
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	client := ret.(matchingservice.MatchingServiceClient)
+
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateLogLevel(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeLogLevels(ctx context.Context, request *matchingservice.DescribeLogLevelsRequest, opts ...grpc.CallOption) (*matchingservice.DescribeLogLevelsResponse, error) {
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	client := ret.(matchingservice.MatchingServiceClient)
+
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeLogLevels(ctx, request, opts...)
+}
+
+// logPartition records the task list partition picked for the request on the span of the caller
+func logPartition(ctx context.Context, partition string) {
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span.LogKV("event", "task list partition picked", "partition", partition)
+	}
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
@@ -31,6 +31,7 @@ import (
 
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
@@ -122,6 +123,11 @@ func (lb *defaultLoadBalancer) pickPartition(
 		return taskList.GetName()
 	}
 
+	if strings.HasPrefix(taskList.GetName(), common.TaskListAffinityPrefix) {
+		// affinity task lists are never partitioned
+		return taskList.GetName()
+	}
+
 	namespace, err := lb.namespaceIDToName(namespaceID)
 	if err != nil {
 		return taskList.GetName()
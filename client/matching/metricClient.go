@@ -210,6 +210,24 @@ func (c *metricClient) CancelOutstandingPoll(
 	return resp, err
 }
 
+func (c *metricClient) ReleaseInFlightTask(
+	ctx context.Context,
+	request *matchingservice.ReleaseInFlightTaskRequest,
+	opts ...grpc.CallOption) (*matchingservice.ReleaseInFlightTaskResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientReleaseInFlightTaskScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientReleaseInFlightTaskScope, metrics.ClientLatency)
+	resp, err := c.client.ReleaseInFlightTask(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientReleaseInFlightTaskScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) DescribeTaskList(
 	ctx context.Context,
 	request *matchingservice.DescribeTaskListRequest,
@@ -246,6 +264,78 @@ func (c *metricClient) ListTaskListPartitions(
 	return resp, err
 }
 
+func (c *metricClient) CaptureProfile(
+	ctx context.Context,
+	request *matchingservice.CaptureProfileRequest,
+	opts ...grpc.CallOption) (*matchingservice.CaptureProfileResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientCaptureProfileScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientCaptureProfileScope, metrics.ClientLatency)
+	resp, err := c.client.CaptureProfile(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientCaptureProfileScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) GetHostDiagnostics(
+	ctx context.Context,
+	request *matchingservice.GetHostDiagnosticsRequest,
+	opts ...grpc.CallOption) (*matchingservice.GetHostDiagnosticsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientGetHostDiagnosticsScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientGetHostDiagnosticsScope, metrics.ClientLatency)
+	resp, err := c.client.GetHostDiagnostics(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientGetHostDiagnosticsScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) UpdateLogLevel(
+	ctx context.Context,
+	request *matchingservice.UpdateLogLevelRequest,
+	opts ...grpc.CallOption) (*matchingservice.UpdateLogLevelResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientUpdateLogLevelScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientUpdateLogLevelScope, metrics.ClientLatency)
+	resp, err := c.client.UpdateLogLevel(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientUpdateLogLevelScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) DescribeLogLevels(
+	ctx context.Context,
+	request *matchingservice.DescribeLogLevelsRequest,
+	opts ...grpc.CallOption) (*matchingservice.DescribeLogLevelsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientDescribeLogLevelsScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientDescribeLogLevelsScope, metrics.ClientLatency)
+	resp, err := c.client.DescribeLogLevels(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientDescribeLogLevelsScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) emitForwardedFromStats(scope int, forwardedFrom string, taskList *tasklistpb.TaskList) {
 	if taskList == nil {
 		return
@@ -162,6 +162,22 @@ func (c *retryableClient) CancelOutstandingPoll(
 	return resp, err
 }
 
+func (c *retryableClient) ReleaseInFlightTask(
+	ctx context.Context,
+	request *matchingservice.ReleaseInFlightTaskRequest,
+	opts ...grpc.CallOption) (*matchingservice.ReleaseInFlightTaskResponse, error) {
+
+	var resp *matchingservice.ReleaseInFlightTaskResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ReleaseInFlightTask(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) DescribeTaskList(
 	ctx context.Context,
 	request *matchingservice.DescribeTaskListRequest,
@@ -193,3 +209,67 @@ func (c *retryableClient) ListTaskListPartitions(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) CaptureProfile(
+	ctx context.Context,
+	request *matchingservice.CaptureProfileRequest,
+	opts ...grpc.CallOption) (*matchingservice.CaptureProfileResponse, error) {
+
+	var resp *matchingservice.CaptureProfileResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.CaptureProfile(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetHostDiagnostics(
+	ctx context.Context,
+	request *matchingservice.GetHostDiagnosticsRequest,
+	opts ...grpc.CallOption) (*matchingservice.GetHostDiagnosticsResponse, error) {
+
+	var resp *matchingservice.GetHostDiagnosticsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetHostDiagnostics(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateLogLevel(
+	ctx context.Context,
+	request *matchingservice.UpdateLogLevelRequest,
+	opts ...grpc.CallOption) (*matchingservice.UpdateLogLevelResponse, error) {
+
+	var resp *matchingservice.UpdateLogLevelResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateLogLevel(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeLogLevels(
+	ctx context.Context,
+	request *matchingservice.DescribeLogLevelsRequest,
+	opts ...grpc.CallOption) (*matchingservice.DescribeLogLevelsResponse, error) {
+
+	var resp *matchingservice.DescribeLogLevelsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeLogLevels(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
@@ -25,6 +25,7 @@
 package temporal
 
 import (
+	"fmt"
 	"log"
 	"time"
 
@@ -35,9 +36,11 @@ import (
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/audit"
 	"github.com/temporalio/temporal/common/authorization"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/elasticsearch"
+	"github.com/temporalio/temporal/common/lifecycle"
 	l "github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/log/tag"
@@ -47,6 +50,7 @@ import (
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
 	"github.com/temporalio/temporal/common/primitives"
 	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/common/rpc"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/config/ringpop"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
@@ -58,20 +62,24 @@ import (
 
 type (
 	server struct {
-		name   string
-		cfg    *config.Config
-		doneC  chan struct{}
-		daemon common.Daemon
+		name         string
+		cfg          *config.Config
+		logLevels    *config.LogLevels
+		recentErrors *config.RecentErrors
+		doneC        chan struct{}
+		daemon       common.Daemon
 	}
 )
 
 // newServer returns a new instance of a daemon
 // that represents a temporal service
-func newServer(service string, cfg *config.Config) common.Daemon {
+func newServer(service string, cfg *config.Config, logLevels *config.LogLevels, recentErrors *config.RecentErrors) common.Daemon {
 	return &server{
-		cfg:   cfg,
-		name:  service,
-		doneC: make(chan struct{}),
+		cfg:          cfg,
+		name:         service,
+		logLevels:    logLevels,
+		recentErrors: recentErrors,
+		doneC:        make(chan struct{}),
 	}
 }
 
@@ -108,7 +116,9 @@ func (s *server) startService() common.Daemon {
 
 	params := resource.BootstrapParams{}
 	params.Name = s.name
-	params.Logger = loggerimpl.NewLogger(s.cfg.Log.NewZapLogger())
+	params.Logger = loggerimpl.NewLogger(s.recentErrors.Wrap(s.name, s.cfg.Log.NewLeveledZapLogger(s.logLevels)))
+	params.LogLevels = s.logLevels
+	params.RecentErrors = s.recentErrors
 	params.PersistenceConfig = s.cfg.Persistence
 
 	params.DynamicConfig, err = dynamicconfig.NewFileBasedClient(&s.cfg.DynamicConfigClient, params.Logger.WithTags(tag.Service(params.Name)), s.doneC)
@@ -120,7 +130,11 @@ func (s *server) startService() common.Daemon {
 
 	svcCfg := s.cfg.Services[s.name]
 	params.MetricScope = svcCfg.Metrics.NewScope(params.Logger)
-	params.RPCFactory = svcCfg.RPC.NewFactory(params.Name, params.Logger)
+	tlsProvider, err := rpc.NewTLSConfigProvider(&s.cfg.Server.TLS)
+	if err != nil {
+		log.Fatalf("error creating TLS config provider: %v", err)
+	}
+	params.RPCFactory = svcCfg.RPC.NewFactory(params.Name, params.Logger, tlsProvider)
 
 	// Ringpop uses a different port to register handlers, this map is needed to resolve
 	// services to correct addresses used by clients through ServiceResolver lookup API
@@ -166,11 +180,15 @@ func (s *server) startService() common.Daemon {
 	if s.cfg.PublicClient.HostPort == "" {
 		log.Fatalf("need to provide an endpoint config for PublicClient")
 	} else {
-		var err error
+		transportCredentials, err := tlsProvider.GetFrontendClientCredentials()
+		if err != nil {
+			log.Fatalf("error creating public client TLS config: %v", err)
+		}
 		params.PublicClient, err = sdkclient.NewClient(sdkclient.Options{
 			HostPort:     s.cfg.PublicClient.HostPort,
 			Namespace:    common.SystemLocalNamespace,
 			MetricsScope: params.MetricScope,
+			GRPCDialer:   rpc.NewSDKGRPCDialer(transportCredentials),
 		})
 		if err != nil {
 			log.Fatalf("failed to create public client: %v", err)
@@ -225,7 +243,64 @@ func (s *server) startService() common.Daemon {
 
 	params.PersistenceConfig.TransactionSizeLimit = dc.GetIntProperty(dynamicconfig.TransactionSizeLimit, common.DefaultTransactionSizeLimit)
 
+	authCfg := s.cfg.Server.Authorization
+	authenticationEnabled := len(authCfg.JWTKeyProvider.KeySourceFiles) > 0 ||
+		len(s.cfg.Server.TLS.Frontend.Server.ClientCAFiles) > 0
 	params.Authorizer = authorization.NewNopAuthorizer()
+	if authCfg.Authorizer == "default" {
+		if !authenticationEnabled {
+			log.Fatalf("default authorizer requires authentication, configure jwtKeyProvider or frontend clientCaFiles")
+		}
+		params.Authorizer, err = authorization.NewDefaultAuthorizer(
+			authCfg.PolicyFile,
+			authCfg.PolicyRefreshInterval,
+			params.Logger,
+			s.doneC,
+		)
+		if err != nil {
+			log.Fatalf("error creating authorizer: %v", err)
+		}
+	}
+	if authenticationEnabled {
+		var keyProvider authorization.TokenKeyProvider
+		if keyProviderCfg := authCfg.JWTKeyProvider; len(keyProviderCfg.KeySourceFiles) > 0 {
+			keyProvider, err = authorization.NewDefaultTokenKeyProvider(
+				keyProviderCfg.KeySourceFiles,
+				keyProviderCfg.RefreshInterval,
+				params.Logger,
+				s.doneC,
+			)
+			if err != nil {
+				log.Fatalf("error creating token key provider: %v", err)
+			}
+		}
+		if len(s.cfg.Server.TLS.Frontend.Server.ClientCAFiles) == 0 {
+			log.Fatalf("internal callers authenticate with a client certificate, frontend clientCaFiles must be set when authentication is enabled")
+		}
+		frontendClient := s.cfg.Server.TLS.Frontend.Client
+		peerPermissions, err := authorization.WithInternalCaller(authCfg.PeerPermissions, frontendClient.CertFile, frontendClient.KeyFile)
+		if err != nil {
+			log.Fatalf("error loading internal caller identity: %v", err)
+		}
+		params.ClaimMapper, err = authorization.NewDefaultJWTClaimMapper(
+			keyProvider,
+			authCfg.TokenIssuer,
+			authCfg.TokenAudience,
+			authCfg.PermissionsClaimName,
+			peerPermissions,
+		)
+		if err != nil {
+			log.Fatalf("error creating claim mapper: %v", err)
+		}
+	}
+
+	if s.name == primitives.FrontendService && s.cfg.Server.Audit.IsEnabled() {
+		params.AuditLoggerInitializer = s.newAuditLogger(&params)
+	}
+
+	if s.name == primitives.HistoryService && s.cfg.Server.LifecycleEvents.IsEnabled() {
+		params.LifecyclePublisher = s.newLifecyclePublisher(&params)
+	}
 
 	params.Logger.Info("Starting service " + s.name)
 
@@ -318,6 +393,45 @@ func immutableClusterMetadataInitialization(
 	}
 }
 
+// newAuditLogger returns the initializer of the audit logger of the frontend service, the persistence
+// sink uses the persistence bean of the service
+func (s *server) newAuditLogger(params *resource.BootstrapParams) resource.AuditLoggerInitializerFunc {
+	cfg := &s.cfg.Server.Audit
+
+	return func(persistenceBean persistenceClient.Bean, logger l.Logger) (audit.Logger, error) {
+		var sink audit.Sink
+		switch cfg.Sink {
+		case audit.SinkFile:
+			fileSink, err := audit.NewFileSink(&cfg.File)
+			if err != nil {
+				return nil, fmt.Errorf("error creating audit log file sink: %v", err)
+			}
+			sink = fileSink
+		case audit.SinkKafka:
+			messagingClient := messaging.NewKafkaClient(&s.cfg.Kafka, params.MetricsClient, zap.NewNop(), params.Logger, params.MetricScope, false, false)
+			producer, err := messagingClient.NewProducer(common.AuditAppName)
+			if err != nil {
+				return nil, fmt.Errorf("error creating audit log producer: %v", err)
+			}
+			sink = audit.NewProducerSink(producer)
+		case audit.SinkPersistence:
+			queue, err := persistenceBean.GetAuditQueue()
+			if err != nil {
+				return nil, fmt.Errorf("error creating audit log queue: %v", err)
+			}
+			sink = audit.NewQueueSink(queue)
+		default:
+			return nil, fmt.Errorf("unknown audit log sink: %v", cfg.Sink)
+		}
+
+		auditLogger, err := audit.NewLogger(cfg, sink, params.MetricsClient, logger.WithTags(tag.ComponentAudit))
+		if err != nil {
+			return nil, fmt.Errorf("error creating audit logger: %v", err)
+		}
+		return auditLogger, nil
+	}
+}
+
 func logImmutableMismatch(l l.Logger, key string, ignored interface{}, value interface{}) {
 	l.Error(
 		"Supplied configuration key/value mismatches persisted ImmutableClusterMetadata."+
@@ -332,3 +446,34 @@ func execute(d common.Daemon, doneC chan struct{}) {
 	d.Start()
 	close(doneC)
 }
+
+func (s *server) newLifecyclePublisher(params *resource.BootstrapParams) lifecycle.Publisher {
+	cfg := &s.cfg.Server.LifecycleEvents
+
+	var sink lifecycle.Sink
+	switch cfg.Sink {
+	case lifecycle.SinkFile:
+		fileSink, err := lifecycle.NewFileSink(&cfg.File)
+		if err != nil {
+			log.Fatalf("error creating lifecycle events file sink: %v", err)
+		}
+		sink = fileSink
+	case lifecycle.SinkKafka:
+		messagingClient := messaging.NewKafkaClient(&s.cfg.Kafka, params.MetricsClient, zap.NewNop(), params.Logger, params.MetricScope, false, false)
+		producer, err := messagingClient.NewProducer(common.LifecycleAppName)
+		if err != nil {
+			log.Fatalf("error creating lifecycle events producer: %v", err)
+		}
+		sink = lifecycle.NewProducerSink(producer)
+	case lifecycle.SinkWebhook:
+		webhookSink, err := lifecycle.NewWebhookSink(&cfg.Webhook)
+		if err != nil {
+			log.Fatalf("error creating lifecycle events webhook sink: %v", err)
+		}
+		sink = webhookSink
+	default:
+		log.Fatalf("unknown lifecycle events sink: %v", cfg.Sink)
+	}
+
+	return lifecycle.NewPublisher(sink)
+}
@@ -34,14 +34,19 @@ import (
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/primitives"
 
+	"github.com/opentracing/opentracing-go"
 	"github.com/urfave/cli"
 
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/service/config"
+	"github.com/temporalio/temporal/common/tracing"
 	"github.com/temporalio/temporal/tools/cassandra"
 	"github.com/temporalio/temporal/tools/sql"
 )
 
+// tracerServiceName is the service name of the spans recorded by the server
+const tracerServiceName = "temporal"
+
 // validServices is the list of all valid temporal services
 var validServices = []string{primitives.FrontendService, primitives.HistoryService, primitives.MatchingService, primitives.WorkerService}
 
@@ -78,6 +83,19 @@ func startHandler(c *cli.Context) {
 		log.Fatalf("fail to start PProf: %v", err)
 	}
 
+	// the tracer is process wide, the services are told apart by the names of their spans
+	tracer, tracerCloser, err := cfg.Server.Tracing.NewTracer(tracerServiceName, loggerimpl.NewLogger(cfg.Log.NewZapLogger()))
+	if err != nil {
+		log.Fatalf("fail to create tracer: %v", err)
+	}
+	if cfg.Server.Tracing.IsEnabled() {
+		opentracing.SetGlobalTracer(tracer)
+	}
+
+	// the log levels of the process are shared by its services and can be changed through the admin service
+	logLevels := cfg.Log.NewLogLevels()
+	// so are the errors they recently logged collected
+	recentErrors := config.NewRecentErrors(config.DefaultRecentErrorsSize)
 	var daemons []common.Daemon
 	services := getServices(c)
 	sigc := make(chan os.Signal, 1)
@@ -86,7 +104,7 @@ func startHandler(c *cli.Context) {
 		if _, ok := cfg.Services[svc]; !ok {
 			log.Fatalf("`%v` service missing config", svc)
 		}
-		server := newServer(svc, &cfg)
+		server := newServer(svc, &cfg, logLevels, recentErrors)
 		daemons = append(daemons, server)
 		server.Start()
 	}
@@ -98,6 +116,9 @@ func startHandler(c *cli.Context) {
 			for _, daemon := range daemons {
 				daemon.Stop()
 			}
+			if err := tracerCloser.Close(); err != nil {
+				log.Printf("fail to close tracer: %v", err)
+			}
 			os.Exit(0)
 		}
 	}
@@ -214,6 +214,7 @@ func (i *historyIterator) readHistory(firstEventID int64) ([]*eventpb.History, e
 		MaxEventID:  common.EndEventID,
 		PageSize:    i.historyPageSize,
 		ShardID:     &i.request.ShardID,
+		NamespaceID: i.request.NamespaceID,
 	}
 	historyBatches, _, _, err := persistence.ReadFullPageV2EventsByBatch(i.historyV2Manager, req)
 	return historyBatches, err
@@ -629,6 +629,7 @@ func (s *HistoryIteratorSuite) constructMockHistoryV2Manager(batchInfo []int, re
 			MaxEventID:  common.EndEventID,
 			PageSize:    testDefaultPersistencePageSize,
 			ShardID:     &testShardId,
+			NamespaceID: testNamespaceID,
 		}
 		if returnErrorOnPage == i {
 			mockHistoryV2Manager.On("ReadHistoryBranchByBatch", req).Return(nil, errors.New("got error getting workflow execution history"))
@@ -648,6 +649,7 @@ func (s *HistoryIteratorSuite) constructMockHistoryV2Manager(batchInfo []int, re
 			MaxEventID:  common.EndEventID,
 			PageSize:    testDefaultPersistencePageSize,
 			ShardID:     &testShardId,
+			NamespaceID: testNamespaceID,
 		}
 		mockHistoryV2Manager.On("ReadHistoryBranchByBatch", req).Return(nil, serviceerror.NewNotFound("Reach the end"))
 	}
@@ -0,0 +1,256 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+const (
+	// SinkFile writes the audit log to a local file with size based rotation
+	SinkFile = "file"
+	// SinkKafka publishes the audit log to the kafka topic of the audit application
+	SinkKafka = "kafka"
+	// SinkPersistence enqueues the audit log to the audit queue of the persistence store
+	SinkPersistence = "persistence"
+
+	// ResultSuccess is the result of an audited call which did not return an error
+	ResultSuccess = "success"
+	// ResultFailure is the result of an audited call which returned an error
+	ResultFailure = "failure"
+
+	redactedValue = "<redacted>"
+
+	defaultBufferSize = 1024
+	minKeySize        = 32
+)
+
+type (
+	// Config is the config of the audit log
+	Config struct {
+		// Sink is one of file, kafka or persistence, the audit log is disabled when empty
+		Sink string `yaml:"sink"`
+		// File is the config of the file sink
+		File FileSinkConfig `yaml:"file"`
+		// SampleRates maps an API name to the fraction of its calls which are recorded, all calls
+		// of an API missing from the map are recorded
+		SampleRates map[string]float64 `yaml:"sampleRates"`
+		// RedactedFields are the request fields which are replaced with a placeholder at any depth
+		// of the request summary, DefaultRedactedFields are used when empty
+		RedactedFields []string `yaml:"redactedFields"`
+		// MaxRequestSize is the max size in bytes of the request summary, larger summaries are dropped
+		MaxRequestSize int `yaml:"maxRequestSize"`
+		// HeadFile is the path of the file holding the anchor, the head of the hash chain. The chain is
+		// continued from it after a restart and Verify uses it to detect truncation of the log.
+		// Defaults to the file sink path with a ".head" suffix, required by the other sinks
+		HeadFile string `yaml:"headFile"`
+		// KeyFile is the path of the file holding the base64 encoded key of the hash chain, the records
+		// are chained with HMAC-SHA256 so they cannot be forged without the key. Required
+		KeyFile string `yaml:"keyFile"`
+		// BufferSize is the number of records buffered for the sink, records are dropped when the
+		// buffer is full unless BlockWhenFull is set. Dropped records are counted by the next record
+		// and the audit_records_dropped metric. Defaults to 1024
+		BufferSize int `yaml:"bufferSize"`
+		// BlockWhenFull makes audited calls wait for room in the buffer instead of dropping their
+		// record, the calls are slowed down to the pace of the sink
+		BlockWhenFull bool `yaml:"blockWhenFull"`
+	}
+
+	// FileSinkConfig is the config of the file sink
+	FileSinkConfig struct {
+		// Path is the path of the audit log file
+		Path string `yaml:"path"`
+		// MaxSizeMB is the size at which the file is rotated, the file is never rotated when zero
+		MaxSizeMB int `yaml:"maxSizeMB"`
+		// MaxBackups is the number of rotated files kept, all rotated files are kept when zero
+		MaxBackups int `yaml:"maxBackups"`
+	}
+
+	// Entry is an API call to be audited
+	Entry struct {
+		Actor      string
+		API        string
+		Namespace  string
+		WorkflowID string
+		RunID      string
+		Request    interface{}
+		Error      error
+	}
+
+	// Record is a single record of the audit log, records of a host form a keyed hash chain
+	// so removed or modified records can be detected with Verify
+	Record struct {
+		Host             string          `json:"host"`
+		Sequence         int64           `json:"sequence"`
+		Timestamp        time.Time       `json:"timestamp"`
+		Actor            string          `json:"actor"`
+		API              string          `json:"api"`
+		Namespace        string          `json:"namespace,omitempty"`
+		WorkflowID       string          `json:"workflowId,omitempty"`
+		RunID            string          `json:"runId,omitempty"`
+		Request          json.RawMessage `json:"request,omitempty"`
+		RequestTruncated bool            `json:"requestTruncated,omitempty"`
+		Result           string          `json:"result"`
+		Error            string          `json:"error,omitempty"`
+		Dropped          int64           `json:"dropped,omitempty"`
+		PrevHash         string          `json:"prevHash"`
+		Hash             string          `json:"hash,omitempty"`
+	}
+
+	// Anchor is the head of the hash chain of a host, it is stored outside of the log
+	Anchor struct {
+		Host     string `json:"host"`
+		Sequence int64  `json:"sequence"`
+		Hash     string `json:"hash"`
+	}
+
+	// Logger records audited API calls
+	Logger interface {
+		Log(entry *Entry)
+		Close() error
+	}
+
+	// Sink stores serialized audit records
+	Sink interface {
+		Write(record []byte) error
+		Close() error
+	}
+)
+
+// DefaultRedactedFields are the request fields carrying user payloads or secrets
+var DefaultRedactedFields = []string{
+	"input",
+	"signalInput",
+	"header",
+	"memo",
+	"searchAttributes",
+	"details",
+	"data",
+	"securityToken",
+}
+
+// IsEnabled returns true if a sink is configured
+func (c *Config) IsEnabled() bool {
+	return c != nil && c.Sink != ""
+}
+
+// Verify checks the hash chain of the serialized records of a single host, in sequence order, with
+// the key of the chain. The anchor is optional, when set the log must reach the head of the chain
+// it records so removed records at the tail of the log are detected
+func Verify(records [][]byte, anchor *Anchor, key []byte) error {
+	prevHash := ""
+	var last Record
+	for i, data := range records {
+		var record Record
+		if err := json.Unmarshal(data, &record); err != nil {
+			return fmt.Errorf("record %v is malformed: %v", i, err)
+		}
+		if i > 0 && record.PrevHash != prevHash {
+			return fmt.Errorf("record %v does not follow the previous record", record.Sequence)
+		}
+		hash, err := record.hash(key)
+		if err != nil {
+			return err
+		}
+		if !hmac.Equal([]byte(hash), []byte(record.Hash)) {
+			return fmt.Errorf("record %v has been modified", record.Sequence)
+		}
+		if anchor != nil && record.Sequence == anchor.Sequence && record.Hash != anchor.Hash {
+			return fmt.Errorf("record %v does not match the anchor", record.Sequence)
+		}
+		prevHash = record.Hash
+		last = record
+	}
+	if anchor != nil && last.Sequence < anchor.Sequence {
+		return fmt.Errorf("log is truncated, records after %v are missing up to %v", last.Sequence, anchor.Sequence)
+	}
+	return nil
+}
+
+// ReadKey reads the base64 encoded key of the hash chain stored in the key file
+func ReadKey(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, fmt.Errorf("audit log key file %v is malformed: %v", path, err)
+	}
+	if len(key) < minKeySize {
+		return nil, fmt.Errorf("audit log key in %v must be at least %v bytes", path, minKeySize)
+	}
+	return key, nil
+}
+
+// ReadAnchor reads the anchor stored in the head file, the anchor is empty when the file does not exist
+func ReadAnchor(path string) (*Anchor, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Anchor{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	anchor := &Anchor{}
+	if err := json.Unmarshal(data, anchor); err != nil {
+		return nil, fmt.Errorf("audit log head file %v is malformed: %v", path, err)
+	}
+	return anchor, nil
+}
+
+// writeAnchor replaces the anchor stored in the head file, the file is never partially written
+func writeAnchor(path string, anchor *Anchor) error {
+	data, err := json.Marshal(anchor)
+	if err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func (r *Record) hash(key []byte) (string, error) {
+	unhashed := *r
+	unhashed.Hash = ""
+	data, err := json.Marshal(&unhashed)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
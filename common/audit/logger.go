@@ -0,0 +1,328 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+type (
+	loggerImpl struct {
+		sink           Sink
+		host           string
+		sampleRates    map[string]float64
+		redactedFields map[string]struct{}
+		maxRequestSize int
+		headFile       string
+		key            []byte
+		blockWhenFull  bool
+		metricsScope   metrics.Scope
+		logger         log.Logger
+
+		records    chan *Record
+		dropped    int64
+		closeOnce  sync.Once
+		shutdownCh chan struct{}
+		doneCh     chan struct{}
+
+		// head is only accessed by the write loop after the logger is created
+		head Anchor
+	}
+
+	// headReader is implemented by sinks which can read back the last record they stored
+	headReader interface {
+		lastRecord() ([]byte, error)
+	}
+
+	nopLogger struct{}
+)
+
+var _ Logger = (*loggerImpl)(nil)
+var _ Logger = (*nopLogger)(nil)
+
+var errHeadFileNotSet = errors.New("audit log head file is not set")
+var errKeyFileNotSet = errors.New("audit log key file is not set")
+
+// NewLogger creates an audit logger which writes records to the sink in the background, the hash
+// chain is continued from the head stored by the sink or in the head file
+func NewLogger(
+	cfg *Config,
+	sink Sink,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) (Logger, error) {
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	fields := cfg.RedactedFields
+	if len(fields) == 0 {
+		fields = DefaultRedactedFields
+	}
+	redactedFields := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		redactedFields[field] = struct{}{}
+	}
+
+	headFile := cfg.HeadFile
+	if headFile == "" && cfg.Sink == SinkFile {
+		headFile = cfg.File.Path + ".head"
+	}
+	if headFile == "" {
+		return nil, errHeadFileNotSet
+	}
+	if cfg.KeyFile == "" {
+		return nil, errKeyFileNotSet
+	}
+	key, err := ReadKey(cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	bufferSize := cfg.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+
+	l := &loggerImpl{
+		sink:           sink,
+		host:           host,
+		sampleRates:    cfg.SampleRates,
+		redactedFields: redactedFields,
+		maxRequestSize: cfg.MaxRequestSize,
+		headFile:       headFile,
+		key:            key,
+		blockWhenFull:  cfg.BlockWhenFull,
+		metricsScope:   metricsClient.Scope(metrics.AuditLogScope),
+		logger:         logger,
+		records:        make(chan *Record, bufferSize),
+		shutdownCh:     make(chan struct{}),
+		doneCh:         make(chan struct{}),
+	}
+	if err := l.loadHead(); err != nil {
+		return nil, err
+	}
+	go l.writeLoop()
+	return l, nil
+}
+
+// NewNopLogger creates an audit logger which records nothing
+func NewNopLogger() Logger {
+	return &nopLogger{}
+}
+
+func (l *loggerImpl) Log(entry *Entry) {
+	if rate, ok := l.sampleRates[entry.API]; ok && rand.Float64() >= rate {
+		return
+	}
+
+	record := &Record{
+		Host:       l.host,
+		Timestamp:  time.Now().UTC(),
+		Actor:      entry.Actor,
+		API:        entry.API,
+		Namespace:  entry.Namespace,
+		WorkflowID: entry.WorkflowID,
+		RunID:      entry.RunID,
+		Result:     ResultSuccess,
+	}
+	if entry.Error != nil {
+		record.Result = ResultFailure
+		record.Error = entry.Error.Error()
+	}
+	if entry.Request != nil {
+		request, err := l.summarize(entry.Request)
+		if err != nil {
+			l.logger.Warn("Failed to summarize audited request", tag.Name(entry.API), tag.Error(err))
+		}
+		if l.maxRequestSize > 0 && len(request) > l.maxRequestSize {
+			request = nil
+			record.RequestTruncated = true
+		}
+		record.Request = request
+	}
+
+	select {
+	case <-l.shutdownCh:
+		return
+	default:
+	}
+	if l.blockWhenFull {
+		select {
+		case l.records <- record:
+		case <-l.shutdownCh:
+		}
+		return
+	}
+	select {
+	case l.records <- record:
+	default:
+		atomic.AddInt64(&l.dropped, 1)
+		l.metricsScope.IncCounter(metrics.AuditRecordsDropped)
+		l.logger.Error("Audit log buffer is full, record dropped", tag.Name(entry.API))
+	}
+}
+
+func (l *loggerImpl) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.shutdownCh)
+		<-l.doneCh
+	})
+	return l.sink.Close()
+}
+
+// loadHead continues the chain from the last record of the sink or the stored anchor, whichever is
+// further. The sink is behind the anchor when the tail of the log has been removed, continuing from
+// the anchor keeps the gap detectable by Verify
+func (l *loggerImpl) loadHead() error {
+	anchor, err := ReadAnchor(l.headFile)
+	if err != nil {
+		return err
+	}
+	l.head = *anchor
+
+	reader, ok := l.sink.(headReader)
+	if !ok {
+		return nil
+	}
+	data, err := reader.lastRecord()
+	if err != nil || len(data) == 0 {
+		return err
+	}
+	var last Record
+	if err := json.Unmarshal(data, &last); err != nil {
+		return err
+	}
+	switch {
+	case last.Sequence > anchor.Sequence:
+		l.head = Anchor{Host: last.Host, Sequence: last.Sequence, Hash: last.Hash}
+	case last.Sequence < anchor.Sequence:
+		l.logger.Error("Audit log is behind its anchor, records have been removed",
+			tag.Counter(int(anchor.Sequence-last.Sequence)))
+	}
+	return nil
+}
+
+func (l *loggerImpl) writeLoop() {
+	defer close(l.doneCh)
+
+	for {
+		select {
+		case record := <-l.records:
+			l.write(record)
+		case <-l.shutdownCh:
+			for {
+				select {
+				case record := <-l.records:
+					l.write(record)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// write chains the record to the head and stores it, the anchor is stored once the buffer is drained
+func (l *loggerImpl) write(record *Record) {
+	record.Sequence = l.head.Sequence + 1
+	record.PrevHash = l.head.Hash
+	record.Dropped = atomic.SwapInt64(&l.dropped, 0)
+	hash, err := record.hash(l.key)
+	if err != nil {
+		l.logger.Error("Failed to hash audit record", tag.Name(record.API), tag.Error(err))
+		atomic.AddInt64(&l.dropped, record.Dropped+1)
+		l.metricsScope.IncCounter(metrics.AuditRecordsDropped)
+		return
+	}
+	record.Hash = hash
+	data, err := json.Marshal(record)
+	if err != nil {
+		l.logger.Error("Failed to serialize audit record", tag.Name(record.API), tag.Error(err))
+		atomic.AddInt64(&l.dropped, record.Dropped+1)
+		l.metricsScope.IncCounter(metrics.AuditRecordsDropped)
+		return
+	}
+	if err := l.sink.Write(data); err != nil {
+		l.logger.Error("Failed to write audit record", tag.Name(record.API), tag.Error(err))
+		atomic.AddInt64(&l.dropped, record.Dropped+1)
+		l.metricsScope.IncCounter(metrics.AuditRecordsDropped)
+		return
+	}
+	l.head = Anchor{Host: l.host, Sequence: record.Sequence, Hash: record.Hash}
+
+	if len(l.records) == 0 {
+		if err := writeAnchor(l.headFile, &l.head); err != nil {
+			l.logger.Error("Failed to store audit log anchor", tag.Error(err))
+		}
+	}
+}
+
+// summarize serializes the request with the redacted fields replaced at any depth
+func (l *loggerImpl) summarize(request interface{}) (json.RawMessage, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	var summary interface{}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, err
+	}
+	return json.Marshal(l.redact(summary))
+}
+
+func (l *loggerImpl) redact(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			if _, ok := l.redactedFields[k]; ok {
+				value[k] = redactedValue
+			} else {
+				value[k] = l.redact(v)
+			}
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = l.redact(v)
+		}
+	}
+	return value
+}
+
+func (l *nopLogger) Log(_ *Entry) {}
+
+func (l *nopLogger) Close() error {
+	return nil
+}
@@ -0,0 +1,338 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+type (
+	loggerSuite struct {
+		suite.Suite
+		dir      string
+		headFile string
+		keyFile  string
+		key      []byte
+		sink     *memorySink
+
+		metricsScope  tally.TestScope
+		metricsClient metrics.Client
+	}
+
+	memorySink struct {
+		sync.Mutex
+		records [][]byte
+		blockCh chan struct{}
+	}
+
+	testRequest struct {
+		WorkflowID string            `json:"workflowId"`
+		Input      []byte            `json:"input"`
+		Memo       map[string]string `json:"memo"`
+		Children   []testRequest     `json:"children"`
+	}
+)
+
+func TestLoggerSuite(t *testing.T) {
+	suite.Run(t, new(loggerSuite))
+}
+
+func (s *loggerSuite) SetupTest() {
+	var err error
+	s.dir, err = ioutil.TempDir("", "audit")
+	s.NoError(err)
+	s.headFile = filepath.Join(s.dir, "audit.head")
+	s.keyFile = filepath.Join(s.dir, "audit.key")
+	s.key = bytes.Repeat([]byte{1}, minKeySize)
+	s.NoError(ioutil.WriteFile(s.keyFile, []byte(base64.StdEncoding.EncodeToString(s.key)+"\n"), 0600))
+	s.sink = &memorySink{}
+	s.metricsScope = tally.NewTestScope("", nil)
+	s.metricsClient = metrics.NewClient(s.metricsScope, metrics.Frontend)
+}
+
+func (s *loggerSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *loggerSuite) TestHashChain() {
+	logger := s.newLogger(&Config{})
+	logger.Log(&Entry{Actor: "alice", API: "StartWorkflowExecution", Namespace: "payments", WorkflowID: "wid"})
+	logger.Log(&Entry{Actor: "bob", API: "TerminateWorkflowExecution", Error: errors.New("not found")})
+	logger.Log(&Entry{Actor: "alice", API: "UpdateNamespace", Namespace: "payments"})
+	s.NoError(logger.Close())
+	s.Len(s.sink.records, 3)
+	s.NoError(Verify(s.sink.records, nil, s.key))
+
+	var record Record
+	s.NoError(json.Unmarshal(s.sink.records[1], &record))
+	s.Equal(int64(2), record.Sequence)
+	s.Equal("bob", record.Actor)
+	s.Equal(ResultFailure, record.Result)
+	s.Equal("not found", record.Error)
+
+	tampered := make([][]byte, len(s.sink.records))
+	copy(tampered, s.sink.records)
+	record.Actor = "carol"
+	tampered[1], _ = json.Marshal(&record)
+	s.Error(Verify(tampered, nil, s.key))
+
+	s.Error(Verify([][]byte{s.sink.records[0], s.sink.records[2]}, nil, s.key))
+
+	// the chain is keyed, it cannot be verified or recomputed without the key
+	s.Error(Verify(s.sink.records, nil, bytes.Repeat([]byte{2}, minKeySize)))
+}
+
+func (s *loggerSuite) TestRestart() {
+	logger := s.newLogger(&Config{})
+	logger.Log(&Entry{API: "StartWorkflowExecution"})
+	logger.Log(&Entry{API: "SignalWorkflowExecution"})
+	s.NoError(logger.Close())
+
+	logger = s.newLogger(&Config{})
+	logger.Log(&Entry{API: "TerminateWorkflowExecution"})
+	s.NoError(logger.Close())
+
+	s.Len(s.sink.records, 3)
+	var record Record
+	s.NoError(json.Unmarshal(s.sink.records[2], &record))
+	s.Equal(int64(3), record.Sequence)
+	anchor, err := ReadAnchor(s.headFile)
+	s.NoError(err)
+	s.Equal(int64(3), anchor.Sequence)
+	s.NoError(Verify(s.sink.records, anchor, s.key))
+}
+
+func (s *loggerSuite) TestTruncationDetected() {
+	logger := s.newLogger(&Config{})
+	for i := 0; i < 3; i++ {
+		logger.Log(&Entry{API: "StartWorkflowExecution"})
+	}
+	s.NoError(logger.Close())
+
+	anchor, err := ReadAnchor(s.headFile)
+	s.NoError(err)
+	s.NoError(Verify(s.sink.records, anchor, s.key))
+	s.Error(Verify(s.sink.records[:2], anchor, s.key))
+
+	var record Record
+	s.NoError(json.Unmarshal(s.sink.records[2], &record))
+	record.Actor = "mallory"
+	record.Hash, err = record.hash(bytes.Repeat([]byte{2}, minKeySize))
+	s.NoError(err)
+	forged, _ := json.Marshal(&record)
+	s.Error(Verify([][]byte{s.sink.records[0], s.sink.records[1], forged}, anchor, s.key))
+}
+
+func (s *loggerSuite) TestFileSinkRestart() {
+	cfg := &Config{Sink: SinkFile, File: FileSinkConfig{Path: filepath.Join(s.dir, "audit.log")}, KeyFile: s.keyFile}
+	for i := 0; i < 2; i++ {
+		sink, err := NewFileSink(&cfg.File)
+		s.NoError(err)
+		logger, err := NewLogger(cfg, sink, s.metricsClient, loggerimpl.NewNopLogger())
+		s.NoError(err)
+		logger.Log(&Entry{API: "StartWorkflowExecution"})
+		logger.Log(&Entry{API: "SignalWorkflowExecution"})
+		s.NoError(logger.Close())
+	}
+
+	// the head file is missing, the chain is continued from the log itself
+	s.NoError(os.Remove(cfg.File.Path + ".head"))
+	sink, err := NewFileSink(&cfg.File)
+	s.NoError(err)
+	logger, err := NewLogger(cfg, sink, s.metricsClient, loggerimpl.NewNopLogger())
+	s.NoError(err)
+	logger.Log(&Entry{API: "TerminateWorkflowExecution"})
+	s.NoError(logger.Close())
+
+	records := readRecords(s.T(), cfg.File.Path)
+	s.Len(records, 5)
+	anchor, err := ReadAnchor(cfg.File.Path + ".head")
+	s.NoError(err)
+	s.Equal(int64(5), anchor.Sequence)
+	s.NoError(Verify(records, anchor, s.key))
+}
+
+func (s *loggerSuite) TestDropped() {
+	s.sink.blockCh = make(chan struct{})
+	logger := s.newLogger(&Config{BufferSize: 1})
+	// the first record blocks the write loop, the second fills the buffer
+	logger.Log(&Entry{API: "StartWorkflowExecution"})
+	s.Eventually(func() bool { return len(logger.(*loggerImpl).records) == 0 }, time.Second, time.Millisecond)
+	logger.Log(&Entry{API: "SignalWorkflowExecution"})
+	logger.Log(&Entry{API: "TerminateWorkflowExecution"})
+	close(s.sink.blockCh)
+	s.NoError(logger.Close())
+
+	logger = s.newLogger(&Config{})
+	logger.Log(&Entry{API: "UpdateNamespace"})
+	s.NoError(logger.Close())
+
+	s.Len(s.sink.records, 3)
+	s.NoError(Verify(s.sink.records, nil, s.key))
+	var record Record
+	s.NoError(json.Unmarshal(s.sink.records[1], &record))
+	s.Equal(int64(1), record.Dropped)
+	s.Equal(int64(1), s.droppedCount())
+}
+
+func (s *loggerSuite) TestBlockWhenFull() {
+	s.sink.blockCh = make(chan struct{})
+	logger := s.newLogger(&Config{BufferSize: 1, BlockWhenFull: true})
+	// the first record blocks the write loop, the second fills the buffer
+	logger.Log(&Entry{API: "StartWorkflowExecution"})
+	s.Eventually(func() bool { return len(logger.(*loggerImpl).records) == 0 }, time.Second, time.Millisecond)
+	logger.Log(&Entry{API: "SignalWorkflowExecution"})
+	loggedCh := make(chan struct{})
+	go func() {
+		logger.Log(&Entry{API: "TerminateWorkflowExecution"})
+		close(loggedCh)
+	}()
+	select {
+	case <-loggedCh:
+		s.Fail("record must wait for room in the buffer")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(s.sink.blockCh)
+	<-loggedCh
+	s.NoError(logger.Close())
+
+	s.Len(s.sink.records, 3)
+	s.NoError(Verify(s.sink.records, nil, s.key))
+	for _, data := range s.sink.records {
+		var record Record
+		s.NoError(json.Unmarshal(data, &record))
+		s.Zero(record.Dropped)
+	}
+	s.Zero(s.droppedCount())
+}
+
+func (s *loggerSuite) TestHeadFileRequired() {
+	_, err := NewLogger(&Config{Sink: SinkKafka}, s.sink, s.metricsClient, loggerimpl.NewNopLogger())
+	s.Equal(errHeadFileNotSet, err)
+}
+
+func (s *loggerSuite) TestKeyFileRequired() {
+	_, err := NewLogger(&Config{HeadFile: s.headFile}, s.sink, s.metricsClient, loggerimpl.NewNopLogger())
+	s.Equal(errKeyFileNotSet, err)
+
+	s.NoError(ioutil.WriteFile(s.keyFile, []byte(base64.StdEncoding.EncodeToString([]byte("short"))), 0600))
+	_, err = NewLogger(&Config{HeadFile: s.headFile, KeyFile: s.keyFile}, s.sink, s.metricsClient, loggerimpl.NewNopLogger())
+	s.Error(err)
+}
+
+func (s *loggerSuite) TestRedaction() {
+	logger := s.newLogger(&Config{})
+	logger.Log(&Entry{
+		API: "StartWorkflowExecution",
+		Request: &testRequest{
+			WorkflowID: "wid",
+			Input:      []byte("secret"),
+			Children:   []testRequest{{Memo: map[string]string{"key": "secret"}}},
+		},
+	})
+	s.NoError(logger.Close())
+	s.Len(s.sink.records, 1)
+
+	var record Record
+	s.NoError(json.Unmarshal(s.sink.records[0], &record))
+	s.Contains(string(record.Request), `"workflowId":"wid"`)
+	s.NotContains(string(record.Request), "secret")
+	s.NotContains(string(record.Request), "c2VjcmV0")
+}
+
+func (s *loggerSuite) TestMaxRequestSize() {
+	logger := s.newLogger(&Config{MaxRequestSize: 10})
+	logger.Log(&Entry{API: "StartWorkflowExecution", Request: &testRequest{WorkflowID: "a long workflow id"}})
+	s.NoError(logger.Close())
+	s.Len(s.sink.records, 1)
+
+	var record Record
+	s.NoError(json.Unmarshal(s.sink.records[0], &record))
+	s.True(record.RequestTruncated)
+	s.Empty(record.Request)
+}
+
+func (s *loggerSuite) TestSampling() {
+	logger := s.newLogger(&Config{SampleRates: map[string]float64{"SignalWorkflowExecution": 0}})
+	logger.Log(&Entry{API: "SignalWorkflowExecution"})
+	logger.Log(&Entry{API: "TerminateWorkflowExecution"})
+	s.NoError(logger.Close())
+	s.Len(s.sink.records, 1)
+	s.NoError(Verify(s.sink.records, nil, s.key))
+}
+
+func (s *loggerSuite) newLogger(cfg *Config) Logger {
+	cfg.HeadFile = s.headFile
+	cfg.KeyFile = s.keyFile
+	logger, err := NewLogger(cfg, s.sink, s.metricsClient, loggerimpl.NewNopLogger())
+	s.NoError(err)
+	return logger
+}
+
+func (s *loggerSuite) droppedCount() int64 {
+	var count int64
+	for _, counter := range s.metricsScope.Snapshot().Counters() {
+		if counter.Name() == "audit_records_dropped" {
+			count += counter.Value()
+		}
+	}
+	return count
+}
+
+func (s *memorySink) Write(record []byte) error {
+	if s.blockCh != nil {
+		<-s.blockCh
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	return nil
+}
+
+func readRecords(t *testing.T, path string) [][]byte {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	return bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n"))
+}
@@ -0,0 +1,252 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/temporalio/temporal/common/messaging"
+)
+
+const (
+	rotatedFileTimeFormat = "20060102T150405.000000000"
+
+	lastRecordReadSize = 64 * 1024
+)
+
+type (
+	fileSink struct {
+		sync.Mutex
+		path       string
+		maxSize    int64
+		maxBackups int
+		file       *os.File
+		size       int64
+	}
+
+	producerSink struct {
+		producer messaging.Producer
+	}
+
+	// Queue is the part of persistence.Queue used by the persistence sink
+	Queue interface {
+		EnqueueMessage(messagePayload []byte) error
+	}
+
+	queueSink struct {
+		queue Queue
+	}
+)
+
+var errEmptyPath = errors.New("audit log file path is empty")
+
+var _ Sink = (*fileSink)(nil)
+var _ Sink = (*producerSink)(nil)
+var _ Sink = (*queueSink)(nil)
+var _ headReader = (*fileSink)(nil)
+
+// NewFileSink creates a sink which appends records to a file, one per line, and rotates the file
+// once it grows over the max size
+func NewFileSink(cfg *FileSinkConfig) (Sink, error) {
+	if cfg.Path == "" {
+		return nil, errEmptyPath
+	}
+	s := &fileSink{
+		path:       cfg.Path,
+		maxSize:    int64(cfg.MaxSizeMB) * 1024 * 1024,
+		maxBackups: cfg.MaxBackups,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewProducerSink creates a sink which publishes records with the producer
+func NewProducerSink(producer messaging.Producer) Sink {
+	return &producerSink{producer: producer}
+}
+
+// NewQueueSink creates a sink which enqueues records to the persistence queue, the queue is owned
+// by the caller and is not closed with the sink
+func NewQueueSink(queue Queue) Sink {
+	return &queueSink{queue: queue}
+}
+
+func (s *fileSink) Write(record []byte) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(record))+1 > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(append(record, '\n'))
+	s.size += int64(n)
+	return err
+}
+
+func (s *fileSink) Close() error {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.file.Close()
+}
+
+func (s *fileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *fileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	rotated := s.path + "." + time.Now().UTC().Format(rotatedFileTimeFormat)
+	if err := os.Rename(s.path, rotated); err != nil {
+		return err
+	}
+	if err := s.open(); err != nil {
+		return err
+	}
+	if s.maxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := s.rotatedFiles()
+	if err != nil {
+		return err
+	}
+	for len(backups) > s.maxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// lastRecord returns the last record of the file, or of the newest rotated file when the file is empty
+func (s *fileSink) lastRecord() ([]byte, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	record, err := readLastLine(s.path)
+	if err != nil || len(record) > 0 {
+		return record, err
+	}
+	backups, err := s.rotatedFiles()
+	if err != nil || len(backups) == 0 {
+		return nil, err
+	}
+	return readLastLine(backups[len(backups)-1])
+}
+
+// rotatedFiles returns the rotated files from oldest to newest, other files sharing the
+// prefix of the path such as the head file are skipped
+func (s *fileSink) rotatedFiles() ([]string, error) {
+	matches, err := filepath.Glob(s.path + ".*")
+	if err != nil {
+		return nil, err
+	}
+	var backups []string
+	for _, match := range matches {
+		if _, err := time.Parse(rotatedFileTimeFormat, match[len(s.path)+1:]); err == nil {
+			backups = append(backups, match)
+		}
+	}
+	// the time format sorts rotated files from oldest to newest
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// readLastLine reads the file backwards until the start of its last non empty line
+func readLastLine(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	var tail []byte
+	for offset := info.Size(); offset > 0; {
+		readSize := int64(lastRecordReadSize)
+		if readSize > offset {
+			readSize = offset
+		}
+		offset -= readSize
+		chunk := make([]byte, readSize)
+		if _, err := file.ReadAt(chunk, offset); err != nil && err != io.EOF {
+			return nil, err
+		}
+		tail = append(chunk, tail...)
+		trimmed := bytes.TrimRight(tail, "\n")
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 {
+			return trimmed[i+1:], nil
+		}
+	}
+	return bytes.TrimRight(tail, "\n"), nil
+}
+
+func (s *producerSink) Write(record []byte) error {
+	return s.producer.Publish(record)
+}
+
+func (s *producerSink) Close() error {
+	if closeable, ok := s.producer.(messaging.CloseableProducer); ok {
+		return closeable.Close()
+	}
+	return nil
+}
+
+func (s *queueSink) Write(record []byte) error {
+	return s.queue.EnqueueMessage(record)
+}
+
+func (s *queueSink) Close() error {
+	return nil
+}
@@ -24,6 +24,10 @@
 
 package auth
 
+import (
+	"time"
+)
+
 type (
 	// TLS describe TLS configuration (for Kafka, Cassandra, SQL)
 	TLS struct {
@@ -43,4 +47,54 @@ type (
 
 		ServerName string `yaml:"serverName"`
 	}
+
+	// RootTLS contains the TLS configuration of the gRPC endpoints of all services
+	RootTLS struct {
+		// Internode is the TLS configuration of the traffic between temporal services
+		Internode GroupTLS `yaml:"internode"`
+		// Frontend is the TLS configuration of the frontend endpoint, used by clients and remote clusters
+		Frontend GroupTLS `yaml:"frontend"`
+		// RefreshInterval is how often certificate files are checked for changes on disk,
+		// certificates are reloaded without restart when they change. Defaults to one minute
+		RefreshInterval time.Duration `yaml:"refreshInterval"`
+	}
+
+	// GroupTLS contains the server and client TLS configuration of a group of endpoints
+	GroupTLS struct {
+		// Server is the TLS configuration of the gRPC servers of the group
+		Server ServerTLS `yaml:"server"`
+		// Client is the TLS configuration used to connect to the servers of the group
+		Client ClientTLS `yaml:"client"`
+	}
+
+	// ServerTLS describes the TLS configuration of a gRPC server
+	ServerTLS struct {
+		// CertFile and KeyFile hold the server certificate, TLS is enabled when they are set
+		CertFile string `yaml:"certFile"`
+		KeyFile  string `yaml:"keyFile"`
+		// ClientCAFiles are the CA certificates used to verify client certificates
+		ClientCAFiles []string `yaml:"clientCaFiles"`
+		// RequireClientAuth enables mutual TLS, clients must present a certificate
+		// signed by one of ClientCAFiles
+		RequireClientAuth bool `yaml:"requireClientAuth"`
+	}
+
+	// ClientTLS describes the TLS configuration of a gRPC client
+	ClientTLS struct {
+		// CertFile and KeyFile hold the client certificate presented to servers requiring mutual TLS
+		CertFile string `yaml:"certFile"`
+		KeyFile  string `yaml:"keyFile"`
+		// RootCAFiles are the CA certificates used to verify server certificates,
+		// system root CAs are used when empty
+		RootCAFiles []string `yaml:"rootCaFiles"`
+		// ServerName overrides the host name used to verify server certificates
+		ServerName string `yaml:"serverName"`
+		// DisableHostVerification skips verification of server certificates
+		DisableHostVerification bool `yaml:"disableHostVerification"`
+	}
 )
+
+// IsEnabled returns true when the servers of the group use TLS
+func (g *GroupTLS) IsEnabled() bool {
+	return g.Server.CertFile != ""
+}
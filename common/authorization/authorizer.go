@@ -37,11 +37,13 @@ const (
 
 type (
 	// Attributes is input for authority to make decision.
-	// It can be extended in future if required auth on resources like WorkflowType and TaskList
+	// WorkflowType and TaskList are set only by APIs targeting them.
 	Attributes struct {
-		Actor     string
-		APIName   string
-		Namespace string
+		Actor        string
+		APIName      string
+		Namespace    string
+		WorkflowType string
+		TaskList     string
 	}
 
 	// Result is result from authority.
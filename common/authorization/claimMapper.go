@@ -0,0 +1,94 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"crypto/x509/pkix"
+)
+
+const (
+	// RoleWorker allows polling for and responding to tasks of a namespace
+	RoleWorker Role = 1 << iota
+	// RoleReader allows reading the state of a namespace and its workflows
+	RoleReader
+	// RoleWriter allows starting, signaling and terminating workflows of a namespace
+	RoleWriter
+	// RoleAdmin allows all operations, including namespace management
+	RoleAdmin
+	// RoleUndefined means no role is granted
+	RoleUndefined Role = 0
+)
+
+// SystemNamespace is the namespace name used in permissions to grant roles on the whole cluster,
+// no namespace can be registered with this name
+const SystemNamespace = "system"
+
+type (
+	// Role is a bitmask of roles granted to a caller
+	Role int32
+
+	// Claims describes the authenticated caller
+	Claims struct {
+		// Subject is the identity of the caller
+		Subject string
+		// System is the role of the caller on the whole cluster
+		System Role
+		// Namespaces maps namespace names to the roles of the caller in the namespace
+		Namespaces map[string]Role
+		// Extensions holds custom data set by a ClaimMapper
+		Extensions interface{}
+	}
+
+	// AuthInfo contains the credentials presented by the caller
+	AuthInfo struct {
+		// AuthToken is the bearer token from the authorization header
+		AuthToken string
+		// TLSSubject is the subject of the verified client certificate, nil without mutual TLS
+		TLSSubject *pkix.Name
+	}
+
+	// ClaimMapper maps the credentials of the caller to its claims
+	ClaimMapper interface {
+		// GetClaims returns the claims of the caller, or an error when the credentials are invalid
+		GetClaims(authInfo *AuthInfo) (*Claims, error)
+	}
+)
+
+// Has returns true when all roles of the given mask are granted
+func (r Role) Has(role Role) bool {
+	return r&role == role
+}
+
+// GetRole returns the roles of the caller in the given namespace, including cluster wide roles.
+// Only cluster wide roles apply to APIs without a namespace
+func (c *Claims) GetRole(namespace string) Role {
+	if c == nil {
+		return RoleUndefined
+	}
+	if namespace == "" {
+		return c.System
+	}
+	return c.System | c.Namespaces[namespace]
+}
@@ -0,0 +1,220 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+)
+
+type (
+	// RBACPolicy is the static policy of the default authorizer
+	RBACPolicy struct {
+		// APIRoles overrides the role required to call an API, the role is one of read, write, worker or admin
+		APIRoles map[string]string `yaml:"apiRoles"`
+		// RoleBindings grants permissions to subjects in addition to the permissions of their claims,
+		// permissions use the "<namespace>:<role>" format of token claims
+		RoleBindings map[string][]string `yaml:"roleBindings"`
+	}
+
+	// defaultAuthorizer is a role based authorizer. Each API requires a role in the namespace of the
+	// request, admin implies all roles and writer implies reader. Roles on namespace "system" apply
+	// to all namespaces. Callers without claims are denied, so the server refuses to start it
+	// without authentication. The services of the cluster are allowed as internal callers.
+	defaultAuthorizer struct {
+		policyFile string
+		logger     log.Logger
+		policy     atomic.Value // *rbacRoles
+	}
+
+	// rbacRoles is the parsed RBACPolicy
+	rbacRoles struct {
+		apiRoles map[string]Role
+		bindings map[string]*Claims
+	}
+)
+
+// defaultAPIRoles maps frontend APIs to the role they require, APIs not listed require admin
+var defaultAPIRoles = map[string]Role{
+	"CountWorkflowExecutions":          RoleReader,
+	"DescribeNamespace":                RoleReader,
+	"DescribeTaskList":                 RoleReader,
+	"DescribeWorkflowExecution":        RoleReader,
+	"GetWorkflowExecutionHistory":      RoleReader,
+	"ListArchivedWorkflowExecutions":   RoleReader,
+	"ListClosedWorkflowExecutions":     RoleReader,
+	"ListNamespaces":                   RoleReader,
+	"ListOpenWorkflowExecutions":       RoleReader,
+	"ListTaskListPartitions":           RoleReader,
+	"ListWorkflowExecutions":           RoleReader,
+	"QueryWorkflow":                    RoleReader,
+	"ScanWorkflowExecutions":           RoleReader,
+	"PollForActivityTask":              RoleWorker,
+	"PollForDecisionTask":              RoleWorker,
+	"RecordActivityTaskHeartbeat":      RoleWorker,
+	"RecordActivityTaskHeartbeatById":  RoleWorker,
+	"ResetStickyTaskList":              RoleWorker,
+	"RespondActivityTaskCanceled":      RoleWorker,
+	"RespondActivityTaskCanceledById":  RoleWorker,
+	"RespondActivityTaskCompleted":     RoleWorker,
+	"RespondActivityTaskCompletedById": RoleWorker,
+	"RespondActivityTaskFailed":        RoleWorker,
+	"RespondActivityTaskFailedById":    RoleWorker,
+	"RequestCancelWorkflowExecution":   RoleWriter,
+	"ResetWorkflowExecution":           RoleWriter,
+	"SignalWithStartWorkflowExecution": RoleWriter,
+	"SignalWorkflowExecution":          RoleWriter,
+	"StartWorkflowExecution":           RoleWriter,
+	"TerminateWorkflowExecution":       RoleWriter,
+	"DeprecateNamespace":               RoleAdmin,
+	"RegisterNamespace":                RoleAdmin,
+	"UpdateNamespace":                  RoleAdmin,
+}
+
+var _ Authorizer = (*defaultAuthorizer)(nil)
+
+// NewDefaultAuthorizer creates a role based authorizer, policyFile is an optional YAML RBACPolicy
+// which is reloaded every refreshInterval until shutdownCh is closed
+func NewDefaultAuthorizer(
+	policyFile string,
+	refreshInterval time.Duration,
+	logger log.Logger,
+	shutdownCh <-chan struct{},
+) (Authorizer, error) {
+
+	a := &defaultAuthorizer{
+		policyFile: policyFile,
+		logger:     logger,
+	}
+	if err := a.reload(); err != nil {
+		return nil, err
+	}
+	if policyFile != "" && refreshInterval > 0 {
+		go a.refreshLoop(refreshInterval, shutdownCh)
+	}
+	return a, nil
+}
+
+func (a *defaultAuthorizer) Authorize(
+	ctx context.Context,
+	attributes *Attributes,
+) (Result, error) {
+
+	roles := a.policy.Load().(*rbacRoles)
+	required, ok := roles.apiRoles[attributes.APIName]
+	if !ok {
+		required = RoleAdmin
+	}
+
+	claims := GetClaims(ctx)
+	if claims == nil {
+		return Result{Decision: DecisionDeny}, nil
+	}
+	granted := claims.GetRole(attributes.Namespace) | roles.bindings[claims.Subject].GetRole(attributes.Namespace)
+	if impliedRoles(granted).Has(required) {
+		return Result{Decision: DecisionAllow}, nil
+	}
+	return Result{Decision: DecisionDeny}, nil
+}
+
+func (a *defaultAuthorizer) refreshLoop(refreshInterval time.Duration, shutdownCh <-chan struct{}) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-shutdownCh:
+			return
+		case <-ticker.C:
+			if err := a.reload(); err != nil {
+				a.logger.Error("Failed to reload authorization policy", tag.Error(err))
+			}
+		}
+	}
+}
+
+func (a *defaultAuthorizer) reload() error {
+	policy := &RBACPolicy{}
+	if a.policyFile != "" {
+		data, err := ioutil.ReadFile(a.policyFile)
+		if err != nil {
+			return err
+		}
+		if err := yaml.Unmarshal(data, policy); err != nil {
+			return fmt.Errorf("unable to parse authorization policy %v: %v", a.policyFile, err)
+		}
+	}
+	roles, err := newRBACRoles(policy)
+	if err != nil {
+		return err
+	}
+	a.policy.Store(roles)
+	return nil
+}
+
+func newRBACRoles(policy *RBACPolicy) (*rbacRoles, error) {
+	roles := &rbacRoles{
+		apiRoles: make(map[string]Role, len(defaultAPIRoles)),
+		bindings: make(map[string]*Claims, len(policy.RoleBindings)),
+	}
+	for api, role := range defaultAPIRoles {
+		roles.apiRoles[api] = role
+	}
+	for api, roleName := range policy.APIRoles {
+		role, err := parseRole(roleName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid role for API %v: %v", api, err)
+		}
+		roles.apiRoles[api] = role
+	}
+	for subject, permissions := range policy.RoleBindings {
+		claims := &Claims{Subject: subject, Namespaces: make(map[string]Role)}
+		for _, permission := range permissions {
+			if err := addPermission(claims, permission); err != nil {
+				return nil, err
+			}
+		}
+		roles.bindings[subject] = claims
+	}
+	return roles, nil
+}
+
+// impliedRoles expands the granted roles, admin implies all roles and writer implies reader
+func impliedRoles(role Role) Role {
+	if role.Has(RoleAdmin) {
+		role |= RoleWriter | RoleReader | RoleWorker
+	}
+	if role.Has(RoleWriter) {
+		role |= RoleReader
+	}
+	return role
+}
@@ -0,0 +1,140 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+)
+
+type (
+	defaultAuthorizerSuite struct {
+		suite.Suite
+		policyFile string
+		authorizer Authorizer
+	}
+)
+
+const testPolicy = `
+apiRoles:
+  DescribeNamespace: admin
+roleBindings:
+  carol:
+    - "payments:worker"
+`
+
+func TestDefaultAuthorizerSuite(t *testing.T) {
+	suite.Run(t, new(defaultAuthorizerSuite))
+}
+
+func (s *defaultAuthorizerSuite) SetupTest() {
+	file, err := ioutil.TempFile("", "policy")
+	s.NoError(err)
+	_, err = file.WriteString(testPolicy)
+	s.NoError(err)
+	s.NoError(file.Close())
+	s.policyFile = file.Name()
+
+	s.authorizer, err = NewDefaultAuthorizer(s.policyFile, 0, loggerimpl.NewNopLogger(), nil)
+	s.NoError(err)
+}
+
+func (s *defaultAuthorizerSuite) TearDownTest() {
+	os.Remove(s.policyFile)
+}
+
+func (s *defaultAuthorizerSuite) TestNamespaceRoles() {
+	ctx := s.contextWithClaims(&Claims{
+		Subject:    "alice",
+		Namespaces: map[string]Role{"payments": RoleWriter},
+	})
+	s.assertDecision(DecisionAllow, ctx, "StartWorkflowExecution", "payments")
+	s.assertDecision(DecisionAllow, ctx, "DescribeWorkflowExecution", "payments")
+	s.assertDecision(DecisionDeny, ctx, "PollForDecisionTask", "payments")
+	s.assertDecision(DecisionDeny, ctx, "StartWorkflowExecution", "orders")
+	s.assertDecision(DecisionDeny, ctx, "UpdateNamespace", "payments")
+
+	// a role granted on an empty namespace does not apply to the APIs without a namespace
+	ctx = s.contextWithClaims(&Claims{
+		Subject:    "mallory",
+		Namespaces: map[string]Role{"": RoleAdmin},
+	})
+	s.assertDecision(DecisionDeny, ctx, "RegisterNamespace", "")
+	s.assertDecision(DecisionDeny, ctx, "ListNamespaces", "")
+}
+
+func (s *defaultAuthorizerSuite) TestWorkerRole() {
+	ctx := s.contextWithClaims(&Claims{
+		Subject:    "worker",
+		Namespaces: map[string]Role{"payments": RoleWorker},
+	})
+	s.assertDecision(DecisionAllow, ctx, "PollForActivityTask", "payments")
+	s.assertDecision(DecisionAllow, ctx, "RecordActivityTaskHeartbeat", "payments")
+	s.assertDecision(DecisionAllow, ctx, "RespondActivityTaskCompleted", "payments")
+	s.assertDecision(DecisionAllow, ctx, "RespondActivityTaskFailedById", "payments")
+	s.assertDecision(DecisionDeny, ctx, "RespondActivityTaskCompleted", "orders")
+	s.assertDecision(DecisionDeny, ctx, "RespondActivityTaskCanceledById", "orders")
+	s.assertDecision(DecisionDeny, ctx, "StartWorkflowExecution", "payments")
+}
+
+func (s *defaultAuthorizerSuite) TestSystemAdmin() {
+	ctx := s.contextWithClaims(&Claims{Subject: "root", System: RoleAdmin})
+	s.assertDecision(DecisionAllow, ctx, "RegisterNamespace", "")
+	s.assertDecision(DecisionAllow, ctx, "PollForActivityTask", "orders")
+	s.assertDecision(DecisionAllow, ctx, "UnknownAPI", "orders")
+}
+
+func (s *defaultAuthorizerSuite) TestPolicy() {
+	ctx := s.contextWithClaims(&Claims{Subject: "carol", System: RoleReader})
+	s.assertDecision(DecisionAllow, ctx, "PollForActivityTask", "payments")
+	s.assertDecision(DecisionAllow, ctx, "ListNamespaces", "")
+	s.assertDecision(DecisionDeny, ctx, "DescribeNamespace", "payments")
+}
+
+func (s *defaultAuthorizerSuite) TestUnauthenticated() {
+	s.assertDecision(DecisionDeny, context.Background(), "DescribeWorkflowExecution", "payments")
+}
+
+func (s *defaultAuthorizerSuite) TestInvalidPolicy() {
+	s.NoError(ioutil.WriteFile(s.policyFile, []byte("apiRoles:\n  StartWorkflowExecution: owner\n"), 0600))
+	_, err := NewDefaultAuthorizer(s.policyFile, 0, loggerimpl.NewNopLogger(), nil)
+	s.Error(err)
+}
+
+func (s *defaultAuthorizerSuite) contextWithClaims(claims *Claims) context.Context {
+	return context.WithValue(context.Background(), mappedClaimsKey, claims)
+}
+
+func (s *defaultAuthorizerSuite) assertDecision(expected Decision, ctx context.Context, api string, namespace string) {
+	result, err := s.authorizer.Authorize(ctx, &Attributes{APIName: api, Namespace: namespace})
+	s.NoError(err)
+	s.Equal(expected, result.Decision, "%v on %q", api, namespace)
+}
@@ -0,0 +1,166 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPermissionsClaimName is the token claim holding the permissions of the caller
+	DefaultPermissionsClaimName = "permissions"
+
+	authorizationBearer = "bearer"
+)
+
+type (
+	// defaultJWTClaimMapper maps JWT bearer tokens to claims. The subject of the token is the
+	// identity of the caller, permissions are a list of "<namespace>:<role>" strings where role
+	// is one of read, write, worker or admin, and namespace "system" grants the role on all namespaces.
+	// Without a token the common name of the verified client certificate is used as identity and
+	// is granted the permissions configured for it in peerPermissions.
+	defaultJWTClaimMapper struct {
+		keyProvider          TokenKeyProvider
+		issuer               string
+		audience             string
+		permissionsClaimName string
+		peerPermissions      map[string][]string
+	}
+)
+
+var (
+	errBearerTokenNotAccepted = errors.New("bearer tokens are not accepted, no token keys are configured")
+	errTokenValidationNotSet  = errors.New("token issuer and audience must be set to accept bearer tokens")
+)
+
+var _ ClaimMapper = (*defaultJWTClaimMapper)(nil)
+
+// NewDefaultJWTClaimMapper creates a ClaimMapper validating JWT bearer tokens with keys from keyProvider,
+// bearer tokens are rejected when keyProvider is nil. Tokens must be issued by issuer for audience,
+// which are required when keyProvider is set. peerPermissions are the "<namespace>:<role>"
+// permissions of callers authenticated by client certificate, keyed by certificate common name.
+func NewDefaultJWTClaimMapper(
+	keyProvider TokenKeyProvider,
+	issuer string,
+	audience string,
+	permissionsClaimName string,
+	peerPermissions map[string][]string,
+) (ClaimMapper, error) {
+	if keyProvider != nil && (issuer == "" || audience == "") {
+		return nil, errTokenValidationNotSet
+	}
+	if permissionsClaimName == "" {
+		permissionsClaimName = DefaultPermissionsClaimName
+	}
+	for commonName, permissions := range peerPermissions {
+		for _, permission := range permissions {
+			if err := addPermission(&Claims{Namespaces: make(map[string]Role)}, permission); err != nil {
+				return nil, fmt.Errorf("peer %q: %v", commonName, err)
+			}
+		}
+	}
+	return &defaultJWTClaimMapper{
+		keyProvider:          keyProvider,
+		issuer:               issuer,
+		audience:             audience,
+		permissionsClaimName: permissionsClaimName,
+		peerPermissions:      peerPermissions,
+	}, nil
+}
+
+func (a *defaultJWTClaimMapper) GetClaims(authInfo *AuthInfo) (*Claims, error) {
+	claims := &Claims{Namespaces: make(map[string]Role)}
+	if authInfo.AuthToken == "" {
+		if authInfo.TLSSubject != nil {
+			claims.Subject = authInfo.TLSSubject.CommonName
+			for _, permission := range a.peerPermissions[claims.Subject] {
+				if err := addPermission(claims, permission); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return claims, nil
+	}
+	if a.keyProvider == nil {
+		return nil, errBearerTokenNotAccepted
+	}
+
+	parts := strings.SplitN(authInfo.AuthToken, " ", 2)
+	if len(parts) != 2 || strings.ToLower(parts[0]) != authorizationBearer {
+		return nil, errors.New("authorization header is not a bearer token")
+	}
+	tokenClaims, err := parseJWT(parts[1], a.keyProvider, a.issuer, a.audience, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if subject, ok := tokenClaims["sub"].(string); ok {
+		claims.Subject = subject
+	}
+	permissions, _ := tokenClaims[a.permissionsClaimName].([]interface{})
+	for _, permission := range permissions {
+		value, _ := permission.(string)
+		if err := addPermission(claims, value); err != nil {
+			return nil, err
+		}
+	}
+	return claims, nil
+}
+
+// addPermission grants the role of a "<namespace>:<role>" permission, the role is granted on the
+// whole cluster when the namespace is "system"
+func addPermission(claims *Claims, permission string) error {
+	parts := strings.SplitN(permission, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("invalid permission %q", permission)
+	}
+	role, err := parseRole(parts[1])
+	if err != nil {
+		return fmt.Errorf("invalid permission %q: %v", permission, err)
+	}
+	if parts[0] == SystemNamespace {
+		claims.System |= role
+	} else {
+		claims.Namespaces[parts[0]] |= role
+	}
+	return nil
+}
+
+func parseRole(role string) (Role, error) {
+	switch strings.ToLower(role) {
+	case "read":
+		return RoleReader, nil
+	case "write":
+		return RoleWriter, nil
+	case "worker":
+		return RoleWorker, nil
+	case "admin":
+		return RoleAdmin, nil
+	default:
+		return RoleUndefined, fmt.Errorf("unknown role %q", role)
+	}
+}
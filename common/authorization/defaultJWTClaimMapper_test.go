@@ -0,0 +1,302 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+)
+
+const (
+	testTokenIssuer   = "https://issuer.example.com"
+	testTokenAudience = "temporal"
+)
+
+type (
+	defaultJWTClaimMapperSuite struct {
+		suite.Suite
+		rsaKey      *rsa.PrivateKey
+		ecdsaKey    *ecdsa.PrivateKey
+		keyFile     string
+		claimMapper ClaimMapper
+	}
+)
+
+func TestDefaultJWTClaimMapperSuite(t *testing.T) {
+	suite.Run(t, new(defaultJWTClaimMapperSuite))
+}
+
+func (s *defaultJWTClaimMapperSuite) SetupTest() {
+	var err error
+	s.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.NoError(err)
+	s.ecdsaKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.NoError(err)
+
+	keySet := jwks{Keys: []jwk{
+		{
+			KeyType: "RSA",
+			KeyID:   "rsa-key",
+			N:       encodeSegment(s.rsaKey.N.Bytes()),
+			E:       encodeSegment(big.NewInt(int64(s.rsaKey.E)).Bytes()),
+		},
+		{
+			KeyType: "EC",
+			KeyID:   "ec-key",
+			Curve:   "P-256",
+			X:       encodeSegment(s.ecdsaKey.X.Bytes()),
+			Y:       encodeSegment(s.ecdsaKey.Y.Bytes()),
+		},
+	}}
+	data, err := json.Marshal(keySet)
+	s.NoError(err)
+	file, err := ioutil.TempFile("", "jwks")
+	s.NoError(err)
+	_, err = file.Write(data)
+	s.NoError(err)
+	s.NoError(file.Close())
+	s.keyFile = file.Name()
+
+	keyProvider, err := NewDefaultTokenKeyProvider([]string{s.keyFile}, 0, loggerimpl.NewNopLogger(), nil)
+	s.NoError(err)
+	s.claimMapper, err = NewDefaultJWTClaimMapper(keyProvider, testTokenIssuer, testTokenAudience, "", map[string][]string{
+		"worker-1": {"payments:worker"},
+		"frontend": {"system:admin"},
+	})
+	s.NoError(err)
+}
+
+func (s *defaultJWTClaimMapperSuite) TearDownTest() {
+	os.Remove(s.keyFile)
+}
+
+func (s *defaultJWTClaimMapperSuite) TestRSAToken() {
+	token := s.rsaToken(tokenClaims(map[string]interface{}{
+		"sub":         "alice",
+		"permissions": []string{"system:read", "payments:write", "payments:worker"},
+	}))
+	claims, err := s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + token})
+	s.NoError(err)
+	s.Equal("alice", claims.Subject)
+	s.Equal(RoleReader, claims.System)
+	s.True(claims.GetRole("payments").Has(RoleReader | RoleWriter | RoleWorker))
+	s.False(claims.GetRole("payments").Has(RoleAdmin))
+	s.Equal(RoleReader, claims.GetRole("orders"))
+}
+
+func (s *defaultJWTClaimMapperSuite) TestInvalidPermissions() {
+	// an empty namespace would grant the role on the APIs without a namespace
+	token := s.rsaToken(tokenClaims(map[string]interface{}{"sub": "mallory", "permissions": []string{":admin"}}))
+	_, err := s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + token})
+	s.Error(err)
+
+	token = s.rsaToken(tokenClaims(map[string]interface{}{"sub": "mallory", "permissions": []string{"payments:owner"}}))
+	_, err = s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + token})
+	s.Error(err)
+}
+
+func (s *defaultJWTClaimMapperSuite) TestSystemPermission() {
+	token := s.rsaToken(tokenClaims(map[string]interface{}{"sub": "root", "permissions": []string{"system:admin"}}))
+	claims, err := s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + token})
+	s.NoError(err)
+	s.Equal(RoleAdmin, claims.System)
+	s.Empty(claims.Namespaces)
+	s.Equal(RoleAdmin, claims.GetRole(""))
+	s.Equal(RoleAdmin, claims.GetRole("payments"))
+
+	// namespace roles do not apply to the APIs without a namespace
+	token = s.rsaToken(tokenClaims(map[string]interface{}{"sub": "alice", "permissions": []string{"payments:admin"}}))
+	claims, err = s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + token})
+	s.NoError(err)
+	s.Equal(RoleAdmin, claims.GetRole("payments"))
+	s.Equal(RoleUndefined, claims.GetRole(""))
+}
+
+func (s *defaultJWTClaimMapperSuite) TestECDSAToken() {
+	token := s.ecdsaToken(tokenClaims(map[string]interface{}{"sub": "bob", "aud": []string{"other", testTokenAudience}}))
+	claims, err := s.claimMapper.GetClaims(&AuthInfo{AuthToken: "bearer " + token})
+	s.NoError(err)
+	s.Equal("bob", claims.Subject)
+	s.Equal(RoleUndefined, claims.GetRole("payments"))
+}
+
+func (s *defaultJWTClaimMapperSuite) TestInvalidToken() {
+	_, err := s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Basic abc"})
+	s.Error(err)
+
+	_, err = s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer abc"})
+	s.Error(err)
+
+	expired := s.rsaToken(tokenClaims(map[string]interface{}{"sub": "alice", "exp": time.Now().Add(-time.Hour).Unix()}))
+	_, err = s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + expired})
+	s.Equal(errTokenExpired, err)
+
+	// signature does not match the modified payload
+	token := s.rsaToken(tokenClaims(map[string]interface{}{"sub": "alice"}))
+	forged := s.rsaToken(tokenClaims(map[string]interface{}{"sub": "mallory"}))
+	_, err = s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + forged[:len(forged)-10] + token[len(token)-10:]})
+	s.Error(err)
+}
+
+func (s *defaultJWTClaimMapperSuite) TestTokenWithoutExpiry() {
+	token := s.rsaToken(tokenClaims(map[string]interface{}{"sub": "alice", "exp": nil}))
+	_, err := s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + token})
+	s.Equal(errTokenExpiryNotSet, err)
+}
+
+func (s *defaultJWTClaimMapperSuite) TestTokenIssuer() {
+	token := s.rsaToken(tokenClaims(map[string]interface{}{"sub": "alice", "iss": "https://other.example.com"}))
+	_, err := s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + token})
+	s.Equal(errTokenIssuerNotValid, err)
+
+	token = s.rsaToken(tokenClaims(map[string]interface{}{"sub": "alice", "iss": nil}))
+	_, err = s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + token})
+	s.Equal(errTokenIssuerNotValid, err)
+}
+
+func (s *defaultJWTClaimMapperSuite) TestTokenAudience() {
+	token := s.rsaToken(tokenClaims(map[string]interface{}{"sub": "alice", "aud": "other"}))
+	_, err := s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + token})
+	s.Equal(errTokenAudienceNotValid, err)
+
+	token = s.ecdsaToken(tokenClaims(map[string]interface{}{"sub": "alice", "aud": []string{"other"}}))
+	_, err = s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + token})
+	s.Equal(errTokenAudienceNotValid, err)
+
+	token = s.rsaToken(tokenClaims(map[string]interface{}{"sub": "alice", "aud": nil}))
+	_, err = s.claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + token})
+	s.Equal(errTokenAudienceNotValid, err)
+}
+
+func (s *defaultJWTClaimMapperSuite) TestTokenValidationNotSet() {
+	keyProvider, err := NewDefaultTokenKeyProvider([]string{s.keyFile}, 0, loggerimpl.NewNopLogger(), nil)
+	s.NoError(err)
+	_, err = NewDefaultJWTClaimMapper(keyProvider, testTokenIssuer, "", "", nil)
+	s.Equal(errTokenValidationNotSet, err)
+	_, err = NewDefaultJWTClaimMapper(keyProvider, "", testTokenAudience, "", nil)
+	s.Equal(errTokenValidationNotSet, err)
+}
+
+func (s *defaultJWTClaimMapperSuite) TestTLSSubject() {
+	claims, err := s.claimMapper.GetClaims(&AuthInfo{TLSSubject: &pkix.Name{CommonName: "worker-1"}})
+	s.NoError(err)
+	s.Equal("worker-1", claims.Subject)
+	s.Equal(RoleWorker, claims.GetRole("payments"))
+	s.Equal(RoleUndefined, claims.System)
+
+	claims, err = s.claimMapper.GetClaims(&AuthInfo{TLSSubject: &pkix.Name{CommonName: "frontend"}})
+	s.NoError(err)
+	s.Equal(RoleAdmin, claims.System)
+
+	claims, err = s.claimMapper.GetClaims(&AuthInfo{TLSSubject: &pkix.Name{CommonName: "unknown"}})
+	s.NoError(err)
+	s.Equal(RoleUndefined, claims.GetRole("payments"))
+
+	claims, err = s.claimMapper.GetClaims(&AuthInfo{})
+	s.NoError(err)
+	s.Equal("", claims.Subject)
+}
+
+func (s *defaultJWTClaimMapperSuite) TestTokenWithoutKeyProvider() {
+	claimMapper, err := NewDefaultJWTClaimMapper(nil, "", "", "", nil)
+	s.NoError(err)
+	token := s.rsaToken(tokenClaims(map[string]interface{}{"sub": "alice"}))
+	_, err = claimMapper.GetClaims(&AuthInfo{AuthToken: "Bearer " + token})
+	s.Equal(errBearerTokenNotAccepted, err)
+}
+
+func (s *defaultJWTClaimMapperSuite) TestInvalidPeerPermissions() {
+	_, err := NewDefaultJWTClaimMapper(nil, "", "", "", map[string][]string{"worker-1": {"payments"}})
+	s.Error(err)
+	_, err = NewDefaultJWTClaimMapper(nil, "", "", "", map[string][]string{"worker-1": {":admin"}})
+	s.Error(err)
+	_, err = NewDefaultJWTClaimMapper(nil, "", "", "", map[string][]string{"worker-1": {"payments:owner"}})
+	s.Error(err)
+}
+
+func (s *defaultJWTClaimMapperSuite) rsaToken(claims map[string]interface{}) string {
+	signingInput := s.signingInput("RS256", "rsa-key", claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.rsaKey, crypto.SHA256, digest[:])
+	s.NoError(err)
+	return signingInput + "." + encodeSegment(signature)
+}
+
+func (s *defaultJWTClaimMapperSuite) ecdsaToken(claims map[string]interface{}) string {
+	signingInput := s.signingInput("ES256", "ec-key", claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, sig, err := ecdsa.Sign(rand.Reader, s.ecdsaKey, digest[:])
+	s.NoError(err)
+	signature := make([]byte, 64)
+	rBytes, sBytes := r.Bytes(), sig.Bytes()
+	copy(signature[32-len(rBytes):32], rBytes)
+	copy(signature[64-len(sBytes):], sBytes)
+	return signingInput + "." + encodeSegment(signature)
+}
+
+func (s *defaultJWTClaimMapperSuite) signingInput(alg string, keyID string, claims map[string]interface{}) string {
+	header, err := json.Marshal(jwtHeader{Algorithm: alg, KeyID: keyID})
+	s.NoError(err)
+	payload, err := json.Marshal(claims)
+	s.NoError(err)
+	return encodeSegment(header) + "." + encodeSegment(payload)
+}
+
+// tokenClaims returns valid expiry, issuer and audience claims merged with claims, a nil claim is removed
+func tokenClaims(claims map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iss": testTokenIssuer,
+		"aud": testTokenAudience,
+	}
+	for name, value := range claims {
+		if value == nil {
+			delete(result, name)
+		} else {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
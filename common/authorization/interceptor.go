@@ -0,0 +1,108 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogo/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+)
+
+type (
+	contextKey int
+)
+
+const (
+	mappedClaimsKey contextKey = iota
+
+	authorizationHeader = "authorization"
+
+	// healthServicePrefix is the method prefix of the gRPC health service, health checks
+	// are allowed without credentials
+	healthServicePrefix = "/grpc.health.v1.Health/"
+)
+
+var errUnauthenticated = status.Error(codes.Unauthenticated, "Request unauthenticated.")
+
+// NewAuthenticationInterceptor creates a gRPC interceptor authenticating callers with the given
+// ClaimMapper, the claims of the caller are added to the context of the request.
+// Callers must present a bearer token or a verified client certificate, only health checks
+// are allowed without credentials.
+func NewAuthenticationInterceptor(claimMapper ClaimMapper, logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+
+		authInfo := &AuthInfo{}
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(authorizationHeader); len(values) > 0 {
+				authInfo.AuthToken = values[0]
+			}
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+				chains := tlsInfo.State.VerifiedChains
+				if len(chains) > 0 && len(chains[0]) > 0 {
+					subject := chains[0][0].Subject
+					authInfo.TLSSubject = &subject
+				}
+			}
+		}
+
+		if authInfo.AuthToken == "" && authInfo.TLSSubject == nil {
+			if strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+				return handler(ctx, req)
+			}
+			logger.Warn("Authentication failed", tag.Name(info.FullMethod), tag.Error(errUnauthenticated))
+			return nil, errUnauthenticated
+		}
+
+		claims, err := claimMapper.GetClaims(authInfo)
+		if err != nil {
+			logger.Warn("Authentication failed", tag.Name(info.FullMethod), tag.Error(err))
+			return nil, errUnauthenticated
+		}
+		return handler(context.WithValue(ctx, mappedClaimsKey, claims), req)
+	}
+}
+
+// GetClaims returns the claims of the caller added by the authentication interceptor, nil when
+// authentication is not enabled
+func GetClaims(ctx context.Context) *Claims {
+	claims, _ := ctx.Value(mappedClaimsKey).(*Claims)
+	return claims
+}
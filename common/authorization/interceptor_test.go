@@ -0,0 +1,74 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/gogo/status"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+)
+
+func TestAuthenticationInterceptor(t *testing.T) {
+	claimMapper, err := NewDefaultJWTClaimMapper(nil, "", "", "", map[string][]string{"worker-1": {"payments:worker"}})
+	assert.NoError(t, err)
+	interceptor := NewAuthenticationInterceptor(claimMapper, loggerimpl.NewNopLogger())
+
+	var handledClaims *Claims
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handledClaims = GetClaims(ctx)
+		return req, nil
+	}
+	pollInfo := &grpc.UnaryServerInfo{FullMethod: "/temporal.workflowservice.WorkflowService/PollForDecisionTask"}
+
+	// callers without credentials are unauthenticated
+	_, err = interceptor(context.Background(), nil, pollInfo, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// except for health checks
+	healthInfo := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	_, err = interceptor(context.Background(), nil, healthInfo, handler)
+	assert.NoError(t, err)
+
+	// callers with a verified client certificate are mapped
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "worker-1"}}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+	})
+	_, err = interceptor(ctx, nil, pollInfo, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "worker-1", handledClaims.Subject)
+	assert.Equal(t, RoleWorker, handledClaims.GetRole("payments"))
+}
@@ -0,0 +1,63 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+)
+
+// internalCallerPermission is granted to the cluster's own services, such as the system
+// workers and the replication of remote clusters, which call frontend like any client
+const internalCallerPermission = SystemNamespace + ":admin"
+
+var errInternalCallerCertNotSet = errors.New("internal callers authenticate with the frontend client certificate, " +
+	"it must be set when authentication is enabled")
+
+// WithInternalCaller returns peerPermissions granting system admin to the common name of the
+// frontend client certificate in certFile, the certificate used by the services of the cluster
+// to call frontend. Remote clusters using a different certificate must be added to peerPermissions.
+func WithInternalCaller(peerPermissions map[string][]string, certFile string, keyFile string) (map[string][]string, error) {
+	if certFile == "" {
+		return nil, errInternalCallerCertNotSet
+	}
+	keyPair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string, len(peerPermissions)+1)
+	for commonName, permissions := range peerPermissions {
+		result[commonName] = permissions
+	}
+	commonName := cert.Subject.CommonName
+	result[commonName] = append(append([]string(nil), result[commonName]...), internalCallerPermission)
+	return result, nil
+}
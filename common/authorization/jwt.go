@@ -0,0 +1,155 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+type (
+	// jwtHeader is the JOSE header of a token
+	jwtHeader struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+)
+
+var (
+	errTokenMalformed        = errors.New("malformed token")
+	errTokenExpired          = errors.New("token is expired")
+	errTokenNotValid         = errors.New("token is not valid yet")
+	errTokenExpiryNotSet     = errors.New("token has no expiration time")
+	errTokenIssuerNotValid   = errors.New("token is not issued by the accepted issuer")
+	errTokenAudienceNotValid = errors.New("token is not intended for the accepted audience")
+)
+
+// parseJWT verifies the signature, time validity, issuer and audience of a compact serialized JWT and
+// returns its claims, RS256 and ES256 signatures are supported. Tokens without an expiration time are rejected
+func parseJWT(
+	token string,
+	keyProvider TokenKeyProvider,
+	issuer string,
+	audience string,
+	now time.Time,
+) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errTokenMalformed
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errTokenMalformed
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+
+	switch header.Algorithm {
+	case "RS256":
+		key, err := keyProvider.RsaKey(header.KeyID)
+		if err != nil {
+			return nil, err
+		}
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return nil, fmt.Errorf("invalid token signature: %v", err)
+		}
+	case "ES256":
+		key, err := keyProvider.EcdsaKey(header.KeyID)
+		if err != nil {
+			return nil, err
+		}
+		if len(signature) != 64 {
+			return nil, errTokenMalformed
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(key, digest[:], r, s) {
+			return nil, errors.New("invalid token signature")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported token signing algorithm %q", header.Algorithm)
+	}
+
+	claims := make(map[string]interface{})
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, errTokenExpiryNotSet
+	}
+	if now.Unix() >= int64(exp) {
+		return nil, errTokenExpired
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Unix() < int64(nbf) {
+		return nil, errTokenNotValid
+	}
+	if iss, _ := claims["iss"].(string); iss != issuer {
+		return nil, errTokenIssuerNotValid
+	}
+	if !hasAudience(claims["aud"], audience) {
+		return nil, errTokenAudienceNotValid
+	}
+	return claims, nil
+}
+
+// hasAudience returns true when the aud claim, a string or an array of strings, contains audience
+func hasAudience(aud interface{}, audience string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, value := range aud {
+			if value == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errTokenMalformed
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errTokenMalformed
+	}
+	return nil
+}
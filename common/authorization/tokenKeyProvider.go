@@ -0,0 +1,206 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+)
+
+type (
+	// TokenKeyProvider provides the public keys used to verify token signatures
+	TokenKeyProvider interface {
+		RsaKey(keyID string) (*rsa.PublicKey, error)
+		EcdsaKey(keyID string) (*ecdsa.PublicKey, error)
+	}
+
+	// jwks is a JSON web key set
+	jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	// jwk is a JSON web key, only RSA and EC public keys are supported
+	jwk struct {
+		KeyType string `json:"kty"`
+		KeyID   string `json:"kid"`
+		N       string `json:"n"`
+		E       string `json:"e"`
+		Curve   string `json:"crv"`
+		X       string `json:"x"`
+		Y       string `json:"y"`
+	}
+
+	// defaultTokenKeyProvider loads keys from JWKS files and reloads them periodically
+	defaultTokenKeyProvider struct {
+		keySourceFiles []string
+		logger         log.Logger
+
+		sync.RWMutex
+		rsaKeys   map[string]*rsa.PublicKey
+		ecdsaKeys map[string]*ecdsa.PublicKey
+	}
+)
+
+var _ TokenKeyProvider = (*defaultTokenKeyProvider)(nil)
+
+// NewDefaultTokenKeyProvider creates a TokenKeyProvider loading keys from the given JWKS files,
+// files are reloaded every refreshInterval until shutdownCh is closed
+func NewDefaultTokenKeyProvider(
+	keySourceFiles []string,
+	refreshInterval time.Duration,
+	logger log.Logger,
+	shutdownCh <-chan struct{},
+) (TokenKeyProvider, error) {
+
+	p := &defaultTokenKeyProvider{
+		keySourceFiles: keySourceFiles,
+		logger:         logger,
+	}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+	if refreshInterval > 0 {
+		go p.refreshLoop(refreshInterval, shutdownCh)
+	}
+	return p, nil
+}
+
+func (p *defaultTokenKeyProvider) RsaKey(keyID string) (*rsa.PublicKey, error) {
+	p.RLock()
+	defer p.RUnlock()
+	key, ok := p.rsaKeys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("RSA key %q not found", keyID)
+	}
+	return key, nil
+}
+
+func (p *defaultTokenKeyProvider) EcdsaKey(keyID string) (*ecdsa.PublicKey, error) {
+	p.RLock()
+	defer p.RUnlock()
+	key, ok := p.ecdsaKeys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("ECDSA key %q not found", keyID)
+	}
+	return key, nil
+}
+
+func (p *defaultTokenKeyProvider) refreshLoop(refreshInterval time.Duration, shutdownCh <-chan struct{}) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-shutdownCh:
+			return
+		case <-ticker.C:
+			if err := p.reload(); err != nil {
+				p.logger.Error("Failed to reload token keys", tag.Error(err))
+			}
+		}
+	}
+}
+
+func (p *defaultTokenKeyProvider) reload() error {
+	rsaKeys := make(map[string]*rsa.PublicKey)
+	ecdsaKeys := make(map[string]*ecdsa.PublicKey)
+	for _, file := range p.keySourceFiles {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		var keySet jwks
+		if err := json.Unmarshal(data, &keySet); err != nil {
+			return fmt.Errorf("unable to parse key set %v: %v", file, err)
+		}
+		for _, key := range keySet.Keys {
+			switch key.KeyType {
+			case "RSA":
+				rsaKey, err := key.rsaKey()
+				if err != nil {
+					return fmt.Errorf("invalid key %q in %v: %v", key.KeyID, file, err)
+				}
+				rsaKeys[key.KeyID] = rsaKey
+			case "EC":
+				ecdsaKey, err := key.ecdsaKey()
+				if err != nil {
+					return fmt.Errorf("invalid key %q in %v: %v", key.KeyID, file, err)
+				}
+				ecdsaKeys[key.KeyID] = ecdsaKey
+			}
+		}
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	p.rsaKeys = rsaKeys
+	p.ecdsaKeys = ecdsaKeys
+	return nil
+}
+
+func (k *jwk) rsaKey() (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeBigInt(k.E)
+	if err != nil {
+		return nil, err
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func (k *jwk) ecdsaKey() (*ecdsa.PublicKey, error) {
+	if k.Curve != "P-256" {
+		return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+	}
+	x, err := decodeBigInt(k.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeBigInt(k.Y)
+	if err != nil {
+		return nil, err
+	}
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
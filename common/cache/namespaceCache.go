@@ -38,6 +38,7 @@ import (
 	namespacepb "go.temporal.io/temporal-proto/namespace"
 	"go.temporal.io/temporal-proto/serviceerror"
 
+	namespacegenpb "github.com/temporalio/temporal/.gen/proto/namespace"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/clock"
@@ -47,6 +48,7 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/primitives"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
 // ReplicationPolicy is the namespace's replication policy,
@@ -801,6 +803,50 @@ var SampleRetentionKey = "sample_retention_days"
 // SampleRateKey is key to specify sample rate
 var SampleRateKey = "sample_retention_rate"
 
+// NamespaceQuota selects one of the quotas of a namespace
+type NamespaceQuota func(quotas *namespacegenpb.NamespaceQuotas) int32
+
+var (
+	// QuotaRPS selects the RPS limit of the namespace per frontend host
+	QuotaRPS NamespaceQuota = (*namespacegenpb.NamespaceQuotas).GetRps
+	// QuotaGlobalRPS selects the RPS limit of the namespace for the whole cluster
+	QuotaGlobalRPS NamespaceQuota = (*namespacegenpb.NamespaceQuotas).GetGlobalRps
+	// QuotaVisibilityListQPS selects the QPS limit of the namespace for listing workflows, enforced when visibility sampling is enabled
+	QuotaVisibilityListQPS NamespaceQuota = (*namespacegenpb.NamespaceQuotas).GetVisibilityListQps
+)
+
+// NamespaceQuotaFn returns a dynamic config property that reads the quota from the namespace config,
+// falling back to the dynamic config value when the namespace has no such quota
+func NamespaceQuotaFn(
+	namespaceCache NamespaceCache,
+	quota NamespaceQuota,
+	defaultFn dynamicconfig.IntPropertyFnWithNamespaceFilter,
+) dynamicconfig.IntPropertyFnWithNamespaceFilter {
+
+	return func(namespace string) int {
+		if namespace != "" {
+			if entry, err := namespaceCache.GetNamespace(namespace); err == nil {
+				if value, ok := entry.GetQuota(quota); ok {
+					return value
+				}
+			}
+		}
+		return defaultFn(namespace)
+	}
+}
+
+// GetQuota returns the given quota of the namespace, if it is set
+func (entry *NamespaceCacheEntry) GetQuota(
+	quota NamespaceQuota,
+) (int, bool) {
+
+	value := quota(entry.config.GetQuotas())
+	if value <= 0 {
+		return 0, false
+	}
+	return int(value), true
+}
+
 // GetRetentionDays returns retention in days for given workflow
 func (entry *NamespaceCacheEntry) GetRetentionDays(
 	workflowID string,
@@ -28,12 +28,15 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally"
 	namespacepb "go.temporal.io/temporal-proto/namespace"
+	"go.temporal.io/temporal-proto/serviceerror"
 
+	namespacegenpb "github.com/temporalio/temporal/.gen/proto/namespace"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/log"
@@ -42,6 +45,7 @@ import (
 	"github.com/temporalio/temporal/common/mocks"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/primitives"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
 type (
@@ -673,3 +677,48 @@ func Test_IsSampledForLongerRetention(t *testing.T) {
 	d.info.Data[SampleRateKey] = "invalid-value"
 	require.False(t, d.IsSampledForLongerRetention(wid))
 }
+
+func Test_GetQuota(t *testing.T) {
+	d := &NamespaceCacheEntry{
+		config: &persistenceblobs.NamespaceConfig{},
+	}
+	_, ok := d.GetQuota(QuotaRPS)
+	require.False(t, ok)
+
+	d.config.Quotas = &namespacegenpb.NamespaceQuotas{Rps: 100}
+	quota, ok := d.GetQuota(QuotaRPS)
+	require.True(t, ok)
+	require.Equal(t, 100, quota)
+	_, ok = d.GetQuota(QuotaGlobalRPS)
+	require.False(t, ok)
+
+	d.config.Quotas.Rps = 0
+	_, ok = d.GetQuota(QuotaRPS)
+	require.False(t, ok) // removed
+}
+
+func Test_NamespaceQuotaFn(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	namespaceCache := NewMockNamespaceCache(controller)
+	entry := &NamespaceCacheEntry{
+		info: &persistenceblobs.NamespaceInfo{Name: "test-namespace"},
+		config: &persistenceblobs.NamespaceConfig{
+			Quotas: &namespacegenpb.NamespaceQuotas{Rps: 100, VisibilityListQps: 5},
+		},
+	}
+	namespaceCache.EXPECT().GetNamespace("test-namespace").Return(entry, nil).AnyTimes()
+	namespaceCache.EXPECT().GetNamespace("other-namespace").Return(nil, serviceerror.NewNotFound("not found")).AnyTimes()
+
+	rpsFn := NamespaceQuotaFn(namespaceCache, QuotaRPS, dynamicconfig.GetIntPropertyFilteredByNamespace(10))
+	require.Equal(t, 100, rpsFn("test-namespace"))
+	require.Equal(t, 10, rpsFn("other-namespace"))
+	require.Equal(t, 10, rpsFn(""))
+
+	globalRPSFn := NamespaceQuotaFn(namespaceCache, QuotaGlobalRPS, dynamicconfig.GetIntPropertyFilteredByNamespace(0))
+	require.Equal(t, 0, globalRPSFn("test-namespace"))
+
+	listQPSFn := NamespaceQuotaFn(namespaceCache, QuotaVisibilityListQPS, dynamicconfig.GetIntPropertyFilteredByNamespace(10))
+	require.Equal(t, 5, listQPSFn("test-namespace"))
+}
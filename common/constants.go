@@ -94,6 +94,10 @@ const (
 const (
 	// VisibilityAppName is used to find kafka topics and ES indexName for visibility
 	VisibilityAppName = "visibility"
+	// AuditAppName is used to find the kafka topic of the audit log
+	AuditAppName = "audit"
+	// LifecycleAppName is used to find the kafka topic of the workflow lifecycle events
+	LifecycleAppName = "lifecycle"
 )
 
 // This was flagged by salus as potentially hardcoded credentials. This is a false positive by the scanner and should be
@@ -162,3 +166,17 @@ const (
 	// TaskTypeReplication is the task type for replication task
 	TaskTypeReplication
 )
+
+const (
+	// ActivityStartDelayHeader is the activity header field asking history to hold a scheduled
+	// activity for the given base 10 number of seconds before dispatching it to matching.
+	// It stands in for a start delay on ScheduleActivityTaskDecisionAttributes, which the
+	// public API does not have yet.
+	ActivityStartDelayHeader = "temporal-activity-start-delay-seconds"
+)
+
+const (
+	// TaskListAffinityPrefix is the naming prefix of affinity task lists, which are bound
+	// to a single activity worker and are of the form /__temporal_affinity/[affinity-key]
+	TaskListAffinityPrefix = "/__temporal_affinity/"
+)
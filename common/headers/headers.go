@@ -41,6 +41,15 @@ const (
 
 	// ClientImplHeaderName refers to the name of the gRPC metadata header that contains the client implementation.
 	ClientImplHeaderName = "temporal-client-name"
+
+	// EagerActivityDispatchHeaderName refers to the name of the gRPC metadata header a worker sets on RespondDecisionTaskCompleted
+	// to the max number of activity tasks, scheduled by the decision on the task list of the workflow, it wants to be started
+	// and returned with the response instead of being dispatched through matching.
+	EagerActivityDispatchHeaderName = "temporal-eager-activity-dispatch"
+
+	// EagerActivityTasksHeaderName refers to the name of the gRPC response metadata header of RespondDecisionTaskCompleted
+	// that carries the activity tasks started eagerly, every value is a serialized PollForActivityTaskResponse.
+	EagerActivityTasksHeaderName = "temporal-eager-activity-tasks-bin"
 )
 
 var (
@@ -0,0 +1,214 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type (
+	// CheckFn checks a dependency or a component of a host, it returns a description of its state
+	// and an error when the host is not ready to serve because of it
+	CheckFn func(ctx context.Context) (string, error)
+
+	// Checker runs the checks of the host of a service, it serves their result as the gRPC health
+	// of the host and as the HTTP endpoints of the liveness and readiness probes of Kubernetes:
+	// /health/live always succeeds and /health/ready fails with 503 when a check fails
+	Checker struct {
+		service string
+
+		sync.RWMutex
+		checks []namedCheck
+	}
+
+	namedCheck struct {
+		name string
+		fn   CheckFn
+	}
+
+	// Result is the result of the checks of a host
+	Result struct {
+		Service string        `json:"service"`
+		Ready   bool          `json:"ready"`
+		Checks  []CheckResult `json:"checks,omitempty"`
+	}
+
+	// CheckResult is the result of a check
+	CheckResult struct {
+		Name   string `json:"name"`
+		Ready  bool   `json:"ready"`
+		Detail string `json:"detail,omitempty"`
+		Error  string `json:"error,omitempty"`
+	}
+)
+
+const (
+	// LivePath is the path of the liveness endpoint
+	LivePath = "/health/live"
+	// ReadyPath is the path of the readiness endpoint
+	ReadyPath = "/health/ready"
+
+	checkTimeout  = 5 * time.Second
+	watchInterval = 5 * time.Second
+)
+
+var (
+	_ healthpb.HealthServer = (*Checker)(nil)
+	_ http.Handler          = (*Checker)(nil)
+
+	errCheckTimedOut = errors.New("check timed out")
+)
+
+// NewChecker creates the checker of the host of a service
+func NewChecker(service string) *Checker {
+	return &Checker{service: service}
+}
+
+// Register adds a check, checks run concurrently and a check not returning within 5 seconds fails
+func (c *Checker) Register(name string, fn CheckFn) {
+	c.Lock()
+	defer c.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, fn: fn})
+}
+
+// Run runs the checks, the host is ready when all of them succeed
+func (c *Checker) Run(ctx context.Context) Result {
+	c.RLock()
+	checks := c.checks
+	c.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	wg.Add(len(checks))
+	for i, check := range checks {
+		go func(i int, check namedCheck) {
+			defer wg.Done()
+			results[i] = runCheck(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	ready := true
+	for _, result := range results {
+		ready = ready && result.Ready
+	}
+	return Result{
+		Service: c.service,
+		Ready:   ready,
+		Checks:  results,
+	}
+}
+
+func runCheck(ctx context.Context, check namedCheck) CheckResult {
+	type checkOutput struct {
+		detail string
+		err    error
+	}
+	// the check may not honor the context, its result is dropped when it returns too late
+	outputC := make(chan checkOutput, 1)
+	go func() {
+		detail, err := check.fn(ctx)
+		outputC <- checkOutput{detail: detail, err: err}
+	}()
+
+	var output checkOutput
+	select {
+	case output = <-outputC:
+	case <-ctx.Done():
+		output.err = errCheckTimedOut
+	}
+
+	result := CheckResult{Name: check.name, Ready: output.err == nil, Detail: output.detail}
+	if output.err != nil {
+		result.Error = output.err.Error()
+	}
+	return result
+}
+
+// Check implements the gRPC health check, the host is serving when it is ready
+// https://github.com/grpc/grpc/blob/master/doc/health-checking.md
+func (c *Checker) Check(ctx context.Context, _ *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	return &healthpb.HealthCheckResponse{Status: servingStatus(c.Run(ctx))}, nil
+}
+
+// Watch implements the gRPC health watch, it sends the status of the host when it changes
+func (c *Checker) Watch(_ *healthpb.HealthCheckRequest, server healthpb.Health_WatchServer) error {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	lastStatus := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		status := servingStatus(c.Run(server.Context()))
+		if status != lastStatus {
+			if err := server.Send(&healthpb.HealthCheckResponse{Status: status}); err != nil {
+				return err
+			}
+			lastStatus = status
+		}
+
+		select {
+		case <-server.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func servingStatus(result Result) healthpb.HealthCheckResponse_ServingStatus {
+	if result.Ready {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+// ServeHTTP serves the liveness and readiness endpoints, the readiness endpoint returns the result of the checks
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var result Result
+	switch r.URL.Path {
+	case LivePath:
+		result = Result{Service: c.service, Ready: true}
+	case ReadyPath:
+		result = c.Run(r.Context())
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !result.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,155 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+)
+
+func TestCheckerReady(t *testing.T) {
+	checker := NewChecker("history")
+	checker.Register("persistence", func(context.Context) (string, error) { return "", nil })
+	checker.Register("shards", func(context.Context) (string, error) { return "4 of 4 owned shards acquired", nil })
+
+	result := checker.Run(context.Background())
+	assert.Equal(t, Result{
+		Service: "history",
+		Ready:   true,
+		Checks: []CheckResult{
+			{Name: "persistence", Ready: true},
+			{Name: "shards", Ready: true, Detail: "4 of 4 owned shards acquired"},
+		},
+	}, result)
+
+	response, err := checker.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.Status)
+}
+
+func TestCheckerNotReady(t *testing.T) {
+	checker := NewChecker("history")
+	checker.Register("persistence", func(context.Context) (string, error) { return "", nil })
+	checker.Register("shards", func(context.Context) (string, error) {
+		return "1 of 4 owned shards acquired", errors.New("3 owned shards are not acquired")
+	})
+
+	result := checker.Run(context.Background())
+	assert.False(t, result.Ready)
+	assert.Equal(t, CheckResult{
+		Name:   "shards",
+		Detail: "1 of 4 owned shards acquired",
+		Error:  "3 owned shards are not acquired",
+	}, result.Checks[1])
+
+	response, err := checker.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, response.Status)
+}
+
+func TestCheckerTimeout(t *testing.T) {
+	checker := NewChecker("matching")
+	blockC := make(chan struct{})
+	defer close(blockC)
+	checker.Register("blocked", func(context.Context) (string, error) {
+		<-blockC
+		return "", nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	result := checker.Run(ctx)
+	assert.False(t, result.Ready)
+	assert.Equal(t, errCheckTimedOut.Error(), result.Checks[0].Error)
+}
+
+func TestCheckerHTTP(t *testing.T) {
+	ready := true
+	checker := NewChecker("frontend")
+	checker.Register("handler", func(context.Context) (string, error) {
+		if !ready {
+			return "", errors.New("shutting down")
+		}
+		return "", nil
+	})
+
+	get := func(path string) (int, Result) {
+		recorder := httptest.NewRecorder()
+		checker.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		var result Result
+		if recorder.Code != http.StatusNotFound {
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+		}
+		return recorder.Code, result
+	}
+
+	code, result := get(ReadyPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, result.Ready)
+
+	ready = false
+	code, result = get(ReadyPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "shutting down", result.Checks[0].Error)
+
+	code, result = get(LivePath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, result.Ready)
+
+	code, _ = get("/metrics")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := NewServer(NewChecker("matching"), listener, loggerimpl.NewNopLogger())
+	server.Start()
+	defer server.Stop()
+
+	response, err := http.Get(fmt.Sprintf("http://%v%v", listener.Addr(), ReadyPath))
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	// a server without listener is disabled
+	disabled := NewServer(NewChecker("matching"), nil, loggerimpl.NewNopLogger())
+	disabled.Start()
+	disabled.Stop()
+}
@@ -0,0 +1,81 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package health
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+)
+
+type (
+	// Server serves the HTTP endpoints of a checker on a listener, all its methods are no-ops
+	// when it has no listener so that services can use it when the endpoints are disabled
+	Server struct {
+		listener net.Listener
+		server   *http.Server
+		logger   log.Logger
+	}
+)
+
+const serverShutdownTimeout = 5 * time.Second
+
+// NewServer creates the HTTP server of the checker, listener may be nil
+func NewServer(checker *Checker, listener net.Listener, logger log.Logger) *Server {
+	return &Server{
+		listener: listener,
+		server:   &http.Server{Handler: checker},
+		logger:   logger,
+	}
+}
+
+// Start starts serving in the background
+func (s *Server) Start() {
+	if s.listener == nil {
+		return
+	}
+	s.logger.Info("Starting to serve health endpoints", tag.Address(s.listener.Addr().String()))
+	go func() {
+		if err := s.server.Serve(s.listener); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("Failed to serve health endpoints", tag.Error(err))
+		}
+	}()
+}
+
+// Stop stops serving, waiting for in flight requests for up to 5 seconds
+func (s *Server) Stop() {
+	if s.listener == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+	defer cancel()
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.logger.Warn("Failed to shut down health endpoints", tag.Error(err))
+	}
+}
@@ -0,0 +1,163 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:generate mockgen -copyright_file ../../LICENSE -package $GOPACKAGE -source $GOFILE -destination lifecycle_mock.go -self_package github.com/temporalio/temporal/common/lifecycle
+
+package lifecycle
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	// SinkFile appends lifecycle events to a local file, one per line
+	SinkFile = "file"
+	// SinkKafka publishes lifecycle events to the kafka topic of the lifecycle application
+	SinkKafka = "kafka"
+	// SinkWebhook posts lifecycle events to an HTTP endpoint
+	SinkWebhook = "webhook"
+
+	// EventTypeWorkflowStarted is published when a workflow execution is started
+	EventTypeWorkflowStarted = "WorkflowStarted"
+	// EventTypeWorkflowClosed is published when a workflow execution is completed, canceled, terminated or
+	// continued as new
+	EventTypeWorkflowClosed = "WorkflowClosed"
+	// EventTypeWorkflowFailed is published when a workflow execution fails
+	EventTypeWorkflowFailed = "WorkflowFailed"
+	// EventTypeWorkflowTimedOut is published when a workflow execution times out
+	EventTypeWorkflowTimedOut = "WorkflowTimedOut"
+	// EventTypeActivityFailed is published when an activity fails after its last attempt
+	EventTypeActivityFailed = "ActivityFailed"
+	// EventTypeSignalReceived is published when a workflow execution receives a signal
+	EventTypeSignalReceived = "SignalReceived"
+)
+
+type (
+	// Config is the config of the lifecycle events published by the history service
+	Config struct {
+		// Sink is one of file, kafka or webhook, lifecycle events are not published when empty
+		Sink string `yaml:"sink"`
+		// File is the config of the file sink
+		File FileSinkConfig `yaml:"file"`
+		// Webhook is the config of the webhook sink
+		Webhook WebhookSinkConfig `yaml:"webhook"`
+	}
+
+	// FileSinkConfig is the config of the file sink
+	FileSinkConfig struct {
+		// Path is the path of the file events are appended to
+		Path string `yaml:"path"`
+	}
+
+	// WebhookSinkConfig is the config of the webhook sink
+	WebhookSinkConfig struct {
+		// URL is the endpoint every event is posted to
+		URL string `yaml:"url"`
+		// Headers are added to every request, e.g. for authentication
+		Headers map[string]string `yaml:"headers"`
+		// Timeout is the timeout of a request, 10 seconds when zero
+		Timeout time.Duration `yaml:"timeout"`
+	}
+
+	// Event is a workflow lifecycle event, an event may be published more than once
+	// and consumers can deduplicate on its ID
+	Event struct {
+		ID           string    `json:"id"`
+		Type         string    `json:"type"`
+		Namespace    string    `json:"namespace"`
+		NamespaceID  string    `json:"namespaceId"`
+		WorkflowID   string    `json:"workflowId"`
+		RunID        string    `json:"runId"`
+		WorkflowType string    `json:"workflowType"`
+		EventID      int64     `json:"eventId"`
+		Timestamp    time.Time `json:"timestamp"`
+		// Status is the close status of WorkflowClosed events
+		Status string `json:"status,omitempty"`
+		// Reason is the failure reason of WorkflowFailed and ActivityFailed events
+		// and the timeout type of WorkflowTimedOut events
+		Reason string `json:"reason,omitempty"`
+		// ActivityScheduledEventID identifies the activity of ActivityFailed events
+		ActivityScheduledEventID int64 `json:"activityScheduledEventId,omitempty"`
+		// SignalName is the name of the signal of SignalReceived events
+		SignalName string `json:"signalName,omitempty"`
+		// Identity is the identity of the worker or client causing ActivityFailed and SignalReceived events
+		Identity string `json:"identity,omitempty"`
+	}
+
+	// Publisher publishes lifecycle events to a sink
+	Publisher interface {
+		Publish(event *Event) error
+		Close() error
+	}
+
+	// Sink stores serialized lifecycle events, the key is the workflow ID of the event
+	Sink interface {
+		Write(key string, event []byte) error
+		Close() error
+	}
+
+	publisherImpl struct {
+		sink Sink
+	}
+)
+
+var _ Publisher = (*publisherImpl)(nil)
+
+// IsEnabled returns true if a sink is configured
+func (c *Config) IsEnabled() bool {
+	return c != nil && c.Sink != ""
+}
+
+// NewPublisher creates a publisher which writes events to the sink as JSON
+func NewPublisher(sink Sink) Publisher {
+	return &publisherImpl{sink: sink}
+}
+
+func (p *publisherImpl) Publish(event *Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return p.sink.Write(event.WorkflowID, data)
+}
+
+func (p *publisherImpl) Close() error {
+	return p.sink.Close()
+}
+
+// MatchesEventTypes returns true if the event type is one of the comma separated event types,
+// every event type matches empty event types
+func MatchesEventTypes(eventTypes string, eventType string) bool {
+	if strings.TrimSpace(eventTypes) == "" {
+		return true
+	}
+	for _, included := range strings.Split(eventTypes, ",") {
+		if strings.EqualFold(strings.TrimSpace(included), eventType) {
+			return true
+		}
+	}
+	return false
+}
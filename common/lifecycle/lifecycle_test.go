@@ -0,0 +1,92 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lifecycle
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesEventTypes(t *testing.T) {
+	require.True(t, MatchesEventTypes("", EventTypeWorkflowStarted))
+	require.True(t, MatchesEventTypes(" ", EventTypeActivityFailed))
+	require.True(t, MatchesEventTypes("WorkflowStarted, workflowfailed", EventTypeWorkflowFailed))
+	require.True(t, MatchesEventTypes("WorkflowStarted,WorkflowFailed", EventTypeWorkflowStarted))
+	require.False(t, MatchesEventTypes("WorkflowStarted,WorkflowFailed", EventTypeSignalReceived))
+}
+
+func TestPublisherWritesEventsAsJSON(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lifecycle")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "events.log")
+	sink, err := NewFileSink(&FileSinkConfig{Path: path})
+	require.NoError(t, err)
+	publisher := NewPublisher(sink)
+
+	events := []*Event{
+		{
+			ID:         "run-id/1",
+			Type:       EventTypeWorkflowStarted,
+			Namespace:  "some random namespace",
+			WorkflowID: "some random workflow ID",
+			RunID:      "run-id",
+			EventID:    1,
+			Timestamp:  time.Unix(0, 100).UTC(),
+		},
+		{
+			ID:         "run-id/5",
+			Type:       EventTypeSignalReceived,
+			Namespace:  "some random namespace",
+			WorkflowID: "some random workflow ID",
+			RunID:      "run-id",
+			EventID:    5,
+			Timestamp:  time.Unix(0, 200).UTC(),
+			SignalName: "some random signal",
+		},
+	}
+	for _, event := range events {
+		require.NoError(t, publisher.Publish(event))
+	}
+	require.NoError(t, publisher.Close())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, len(events))
+	for i, line := range lines {
+		var event Event
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		require.Equal(t, *events[i], event)
+	}
+}
@@ -0,0 +1,156 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lifecycle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/temporalio/temporal/common/messaging"
+)
+
+const defaultWebhookTimeout = 10 * time.Second
+
+type (
+	fileSink struct {
+		sync.Mutex
+		file *os.File
+	}
+
+	producerSink struct {
+		producer messaging.Producer
+	}
+
+	webhookSink struct {
+		url     string
+		headers map[string]string
+		client  *http.Client
+	}
+)
+
+var (
+	errEmptyPath = errors.New("lifecycle events file path is empty")
+	errEmptyURL  = errors.New("lifecycle events webhook url is empty")
+)
+
+var _ Sink = (*fileSink)(nil)
+var _ Sink = (*producerSink)(nil)
+var _ Sink = (*webhookSink)(nil)
+
+// NewFileSink creates a sink which appends events to a file, one per line
+func NewFileSink(cfg *FileSinkConfig) (Sink, error) {
+	if cfg.Path == "" {
+		return nil, errEmptyPath
+	}
+	file, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &fileSink{file: file}, nil
+}
+
+// NewProducerSink creates a sink which publishes events with the producer
+func NewProducerSink(producer messaging.Producer) Sink {
+	return &producerSink{producer: producer}
+}
+
+// NewWebhookSink creates a sink which posts every event to the webhook url
+func NewWebhookSink(cfg *WebhookSinkConfig) (Sink, error) {
+	if cfg.URL == "" {
+		return nil, errEmptyURL
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	return &webhookSink{
+		url:     cfg.URL,
+		headers: cfg.Headers,
+		client:  &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (s *fileSink) Write(_ string, event []byte) error {
+	s.Lock()
+	defer s.Unlock()
+
+	_, err := s.file.Write(append(event, '\n'))
+	return err
+}
+
+func (s *fileSink) Close() error {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.file.Close()
+}
+
+func (s *producerSink) Write(key string, event []byte) error {
+	// use the workflow ID as the partition key so the events of a workflow are published in order
+	return s.producer.Publish(&messaging.KeyedMessage{Key: key, Value: event})
+}
+
+func (s *producerSink) Close() error {
+	if closeable, ok := s.producer.(messaging.CloseableProducer); ok {
+		return closeable.Close()
+	}
+	return nil
+}
+
+func (s *webhookSink) Write(_ string, event []byte) error {
+	request, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(event))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range s.headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("lifecycle events webhook returned status %v", response.StatusCode)
+	}
+	return nil
+}
+
+func (s *webhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,94 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lifecycle
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temporalio/temporal/common/messaging"
+	"github.com/temporalio/temporal/common/mocks"
+)
+
+func TestWebhookSink(t *testing.T) {
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		header = r.Header
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink, err := NewWebhookSink(&WebhookSinkConfig{
+		URL:     server.URL,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.NoError(t, sink.Write("workflow-id", []byte(`{"type":"WorkflowStarted"}`)))
+	require.Equal(t, `{"type":"WorkflowStarted"}`, string(body))
+	require.Equal(t, "application/json", header.Get("Content-Type"))
+	require.Equal(t, "Bearer token", header.Get("Authorization"))
+}
+
+func TestWebhookSinkFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sink, err := NewWebhookSink(&WebhookSinkConfig{URL: server.URL})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.Error(t, sink.Write("workflow-id", []byte(`{}`)))
+}
+
+func TestProducerSinkKeysByWorkflowID(t *testing.T) {
+	producer := &mocks.KafkaProducer{}
+	producer.On("Publish", &messaging.KeyedMessage{
+		Key:   "workflow-id",
+		Value: []byte(`{"type":"WorkflowStarted"}`),
+	}).Return(nil).Once()
+	producer.On("Close").Return(nil).Once()
+
+	sink := NewProducerSink(producer)
+	require.NoError(t, sink.Write("workflow-id", []byte(`{"type":"WorkflowStarted"}`)))
+	require.NoError(t, sink.Close())
+	producer.AssertExpectations(t)
+}
+
+func TestSinkConfigValidation(t *testing.T) {
+	_, err := NewFileSink(&FileSinkConfig{})
+	require.Equal(t, errEmptyPath, err)
+	_, err = NewWebhookSink(&WebhookSinkConfig{})
+	require.Equal(t, errEmptyURL, err)
+}
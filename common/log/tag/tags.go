@@ -50,6 +50,11 @@ func ClusterName(clusterName string) Tag {
 	return newStringTag("cluster-name", clusterName)
 }
 
+// Actor returns tag for the authenticated caller
+func Actor(actor string) Tag {
+	return newStringTag("actor", actor)
+}
+
 // Timestamp returns tag for Timestamp
 func Timestamp(timestamp time.Time) Tag {
 	return newTimeTag("timestamp", timestamp)
@@ -293,6 +298,11 @@ func WorkflowTaskListName(taskListName string) Tag {
 	return newStringTag("wf-task-list-name", taskListName)
 }
 
+// PollerIdentity returns tag for PollerIdentity
+func PollerIdentity(identity string) Tag {
+	return newStringTag("poller-identity", identity)
+}
+
 // size limit
 
 // WorkflowSize returns tag for WorkflowSize
@@ -837,6 +847,41 @@ func TransportType(transportType string) Tag {
 	return newStringTag("transport-type", transportType)
 }
 
+// RPCMethod returns tag for the full method name of a gRPC request
+func RPCMethod(method string) Tag {
+	return newStringTag("rpc-method", method)
+}
+
+// RPCCode returns tag for the status code of a gRPC response
+func RPCCode(code string) Tag {
+	return newStringTag("rpc-code", code)
+}
+
+// RPCLatency returns tag for the latency of a gRPC request
+func RPCLatency(latency time.Duration) Tag {
+	return newDurationTag("rpc-latency", latency)
+}
+
+// TraceID returns tag for the trace id of a span
+func TraceID(traceID string) Tag {
+	return newStringTag("trace-id", traceID)
+}
+
+// SpanID returns tag for the id of a span
+func SpanID(spanID string) Tag {
+	return newStringTag("span-id", spanID)
+}
+
+// SpanOperation returns tag for the operation name of a span
+func SpanOperation(operation string) Tag {
+	return newStringTag("span-operation", operation)
+}
+
+// SpanDuration returns tag for the duration of a span
+func SpanDuration(duration time.Duration) Tag {
+	return newDurationTag("span-duration", duration)
+}
+
 // ActivityInfo returns tag for activity info
 func ActivityInfo(activityInfo interface{}) Tag {
 	return newObjectTag("activity-info", activityInfo)
@@ -126,6 +126,8 @@ var (
 	ComponentWorker                   = component("worker")
 	ComponentServiceResolver          = component("service-resolver")
 	ComponentMetadataInitializer      = component("metadata-initializer")
+	ComponentAudit                    = component("audit")
+	ComponentLifecyclePublisher       = component("lifecycle-publisher")
 )
 
 // Pre-defined values for TagSysLifecycle
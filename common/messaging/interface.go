@@ -67,4 +67,11 @@ type (
 		Producer
 		Close() error
 	}
+
+	// KeyedMessage is a serialized message published with a partition key, messages with
+	// the same key are dispatched to the same partition
+	KeyedMessage struct {
+		Key   string
+		Value []byte
+	}
 )
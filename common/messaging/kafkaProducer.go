@@ -152,6 +152,19 @@ func (p *kafkaProducer) getProducerMessage(message interface{}) (*sarama.Produce
 			Value: sarama.ByteEncoder(payload),
 		}
 		return msg, nil
+	case *KeyedMessage:
+		msg := &sarama.ProducerMessage{
+			Topic: p.topic,
+			Key:   sarama.StringEncoder(message.Key),
+			Value: sarama.ByteEncoder(message.Value),
+		}
+		return msg, nil
+	case []byte:
+		msg := &sarama.ProducerMessage{
+			Topic: p.topic,
+			Value: sarama.ByteEncoder(message),
+		}
+		return msg, nil
 	default:
 		return nil, errors.New("unknown producer message type")
 	}
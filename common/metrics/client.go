@@ -92,15 +92,15 @@ func (m *ClientImpl) AddCounter(scopeIdx int, counterIdx int, delta int64) {
 // StartTimer starts a timer for the given
 // metric name
 func (m *ClientImpl) StartTimer(scopeIdx int, timerIdx int) tally.Stopwatch {
-	name := string(m.metricDefs[timerIdx].metricName)
-	return m.childScopes[scopeIdx].Timer(name).Start()
+	def := m.metricDefs[timerIdx]
+	return def.timer(m.childScopes[scopeIdx], def.metricName).Start()
 }
 
 // RecordTimer record and emit a timer for the given
 // metric name
 func (m *ClientImpl) RecordTimer(scopeIdx int, timerIdx int, d time.Duration) {
-	name := string(m.metricDefs[timerIdx].metricName)
-	m.childScopes[scopeIdx].Timer(name).Record(d)
+	def := m.metricDefs[timerIdx]
+	def.timer(m.childScopes[scopeIdx], def.metricName).Record(d)
 }
 
 // UpdateGauge reports Gauge type metric
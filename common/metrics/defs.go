@@ -24,7 +24,11 @@
 
 package metrics
 
-import "github.com/uber-go/tally"
+import (
+	"time"
+
+	"github.com/uber-go/tally"
+)
 
 // types used/defined by the package
 type (
@@ -40,7 +44,8 @@ type (
 		metricType       MetricType    // metric type
 		metricName       MetricName    // metric name
 		metricRollupName MetricName    // optional. if non-empty, this name must be used for rolled-up version of this metric
-		buckets          tally.Buckets // buckets if we are emitting histograms
+		buckets          tally.Buckets // buckets if we are emitting histograms, timers with buckets are emitted as histograms
+		unit             MetricUnit    // unit of histograms, the names of histograms end with it
 	}
 
 	// scopeDefinition holds the tag definitions for a scope
@@ -51,6 +56,9 @@ type (
 
 	// ServiceIdx is an index that uniquely identifies the service
 	ServiceIdx int
+
+	// MetricUnit is the unit of a histogram
+	MetricUnit string
 )
 
 // MetricTypes which are supported
@@ -58,6 +66,56 @@ const (
 	Counter MetricType = iota
 	Timer
 	Gauge
+	Histogram
+)
+
+// MetricUnits of histograms, named after the Prometheus base units
+const (
+	Dimensionless MetricUnit = ""
+	Seconds       MetricUnit = "seconds"
+	Bytes         MetricUnit = "bytes"
+)
+
+// Buckets of histograms
+var (
+	// LatencyBuckets are the buckets of request latencies, from 1ms to 60s
+	LatencyBuckets = tally.DurationBuckets{
+		time.Millisecond,
+		2 * time.Millisecond,
+		5 * time.Millisecond,
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		50 * time.Millisecond,
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		500 * time.Millisecond,
+		time.Second,
+		2 * time.Second,
+		5 * time.Second,
+		10 * time.Second,
+		20 * time.Second,
+		60 * time.Second,
+	}
+
+	// QueueLatencyBuckets are the buckets of the time tasks wait in queues, from 10ms to 1h
+	QueueLatencyBuckets = tally.DurationBuckets{
+		10 * time.Millisecond,
+		50 * time.Millisecond,
+		100 * time.Millisecond,
+		500 * time.Millisecond,
+		time.Second,
+		5 * time.Second,
+		10 * time.Second,
+		30 * time.Second,
+		time.Minute,
+		5 * time.Minute,
+		10 * time.Minute,
+		30 * time.Minute,
+		time.Hour,
+	}
+
+	// SizeBuckets are the buckets of payload and history sizes, from 1KB to 64MB
+	SizeBuckets = tally.MustMakeExponentialValueBuckets(1024, 4, 9)
 )
 
 // Service names for all services that emit metrics.
@@ -362,10 +420,20 @@ const (
 	MatchingClientRespondQueryTaskCompletedScope
 	// MatchingClientCancelOutstandingPollScope tracks RPC calls to matching service
 	MatchingClientCancelOutstandingPollScope
+	// MatchingClientReleaseInFlightTaskScope tracks RPC calls to matching service
+	MatchingClientReleaseInFlightTaskScope
 	// MatchingClientDescribeTaskListScope tracks RPC calls to matching service
 	MatchingClientDescribeTaskListScope
 	// MatchingClientListTaskListPartitionsScope tracks RPC calls to matching service
 	MatchingClientListTaskListPartitionsScope
+	// MatchingClientCaptureProfileScope tracks RPC calls to matching service
+	MatchingClientCaptureProfileScope
+	// MatchingClientGetHostDiagnosticsScope tracks RPC calls to matching service
+	MatchingClientGetHostDiagnosticsScope
+	// MatchingClientDescribeLogLevelsScope tracks RPC calls to matching service
+	MatchingClientDescribeLogLevelsScope
+	// MatchingClientUpdateLogLevelScope tracks RPC calls to matching service
+	MatchingClientUpdateLogLevelScope
 	// FrontendClientDeprecateNamespaceScope tracks RPC calls to frontend service
 	FrontendClientDeprecateNamespaceScope
 	// FrontendClientDescribeNamespaceScope tracks RPC calls to frontend service
@@ -476,6 +544,22 @@ const (
 	AdminClientMergeDLQMessagesScope
 	// AdminClientRefreshWorkflowTasksScope tracks RPC calls to admin service
 	AdminClientRefreshWorkflowTasksScope
+	// AdminClientDescribeLogLevelsScope tracks RPC calls to admin service
+	AdminClientDescribeLogLevelsScope
+	// AdminClientUpdateLogLevelScope tracks RPC calls to admin service
+	AdminClientUpdateLogLevelScope
+	// AdminClientDescribeNamespaceQuotasScope tracks RPC calls to admin service
+	AdminClientDescribeNamespaceQuotasScope
+	// AdminClientUpdateNamespaceQuotasScope tracks RPC calls to admin service
+	AdminClientUpdateNamespaceQuotasScope
+	// AdminClientCaptureProfileScope tracks RPC calls to admin service
+	AdminClientCaptureProfileScope
+	// AdminClientGetHostDiagnosticsScope tracks RPC calls to admin service
+	AdminClientGetHostDiagnosticsScope
+	// AdminClientDescribeShardQueuesScope tracks RPC calls to admin service
+	AdminClientDescribeShardQueuesScope
+	// AdminClientDescribeTaskListScope tracks RPC calls to admin service
+	AdminClientDescribeTaskListScope
 	// DCRedirectionDeprecateNamespaceScope tracks RPC calls for dc redirection
 	DCRedirectionDeprecateNamespaceScope
 	// DCRedirectionDescribeNamespaceScope tracks RPC calls for dc redirection
@@ -652,6 +736,11 @@ const (
 	// BlobstoreClientDirectoryExistsScope tracks DirectoryExists calls to blobstore
 	BlobstoreClientDirectoryExistsScope
 
+	// RPCServerScope tracks the requests served by the gRPC server of a service
+	RPCServerScope
+	// AuditLogScope is the scope used by the audit logger
+	AuditLogScope
+
 	NumCommonScopes
 )
 
@@ -687,6 +776,24 @@ const (
 	AdminPurgeDLQMessagesScope
 	//AdminMergeDLQMessagesScope is the metric scope for admin.AdminMergeDLQMessagesScope
 	AdminMergeDLQMessagesScope
+	// AdminDescribeClusterScope is the metric scope for admin.DescribeCluster
+	AdminDescribeClusterScope
+	// AdminDescribeLogLevelsScope is the metric scope for admin.DescribeLogLevels
+	AdminDescribeLogLevelsScope
+	// AdminUpdateLogLevelScope is the metric scope for admin.UpdateLogLevel
+	AdminUpdateLogLevelScope
+	// AdminDescribeNamespaceQuotasScope is the metric scope for admin.DescribeNamespaceQuotas
+	AdminDescribeNamespaceQuotasScope
+	// AdminUpdateNamespaceQuotasScope is the metric scope for admin.UpdateNamespaceQuotas
+	AdminUpdateNamespaceQuotasScope
+	// AdminCaptureProfileScope is the metric scope for admin.CaptureProfile
+	AdminCaptureProfileScope
+	// AdminGetHostDiagnosticsScope is the metric scope for admin.GetHostDiagnostics
+	AdminGetHostDiagnosticsScope
+	// AdminDescribeShardQueuesScope is the metric scope for admin.DescribeShardQueues
+	AdminDescribeShardQueuesScope
+	// AdminDescribeTaskListScope is the metric scope for admin.DescribeTaskList
+	AdminDescribeTaskListScope
 
 	NumAdminScopes
 )
@@ -771,6 +878,8 @@ const (
 	FrontendResetWorkflowExecutionScope
 	// FrontendGetSearchAttributesScope is the metric scope for frontend.GetSearchAttributes
 	FrontendGetSearchAttributesScope
+	// FrontendAdmissionControlScope is the metric scope for the frontend admission control
+	FrontendAdmissionControlScope
 
 	NumFrontendScopes
 )
@@ -845,6 +954,8 @@ const (
 	HistoryReapplyEventsScope
 	// HistoryRefreshWorkflowTasksScope is the scope used by refresh workflow tasks API
 	HistoryRefreshWorkflowTasksScope
+	// HistoryDescribeShardQueuesScope tracks DescribeShardQueues API calls received by service
+	HistoryDescribeShardQueuesScope
 	// TaskPriorityAssignerScope is the scope used by all metric emitted by task priority assigner
 	TaskPriorityAssignerScope
 	// TransferQueueProcessorScope is the scope used by all metric emitted by transfer queue processor
@@ -871,6 +982,8 @@ const (
 	TransferActiveTaskResetWorkflowScope
 	// TransferActiveTaskUpsertWorkflowSearchAttributesScope is the scope used for upsert search attributes processing by transfer queue processor
 	TransferActiveTaskUpsertWorkflowSearchAttributesScope
+	// TransferActiveTaskLifecycleEventScope is the scope used for lifecycle event publishing by transfer queue processor
+	TransferActiveTaskLifecycleEventScope
 	// TransferStandbyTaskResetWorkflowScope is the scope used for record workflow started task processing by transfer queue processor
 	TransferStandbyTaskResetWorkflowScope
 	// TransferStandbyTaskActivityScope is the scope used for activity task processing by transfer queue processor
@@ -889,6 +1002,8 @@ const (
 	TransferStandbyTaskRecordWorkflowStartedScope
 	// TransferStandbyTaskUpsertWorkflowSearchAttributesScope is the scope used for upsert search attributes processing by transfer queue processor
 	TransferStandbyTaskUpsertWorkflowSearchAttributesScope
+	// TransferStandbyTaskLifecycleEventScope is the scope used for lifecycle event tasks skipped by standby transfer queue processor
+	TransferStandbyTaskLifecycleEventScope
 	// TimerQueueProcessorScope is the scope used by all metric emitted by timer queue processor
 	TimerQueueProcessorScope
 	// TimerActiveQueueProcessorScope is the scope used by all metric emitted by timer queue processor
@@ -999,10 +1114,20 @@ const (
 	MatchingRespondQueryTaskCompletedScope
 	// MatchingCancelOutstandingPollScope tracks CancelOutstandingPoll API calls received by service
 	MatchingCancelOutstandingPollScope
+	// MatchingReleaseInFlightTaskScope tracks ReleaseInFlightTask API calls received by service
+	MatchingReleaseInFlightTaskScope
 	// MatchingDescribeTaskListScope tracks DescribeTaskList API calls received by service
 	MatchingDescribeTaskListScope
 	// MatchingListTaskListPartitionsScope tracks ListTaskListPartitions API calls received by service
 	MatchingListTaskListPartitionsScope
+	// MatchingCaptureProfileScope tracks CaptureProfile API calls received by service
+	MatchingCaptureProfileScope
+	// MatchingGetHostDiagnosticsScope tracks GetHostDiagnostics API calls received by service
+	MatchingGetHostDiagnosticsScope
+	// MatchingDescribeLogLevelsScope tracks DescribeLogLevels API calls received by service
+	MatchingDescribeLogLevelsScope
+	// MatchingUpdateLogLevelScope tracks UpdateLogLevel API calls received by service
+	MatchingUpdateLogLevelScope
 
 	NumMatchingScopes
 )
@@ -1184,8 +1309,13 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingClientQueryWorkflowScope:                      {operation: "MatchingClientQueryWorkflow", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientRespondQueryTaskCompletedScope:          {operation: "MatchingClientRespondQueryTaskCompleted", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientCancelOutstandingPollScope:              {operation: "MatchingClientCancelOutstandingPoll", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientReleaseInFlightTaskScope:                {operation: "MatchingClientReleaseInFlightTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientDescribeTaskListScope:                   {operation: "MatchingClientDescribeTaskList", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientListTaskListPartitionsScope:             {operation: "MatchingClientListTaskListPartitions", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientCaptureProfileScope:                     {operation: "MatchingClientCaptureProfile", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientGetHostDiagnosticsScope:                 {operation: "MatchingClientGetHostDiagnostics", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientDescribeLogLevelsScope:                  {operation: "MatchingClientDescribeLogLevels", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientUpdateLogLevelScope:                     {operation: "MatchingClientUpdateLogLevel", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		FrontendClientDeprecateNamespaceScope:                 {operation: "FrontendClientDeprecateNamespace", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeNamespaceScope:                  {operation: "FrontendClientDescribeNamespace", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeTaskListScope:                   {operation: "FrontendClientDescribeTaskList", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
@@ -1237,6 +1367,14 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminClientGetWorkflowExecutionRawHistoryV2Scope:      {operation: "AdminClientGetWorkflowExecutionRawHistoryV2", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientDescribeClusterScope:                       {operation: "AdminClientDescribeCluster", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientRefreshWorkflowTasksScope:                  {operation: "AdminClientRefreshWorkflowTasks", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeLogLevelsScope:                     {operation: "AdminClientDescribeLogLevels", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientUpdateLogLevelScope:                        {operation: "AdminClientUpdateLogLevel", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeNamespaceQuotasScope:               {operation: "AdminClientDescribeNamespaceQuotas", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientUpdateNamespaceQuotasScope:                 {operation: "AdminClientUpdateNamespaceQuotas", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientCaptureProfileScope:                        {operation: "AdminClientCaptureProfile", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientGetHostDiagnosticsScope:                    {operation: "AdminClientGetHostDiagnostics", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeShardQueuesScope:                   {operation: "AdminClientDescribeShardQueues", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeTaskListScope:                      {operation: "AdminClientDescribeTaskList", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientCloseShardScope:                            {operation: "AdminClientCloseShard", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientReadDLQMessagesScope:                       {operation: "AdminClientReadDLQMessages", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientPurgeDLQMessagesScope:                      {operation: "AdminClientPurgeDLQMessages", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
@@ -1319,6 +1457,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		BlobstoreClientExistsScope:          {operation: "BlobstoreClientExists", tags: map[string]string{ServiceRoleTagName: BlobstoreRoleTagValue}},
 		BlobstoreClientDeleteScope:          {operation: "BlobstoreClientDelete", tags: map[string]string{ServiceRoleTagName: BlobstoreRoleTagValue}},
 		BlobstoreClientDirectoryExistsScope: {operation: "BlobstoreClientDirectoryExists", tags: map[string]string{ServiceRoleTagName: BlobstoreRoleTagValue}},
+		RPCServerScope:                      {operation: "RPCServer"},
+		AuditLogScope:                       {operation: "AuditLog"},
 	},
 	// Frontend Scope Names
 	Frontend: {
@@ -1328,6 +1468,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminReadDLQMessagesScope:                  {operation: "AdminReadDLQMessages"},
 		AdminPurgeDLQMessagesScope:                 {operation: "AdminPurgeDLQMessages"},
 		AdminMergeDLQMessagesScope:                 {operation: "AdminMergeDLQMessages"},
+		AdminDescribeClusterScope:                  {operation: "AdminDescribeCluster"},
 		AdminDescribeHistoryHostScope:              {operation: "DescribeHistoryHost"},
 		AdminAddSearchAttributeScope:               {operation: "AddSearchAttribute"},
 		AdminDescribeWorkflowExecutionScope:        {operation: "DescribeWorkflowExecution"},
@@ -1338,6 +1479,14 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminGetDLQReplicationMessagesScope:        {operation: "AdminGetDLQReplicationMessages"},
 		AdminReapplyEventsScope:                    {operation: "ReapplyEvents"},
 		AdminRefreshWorkflowTasksScope:             {operation: "RefreshWorkflowTasks"},
+		AdminDescribeLogLevelsScope:                {operation: "DescribeLogLevels"},
+		AdminUpdateLogLevelScope:                   {operation: "UpdateLogLevel"},
+		AdminDescribeNamespaceQuotasScope:          {operation: "DescribeNamespaceQuotas"},
+		AdminUpdateNamespaceQuotasScope:            {operation: "UpdateNamespaceQuotas"},
+		AdminCaptureProfileScope:                   {operation: "CaptureProfile"},
+		AdminGetHostDiagnosticsScope:               {operation: "GetHostDiagnostics"},
+		AdminDescribeShardQueuesScope:              {operation: "DescribeShardQueues"},
+		AdminDescribeTaskListScope:                 {operation: "DescribeTaskList"},
 
 		FrontendStartWorkflowExecutionScope:             {operation: "StartWorkflowExecution"},
 		FrontendPollForDecisionTaskScope:                {operation: "PollForDecisionTask"},
@@ -1378,6 +1527,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendDescribeTaskListScope:                   {operation: "DescribeTaskList"},
 		FrontendResetStickyTaskListScope:                {operation: "ResetStickyTaskList"},
 		FrontendGetSearchAttributesScope:                {operation: "GetSearchAttributes"},
+		FrontendAdmissionControlScope:                   {operation: "AdmissionControl"},
 	},
 	// History Scope Names
 	History: {
@@ -1418,6 +1568,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryShardControllerScope:                            {operation: "ShardController"},
 		HistoryReapplyEventsScope:                              {operation: "EventReapplication"},
 		HistoryRefreshWorkflowTasksScope:                       {operation: "RefreshWorkflowTasks"},
+		HistoryDescribeShardQueuesScope:                        {operation: "DescribeShardQueues"},
 		TaskPriorityAssignerScope:                              {operation: "TaskPriorityAssigner"},
 		TransferQueueProcessorScope:                            {operation: "TransferQueueProcessor"},
 		TransferActiveQueueProcessorScope:                      {operation: "TransferActiveQueueProcessor"},
@@ -1431,6 +1582,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TransferActiveTaskRecordWorkflowStartedScope:           {operation: "TransferActiveTaskRecordWorkflowStarted"},
 		TransferActiveTaskResetWorkflowScope:                   {operation: "TransferActiveTaskResetWorkflow"},
 		TransferActiveTaskUpsertWorkflowSearchAttributesScope:  {operation: "TransferActiveTaskUpsertWorkflowSearchAttributes"},
+		TransferActiveTaskLifecycleEventScope:                  {operation: "TransferActiveTaskLifecycleEvent"},
 		TransferStandbyTaskActivityScope:                       {operation: "TransferStandbyTaskActivity"},
 		TransferStandbyTaskDecisionScope:                       {operation: "TransferStandbyTaskDecision"},
 		TransferStandbyTaskCloseExecutionScope:                 {operation: "TransferStandbyTaskCloseExecution"},
@@ -1440,6 +1592,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TransferStandbyTaskRecordWorkflowStartedScope:          {operation: "TransferStandbyTaskRecordWorkflowStarted"},
 		TransferStandbyTaskResetWorkflowScope:                  {operation: "TransferStandbyTaskResetWorkflow"},
 		TransferStandbyTaskUpsertWorkflowSearchAttributesScope: {operation: "TransferStandbyTaskUpsertWorkflowSearchAttributes"},
+		TransferStandbyTaskLifecycleEventScope:                 {operation: "TransferStandbyTaskLifecycleEvent"},
 		TimerQueueProcessorScope:                               {operation: "TimerQueueProcessor"},
 		TimerActiveQueueProcessorScope:                         {operation: "TimerActiveQueueProcessor"},
 		TimerStandbyQueueProcessorScope:                        {operation: "TimerStandbyQueueProcessor"},
@@ -1492,8 +1645,13 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingQueryWorkflowScope:             {operation: "QueryWorkflow"},
 		MatchingRespondQueryTaskCompletedScope: {operation: "RespondQueryTaskCompleted"},
 		MatchingCancelOutstandingPollScope:     {operation: "CancelOutstandingPoll"},
+		MatchingReleaseInFlightTaskScope:       {operation: "ReleaseInFlightTask"},
 		MatchingDescribeTaskListScope:          {operation: "DescribeTaskList"},
 		MatchingListTaskListPartitionsScope:    {operation: "ListTaskListPartitions"},
+		MatchingCaptureProfileScope:            {operation: "CaptureProfile"},
+		MatchingGetHostDiagnosticsScope:        {operation: "GetHostDiagnostics"},
+		MatchingDescribeLogLevelsScope:         {operation: "DescribeLogLevels"},
+		MatchingUpdateLogLevelScope:            {operation: "UpdateLogLevel"},
 	},
 	// Worker Scope Names
 	Worker: {
@@ -1522,6 +1680,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 
 // Common Metrics enum
 const (
+	// ServiceRequests and ServiceLatency are not emitted for the RPCs served by the frontend, history and matching
+	// handlers, the server interceptors measure every RPC as RPCServerRequests and RPCServerLatency instead
 	ServiceRequests = iota
 	ServiceFailures
 	ServiceCriticalFailures
@@ -1567,6 +1727,16 @@ const (
 
 	ServiceAuthorizationLatency
 
+	ServiceAdmissionRejectedCounter
+	ServiceConcurrencyLimitGauge
+
+	RPCServerRequests
+	RPCServerFailures
+	RPCServerLatency
+	RPCServerPanics
+	RPCServerNoDeadline
+	RPCServerDeadlineExpired
+
 	NamespaceCachePrepareCallbacksLatency
 	NamespaceCacheCallbacksLatency
 
@@ -1625,6 +1795,8 @@ const (
 	NamespaceReplicationDLQAckLevelGauge
 	NamespaceReplicationDLQMaxLevelGauge
 
+	AuditRecordsDropped
+
 	// common metrics that are emitted per task list
 	ServiceRequestsPerTaskList
 	ServiceFailuresPerTaskList
@@ -1668,6 +1840,7 @@ const (
 	TimerTaskThrottledCounter
 
 	ActivityE2ELatency
+	DecisionE2ELatency
 	AckLevelUpdateCounter
 	AckLevelUpdateFailedCounter
 	DecisionTypeScheduleActivityCounter
@@ -1683,6 +1856,7 @@ const (
 	DecisionTypeContinueAsNewCounter
 	DecisionTypeSignalExternalWorkflowCounter
 	DecisionTypeUpsertWorkflowSearchAttributesCounter
+	EagerActivityTaskCounter
 	EmptyCompletionDecisionsCounter
 	MultipleCompletionDecisionsCounter
 	FailedDecisionsCounter
@@ -1793,6 +1967,11 @@ const (
 	ReplicationDLQFailed
 	ReplicationDLQMaxLevelGauge
 	ReplicationDLQAckLevelGauge
+	ShardQueueLagGauge
+	ShardQueueOldestPendingTaskAgeGauge
+	ShardQueueInFlightTasksGauge
+	ShardQueueFailingTasksGauge
+	ShardQueueMaxTaskAttemptGauge
 	GetReplicationMessagesForShardLatency
 	GetDLQReplicationMessagesLatency
 	EventReapplySkippedCount
@@ -1816,6 +1995,7 @@ const (
 	ReplicationTaskCleanupFailure
 	MutableStateChecksumMismatch
 	MutableStateChecksumInvalidated
+	AffinityOwnerUnavailableCounter
 
 	NumHistoryMetrics
 )
@@ -1831,6 +2011,7 @@ const (
 	RespondQueryTaskFailedPerTaskListCounter
 	SyncThrottlePerTaskListCounter
 	BufferThrottlePerTaskListCounter
+	PollerThrottlePerTaskListCounter
 	SyncMatchLatencyPerTaskList
 	AsyncMatchLatencyPerTaskList
 	ExpiredTasksPerTaskListCounter
@@ -1920,7 +2101,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ServiceRequests:                                     {metricName: "service_requests", metricType: Counter},
 		ServiceFailures:                                     {metricName: "service_errors", metricType: Counter},
 		ServiceCriticalFailures:                             {metricName: "service_errors_critical", metricType: Counter},
-		ServiceLatency:                                      {metricName: "service_latency", metricType: Timer},
+		ServiceLatency:                                      {metricName: "service_latency", metricType: Timer, buckets: LatencyBuckets},
 		ServiceErrInvalidArgumentCounter:                    {metricName: "service_errors_invalid_argument", metricType: Counter},
 		ServiceErrNamespaceNotActiveCounter:                 {metricName: "service_errors_namespace_not_active", metricType: Counter},
 		ServiceErrResourceExhaustedCounter:                  {metricName: "service_errors_resource_exhausted", metricType: Counter},
@@ -1939,7 +2120,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ServiceErrAuthorizeFailedCounter:                    {metricName: "service_errors_authorize_failed", metricType: Counter},
 		PersistenceRequests:                                 {metricName: "persistence_requests", metricType: Counter},
 		PersistenceFailures:                                 {metricName: "persistence_errors", metricType: Counter},
-		PersistenceLatency:                                  {metricName: "persistence_latency", metricType: Timer},
+		PersistenceLatency:                                  {metricName: "persistence_latency", metricType: Timer, buckets: LatencyBuckets},
 		PersistenceErrShardExistsCounter:                    {metricName: "persistence_errors_shard_exists", metricType: Counter},
 		PersistenceErrShardOwnershipLostCounter:             {metricName: "persistence_errors_shard_ownership_lost", metricType: Counter},
 		PersistenceErrConditionFailedCounter:                {metricName: "persistence_errors_condition_failed", metricType: Counter},
@@ -1953,16 +2134,24 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		PersistenceSampledCounter:                           {metricName: "persistence_sampled", metricType: Counter},
 		ClientRequests:                                      {metricName: "client_requests", metricType: Counter},
 		ClientFailures:                                      {metricName: "client_errors", metricType: Counter},
-		ClientLatency:                                       {metricName: "client_latency", metricType: Timer},
+		ClientLatency:                                       {metricName: "client_latency", metricType: Timer, buckets: LatencyBuckets},
 		ClientRedirectionRequests:                           {metricName: "client_redirection_requests", metricType: Counter},
 		ClientRedirectionFailures:                           {metricName: "client_redirection_errors", metricType: Counter},
 		ClientRedirectionLatency:                            {metricName: "client_redirection_latency", metricType: Timer},
 		ServiceAuthorizationLatency:                         {metricName: "service_authorization_latency", metricType: Timer},
+		ServiceAdmissionRejectedCounter:                     {metricName: "service_admission_rejected", metricType: Counter},
+		ServiceConcurrencyLimitGauge:                        {metricName: "service_concurrency_limit", metricType: Gauge},
+		RPCServerRequests:                                   {metricName: "rpc_server_requests", metricType: Counter},
+		RPCServerFailures:                                   {metricName: "rpc_server_errors", metricType: Counter},
+		RPCServerLatency:                                    {metricName: "rpc_server_latency", metricType: Timer},
+		RPCServerPanics:                                     {metricName: "rpc_server_panics", metricType: Counter},
+		RPCServerNoDeadline:                                 {metricName: "rpc_server_no_deadline", metricType: Counter},
+		RPCServerDeadlineExpired:                            {metricName: "rpc_server_deadline_expired", metricType: Counter},
 		NamespaceCachePrepareCallbacksLatency:               {metricName: "namespace_cache_prepare_callbacks_latency", metricType: Timer},
 		NamespaceCacheCallbacksLatency:                      {metricName: "namespace_cache_callbacks_latency", metricType: Timer},
-		HistorySize:                                         {metricName: "history_size", metricType: Timer},
+		HistorySize:                                         {metricName: "history_size", metricType: Timer, buckets: SizeBuckets, unit: Bytes},
 		HistoryCount:                                        {metricName: "history_count", metricType: Timer},
-		EventBlobSize:                                       {metricName: "event_blob_size", metricType: Timer},
+		EventBlobSize:                                       {metricName: "event_blob_size", metricType: Timer, buckets: SizeBuckets, unit: Bytes},
 		ArchivalConfigFailures:                              {metricName: "archivalconfig_failures", metricType: Counter},
 		ElasticsearchRequests:                               {metricName: "elasticsearch_requests", metricType: Counter},
 		ElasticsearchFailures:                               {metricName: "elasticsearch_errors", metricType: Counter},
@@ -2005,16 +2194,19 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		NamespaceReplicationDLQAckLevelGauge:  {metricName: "namespace_dlq_ack_level", metricType: Gauge},
 		NamespaceReplicationDLQMaxLevelGauge:  {metricName: "namespace_dlq_max_level", metricType: Gauge},
 
+		AuditRecordsDropped: {metricName: "audit_records_dropped", metricType: Counter},
+
 		// per task list common metrics
 
+		// the totals of requests and latency are rpc_server_requests and rpc_server_latency, so these are not rolled up
 		ServiceRequestsPerTaskList: {
-			metricName: "service_requests_per_tl", metricRollupName: "service_requests", metricType: Counter,
+			metricName: "service_requests_per_tl", metricType: Counter,
 		},
 		ServiceFailuresPerTaskList: {
 			metricName: "service_errors_per_tl", metricRollupName: "service_errors", metricType: Counter,
 		},
 		ServiceLatencyPerTaskList: {
-			metricName: "service_latency_per_tl", metricRollupName: "service_latency", metricType: Timer,
+			metricName: "service_latency_per_tl", metricType: Timer, buckets: LatencyBuckets,
 		},
 		ServiceErrInvalidArgumentPerTaskListCounter: {
 			metricName: "service_errors_invalid_argument_per_tl", metricRollupName: "service_errors_invalid_argument", metricType: Counter,
@@ -2067,7 +2259,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 	},
 	History: {
 		TaskRequests:                                      {metricName: "task_requests", metricType: Counter},
-		TaskLatency:                                       {metricName: "task_latency", metricType: Timer},
+		TaskLatency:                                       {metricName: "task_latency", metricType: Timer, buckets: QueueLatencyBuckets},
 		TaskAttemptTimer:                                  {metricName: "task_attempt", metricType: Timer},
 		TaskFailures:                                      {metricName: "task_errors", metricType: Counter},
 		TaskDiscarded:                                     {metricName: "task_errors_discarded", metricType: Counter},
@@ -2075,12 +2267,13 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		TaskNotActiveCounter:                              {metricName: "task_errors_not_active_counter", metricType: Counter},
 		TaskLimitExceededCounter:                          {metricName: "task_errors_limit_exceeded_counter", metricType: Counter},
 		TaskProcessingLatency:                             {metricName: "task_latency_processing", metricType: Timer},
-		TaskQueueLatency:                                  {metricName: "task_latency_queue", metricType: Timer},
+		TaskQueueLatency:                                  {metricName: "task_latency_queue", metricType: Timer, buckets: QueueLatencyBuckets},
 		TaskBatchCompleteCounter:                          {metricName: "task_batch_complete_counter", metricType: Counter},
 		TaskRedispatchQueuePendingTasksTimer:              {metricName: "task_redispatch_queue_pending_tasks", metricType: Timer},
 		TransferTaskThrottledCounter:                      {metricName: "transfer_task_throttled_counter", metricType: Counter},
 		TimerTaskThrottledCounter:                         {metricName: "timer_task_throttled_counter", metricType: Counter},
-		ActivityE2ELatency:                                {metricName: "activity_end_to_end_latency", metricType: Timer},
+		ActivityE2ELatency:                                {metricName: "activity_end_to_end_latency", metricType: Timer, buckets: QueueLatencyBuckets},
+		DecisionE2ELatency:                                {metricName: "decision_end_to_end_latency", metricType: Timer, buckets: LatencyBuckets},
 		AckLevelUpdateCounter:                             {metricName: "ack_level_update", metricType: Counter},
 		AckLevelUpdateFailedCounter:                       {metricName: "ack_level_update_failed", metricType: Counter},
 		DecisionTypeScheduleActivityCounter:               {metricName: "schedule_activity_decision", metricType: Counter},
@@ -2095,6 +2288,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		DecisionTypeContinueAsNewCounter:                  {metricName: "continue_as_new_decision", metricType: Counter},
 		DecisionTypeSignalExternalWorkflowCounter:         {metricName: "signal_external_workflow_decision", metricType: Counter},
 		DecisionTypeUpsertWorkflowSearchAttributesCounter: {metricName: "upsert_workflow_search_attributes_decision", metricType: Counter},
+		EagerActivityTaskCounter:                          {metricName: "eager_activity_task", metricType: Counter},
 		DecisionTypeChildWorkflowCounter:                  {metricName: "child_workflow_decision", metricType: Counter},
 		EmptyCompletionDecisionsCounter:                   {metricName: "empty_completion_decisions", metricType: Counter},
 		MultipleCompletionDecisionsCounter:                {metricName: "multiple_completion_decisions", metricType: Counter},
@@ -2206,6 +2400,11 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ReplicationDLQFailed:                              {metricName: "replication_dlq_enqueue_failed", metricType: Counter},
 		ReplicationDLQMaxLevelGauge:                       {metricName: "replication_dlq_max_level", metricType: Gauge},
 		ReplicationDLQAckLevelGauge:                       {metricName: "replication_dlq_ack_level", metricType: Gauge},
+		ShardQueueLagGauge:                                {metricName: "shard_queue_lag", metricType: Gauge},
+		ShardQueueOldestPendingTaskAgeGauge:               {metricName: "shard_queue_oldest_pending_task_age", metricType: Gauge},
+		ShardQueueInFlightTasksGauge:                      {metricName: "shard_queue_in_flight_tasks", metricType: Gauge},
+		ShardQueueFailingTasksGauge:                       {metricName: "shard_queue_failing_tasks", metricType: Gauge},
+		ShardQueueMaxTaskAttemptGauge:                     {metricName: "shard_queue_max_task_attempt", metricType: Gauge},
 		GetReplicationMessagesForShardLatency:             {metricName: "get_replication_messages_for_shard", metricType: Timer},
 		GetDLQReplicationMessagesLatency:                  {metricName: "get_dlq_replication_messages", metricType: Timer},
 		EventReapplySkippedCount:                          {metricName: "event_reapply_skipped_count", metricType: Counter},
@@ -2229,6 +2428,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ReplicationTaskCleanupFailure:                     {metricName: "replication_task_cleanup_failed", metricType: Counter},
 		MutableStateChecksumMismatch:                      {metricName: "mutable_state_checksum_mismatch", metricType: Counter},
 		MutableStateChecksumInvalidated:                   {metricName: "mutable_state_checksum_invalidated", metricType: Counter},
+		AffinityOwnerUnavailableCounter:                   {metricName: "affinity_owner_unavailable", metricType: Counter},
 	},
 	Matching: {
 		PollSuccessPerTaskListCounter:            {metricName: "poll_success_per_tl", metricRollupName: "poll_success"},
@@ -2240,6 +2440,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		RespondQueryTaskFailedPerTaskListCounter: {metricName: "respond_query_failed_per_tl", metricRollupName: "respond_query_failed"},
 		SyncThrottlePerTaskListCounter:           {metricName: "sync_throttle_count_per_tl", metricRollupName: "sync_throttle_count"},
 		BufferThrottlePerTaskListCounter:         {metricName: "buffer_throttle_count_per_tl", metricRollupName: "buffer_throttle_count"},
+		PollerThrottlePerTaskListCounter:         {metricName: "poller_throttle_count_per_tl", metricRollupName: "poller_throttle_count"},
 		ExpiredTasksPerTaskListCounter:           {metricName: "tasks_expired_per_tl", metricRollupName: "tasks_expired"},
 		ForwardedPerTaskListCounter:              {metricName: "forwarded_per_tl", metricRollupName: "forwarded"},
 		ForwardTaskCallsPerTaskList:              {metricName: "forward_task_calls_per_tl", metricRollupName: "forward_task_calls"},
@@ -2248,8 +2449,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ForwardQueryErrorsPerTaskList:            {metricName: "forward_query_errors_per_tl", metricRollupName: "forward_query_errors"},
 		ForwardPollCallsPerTaskList:              {metricName: "forward_poll_calls_per_tl", metricRollupName: "forward_poll_calls"},
 		ForwardPollErrorsPerTaskList:             {metricName: "forward_poll_errors_per_tl", metricRollupName: "forward_poll_errors"},
-		SyncMatchLatencyPerTaskList:              {metricName: "syncmatch_latency_per_tl", metricRollupName: "syncmatch_latency", metricType: Timer},
-		AsyncMatchLatencyPerTaskList:             {metricName: "asyncmatch_latency_per_tl", metricRollupName: "asyncmatch_latency", metricType: Timer},
+		SyncMatchLatencyPerTaskList:              {metricName: "syncmatch_latency_per_tl", metricRollupName: "syncmatch_latency", metricType: Timer, buckets: LatencyBuckets},
+		AsyncMatchLatencyPerTaskList:             {metricName: "asyncmatch_latency_per_tl", metricRollupName: "asyncmatch_latency", metricType: Timer, buckets: QueueLatencyBuckets},
 		ForwardTaskLatencyPerTaskList:            {metricName: "forward_task_latency_per_tl", metricRollupName: "forward_task_latency"},
 		ForwardQueryLatencyPerTaskList:           {metricName: "forward_query_latency_per_tl", metricRollupName: "forward_query_latency"},
 		ForwardPollLatencyPerTaskList:            {metricName: "forward_poll_latency_per_tl", metricRollupName: "forward_poll_latency"},
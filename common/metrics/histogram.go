@@ -0,0 +1,94 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/uber-go/tally"
+)
+
+type (
+	// histogramTimer is a timer emitted as a histogram, so backends such as Prometheus
+	// get latencies with buckets they can aggregate across hosts
+	histogramTimer struct {
+		histogram tally.Histogram
+		unit      MetricUnit
+	}
+)
+
+var _ tally.Timer = (*histogramTimer)(nil)
+
+// histogramUnit returns the unit of the metric once emitted as a histogram, timers without a unit
+// measure seconds
+func (d metricDefinition) histogramUnit() MetricUnit {
+	if d.unit == Dimensionless && d.metricType == Timer {
+		return Seconds
+	}
+	return d.unit
+}
+
+// histogramName returns the name of the metric once emitted as a histogram, which ends with its
+// unit as Prometheus names do
+func (d metricDefinition) histogramName(name MetricName) MetricName {
+	unit := d.histogramUnit()
+	if unit == Dimensionless || strings.HasSuffix(name.String(), "_"+string(unit)) {
+		return name
+	}
+	return MetricName(name.String() + "_" + string(unit))
+}
+
+// timer returns the timer of the metric emitted with the given name, which is emitted as a
+// histogram instead when the metric has buckets
+func (d metricDefinition) timer(scope tally.Scope, name MetricName) tally.Timer {
+	if d.buckets == nil {
+		return scope.Timer(name.String())
+	}
+	return &histogramTimer{
+		histogram: scope.Histogram(d.histogramName(name).String(), d.buckets),
+		unit:      d.histogramUnit(),
+	}
+}
+
+// Record records the duration to the histogram
+func (t *histogramTimer) Record(d time.Duration) {
+	if t.unit == Seconds {
+		t.histogram.RecordDuration(d)
+		return
+	}
+	// timers of other units carry the value as a duration
+	t.histogram.RecordValue(float64(d))
+}
+
+// Start returns a stopwatch recording to the histogram
+func (t *histogramTimer) Start() tally.Stopwatch {
+	return tally.NewStopwatch(time.Now(), t)
+}
+
+// RecordStopwatch implements tally.StopwatchRecorder
+func (t *histogramTimer) RecordStopwatch(stopwatchStart time.Time) {
+	t.Record(time.Since(stopwatchStart))
+}
@@ -0,0 +1,230 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/uber-go/tally"
+)
+
+type (
+	// labelNormalizingReporter makes every metric carry all the labels of the metric definitions,
+	// Prometheus rejects a metric reported with different label sets, e.g. with and without a namespace
+	labelNormalizingReporter struct {
+		tally.CachedStatsReporter
+	}
+)
+
+var (
+	// metricLabels are the labels the scopes of the metric definitions may carry, on top of the
+	// tags of the root scope. Every metric reported through a label normalizing reporter carries
+	// all of them, metrics emitted without one of them report it as _unknown_.
+	metricLabels = map[string]struct{}{
+		OperationTagName:   {},
+		ServiceRoleTagName: {},
+		StatsTypeTagName:   {},
+		CacheTypeTagName:   {},
+		instance:           {},
+		namespace:          {},
+		targetCluster:      {},
+		taskList:           {},
+		workflowType:       {},
+		activityType:       {},
+		decisionType:       {},
+		apiClass:           {},
+		rpcMethod:          {},
+		rpcCode:            {},
+	}
+
+	metricNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// NewLabelNormalizingReporter wraps a cached reporter so every metric it reports carries all the
+// labels of the metric definitions
+func NewLabelNormalizingReporter(reporter tally.CachedStatsReporter) tally.CachedStatsReporter {
+	return &labelNormalizingReporter{CachedStatsReporter: reporter}
+}
+
+func (r *labelNormalizingReporter) AllocateCounter(name string, tags map[string]string) tally.CachedCount {
+	return r.CachedStatsReporter.AllocateCounter(name, withMetricLabels(tags))
+}
+
+func (r *labelNormalizingReporter) AllocateGauge(name string, tags map[string]string) tally.CachedGauge {
+	return r.CachedStatsReporter.AllocateGauge(name, withMetricLabels(tags))
+}
+
+func (r *labelNormalizingReporter) AllocateTimer(name string, tags map[string]string) tally.CachedTimer {
+	return r.CachedStatsReporter.AllocateTimer(name, withMetricLabels(tags))
+}
+
+func (r *labelNormalizingReporter) AllocateHistogram(
+	name string,
+	tags map[string]string,
+	buckets tally.Buckets,
+) tally.CachedHistogram {
+	return r.CachedStatsReporter.AllocateHistogram(name, withMetricLabels(tags), buckets)
+}
+
+func withMetricLabels(tags map[string]string) map[string]string {
+	normalized := make(map[string]string, len(tags)+len(metricLabels))
+	for label := range metricLabels {
+		normalized[label] = unknownValue
+	}
+	for k, v := range tags {
+		normalized[k] = v
+	}
+	return normalized
+}
+
+// validateDefinitions checks that the metric and scope definitions can be emitted to every
+// backend: names are valid Prometheus names, a name has a single type per service, histograms
+// have buckets and a name ending with their unit, and scopes only carry known labels
+func validateDefinitions(
+	metricDefs map[ServiceIdx]map[int]metricDefinition,
+	scopeDefs map[ServiceIdx]map[int]scopeDefinition,
+) error {
+
+	var errs []string
+	for serviceIdx := ServiceIdx(0); serviceIdx < NumServices; serviceIdx++ {
+		types := make(map[MetricName]MetricType)
+		defineName := func(name MetricName, metricType MetricType) {
+			if !metricNameRegex.MatchString(name.String()) {
+				errs = append(errs, fmt.Sprintf("service %v: invalid metric name %q", serviceIdx, name))
+			}
+			if previous, ok := types[name]; ok && previous != metricType {
+				errs = append(errs, fmt.Sprintf("service %v: metric %q defined with types %v and %v", serviceIdx, name, previous, metricType))
+			}
+			types[name] = metricType
+		}
+
+		defs := metricDefs[Common]
+		if serviceIdx != Common {
+			defs = mergeMetricDefs(metricDefs[Common], metricDefs[serviceIdx])
+		}
+		for _, def := range sortedMetricDefs(defs) {
+			if err := validateHistogram(def); err != nil {
+				errs = append(errs, fmt.Sprintf("service %v: metric %q: %v", serviceIdx, def.metricName, err))
+				continue
+			}
+			// timers with buckets are only emitted as histograms
+			if def.buckets != nil && def.metricType == Timer {
+				defineName(def.histogramName(def.metricName), Histogram)
+				if !def.metricRollupName.Empty() {
+					defineName(def.histogramName(def.metricRollupName), Histogram)
+				}
+				continue
+			}
+			defineName(def.metricName, def.metricType)
+			if !def.metricRollupName.Empty() {
+				defineName(def.metricRollupName, def.metricType)
+			}
+		}
+
+		for idx, def := range scopeDefs[serviceIdx] {
+			if len(def.operation) == 0 {
+				errs = append(errs, fmt.Sprintf("service %v: scope %v has no operation", serviceIdx, idx))
+			}
+			for label := range def.tags {
+				if _, ok := metricLabels[label]; !ok {
+					errs = append(errs, fmt.Sprintf("service %v: scope %v has unknown label %q", serviceIdx, def.operation, label))
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("invalid metric definitions: %v", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func validateHistogram(def metricDefinition) error {
+	switch def.metricType {
+	case Histogram:
+		if def.buckets == nil {
+			return fmt.Errorf("histogram has no buckets")
+		}
+		if def.histogramName(def.metricName) != def.metricName {
+			return fmt.Errorf("histogram name does not end with its unit %v", def.unit)
+		}
+	case Timer:
+	default:
+		if def.buckets != nil || def.unit != Dimensionless {
+			return fmt.Errorf("only timers and histograms have buckets and units")
+		}
+		return nil
+	}
+	if def.buckets == nil {
+		return nil
+	}
+	values := def.buckets.AsValues()
+	if len(values) == 0 {
+		return fmt.Errorf("buckets are empty")
+	}
+	for i := 1; i < len(values); i++ {
+		if values[i] <= values[i-1] {
+			return fmt.Errorf("buckets are not increasing")
+		}
+	}
+	if def.histogramUnit() == Seconds {
+		if _, ok := def.buckets.(tally.DurationBuckets); !ok {
+			return fmt.Errorf("histograms of seconds need duration buckets")
+		}
+	}
+	return nil
+}
+
+func mergeMetricDefs(
+	common map[int]metricDefinition,
+	service map[int]metricDefinition,
+) map[int]metricDefinition {
+
+	defs := make(map[int]metricDefinition, len(common)+len(service))
+	for idx, def := range common {
+		defs[idx] = def
+	}
+	for idx, def := range service {
+		defs[idx] = def
+	}
+	return defs
+}
+
+func sortedMetricDefs(defs map[int]metricDefinition) []metricDefinition {
+	indexes := make([]int, 0, len(defs))
+	for idx := range defs {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+	sorted := make([]metricDefinition, 0, len(indexes))
+	for _, idx := range indexes {
+		sorted = append(sorted, defs[idx])
+	}
+	return sorted
+}
@@ -0,0 +1,155 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+	"github.com/uber-go/tally/prometheus"
+)
+
+func TestValidateDefinitions(t *testing.T) {
+	require.NoError(t, validateDefinitions(MetricDefs, ScopeDefs))
+}
+
+func TestValidateDefinitionsRejectsInvalidDefinitions(t *testing.T) {
+	scopeDefs := map[ServiceIdx]map[int]scopeDefinition{
+		Common: {0: {operation: "Operation"}},
+	}
+	testCases := map[string]map[int]metricDefinition{
+		"invalid name": {
+			0: {metricName: "Invalid-Name", metricType: Counter},
+		},
+		"conflicting types": {
+			0: {metricName: "requests", metricType: Counter},
+			1: {metricName: "requests", metricType: Timer},
+		},
+		"conflicting rollup type": {
+			0: {metricName: "requests_per_tl", metricRollupName: "requests", metricType: Counter},
+			1: {metricName: "requests", metricType: Gauge},
+		},
+		"histogram without buckets": {
+			0: {metricName: "payload_bytes", metricType: Histogram, unit: Bytes},
+		},
+		"histogram without unit suffix": {
+			0: {metricName: "payload", metricType: Histogram, unit: Bytes, buckets: SizeBuckets},
+		},
+		"timer histogram with value buckets": {
+			0: {metricName: "latency", metricType: Timer, buckets: SizeBuckets},
+		},
+		"counter with buckets": {
+			0: {metricName: "requests", metricType: Counter, buckets: LatencyBuckets},
+		},
+		"decreasing buckets": {
+			0: {metricName: "latency", metricType: Timer, buckets: tally.DurationBuckets{time.Second, time.Millisecond}},
+		},
+		"histogram of timer conflicting with metric": {
+			0: {metricName: "latency", metricType: Timer, buckets: LatencyBuckets},
+			1: {metricName: "latency_seconds", metricType: Gauge},
+		},
+	}
+	for name, metricDefs := range testCases {
+		err := validateDefinitions(map[ServiceIdx]map[int]metricDefinition{Common: metricDefs}, scopeDefs)
+		assert.Error(t, err, name)
+	}
+
+	metricDefs := map[ServiceIdx]map[int]metricDefinition{
+		Common: {0: {metricName: "requests", metricType: Counter}},
+	}
+	assert.Error(t, validateDefinitions(metricDefs, map[ServiceIdx]map[int]scopeDefinition{
+		Common: {0: {operation: ""}},
+	}))
+	assert.Error(t, validateDefinitions(metricDefs, map[ServiceIdx]map[int]scopeDefinition{
+		Common: {0: {operation: "Operation", tags: map[string]string{"unknown": "value"}}},
+	}))
+	assert.NoError(t, validateDefinitions(metricDefs, scopeDefs))
+}
+
+func TestTimerWithBucketsEmitsHistogram(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	client := NewClient(scope, History)
+
+	client.RecordTimer(PersistenceCreateShardScope, PersistenceLatency, 30*time.Millisecond)
+	sw := client.StartTimer(PersistenceCreateShardScope, PersistenceLatency)
+	sw.Stop()
+	client.RecordTimer(PersistenceCreateShardScope, HistorySize, time.Duration(2048))
+	client.Scope(PersistenceCreateShardScope).RecordTimer(PersistenceLatency, 30*time.Millisecond)
+
+	snapshot := scope.Snapshot()
+	var latencies, sizes int64
+	for _, histogram := range snapshot.Histograms() {
+		switch histogram.Name() {
+		case "persistence_latency_seconds":
+			for upper, count := range histogram.Durations() {
+				if upper == 50*time.Millisecond {
+					latencies += count
+				}
+			}
+		case "history_size_bytes":
+			for _, count := range histogram.Values() {
+				sizes += count
+			}
+		}
+	}
+	assert.Equal(t, int64(2), latencies)
+	assert.Equal(t, int64(1), sizes)
+
+	for _, timer := range snapshot.Timers() {
+		assert.NotEqual(t, "persistence_latency", timer.Name())
+		assert.NotEqual(t, "history_size", timer.Name())
+	}
+}
+
+func TestLabelNormalizingReporter(t *testing.T) {
+	var errs []error
+	reporter := prometheus.NewReporter(prometheus.Options{
+		OnRegisterError: func(err error) {
+			errs = append(errs, err)
+		},
+	})
+	scope, closer := tally.NewRootScope(tally.ScopeOptions{
+		CachedReporter: NewLabelNormalizingReporter(reporter),
+		Separator:      prometheus.DefaultSeparator,
+	}, time.Millisecond)
+
+	scope.Tagged(map[string]string{OperationTagName: "StartWorkflowExecution"}).Counter("requests").Inc(1)
+	scope.Tagged(map[string]string{OperationTagName: "StartWorkflowExecution", namespace: "test"}).Counter("requests").Inc(1)
+	scope.Counter("requests").Inc(1)
+	scope.Tagged(map[string]string{workflowType: "test", rpcCode: "OK"}).Counter("requests").Inc(1)
+	scope.Tagged(map[string]string{instance: "1", targetCluster: "standby"}).Counter("requests").Inc(1)
+	require.NoError(t, closer.Close())
+	assert.Empty(t, errs)
+
+	labels := withMetricLabels(map[string]string{namespace: "test", "service_name": "history"})
+	assert.Len(t, labels, len(metricLabels)+1)
+	assert.Equal(t, "test", labels[namespace])
+	assert.Equal(t, "history", labels["service_name"])
+	assert.Equal(t, unknownValue, labels[workflowType])
+	assert.Equal(t, unknownValue, labels[targetCluster])
+}
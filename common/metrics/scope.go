@@ -82,12 +82,12 @@ func (m *metricsScope) UpdateGauge(id int, value float64) {
 
 func (m *metricsScope) StartTimer(id int) Stopwatch {
 	def := m.defs[id]
-	timer := m.scope.Timer(def.metricName.String())
+	timer := def.timer(m.scope, def.metricName)
 	switch {
 	case !def.metricRollupName.Empty():
-		return NewStopwatch(timer, m.rootScope.Timer(def.metricRollupName.String()))
+		return NewStopwatch(timer, def.timer(m.rootScope, def.metricRollupName))
 	case m.isNamespaceTagged:
-		timerAll := m.scope.Tagged(map[string]string{namespace: namespaceAllValue}).Timer(def.metricName.String())
+		timerAll := def.timer(m.scope.Tagged(map[string]string{namespace: namespaceAllValue}), def.metricName)
 		return NewStopwatch(timer, timerAll)
 	default:
 		return NewStopwatch(timer)
@@ -96,12 +96,12 @@ func (m *metricsScope) StartTimer(id int) Stopwatch {
 
 func (m *metricsScope) RecordTimer(id int, d time.Duration) {
 	def := m.defs[id]
-	m.scope.Timer(def.metricName.String()).Record(d)
+	def.timer(m.scope, def.metricName).Record(d)
 	switch {
 	case !def.metricRollupName.Empty():
-		m.rootScope.Timer(def.metricRollupName.String()).Record(d)
+		def.timer(m.rootScope, def.metricRollupName).Record(d)
 	case m.isNamespaceTagged:
-		m.scope.Tagged(map[string]string{namespace: namespaceAllValue}).Timer(def.metricName.String()).Record(d)
+		def.timer(m.scope.Tagged(map[string]string{namespace: namespaceAllValue}), def.metricName).Record(d)
 	}
 }
 
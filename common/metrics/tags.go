@@ -38,6 +38,9 @@ const (
 	workflowType  = "workflowType"
 	activityType  = "activityType"
 	decisionType  = "decisionType"
+	apiClass      = "apiClass"
+	rpcMethod     = "rpcMethod"
+	rpcCode       = "rpcCode"
 
 	namespaceAllValue = "all"
 	unknownValue      = "_unknown_"
@@ -81,6 +84,18 @@ type (
 	decisionTypeTag struct {
 		value string
 	}
+
+	apiClassTag struct {
+		value string
+	}
+
+	rpcMethodTag struct {
+		value string
+	}
+
+	rpcCodeTag struct {
+		value string
+	}
 )
 
 // NamespaceTag returns a new namespace tag. For timers, this also ensures that we
@@ -237,3 +252,48 @@ func (d decisionTypeTag) Key() string {
 func (d decisionTypeTag) Value() string {
 	return d.value
 }
+
+// APIClassTag returns a new API class tag
+func APIClassTag(value string) Tag {
+	return apiClassTag{value}
+}
+
+// Key returns the key of the API class tag
+func (d apiClassTag) Key() string {
+	return apiClass
+}
+
+// Value returns the value of the API class tag
+func (d apiClassTag) Value() string {
+	return d.value
+}
+
+// RPCMethodTag returns a new gRPC method tag
+func RPCMethodTag(value string) Tag {
+	return rpcMethodTag{value}
+}
+
+// Key returns the key of the gRPC method tag
+func (d rpcMethodTag) Key() string {
+	return rpcMethod
+}
+
+// Value returns the value of the gRPC method tag
+func (d rpcMethodTag) Value() string {
+	return d.value
+}
+
+// RPCCodeTag returns a new gRPC status code tag
+func RPCCodeTag(value string) Tag {
+	return rpcCodeTag{value}
+}
+
+// Key returns the key of the gRPC status code tag
+func (d rpcCodeTag) Key() string {
+	return rpcCode
+}
+
+// Value returns the value of the gRPC status code tag
+func (d rpcCodeTag) Value() string {
+	return d.value
+}
@@ -0,0 +1,203 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	typeAllValue   = "all"
+	typeOtherValue = "_other_"
+
+	// typeCandidatesPerTag is the number of types of a namespace counted for each type tag value, so that
+	// a type getting busier than a tagged one is counted long enough to take its place
+	typeCandidatesPerTag = 4
+	// typeRankingInterval is the number of times the types of a namespace are seen between two rankings,
+	// each ranking halves the counts so that the top types follow the recent traffic
+	typeRankingInterval = 1000
+)
+
+type (
+	// TypeTagLimiter bounds the number of workflow and activity type tag values metrics of a namespace
+	// are emitted with. Types are tagged when the namespace is enabled and the type is in the workflow or
+	// activity allowlist of the namespace, or, without an allowlist, when it is one of the types seen most
+	// often recently, up to the namespace limit. Other types are tagged as _other_, and every type as all
+	// when the namespace is not enabled, so that a metric always carries the same labels.
+	TypeTagLimiter struct {
+		enabled           func(namespace string) bool
+		workflowAllowlist func(namespace string) string
+		activityAllowlist func(namespace string) string
+		limit             func(namespace string) int
+
+		sync.Mutex
+		workflowTypes map[string]*typeCounts
+		activityTypes map[string]*typeCounts
+	}
+
+	// typeCounts counts how often the types of a namespace are seen with the space saving algorithm:
+	// at most a few times the limit of types are counted, and a new type replaces the least counted one
+	// and starts from its count
+	typeCounts struct {
+		counts map[string]int64
+		top    map[string]struct{}
+		seen   int
+	}
+)
+
+// NewTypeTagLimiter returns a new type tag limiter
+func NewTypeTagLimiter(
+	enabled func(namespace string) bool,
+	workflowAllowlist func(namespace string) string,
+	activityAllowlist func(namespace string) string,
+	limit func(namespace string) int,
+) *TypeTagLimiter {
+	return &TypeTagLimiter{
+		enabled:           enabled,
+		workflowAllowlist: workflowAllowlist,
+		activityAllowlist: activityAllowlist,
+		limit:             limit,
+		workflowTypes:     make(map[string]*typeCounts),
+		activityTypes:     make(map[string]*typeCounts),
+	}
+}
+
+// Enabled returns whether metrics of the namespace are tagged with their types, a nil limiter is never enabled
+func (l *TypeTagLimiter) Enabled(namespace string) bool {
+	return l != nil && l.enabled(namespace)
+}
+
+// WorkflowTypeTag returns the workflow type tag of a metric of the namespace, a nil limiter tags every type as all
+func (l *TypeTagLimiter) WorkflowTypeTag(namespace string, workflowType string) Tag {
+	if !l.Enabled(namespace) {
+		return workflowTypeTag{typeAllValue}
+	}
+	return workflowTypeTag{l.value(l.workflowTypes, l.workflowAllowlist, namespace, workflowType)}
+}
+
+// ActivityTypeTag returns the activity type tag of a metric of the namespace, a nil limiter tags every type as all
+func (l *TypeTagLimiter) ActivityTypeTag(namespace string, activityType string) Tag {
+	if !l.Enabled(namespace) {
+		return activityTypeTag{typeAllValue}
+	}
+	return activityTypeTag{l.value(l.activityTypes, l.activityAllowlist, namespace, activityType)}
+}
+
+func (l *TypeTagLimiter) value(
+	seen map[string]*typeCounts,
+	allowlist func(namespace string) string,
+	namespace string,
+	typeName string,
+) string {
+	if len(typeName) == 0 {
+		return unknownValue
+	}
+
+	if allowed := allowlist(namespace); len(allowed) > 0 {
+		for _, allowedType := range strings.Split(allowed, ",") {
+			if strings.TrimSpace(allowedType) == typeName {
+				return typeName
+			}
+		}
+		return typeOtherValue
+	}
+
+	limit := l.limit(namespace)
+	l.Lock()
+	defer l.Unlock()
+	types, ok := seen[namespace]
+	if !ok {
+		types = &typeCounts{
+			counts: make(map[string]int64),
+			top:    make(map[string]struct{}),
+		}
+		seen[namespace] = types
+	}
+	if types.see(typeName, limit) {
+		return typeName
+	}
+	return typeOtherValue
+}
+
+// see counts the type and returns whether it is one of the limit types seen most often
+func (c *typeCounts) see(typeName string, limit int) bool {
+	if limit <= 0 {
+		return false
+	}
+	if _, ok := c.counts[typeName]; !ok && len(c.counts) >= limit*typeCandidatesPerTag {
+		leastCounted := c.leastCounted()
+		c.counts[typeName] = c.counts[leastCounted]
+		delete(c.counts, leastCounted)
+		delete(c.top, leastCounted)
+	}
+	c.counts[typeName]++
+	c.seen++
+
+	if c.seen >= typeRankingInterval || len(c.top) > limit {
+		c.rank(limit)
+	} else if _, ok := c.top[typeName]; !ok && len(c.top) < limit {
+		c.top[typeName] = struct{}{}
+	}
+	_, ok := c.top[typeName]
+	return ok
+}
+
+func (c *typeCounts) leastCounted() string {
+	var leastCounted string
+	for typeName, count := range c.counts {
+		if len(leastCounted) == 0 || count < c.counts[leastCounted] ||
+			(count == c.counts[leastCounted] && typeName < leastCounted) {
+			leastCounted = typeName
+		}
+	}
+	return leastCounted
+}
+
+// rank makes the limit types counted most the top types, and halves the counts
+func (c *typeCounts) rank(limit int) {
+	types := make([]string, 0, len(c.counts))
+	for typeName := range c.counts {
+		types = append(types, typeName)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if c.counts[types[i]] != c.counts[types[j]] {
+			return c.counts[types[i]] > c.counts[types[j]]
+		}
+		return types[i] < types[j]
+	})
+
+	c.top = make(map[string]struct{}, limit)
+	for i, typeName := range types {
+		if i < limit {
+			c.top[typeName] = struct{}{}
+		}
+		if c.counts[typeName] /= 2; c.counts[typeName] == 0 {
+			delete(c.counts, typeName)
+		}
+	}
+	c.seen = 0
+}
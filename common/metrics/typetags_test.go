@@ -0,0 +1,124 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypeTagLimiterDisabled(t *testing.T) {
+	limiter := NewTypeTagLimiter(
+		func(string) bool { return false },
+		func(string) string { return "" },
+		func(string) string { return "" },
+		func(string) int { return 10 },
+	)
+	assert.False(t, limiter.Enabled("ns"))
+	assert.Equal(t, "all", limiter.WorkflowTypeTag("ns", "wf").Value())
+	assert.Equal(t, "all", limiter.ActivityTypeTag("ns", "act").Value())
+	assert.Equal(t, workflowType, limiter.WorkflowTypeTag("ns", "wf").Key())
+	assert.Equal(t, activityType, limiter.ActivityTypeTag("ns", "act").Key())
+
+	var nilLimiter *TypeTagLimiter
+	assert.False(t, nilLimiter.Enabled("ns"))
+	assert.Equal(t, "all", nilLimiter.WorkflowTypeTag("ns", "wf").Value())
+}
+
+func TestTypeTagLimiterAllowlist(t *testing.T) {
+	limiter := NewTypeTagLimiter(
+		func(string) bool { return true },
+		func(namespace string) string {
+			if namespace == "ns" {
+				return "wf1, wf2"
+			}
+			return ""
+		},
+		func(namespace string) string {
+			if namespace == "ns" {
+				return "act1"
+			}
+			return ""
+		},
+		func(string) int { return 0 },
+	)
+	assert.True(t, limiter.Enabled("ns"))
+	assert.Equal(t, "wf1", limiter.WorkflowTypeTag("ns", "wf1").Value())
+	assert.Equal(t, "wf2", limiter.WorkflowTypeTag("ns", "wf2").Value())
+	assert.Equal(t, "_other_", limiter.WorkflowTypeTag("ns", "wf3").Value())
+	assert.Equal(t, "act1", limiter.ActivityTypeTag("ns", "act1").Value())
+	assert.Equal(t, "_other_", limiter.ActivityTypeTag("ns", "act2").Value())
+	// the workflow and activity allowlists are separate
+	assert.Equal(t, "_other_", limiter.ActivityTypeTag("ns", "wf1").Value())
+	assert.Equal(t, "_other_", limiter.WorkflowTypeTag("ns", "act1").Value())
+	assert.Equal(t, "_other_", limiter.WorkflowTypeTag("other-ns", "wf1").Value())
+	assert.Equal(t, "_unknown_", limiter.WorkflowTypeTag("ns", "").Value())
+}
+
+func TestTypeTagLimiterLimit(t *testing.T) {
+	limiter := NewTypeTagLimiter(
+		func(string) bool { return true },
+		func(string) string { return "" },
+		func(string) string { return "" },
+		func(string) int { return 2 },
+	)
+	assert.Equal(t, "wf1", limiter.WorkflowTypeTag("ns", "wf1").Value())
+	assert.Equal(t, "wf2", limiter.WorkflowTypeTag("ns", "wf2").Value())
+	assert.Equal(t, "_other_", limiter.WorkflowTypeTag("ns", "wf3").Value())
+	assert.Equal(t, "wf1", limiter.WorkflowTypeTag("ns", "wf1").Value())
+
+	// namespaces and activity types are limited separately
+	assert.Equal(t, "wf3", limiter.WorkflowTypeTag("other-ns", "wf3").Value())
+	assert.Equal(t, "act1", limiter.ActivityTypeTag("ns", "act1").Value())
+	assert.Equal(t, "act2", limiter.ActivityTypeTag("ns", "act2").Value())
+	assert.Equal(t, "_other_", limiter.ActivityTypeTag("ns", "act3").Value())
+}
+
+func TestTypeTagLimiterTopTypes(t *testing.T) {
+	limiter := NewTypeTagLimiter(
+		func(string) bool { return true },
+		func(string) string { return "" },
+		func(string) string { return "" },
+		func(string) int { return 1 },
+	)
+	assert.Equal(t, "wf1", limiter.WorkflowTypeTag("ns", "wf1").Value())
+	assert.Equal(t, "_other_", limiter.WorkflowTypeTag("ns", "wf2").Value())
+
+	// a type seen more often takes the place of the first type seen once ranked
+	for i := 0; i < typeRankingInterval; i++ {
+		limiter.WorkflowTypeTag("ns", "wf2")
+	}
+	assert.Equal(t, "wf2", limiter.WorkflowTypeTag("ns", "wf2").Value())
+	assert.Equal(t, "_other_", limiter.WorkflowTypeTag("ns", "wf1").Value())
+
+	// the number of types counted stays bounded
+	for i := 0; i < 100; i++ {
+		limiter.WorkflowTypeTag("ns", fmt.Sprintf("wf-%v", i))
+	}
+	assert.LessOrEqual(t, len(limiter.workflowTypes["ns"].counts), typeCandidatesPerTag)
+	assert.Equal(t, "wf2", limiter.WorkflowTypeTag("ns", "wf2").Value())
+}
@@ -0,0 +1,128 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	namespacegenpb "github.com/temporalio/temporal/.gen/proto/namespace"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	// QuotaHandler is the interface handles the quotas of namespaces. Quotas are kept in the namespace config
+	// of the local cluster and are not replicated.
+	QuotaHandler interface {
+		Describe(name string) (*namespacegenpb.NamespaceQuotas, error)
+		Update(name string, quotas *namespacegenpb.NamespaceQuotas) (*namespacegenpb.NamespaceQuotas, error)
+	}
+
+	quotaHandlerImpl struct {
+		metadataMgr persistence.MetadataManager
+		logger      log.Logger
+	}
+)
+
+// NewQuotaHandler returns a QuotaHandler instance
+func NewQuotaHandler(
+	metadataMgr persistence.MetadataManager,
+	logger log.Logger,
+) QuotaHandler {
+	return &quotaHandlerImpl{
+		metadataMgr: metadataMgr,
+		logger:      logger,
+	}
+}
+
+// Describe returns the quotas of the namespace
+func (q *quotaHandlerImpl) Describe(
+	name string,
+) (*namespacegenpb.NamespaceQuotas, error) {
+
+	resp, err := q.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return getQuotas(resp.Namespace.Config.GetQuotas()), nil
+}
+
+// Update replaces the quotas of the namespace
+func (q *quotaHandlerImpl) Update(
+	name string,
+	quotas *namespacegenpb.NamespaceQuotas,
+) (*namespacegenpb.NamespaceQuotas, error) {
+
+	quotas = getQuotas(quotas)
+	if err := validateQuotas(quotas); err != nil {
+		return nil, err
+	}
+
+	// must get the metadata (notificationVersion) first, see HandlerImpl.UpdateNamespace
+	metadata, err := q.metadataMgr.GetMetadata()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := q.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	// the config version is left as is, as it orders the namespace replication tasks
+	// and the quotas are local to this cluster
+	detail := resp.Namespace
+	detail.Config.Quotas = quotas
+	if err := q.metadataMgr.UpdateNamespace(&persistence.UpdateNamespaceRequest{
+		Namespace:           detail,
+		NotificationVersion: metadata.NotificationVersion,
+	}); err != nil {
+		return nil, err
+	}
+
+	q.logger.Info("Namespace quotas updated.",
+		tag.WorkflowNamespace(name),
+		tag.Value(quotas),
+	)
+	return quotas, nil
+}
+
+func getQuotas(
+	quotas *namespacegenpb.NamespaceQuotas,
+) *namespacegenpb.NamespaceQuotas {
+
+	if quotas == nil {
+		return &namespacegenpb.NamespaceQuotas{}
+	}
+	return quotas
+}
+
+func validateQuotas(
+	quotas *namespacegenpb.NamespaceQuotas,
+) error {
+
+	if quotas.GetRps() < 0 || quotas.GetGlobalRps() < 0 || quotas.GetVisibilityListQps() < 0 {
+		return errInvalidQuota
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	namespacegenpb "github.com/temporalio/temporal/.gen/proto/namespace"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	quotaHandlerSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		metadataMgr  *mocks.MetadataManager
+		quotaHandler QuotaHandler
+	}
+)
+
+func TestQuotaHandlerSuite(t *testing.T) {
+	s := new(quotaHandlerSuite)
+	suite.Run(t, s)
+}
+
+func (s *quotaHandlerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.metadataMgr = &mocks.MetadataManager{}
+	s.quotaHandler = NewQuotaHandler(s.metadataMgr, loggerimpl.NewDevelopmentForTest(s.Suite))
+}
+
+func (s *quotaHandlerSuite) TearDownTest() {
+	s.metadataMgr.AssertExpectations(s.T())
+}
+
+func (s *quotaHandlerSuite) TestDescribe() {
+	s.metadataMgr.On("GetNamespace", &persistence.GetNamespaceRequest{Name: "test-namespace"}).
+		Return(s.namespace(&namespacegenpb.NamespaceQuotas{Rps: 10}), nil).Once()
+	s.metadataMgr.On("GetNamespace", &persistence.GetNamespaceRequest{Name: "other-namespace"}).
+		Return(s.namespace(nil), nil).Once()
+
+	quotas, err := s.quotaHandler.Describe("test-namespace")
+	s.NoError(err)
+	s.Equal(&namespacegenpb.NamespaceQuotas{Rps: 10}, quotas)
+
+	quotas, err = s.quotaHandler.Describe("other-namespace")
+	s.NoError(err)
+	s.Equal(&namespacegenpb.NamespaceQuotas{}, quotas)
+}
+
+func (s *quotaHandlerSuite) TestUpdate() {
+	quotas := &namespacegenpb.NamespaceQuotas{Rps: 10, GlobalRps: 100, VisibilityListQps: 1}
+	s.metadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: 5}, nil).Once()
+	s.metadataMgr.On("GetNamespace", &persistence.GetNamespaceRequest{Name: "test-namespace"}).
+		Return(s.namespace(&namespacegenpb.NamespaceQuotas{Rps: 1}), nil).Once()
+	s.metadataMgr.On("UpdateNamespace", mock.MatchedBy(func(request *persistence.UpdateNamespaceRequest) bool {
+		return request.NotificationVersion == 5 &&
+			request.Namespace.ConfigVersion == 3 &&
+			request.Namespace.Config.RetentionDays == 7 &&
+			proto.Equal(request.Namespace.Config.Quotas, quotas)
+	})).Return(nil).Once()
+
+	updated, err := s.quotaHandler.Update("test-namespace", quotas)
+	s.NoError(err)
+	s.Equal(quotas, updated)
+}
+
+func (s *quotaHandlerSuite) TestUpdate_InvalidQuota() {
+	_, err := s.quotaHandler.Update("test-namespace", &namespacegenpb.NamespaceQuotas{GlobalRps: -1})
+	s.Equal(errInvalidQuota, err)
+}
+
+func (s *quotaHandlerSuite) namespace(quotas *namespacegenpb.NamespaceQuotas) *persistence.GetNamespaceResponse {
+	return &persistence.GetNamespaceResponse{
+		Namespace: &persistenceblobs.NamespaceDetail{
+			Info:          &persistenceblobs.NamespaceInfo{Name: "test-namespace"},
+			Config:        &persistenceblobs.NamespaceConfig{RetentionDays: 7, Quotas: quotas},
+			ConfigVersion: 3,
+		},
+	}
+}
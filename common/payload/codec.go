@@ -0,0 +1,107 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package payload
+
+import (
+	"reflect"
+
+	commonpb "go.temporal.io/temporal-proto/common"
+)
+
+type (
+	// Codec transforms payloads before they are stored and after they are read by persistence
+	Codec interface {
+		// Encode transforms the payload of the namespace in place
+		Encode(namespaceID string, payload *commonpb.Payload) error
+		// Decode reverts Encode of the payload of the namespace in place, payloads which were not encoded
+		// are left untouched
+		Decode(namespaceID string, payload *commonpb.Payload) error
+	}
+)
+
+var payloadType = reflect.TypeOf((*commonpb.Payload)(nil))
+
+// Walk calls fn on every payload reachable from message, e.g. all the payloads of a history event
+func Walk(message interface{}, fn func(payload *commonpb.Payload) error) error {
+	return walk(reflect.ValueOf(message), fn)
+}
+
+// EncodeAll encodes every payload reachable from message in place
+func EncodeAll(codec Codec, namespaceID string, message interface{}) error {
+	return Walk(message, func(payload *commonpb.Payload) error {
+		return codec.Encode(namespaceID, payload)
+	})
+}
+
+// DecodeAll decodes every payload of the namespace reachable from message in place
+func DecodeAll(codec Codec, namespaceID string, message interface{}) error {
+	return Walk(message, func(payload *commonpb.Payload) error {
+		return codec.Decode(namespaceID, payload)
+	})
+}
+
+func walk(value reflect.Value, fn func(payload *commonpb.Payload) error) error {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+		if value.Type() == payloadType {
+			return fn(value.Interface().(*commonpb.Payload))
+		}
+		return walk(value.Elem(), fn)
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return walk(value.Elem(), fn)
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if err := walk(value.Field(i), fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		for i := 0; i < value.Len(); i++ {
+			if err := walk(value.Index(i), fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			if err := walk(iter.Value(), fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,321 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package payload
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	commonpb "go.temporal.io/temporal-proto/common"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+)
+
+const (
+	// MetadataEncoding is the payload item metadata key of the payload encoding
+	MetadataEncoding = "encoding"
+	// MetadataServerEncryption is the payload item metadata key marking items encrypted by the server,
+	// the value is the encryption algorithm. Items encrypted by clients do not carry it and are
+	// encrypted again like any other item. It is also the prefix of all the metadata keys reserved
+	// for the server, which clients are not allowed to set
+	MetadataServerEncryption = "temporal-server-encryption"
+	// MetadataEncryptionKeyID is the payload item metadata key of the id of the key wrapping the data key
+	MetadataEncryptionKeyID = "temporal-server-encryption-key-id"
+	// MetadataEncryptedDataKey is the payload item metadata key of the wrapped data key
+	MetadataEncryptedDataKey = "temporal-server-encryption-data-key"
+	// EncodingServerEncrypted is the encoding of items encrypted by the server, the data is the
+	// encrypted original item
+	EncodingServerEncrypted = "binary/server-encrypted"
+	// ServerEncryptionAlgorithm is the algorithm of items encrypted by the server
+	ServerEncryptionAlgorithm = "AES-256-GCM"
+
+	dataKeySize                    = 32
+	defaultDataKeyRotationInterval = 24 * time.Hour
+)
+
+type (
+	// EncryptionConfig is the config of payload encryption at rest
+	EncryptionConfig struct {
+		// KeyFile is the YAML file of the key encryption keys
+		KeyFile string `yaml:"keyFile"`
+		// KeyRefreshInterval is how often the key file is reloaded, it is not reloaded when zero
+		KeyRefreshInterval time.Duration `yaml:"keyRefreshInterval"`
+		// DataKeyRotationInterval is how long a namespace data key encrypts new payloads, one day when zero
+		DataKeyRotationInterval time.Duration `yaml:"dataKeyRotationInterval"`
+	}
+
+	// encryptionCodec encrypts payload items with AES-GCM using per namespace data keys,
+	// the data keys are wrapped by the key provider keys and stored with every item (envelope encryption)
+	encryptionCodec struct {
+		keyProvider      KeyProvider
+		rotationInterval time.Duration
+		logger           log.Logger
+
+		sync.RWMutex
+		dataKeys      map[string]*dataKey
+		unwrappedKeys map[string]cipher.AEAD
+	}
+
+	dataKey struct {
+		keyID   string
+		wrapped []byte
+		aead    cipher.AEAD
+		expiry  time.Time
+	}
+)
+
+var errMalformedCiphertext = errors.New("malformed encrypted payload")
+var errEncryptionKeyNotSet = errors.New("encrypted payload has no encryption key id or data key")
+var errUnknownEncryptionAlgorithm = errors.New("encrypted payload has an unknown encryption algorithm")
+var errReservedMetadata = errors.New("payload item has a metadata key reserved for the server")
+
+var _ Codec = (*encryptionCodec)(nil)
+
+// NewEncryptionCodec creates a codec which encrypts payloads with data keys wrapped by the key provider keys,
+// the ciphertext of every item is bound to its namespace
+func NewEncryptionCodec(
+	keyProvider KeyProvider,
+	dataKeyRotationInterval time.Duration,
+	logger log.Logger,
+) Codec {
+
+	if dataKeyRotationInterval <= 0 {
+		dataKeyRotationInterval = defaultDataKeyRotationInterval
+	}
+	return &encryptionCodec{
+		keyProvider:      keyProvider,
+		rotationInterval: dataKeyRotationInterval,
+		logger:           logger,
+		dataKeys:         make(map[string]*dataKey),
+		unwrappedKeys:    make(map[string]cipher.AEAD),
+	}
+}
+
+func (c *encryptionCodec) Encode(namespaceID string, payload *commonpb.Payload) error {
+	for i, item := range payload.GetItems() {
+		if isEncrypted(item) {
+			continue
+		}
+		key, err := c.getDataKey(namespaceID)
+		if err != nil {
+			return err
+		}
+		plaintext, err := item.Marshal()
+		if err != nil {
+			return err
+		}
+		ciphertext, err := seal(key.aead, plaintext, []byte(namespaceID))
+		if err != nil {
+			return err
+		}
+		payload.Items[i] = &commonpb.PayloadItem{
+			Metadata: map[string][]byte{
+				MetadataEncoding:         []byte(EncodingServerEncrypted),
+				MetadataServerEncryption: []byte(ServerEncryptionAlgorithm),
+				MetadataEncryptionKeyID:  []byte(key.keyID),
+				MetadataEncryptedDataKey: key.wrapped,
+			},
+			Data: ciphertext,
+		}
+	}
+	return nil
+}
+
+// Decode decrypts the items encrypted by the server, it fails when an item cannot be decrypted
+// so encrypted items are never returned to callers
+func (c *encryptionCodec) Decode(namespaceID string, payload *commonpb.Payload) error {
+	for i, item := range payload.GetItems() {
+		if !isEncrypted(item) {
+			continue
+		}
+		decrypted, err := c.decrypt(namespaceID, item)
+		if err != nil {
+			c.logger.Error("Unable to decrypt payload item",
+				tag.WorkflowNamespaceID(namespaceID),
+				tag.Error(err))
+			return fmt.Errorf("unable to decrypt payload item: %v", err)
+		}
+		payload.Items[i] = decrypted
+	}
+	return nil
+}
+
+func (c *encryptionCodec) decrypt(namespaceID string, item *commonpb.PayloadItem) (*commonpb.PayloadItem, error) {
+	if string(item.Metadata[MetadataServerEncryption]) != ServerEncryptionAlgorithm {
+		return nil, errUnknownEncryptionAlgorithm
+	}
+	keyID := string(item.Metadata[MetadataEncryptionKeyID])
+	wrapped := item.Metadata[MetadataEncryptedDataKey]
+	if keyID == "" || len(wrapped) == 0 {
+		return nil, errEncryptionKeyNotSet
+	}
+	aead, err := c.unwrapDataKey(keyID, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := open(aead, item.Data, []byte(namespaceID))
+	if err != nil {
+		return nil, err
+	}
+	decrypted := &commonpb.PayloadItem{}
+	if err := decrypted.Unmarshal(plaintext); err != nil {
+		return nil, err
+	}
+	return decrypted, nil
+}
+
+// getDataKey returns the data key of the namespace, a new key is generated when the key expires
+// or the active key encryption key changes
+func (c *encryptionCodec) getDataKey(namespaceID string) (*dataKey, error) {
+	keyID, kek, err := c.keyProvider.ActiveKey()
+	if err != nil {
+		return nil, err
+	}
+
+	c.RLock()
+	key, ok := c.dataKeys[namespaceID]
+	c.RUnlock()
+	if ok && key.keyID == keyID && time.Now().Before(key.expiry) {
+		return key, nil
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	key, ok = c.dataKeys[namespaceID]
+	if ok && key.keyID == keyID && time.Now().Before(key.expiry) {
+		return key, nil
+	}
+	plain := make([]byte, dataKeySize)
+	if _, err := rand.Read(plain); err != nil {
+		return nil, err
+	}
+	kekAEAD, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := seal(kekAEAD, plain, nil)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(plain)
+	if err != nil {
+		return nil, err
+	}
+	key = &dataKey{
+		keyID:   keyID,
+		wrapped: wrapped,
+		aead:    aead,
+		expiry:  time.Now().Add(c.rotationInterval),
+	}
+	c.dataKeys[namespaceID] = key
+	c.unwrappedKeys[keyID+string(wrapped)] = aead
+	return key, nil
+}
+
+func (c *encryptionCodec) unwrapDataKey(keyID string, wrapped []byte) (cipher.AEAD, error) {
+	cacheKey := keyID + string(wrapped)
+	c.RLock()
+	aead, ok := c.unwrappedKeys[cacheKey]
+	c.RUnlock()
+	if ok {
+		return aead, nil
+	}
+
+	kek, err := c.keyProvider.Key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	kekAEAD, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := open(kekAEAD, wrapped, nil)
+	if err != nil {
+		return nil, err
+	}
+	aead, err = newAEAD(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.unwrappedKeys[cacheKey] = aead
+	return aead, nil
+}
+
+// isEncrypted returns true for items encrypted by the server, items encrypted by clients are opaque data
+func isEncrypted(item *commonpb.PayloadItem) bool {
+	_, ok := item.GetMetadata()[MetadataServerEncryption]
+	return ok
+}
+
+// HasReservedMetadata returns true if a payload item reachable from message carries a metadata key reserved
+// for the server, clients setting them could skip encryption at rest or make their items unreadable
+func HasReservedMetadata(message interface{}) bool {
+	return Walk(message, func(payload *commonpb.Payload) error {
+		for _, item := range payload.GetItems() {
+			for key := range item.GetMetadata() {
+				if strings.HasPrefix(key, MetadataServerEncryption) {
+					return errReservedMetadata
+				}
+			}
+		}
+		return nil
+	}) != nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plaintext and prefixes the result with the random nonce, the additional data
+// is authenticated but not stored so open only succeeds with the same additional data
+func seal(aead cipher.AEAD, plaintext []byte, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, ciphertext []byte, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errMalformedCiphertext
+	}
+	nonce := ciphertext[:aead.NonceSize()]
+	return aead.Open(nil, nonce, ciphertext[aead.NonceSize():], additionalData)
+}
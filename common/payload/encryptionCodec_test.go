@@ -0,0 +1,254 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package payload
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+)
+
+type (
+	encryptionCodecSuite struct {
+		suite.Suite
+		keyFile     string
+		keyProvider KeyProvider
+		codec       Codec
+	}
+)
+
+func TestEncryptionCodecSuite(t *testing.T) {
+	suite.Run(t, new(encryptionCodecSuite))
+}
+
+func (s *encryptionCodecSuite) SetupTest() {
+	file, err := ioutil.TempFile("", "keys")
+	s.NoError(err)
+	s.NoError(file.Close())
+	s.keyFile = file.Name()
+	s.writeKeys("key-1", "key-1")
+
+	s.keyProvider, err = NewFileKeyProvider(s.keyFile, 0, loggerimpl.NewNopLogger(), nil)
+	s.NoError(err)
+	s.codec = NewEncryptionCodec(s.keyProvider, 0, loggerimpl.NewNopLogger())
+}
+
+func (s *encryptionCodecSuite) TearDownTest() {
+	os.Remove(s.keyFile)
+}
+
+func (s *encryptionCodecSuite) TestRoundTrip() {
+	payload := EncodeString("secret")
+	original := *payload.Items[0]
+
+	s.NoError(s.codec.Encode("namespace-id", payload))
+	item := payload.Items[0]
+	s.Equal(EncodingServerEncrypted, string(item.Metadata[MetadataEncoding]))
+	s.Equal(ServerEncryptionAlgorithm, string(item.Metadata[MetadataServerEncryption]))
+	s.Equal("key-1", string(item.Metadata[MetadataEncryptionKeyID]))
+	s.NotContains(string(item.Data), "secret")
+
+	// encoding an encrypted payload again is a no-op
+	s.NoError(s.codec.Encode("namespace-id", payload))
+	s.Equal(item, payload.Items[0])
+
+	s.NoError(s.codec.Decode("namespace-id", payload))
+	s.Equal(original, *payload.Items[0])
+	var value string
+	s.NoError(Decode(payload, &value))
+	s.Equal("secret", value)
+}
+
+func (s *encryptionCodecSuite) TestNamespaceDataKeys() {
+	payload1 := EncodeString("secret")
+	payload2 := EncodeString("secret")
+	payload3 := EncodeString("secret")
+	s.NoError(s.codec.Encode("namespace-1", payload1))
+	s.NoError(s.codec.Encode("namespace-1", payload2))
+	s.NoError(s.codec.Encode("namespace-2", payload3))
+
+	s.Equal(payload1.Items[0].Metadata[MetadataEncryptedDataKey], payload2.Items[0].Metadata[MetadataEncryptedDataKey])
+	s.NotEqual(payload1.Items[0].Metadata[MetadataEncryptedDataKey], payload3.Items[0].Metadata[MetadataEncryptedDataKey])
+}
+
+func (s *encryptionCodecSuite) TestKeyRotation() {
+	oldPayload := EncodeString("old")
+	s.NoError(s.codec.Encode("namespace-id", oldPayload))
+
+	s.writeKeys("key-2", "key-1", "key-2")
+	keyProvider, err := NewFileKeyProvider(s.keyFile, 0, loggerimpl.NewNopLogger(), nil)
+	s.NoError(err)
+	codec := NewEncryptionCodec(keyProvider, 0, loggerimpl.NewNopLogger())
+
+	newPayload := EncodeString("new")
+	s.NoError(codec.Encode("namespace-id", newPayload))
+	s.Equal("key-2", string(newPayload.Items[0].Metadata[MetadataEncryptionKeyID]))
+
+	var value string
+	s.NoError(codec.Decode("namespace-id", oldPayload))
+	s.NoError(Decode(oldPayload, &value))
+	s.Equal("old", value)
+	s.NoError(codec.Decode("namespace-id", newPayload))
+	s.NoError(Decode(newPayload, &value))
+	s.Equal("new", value)
+}
+
+func (s *encryptionCodecSuite) TestTamperedPayload() {
+	payload := EncodeString("secret")
+	s.NoError(s.codec.Encode("namespace-id", payload))
+	payload.Items[0].Data[len(payload.Items[0].Data)-1] ^= 1
+	s.Error(s.codec.Decode("namespace-id", payload))
+}
+
+func (s *encryptionCodecSuite) TestOtherNamespace() {
+	payload := EncodeString("secret")
+	s.NoError(s.codec.Encode("namespace-1", payload))
+	encrypted := *payload.Items[0]
+
+	// the ciphertext is bound to the namespace it was encrypted for
+	s.Error(s.codec.Decode("namespace-2", payload))
+	s.Equal(encrypted, *payload.Items[0])
+
+	s.NoError(s.codec.Decode("namespace-1", payload))
+	var value string
+	s.NoError(Decode(payload, &value))
+	s.Equal("secret", value)
+}
+
+func (s *encryptionCodecSuite) TestClientEncryptedPayload() {
+	clientItem := &commonpb.PayloadItem{
+		Metadata: map[string][]byte{
+			MetadataEncoding:    []byte("binary/encrypted"),
+			"encryption-key-id": []byte("client-key"),
+		},
+		Data: []byte("client ciphertext"),
+	}
+	payload := &commonpb.Payload{Items: []*commonpb.PayloadItem{clientItem}}
+	original := *clientItem
+
+	// decoding leaves items encrypted by clients untouched
+	s.NoError(s.codec.Decode("namespace-id", payload))
+	s.Equal(original, *payload.Items[0])
+
+	// the server encrypts them like any other item and restores them as written by the client
+	s.NoError(s.codec.Encode("namespace-id", payload))
+	s.Equal(EncodingServerEncrypted, string(payload.Items[0].Metadata[MetadataEncoding]))
+	s.NotContains(string(payload.Items[0].Data), "client ciphertext")
+	s.NoError(s.codec.Decode("namespace-id", payload))
+	s.Equal(original, *payload.Items[0])
+}
+
+func (s *encryptionCodecSuite) TestMissingKeyID() {
+	payload := EncodeString("secret")
+	s.NoError(s.codec.Encode("namespace-id", payload))
+	delete(payload.Items[0].Metadata, MetadataEncryptionKeyID)
+	_, err := s.codec.(*encryptionCodec).decrypt("namespace-id", payload.Items[0])
+	s.Equal(errEncryptionKeyNotSet, err)
+
+	payload = EncodeString("secret")
+	s.NoError(s.codec.Encode("namespace-id", payload))
+	payload.Items[0].Metadata[MetadataServerEncryption] = []byte("ROT13")
+	_, err = s.codec.(*encryptionCodec).decrypt("namespace-id", payload.Items[0])
+	s.Equal(errUnknownEncryptionAlgorithm, err)
+}
+
+func (s *encryptionCodecSuite) TestUndecryptableItem() {
+	payload := EncodeString("secret")
+	s.NoError(s.codec.Encode("namespace-id", payload))
+	payload.Items[0].Metadata[MetadataEncryptionKeyID] = []byte("unknown-key")
+	other := EncodeString("other")
+	s.NoError(s.codec.Encode("namespace-id", other))
+	payload.Items = append(payload.Items, other.Items[0])
+
+	// a bad item fails the whole payload, it is never returned encrypted
+	s.Error(s.codec.Decode("namespace-id", payload))
+}
+
+func (s *encryptionCodecSuite) TestHasReservedMetadata() {
+	event := &eventpb.HistoryEvent{
+		EventId: 1,
+		Attributes: &eventpb.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+			WorkflowExecutionStartedEventAttributes: &eventpb.WorkflowExecutionStartedEventAttributes{
+				Input: EncodeString("input"),
+			},
+		},
+	}
+	s.False(HasReservedMetadata(event))
+
+	event.GetWorkflowExecutionStartedEventAttributes().Input.Items[0].Metadata[MetadataEncryptionKeyID] = []byte("key-1")
+	s.True(HasReservedMetadata(event))
+}
+
+func (s *encryptionCodecSuite) TestEncodeAllEvents() {
+	event := &eventpb.HistoryEvent{
+		EventId: 1,
+		Attributes: &eventpb.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+			WorkflowExecutionStartedEventAttributes: &eventpb.WorkflowExecutionStartedEventAttributes{
+				Input: EncodeString("input"),
+				Memo: &commonpb.Memo{
+					Fields: map[string]*commonpb.Payload{"key": EncodeString("memo")},
+				},
+			},
+		},
+	}
+	s.NoError(EncodeAll(s.codec, "namespace-id", event))
+
+	count := 0
+	s.NoError(Walk(event, func(payload *commonpb.Payload) error {
+		count++
+		s.Equal(EncodingServerEncrypted, string(payload.Items[0].Metadata[MetadataEncoding]))
+		return nil
+	}))
+	s.Equal(2, count)
+
+	s.NoError(DecodeAll(s.codec, "namespace-id", event))
+	var value string
+	s.NoError(Decode(event.GetWorkflowExecutionStartedEventAttributes().Input, &value))
+	s.Equal("input", value)
+}
+
+func (s *encryptionCodecSuite) TestInvalidKeyFile() {
+	s.NoError(ioutil.WriteFile(s.keyFile, []byte("activeKey: missing\nkeys: {}\n"), 0600))
+	_, err := NewFileKeyProvider(s.keyFile, 0, loggerimpl.NewNopLogger(), nil)
+	s.Error(err)
+}
+
+func (s *encryptionCodecSuite) writeKeys(activeKey string, keyIDs ...string) {
+	var builder strings.Builder
+	builder.WriteString("activeKey: " + activeKey + "\nkeys:\n")
+	for _, keyID := range keyIDs {
+		key := []byte(strings.Repeat(keyID[len(keyID)-1:], keyEncryptionKeySize))
+		builder.WriteString("  " + keyID + ": " + base64.StdEncoding.EncodeToString(key) + "\n")
+	}
+	s.NoError(ioutil.WriteFile(s.keyFile, []byte(builder.String()), 0600))
+}
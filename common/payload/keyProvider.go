@@ -0,0 +1,154 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package payload
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+)
+
+const keyEncryptionKeySize = 32
+
+type (
+	// KeyProvider provides the key encryption keys wrapping the data keys of the encryption codec,
+	// it stands in for a KMS
+	KeyProvider interface {
+		// ActiveKey returns the key which wraps new data keys
+		ActiveKey() (keyID string, key []byte, err error)
+		// Key returns the key with the id, to unwrap existing data keys
+		Key(keyID string) ([]byte, error)
+	}
+
+	// keyFile is the YAML key file of the file key provider, keys are base64 encoded 256 bit AES keys
+	keyFile struct {
+		ActiveKey string            `yaml:"activeKey"`
+		Keys      map[string]string `yaml:"keys"`
+	}
+
+	// fileKeyProvider loads keys from a local file and reloads it periodically,
+	// keys are rotated by adding a new key to the file and making it active
+	fileKeyProvider struct {
+		keyFile string
+		logger  log.Logger
+
+		sync.RWMutex
+		activeKey string
+		keys      map[string][]byte
+	}
+)
+
+var _ KeyProvider = (*fileKeyProvider)(nil)
+
+// NewFileKeyProvider creates a KeyProvider loading keys from the given YAML file,
+// the file is reloaded every refreshInterval until shutdownCh is closed
+func NewFileKeyProvider(
+	keyFile string,
+	refreshInterval time.Duration,
+	logger log.Logger,
+	shutdownCh <-chan struct{},
+) (KeyProvider, error) {
+
+	p := &fileKeyProvider{
+		keyFile: keyFile,
+		logger:  logger,
+	}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+	if refreshInterval > 0 {
+		go p.refreshLoop(refreshInterval, shutdownCh)
+	}
+	return p, nil
+}
+
+func (p *fileKeyProvider) ActiveKey() (string, []byte, error) {
+	p.RLock()
+	defer p.RUnlock()
+	return p.activeKey, p.keys[p.activeKey], nil
+}
+
+func (p *fileKeyProvider) Key(keyID string) ([]byte, error) {
+	p.RLock()
+	defer p.RUnlock()
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("encryption key %q not found", keyID)
+	}
+	return key, nil
+}
+
+func (p *fileKeyProvider) refreshLoop(refreshInterval time.Duration, shutdownCh <-chan struct{}) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-shutdownCh:
+			return
+		case <-ticker.C:
+			if err := p.reload(); err != nil {
+				p.logger.Error("Failed to reload encryption keys", tag.Error(err))
+			}
+		}
+	}
+}
+
+func (p *fileKeyProvider) reload() error {
+	data, err := ioutil.ReadFile(p.keyFile)
+	if err != nil {
+		return err
+	}
+	var file keyFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("unable to parse key file %v: %v", p.keyFile, err)
+	}
+	keys := make(map[string][]byte, len(file.Keys))
+	for keyID, encoded := range file.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("invalid key %q in %v: %v", keyID, p.keyFile, err)
+		}
+		if len(key) != keyEncryptionKeySize {
+			return fmt.Errorf("invalid key %q in %v: key must be %v bytes", keyID, p.keyFile, keyEncryptionKeySize)
+		}
+		keys[keyID] = key
+	}
+	if _, ok := keys[file.ActiveKey]; !ok {
+		return fmt.Errorf("active key %q not found in %v", file.ActiveKey, p.keyFile)
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	p.activeKey = file.ActiveKey
+	p.keys = keys
+	return nil
+}
@@ -709,6 +709,7 @@ func createTransferTasks(
 		targetRunID := ""
 		targetChildWorkflowOnly := false
 		recordVisibility := false
+		var eventBatchID int64
 
 		switch task.GetType() {
 		case p.TransferTaskTypeActivityTask:
@@ -743,6 +744,10 @@ func createTransferTasks(
 			targetWorkflowID = task.(*p.StartChildExecutionTask).TargetWorkflowID
 			scheduleID = task.(*p.StartChildExecutionTask).InitiatedID
 
+		case p.TransferTaskTypeLifecycleEvent:
+			scheduleID = task.(*p.LifecycleEventTask).EventID
+			eventBatchID = task.(*p.LifecycleEventTask).EventBatchID
+
 		case p.TransferTaskTypeCloseExecution,
 			p.TransferTaskTypeRecordWorkflowStarted,
 			p.TransferTaskTypeResetWorkflow,
@@ -775,6 +780,7 @@ func createTransferTasks(
 			TaskId:                  task.GetTaskID(),
 			VisibilityTimestamp:     taskVisTs,
 			RecordVisibility:        recordVisibility,
+			EventBatchId:            eventBatchID,
 		}
 
 		datablob, err := serialization.TransferTaskInfoToBlob(p)
@@ -60,6 +60,8 @@ type (
 
 		GetExecutionManager(int) (persistence.ExecutionManager, error)
 		SetExecutionManager(int, persistence.ExecutionManager)
+
+		GetAuditQueue() (persistence.Queue, error)
 	}
 
 	// BeanImpl stores persistence managers
@@ -71,10 +73,11 @@ type (
 		namespaceReplicationQueue persistence.NamespaceReplicationQueue
 		shardManager              persistence.ShardManager
 		historyManager            persistence.HistoryManager
-		executionManagerFactory   persistence.ExecutionManagerFactory
+		factory                   Factory
 
 		sync.RWMutex
 		shardIDToExecutionManager map[int]persistence.ExecutionManager
+		auditQueue                persistence.Queue
 	}
 )
 
@@ -140,7 +143,7 @@ func NewBean(
 	namespaceReplicationQueue persistence.NamespaceReplicationQueue,
 	shardManager persistence.ShardManager,
 	historyManager persistence.HistoryManager,
-	executionManagerFactory persistence.ExecutionManagerFactory,
+	factory Factory,
 ) *BeanImpl {
 	return &BeanImpl{
 		clusterMetadataManager:    clusterMetadataManager,
@@ -150,7 +153,7 @@ func NewBean(
 		namespaceReplicationQueue: namespaceReplicationQueue,
 		shardManager:              shardManager,
 		historyManager:            historyManager,
-		executionManagerFactory:   executionManagerFactory,
+		factory:                   factory,
 
 		shardIDToExecutionManager: make(map[int]persistence.ExecutionManager),
 	}
@@ -316,7 +319,7 @@ func (s *BeanImpl) GetExecutionManager(
 		return executionManager, nil
 	}
 
-	executionManager, err := s.executionManagerFactory.NewExecutionManager(shardID)
+	executionManager, err := s.factory.NewExecutionManager(shardID)
 	if err != nil {
 		return nil, err
 	}
@@ -337,6 +340,23 @@ func (s *BeanImpl) SetExecutionManager(
 	s.shardIDToExecutionManager[shardID] = executionManager
 }
 
+// GetAuditQueue get the audit log Queue, the queue is created on first use
+func (s *BeanImpl) GetAuditQueue() (persistence.Queue, error) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.auditQueue != nil {
+		return s.auditQueue, nil
+	}
+	auditQueue, err := s.factory.NewAuditQueue()
+	if err != nil {
+		return nil, err
+	}
+	s.auditQueue = auditQueue
+	return auditQueue, nil
+}
+
 // Close cleanup connections
 func (s *BeanImpl) Close() {
 
@@ -349,7 +369,10 @@ func (s *BeanImpl) Close() {
 	s.namespaceReplicationQueue.Stop()
 	s.shardManager.Close()
 	s.historyManager.Close()
-	s.executionManagerFactory.Close()
+	if s.auditQueue != nil {
+		s.auditQueue.Close()
+	}
+	s.factory.Close()
 	for _, executionMgr := range s.shardIDToExecutionManager {
 		executionMgr.Close()
 	}
@@ -174,6 +174,21 @@ func (mr *MockBeanMockRecorder) SetVisibilityManager(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVisibilityManager", reflect.TypeOf((*MockBean)(nil).SetVisibilityManager), arg0)
 }
 
+// GetAuditQueue mocks base method.
+func (m *MockBean) GetAuditQueue() (persistence.Queue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditQueue")
+	ret0, _ := ret[0].(persistence.Queue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditQueue indicates an expected call of GetAuditQueue.
+func (mr *MockBeanMockRecorder) GetAuditQueue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditQueue", reflect.TypeOf((*MockBean)(nil).GetAuditQueue))
+}
+
 // GetNamespaceReplicationQueue mocks base method.
 func (m *MockBean) GetNamespaceReplicationQueue() persistence.NamespaceReplicationQueue {
 	m.ctrl.T.Helper()
@@ -27,8 +27,12 @@ package client
 import (
 	"sync"
 
+	"github.com/opentracing/opentracing-go"
+
 	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/payload"
 	p "github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/persistence/cassandra"
 	"github.com/temporalio/temporal/common/persistence/sql"
@@ -58,6 +62,8 @@ type (
 		NewVisibilityManager() (p.VisibilityManager, error)
 		// NewNamespaceReplicationQueue returns a new queue for namespace replication
 		NewNamespaceReplicationQueue() (p.NamespaceReplicationQueue, error)
+		// NewAuditQueue returns a new queue for the audit log
+		NewAuditQueue() (p.Queue, error)
 		// NewClusterMetadata returns a new manager for cluster specific metadata
 		NewClusterMetadataManager() (p.ClusterMetadataManager, error)
 	}
@@ -98,9 +104,12 @@ type (
 		config                   *config.Persistence
 		abstractDataStoreFactory AbstractDataStoreFactory
 		metricsClient            metrics.Client
+		tracer                   opentracing.Tracer
 		logger                   log.Logger
 		datastores               map[storeType]Datastore
 		clusterName              string
+		payloadCodec             payload.Codec
+		doneCh                   chan struct{}
 	}
 
 	storeType int
@@ -134,7 +143,8 @@ var storeTypes = []storeType{
 // also contains config for individual datastores themselves.
 //
 // The objects returned by this factory enforce ratelimit and maxconns according to
-// given configuration. In addition, all objects will emit metrics automatically
+// given configuration. In addition, all objects will emit metrics automatically, and
+// record trace spans when a global tracer is registered
 func NewFactory(
 	cfg *config.Persistence,
 	persistenceMaxQPS dynamicconfig.IntPropertyFn,
@@ -149,9 +159,14 @@ func NewFactory(
 		metricsClient:            metricsClient,
 		logger:                   logger,
 		clusterName:              clusterName,
+		doneCh:                   make(chan struct{}),
+	}
+	if opentracing.IsGlobalTracerRegistered() {
+		factory.tracer = opentracing.GlobalTracer()
 	}
 	limiters := buildRatelimiters(cfg, persistenceMaxQPS)
 	factory.init(clusterName, limiters)
+	factory.initPayloadCodec()
 	return factory
 }
 
@@ -168,6 +183,9 @@ func (f *factoryImpl) NewTaskManager() (p.TaskManager, error) {
 	if f.metricsClient != nil {
 		result = p.NewTaskPersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
+	if f.tracer != nil {
+		result = p.NewTaskPersistenceTracingClient(result, f.tracer)
+	}
 	return result, nil
 }
 
@@ -184,6 +202,9 @@ func (f *factoryImpl) NewShardManager() (p.ShardManager, error) {
 	if f.metricsClient != nil {
 		result = p.NewShardPersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
+	if f.tracer != nil {
+		result = p.NewShardPersistenceTracingClient(result, f.tracer)
+	}
 	return result, nil
 }
 
@@ -194,13 +215,16 @@ func (f *factoryImpl) NewHistoryManager() (p.HistoryManager, error) {
 	if err != nil {
 		return nil, err
 	}
-	result := p.NewHistoryV2ManagerImpl(store, f.logger, f.config.TransactionSizeLimit)
+	result := p.NewHistoryV2ManagerImpl(store, f.payloadCodec, f.logger, f.config.TransactionSizeLimit)
 	if ds.ratelimit != nil {
 		result = p.NewHistoryV2PersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
 	if f.metricsClient != nil {
 		result = p.NewHistoryV2PersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
+	if f.tracer != nil {
+		result = p.NewHistoryV2PersistenceTracingClient(result, f.tracer)
+	}
 	return result, nil
 }
 
@@ -221,6 +245,9 @@ func (f *factoryImpl) NewMetadataManager() (p.MetadataManager, error) {
 	if f.metricsClient != nil {
 		result = p.NewMetadataPersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
+	if f.tracer != nil {
+		result = p.NewMetadataPersistenceTracingClient(result, f.tracer)
+	}
 	return result, nil
 }
 
@@ -241,6 +268,9 @@ func (f *factoryImpl) NewClusterMetadataManager() (p.ClusterMetadataManager, err
 	if f.metricsClient != nil {
 		result = p.NewClusterMetadataPersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
+	if f.tracer != nil {
+		result = p.NewClusterMetadataPersistenceTracingClient(result, f.tracer)
+	}
 	return result, nil
 }
 
@@ -251,13 +281,16 @@ func (f *factoryImpl) NewExecutionManager(shardID int) (p.ExecutionManager, erro
 	if err != nil {
 		return nil, err
 	}
-	result := p.NewExecutionManagerImpl(store, f.logger)
+	result := p.NewExecutionManagerImpl(store, f.payloadCodec, f.logger)
 	if ds.ratelimit != nil {
 		result = p.NewWorkflowExecutionPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
 	if f.metricsClient != nil {
 		result = p.NewWorkflowExecutionPersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
+	if f.tracer != nil {
+		result = p.NewWorkflowExecutionPersistenceTracingClient(result, f.tracer)
+	}
 	return result, nil
 }
 
@@ -279,10 +312,14 @@ func (f *factoryImpl) NewVisibilityManager() (p.VisibilityManager, error) {
 	}
 	if visConfig != nil && visConfig.EnableSampling() {
 		result = p.NewVisibilitySamplingClient(result, visConfig, f.metricsClient, f.logger)
+		result = p.NewVisibilityListRateLimitedClient(result, visConfig.VisibilityListMaxQPS, f.logger)
 	}
 	if f.metricsClient != nil {
 		result = p.NewVisibilityPersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
+	if f.tracer != nil {
+		result = p.NewVisibilityPersistenceTracingClient(result, f.tracer)
+	}
 
 	return result, nil
 }
@@ -299,14 +336,44 @@ func (f *factoryImpl) NewNamespaceReplicationQueue() (p.NamespaceReplicationQueu
 	if f.metricsClient != nil {
 		result = p.NewQueuePersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
+	if f.tracer != nil {
+		result = p.NewQueuePersistenceTracingClient(result, f.tracer)
+	}
 
 	return p.NewNamespaceReplicationQueue(result, f.clusterName, f.metricsClient, f.logger), nil
 }
 
+func (f *factoryImpl) NewAuditQueue() (p.Queue, error) {
+	ds := f.datastores[storeTypeQueue]
+	result, err := ds.factory.NewQueue(p.AuditQueueType)
+	if err != nil {
+		return nil, err
+	}
+	if ds.ratelimit != nil {
+		result = p.NewQueuePersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	}
+	if f.metricsClient != nil {
+		result = p.NewQueuePersistenceMetricsClient(result, f.metricsClient, f.logger)
+	}
+	if f.tracer != nil {
+		result = p.NewQueuePersistenceTracingClient(result, f.tracer)
+	}
+
+	return result, nil
+}
+
 // Close closes this factory
 func (f *factoryImpl) Close() {
 	ds := f.datastores[storeTypeExecution]
 	ds.factory.Close()
+
+	f.Lock()
+	defer f.Unlock()
+	select {
+	case <-f.doneCh:
+	default:
+		close(f.doneCh)
+	}
 }
 
 func (f *factoryImpl) isCassandra() bool {
@@ -354,6 +421,18 @@ func (f *factoryImpl) init(clusterName string, limiters map[string]quotas.Limite
 	f.datastores[storeTypeVisibility] = visibilityDataStore
 }
 
+func (f *factoryImpl) initPayloadCodec() {
+	cfg := f.config.PayloadEncryption
+	if cfg == nil || cfg.KeyFile == "" {
+		return
+	}
+	keyProvider, err := payload.NewFileKeyProvider(cfg.KeyFile, cfg.KeyRefreshInterval, f.logger, f.doneCh)
+	if err != nil {
+		f.logger.Fatal("invalid config: unable to load payload encryption keys", tag.Error(err))
+	}
+	f.payloadCodec = payload.NewEncryptionCodec(keyProvider, cfg.DataKeyRotationInterval, f.logger)
+}
+
 func buildRatelimiters(cfg *config.Persistence, maxQPS dynamicconfig.IntPropertyFn) map[string]quotas.Limiter {
 	result := make(map[string]quotas.Limiter, len(cfg.DataStores))
 	for dsName := range cfg.DataStores {
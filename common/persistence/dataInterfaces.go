@@ -70,6 +70,7 @@ type QueueType int
 // Negative numbers are reserved for DLQ
 const (
 	NamespaceReplicationQueueType QueueType = iota + 1
+	AuditQueueType
 )
 
 // Create Workflow Execution Mode
@@ -147,6 +148,7 @@ const (
 	TransferTaskTypeRecordWorkflowStarted
 	TransferTaskTypeResetWorkflow
 	TransferTaskTypeUpsertWorkflowSearchAttributes
+	TransferTaskTypeLifecycleEvent
 )
 
 // Types of replication tasks
@@ -457,6 +459,15 @@ type (
 		Version int64
 	}
 
+	// LifecycleEventTask identifies a transfer task for publishing a workflow lifecycle event
+	LifecycleEventTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		EventID             int64
+		EventBatchID        int64
+		Version             int64
+	}
+
 	// StartChildExecutionTask identifies a transfer task for starting child execution
 	StartChildExecutionTask struct {
 		VisibilityTimestamp time.Time
@@ -1149,6 +1160,8 @@ type (
 		Encoding common.EncodingType
 		// The shard to get history node data
 		ShardID *int
+		// The namespace of the events, used to encode their payloads
+		NamespaceID string
 	}
 
 	// AppendHistoryNodesResponse is a response to AppendHistoryNodesRequest
@@ -1172,6 +1185,8 @@ type (
 		NextPageToken []byte
 		// The shard to get history branch data
 		ShardID *int
+		// The namespace of the events, used to decode their payloads
+		NamespaceID string
 	}
 
 	// ReadHistoryBranchResponse is the response to ReadHistoryBranchRequest
@@ -2057,6 +2072,41 @@ func (u *UpsertWorkflowSearchAttributesTask) SetVisibilityTimestamp(timestamp ti
 	u.VisibilityTimestamp = timestamp
 }
 
+// GetType returns the type of the lifecycle event transfer task
+func (u *LifecycleEventTask) GetType() int {
+	return TransferTaskTypeLifecycleEvent
+}
+
+// GetVersion returns the version of the lifecycle event transfer task
+func (u *LifecycleEventTask) GetVersion() int64 {
+	return u.Version
+}
+
+// SetVersion returns the version of the lifecycle event transfer task
+func (u *LifecycleEventTask) SetVersion(version int64) {
+	u.Version = version
+}
+
+// GetTaskID returns the sequence ID of the lifecycle event transfer task.
+func (u *LifecycleEventTask) GetTaskID() int64 {
+	return u.TaskID
+}
+
+// SetTaskID sets the sequence ID of the lifecycle event transfer task.
+func (u *LifecycleEventTask) SetTaskID(id int64) {
+	u.TaskID = id
+}
+
+// GetVisibilityTimestamp get the visibility timestamp
+func (u *LifecycleEventTask) GetVisibilityTimestamp() time.Time {
+	return u.VisibilityTimestamp
+}
+
+// SetVisibilityTimestamp set the visibility timestamp
+func (u *LifecycleEventTask) SetVisibilityTimestamp(timestamp time.Time) {
+	u.VisibilityTimestamp = timestamp
+}
+
 // GetType returns the type of the start child transfer task
 func (u *StartChildExecutionTask) GetType() int {
 	return TransferTaskTypeStartChildExecution
@@ -55,6 +55,7 @@ func NewESVisibilityManager(indexName string, esClient es.Client, config *config
 		}
 		if config.EnableSampling != nil && config.EnableSampling() {
 			visibilityFromES = p.NewVisibilitySamplingClient(visibilityFromES, config, metricsClient, log)
+			visibilityFromES = p.NewVisibilityListRateLimitedClient(visibilityFromES, config.VisibilityListMaxQPS, log)
 		}
 	}
 	if metricsClient != nil {
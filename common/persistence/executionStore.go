@@ -30,6 +30,7 @@ import (
 
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/persistence/serialization"
 	"github.com/temporalio/temporal/common/primitives"
 )
@@ -40,15 +41,18 @@ type (
 		serializer    PayloadSerializer
 		persistence   ExecutionStore
 		statsComputer statsComputer
+		payloadCodec  payload.Codec
 		logger        log.Logger
 	}
 )
 
 var _ ExecutionManager = (*executionManagerImpl)(nil)
 
-// NewExecutionManagerImpl returns new ExecutionManager
+// NewExecutionManagerImpl returns new ExecutionManager, payloadCodec is optional and encodes
+// the payloads of the mutable state when set
 func NewExecutionManagerImpl(
 	persistence ExecutionStore,
+	payloadCodec payload.Codec,
 	logger log.Logger,
 ) ExecutionManager {
 
@@ -56,6 +60,7 @@ func NewExecutionManagerImpl(
 		serializer:    NewPayloadSerializer(),
 		persistence:   persistence,
 		statsComputer: statsComputer{},
+		payloadCodec:  payloadCodec,
 		logger:        logger,
 	}
 }
@@ -92,11 +97,11 @@ func (m *executionManagerImpl) GetWorkflowExecution(
 	if err != nil {
 		return nil, err
 	}
-	newResponse.State.ChildExecutionInfos, err = m.DeserializeChildExecutionInfos(response.State.ChildExecutionInfos)
+	newResponse.State.ChildExecutionInfos, err = m.DeserializeChildExecutionInfos(request.NamespaceID, response.State.ChildExecutionInfos)
 	if err != nil {
 		return nil, err
 	}
-	newResponse.State.BufferedEvents, err = m.DeserializeBufferedEvents(response.State.BufferedEvents)
+	newResponse.State.BufferedEvents, err = m.DeserializeBufferedEvents(request.NamespaceID, response.State.BufferedEvents)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +127,9 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := decodePayloads(m.payloadCodec, info.NamespaceID, completionEvent); err != nil {
+		return nil, nil, err
+	}
 
 	autoResetPoints, err := m.serializer.DeserializeResetPoints(info.AutoResetPoints)
 	if err != nil {
@@ -191,6 +199,7 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(
 }
 
 func (m *executionManagerImpl) DeserializeBufferedEvents(
+	namespaceID string,
 	blobs []*serialization.DataBlob,
 ) ([]*eventpb.HistoryEvent, error) {
 
@@ -200,12 +209,16 @@ func (m *executionManagerImpl) DeserializeBufferedEvents(
 		if err != nil {
 			return nil, err
 		}
+		if err := decodePayloads(m.payloadCodec, namespaceID, history); err != nil {
+			return nil, err
+		}
 		events = append(events, history...)
 	}
 	return events, nil
 }
 
 func (m *executionManagerImpl) DeserializeChildExecutionInfos(
+	namespaceID string,
 	infos map[int64]*InternalChildExecutionInfo,
 ) (map[int64]*ChildExecutionInfo, error) {
 
@@ -219,6 +232,9 @@ func (m *executionManagerImpl) DeserializeChildExecutionInfos(
 		if err != nil {
 			return nil, err
 		}
+		if err := decodePayloads(m.payloadCodec, namespaceID, []*eventpb.HistoryEvent{initiatedEvent, startedEvent}); err != nil {
+			return nil, err
+		}
 		c := &ChildExecutionInfo{
 			InitiatedEvent: initiatedEvent,
 			StartedEvent:   startedEvent,
@@ -266,6 +282,14 @@ func (m *executionManagerImpl) DeserializeActivityInfos(
 		if err != nil {
 			return nil, err
 		}
+		if err := decodePayloads(m.payloadCodec, v.NamespaceID.String(), []interface{}{
+			scheduledEvent,
+			startedEvent,
+			v.Details,
+			v.LastFailureDetails,
+		}); err != nil {
+			return nil, err
+		}
 		a := &ActivityInfo{
 			ScheduledEvent: scheduledEvent,
 			StartedEvent:   startedEvent,
@@ -339,17 +363,22 @@ func (m *executionManagerImpl) UpdateWorkflowExecution(
 }
 
 func (m *executionManagerImpl) SerializeUpsertChildExecutionInfos(
+	namespaceID string,
 	infos []*ChildExecutionInfo,
 	encoding common.EncodingType,
 ) ([]*InternalChildExecutionInfo, error) {
 
 	newInfos := make([]*InternalChildExecutionInfo, 0)
 	for _, v := range infos {
-		initiatedEvent, err := m.serializer.SerializeEvent(v.InitiatedEvent, encoding)
+		events, err := encodeEvents(m.payloadCodec, namespaceID, []*eventpb.HistoryEvent{v.InitiatedEvent, v.StartedEvent})
 		if err != nil {
 			return nil, err
 		}
-		startedEvent, err := m.serializer.SerializeEvent(v.StartedEvent, encoding)
+		initiatedEvent, err := m.serializer.SerializeEvent(events[0], encoding)
+		if err != nil {
+			return nil, err
+		}
+		startedEvent, err := m.serializer.SerializeEvent(events[1], encoding)
 		if err != nil {
 			return nil, err
 		}
@@ -380,11 +409,23 @@ func (m *executionManagerImpl) SerializeUpsertActivityInfos(
 
 	newInfos := make([]*InternalActivityInfo, 0)
 	for _, v := range infos {
-		scheduledEvent, err := m.serializer.SerializeEvent(v.ScheduledEvent, encoding)
+		events, err := encodeEvents(m.payloadCodec, v.NamespaceID, []*eventpb.HistoryEvent{v.ScheduledEvent, v.StartedEvent})
+		if err != nil {
+			return nil, err
+		}
+		scheduledEvent, err := m.serializer.SerializeEvent(events[0], encoding)
+		if err != nil {
+			return nil, err
+		}
+		startedEvent, err := m.serializer.SerializeEvent(events[1], encoding)
+		if err != nil {
+			return nil, err
+		}
+		details, err := encodePayload(m.payloadCodec, v.NamespaceID, v.Details)
 		if err != nil {
 			return nil, err
 		}
-		startedEvent, err := m.serializer.SerializeEvent(v.StartedEvent, encoding)
+		lastFailureDetails, err := encodePayload(m.payloadCodec, v.NamespaceID, v.LastFailureDetails)
 		if err != nil {
 			return nil, err
 		}
@@ -399,7 +440,7 @@ func (m *executionManagerImpl) SerializeUpsertActivityInfos(
 			StartedTime:                             v.StartedTime,
 			ActivityID:                              v.ActivityID,
 			RequestID:                               v.RequestID,
-			Details:                                 v.Details,
+			Details:                                 details,
 			ScheduleToStartTimeout:                  v.ScheduleToStartTimeout,
 			ScheduleToCloseTimeout:                  v.ScheduleToCloseTimeout,
 			StartToCloseTimeout:                     v.StartToCloseTimeout,
@@ -421,7 +462,7 @@ func (m *executionManagerImpl) SerializeUpsertActivityInfos(
 			NonRetriableErrors:                      v.NonRetriableErrors,
 			LastFailureReason:                       v.LastFailureReason,
 			LastWorkerIdentity:                      v.LastWorkerIdentity,
-			LastFailureDetails:                      v.LastFailureDetails,
+			LastFailureDetails:                      lastFailureDetails,
 			LastHeartbeatTimeoutVisibilityInSeconds: v.LastHeartbeatTimeoutVisibilityInSeconds,
 		}
 		newInfos = append(newInfos, i)
@@ -438,7 +479,11 @@ func (m *executionManagerImpl) SerializeExecutionInfo(
 	if info == nil {
 		return &InternalWorkflowExecutionInfo{}, nil
 	}
-	completionEvent, err := m.serializer.SerializeEvent(info.CompletionEvent, encoding)
+	completionEvent, err := encodeEvent(m.payloadCodec, info.NamespaceID, info.CompletionEvent)
+	if err != nil {
+		return nil, err
+	}
+	serializedCompletionEvent, err := m.serializer.SerializeEvent(completionEvent, encoding)
 	if err != nil {
 		return nil, err
 	}
@@ -457,7 +502,7 @@ func (m *executionManagerImpl) SerializeExecutionInfo(
 		ParentRunID:                        info.ParentRunID,
 		InitiatedID:                        info.InitiatedID,
 		CompletionEventBatchID:             info.CompletionEventBatchID,
-		CompletionEvent:                    completionEvent,
+		CompletionEvent:                    serializedCompletionEvent,
 		TaskList:                           info.TaskList,
 		WorkflowTypeName:                   info.WorkflowTypeName,
 		WorkflowTimeout:                    info.WorkflowTimeout,
@@ -631,13 +676,21 @@ func (m *executionManagerImpl) SerializeWorkflowMutation(
 	if err != nil {
 		return nil, err
 	}
-	serializedUpsertChildExecutionInfos, err := m.SerializeUpsertChildExecutionInfos(input.UpsertChildExecutionInfos, encoding)
+	serializedUpsertChildExecutionInfos, err := m.SerializeUpsertChildExecutionInfos(
+		input.ExecutionInfo.NamespaceID,
+		input.UpsertChildExecutionInfos,
+		encoding,
+	)
 	if err != nil {
 		return nil, err
 	}
 	var serializedNewBufferedEvents *serialization.DataBlob
 	if input.NewBufferedEvents != nil {
-		serializedNewBufferedEvents, err = m.serializer.SerializeBatchEvents(input.NewBufferedEvents, encoding)
+		newBufferedEvents, err := encodeEvents(m.payloadCodec, input.ExecutionInfo.NamespaceID, input.NewBufferedEvents)
+		if err != nil {
+			return nil, err
+		}
+		serializedNewBufferedEvents, err = m.serializer.SerializeBatchEvents(newBufferedEvents, encoding)
 		if err != nil {
 			return nil, err
 		}
@@ -704,7 +757,11 @@ func (m *executionManagerImpl) SerializeWorkflowSnapshot(
 	if err != nil {
 		return nil, err
 	}
-	serializedChildExecutionInfos, err := m.SerializeUpsertChildExecutionInfos(input.ChildExecutionInfos, encoding)
+	serializedChildExecutionInfos, err := m.SerializeUpsertChildExecutionInfos(
+		input.ExecutionInfo.NamespaceID,
+		input.ChildExecutionInfos,
+		encoding,
+	)
 	if err != nil {
 		return nil, err
 	}
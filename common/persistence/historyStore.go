@@ -35,6 +35,7 @@ import (
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/persistence/serialization"
 	"github.com/temporalio/temporal/common/primitives"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
@@ -45,6 +46,7 @@ type (
 	historyV2ManagerImpl struct {
 		historySerializer     PayloadSerializer
 		persistence           HistoryStore
+		payloadCodec          payload.Codec
 		logger                log.Logger
 		pagingTokenSerializer *jsonHistoryTokenSerializer
 		transactionSizeLimit  dynamicconfig.IntPropertyFn
@@ -58,9 +60,11 @@ const (
 
 var _ HistoryManager = (*historyV2ManagerImpl)(nil)
 
-// NewHistoryV2ManagerImpl returns new HistoryManager
+// NewHistoryV2ManagerImpl returns new HistoryManager, payloadCodec is optional and encodes
+// the payloads of the history events when set
 func NewHistoryV2ManagerImpl(
 	persistence HistoryStore,
+	payloadCodec payload.Codec,
 	logger log.Logger,
 	transactionSizeLimit dynamicconfig.IntPropertyFn,
 ) HistoryManager {
@@ -68,6 +72,7 @@ func NewHistoryV2ManagerImpl(
 	return &historyV2ManagerImpl{
 		historySerializer:     NewPayloadSerializer(),
 		persistence:           persistence,
+		payloadCodec:          payloadCodec,
 		logger:                logger,
 		pagingTokenSerializer: newJSONHistoryTokenSerializer(),
 		transactionSizeLimit:  transactionSizeLimit,
@@ -196,8 +201,12 @@ func (m *historyV2ManagerImpl) AppendHistoryNodes(
 		lastID++
 	}
 
+	events, err := encodeEvents(m.payloadCodec, request.NamespaceID, request.Events)
+	if err != nil {
+		return nil, err
+	}
 	// nodeID will be the first eventID
-	blob, err := m.historySerializer.SerializeBatchEvents(request.Events, request.Encoding)
+	blob, err := m.historySerializer.SerializeBatchEvents(events, request.Encoding)
 	if err != nil {
 		return nil, err
 	}
@@ -271,6 +280,16 @@ func (m *historyV2ManagerImpl) ReadRawHistoryBranch(
 	if err != nil {
 		return nil, err
 	}
+	if m.payloadCodec != nil {
+		// raw history is sent to other clusters, which may not have the keys of this cluster
+		if dataBlobs, err = m.decodeEventBlobs(request.NamespaceID, dataBlobs); err != nil {
+			return nil, err
+		}
+		dataSize = 0
+		for _, dataBlob := range dataBlobs {
+			dataSize += len(dataBlob.Data)
+		}
+	}
 
 	nextPageToken, err := m.serializeToken(token)
 	if err != nil {
@@ -291,6 +310,30 @@ func (m *historyV2ManagerImpl) GetAllHistoryTreeBranches(
 	return m.persistence.GetAllHistoryTreeBranches(request)
 }
 
+// decodeEventBlobs returns the event batches with their payloads decoded, in the encoding they were read with
+func (m *historyV2ManagerImpl) decodeEventBlobs(
+	namespaceID string,
+	dataBlobs []*serialization.DataBlob,
+) ([]*serialization.DataBlob, error) {
+
+	decoded := make([]*serialization.DataBlob, 0, len(dataBlobs))
+	for _, dataBlob := range dataBlobs {
+		events, err := m.historySerializer.DeserializeBatchEvents(dataBlob)
+		if err != nil {
+			return nil, err
+		}
+		if err := decodePayloads(m.payloadCodec, namespaceID, events); err != nil {
+			return nil, err
+		}
+		blob, err := m.historySerializer.SerializeBatchEvents(events, dataBlob.Encoding)
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, blob)
+	}
+	return decoded, nil
+}
+
 func (m *historyV2ManagerImpl) readRawHistoryBranch(
 	request *ReadHistoryBranchRequest,
 ) ([]*serialization.DataBlob, *historyV2PagingToken, int, log.Logger, error) {
@@ -426,6 +469,9 @@ func (m *historyV2ManagerImpl) readHistoryBranch(
 		if err != nil {
 			return nil, nil, nil, 0, 0, err
 		}
+		if err := decodePayloads(m.payloadCodec, request.NamespaceID, events); err != nil {
+			return nil, nil, nil, 0, 0, err
+		}
 		if len(events) == 0 {
 			logger.Error("Empty events in a batch")
 			return nil, nil, nil, 0, 0, serviceerror.NewInternal(fmt.Sprintf("corrupted history event batch, empty events"))
@@ -0,0 +1,180 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	eventpb "go.temporal.io/temporal-proto/event"
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/payload"
+	"github.com/temporalio/temporal/common/persistence/serialization"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	historyStoreSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		store          *testHistoryStore
+		historyManager HistoryManager
+	}
+
+	// testHistoryStore keeps the appended history nodes in memory
+	testHistoryStore struct {
+		HistoryStore
+		nodes []*serialization.DataBlob
+	}
+
+	testKeyProvider struct{}
+)
+
+func TestHistoryStoreSuite(t *testing.T) {
+	s := new(historyStoreSuite)
+	suite.Run(t, s)
+}
+
+func (s *historyStoreSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	logger := loggerimpl.NewNopLogger()
+	s.store = &testHistoryStore{}
+	s.historyManager = NewHistoryV2ManagerImpl(
+		s.store,
+		payload.NewEncryptionCodec(testKeyProvider{}, 0, logger),
+		logger,
+		dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit),
+	)
+}
+
+func (s *historyStoreSuite) TestReadRawHistoryBranch_DecodesPayloads() {
+	shardID := 1
+	namespaceID := uuid.New()
+	branchToken, err := NewHistoryBranchToken(uuid.NewRandom())
+	s.NoError(err)
+
+	_, err = s.historyManager.AppendHistoryNodes(&AppendHistoryNodesRequest{
+		IsNewBranch: true,
+		BranchToken: branchToken,
+		Events: []*eventpb.HistoryEvent{{
+			EventId:   common.FirstEventID,
+			EventType: eventpb.EventType_WorkflowExecutionStarted,
+			Attributes: &eventpb.HistoryEvent_WorkflowExecutionStartedEventAttributes{WorkflowExecutionStartedEventAttributes: &eventpb.WorkflowExecutionStartedEventAttributes{
+				Input: payload.EncodeString("secret input"),
+			}},
+		}},
+		TransactionID: 1,
+		Encoding:      common.EncodingTypeProto3,
+		ShardID:       &shardID,
+		NamespaceID:   namespaceID,
+	})
+	s.NoError(err)
+	s.Len(s.store.nodes, 1)
+	s.False(bytes.Contains(s.store.nodes[0].Data, []byte("secret input")))
+
+	resp, err := s.historyManager.ReadRawHistoryBranch(&ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		MinEventID:  common.FirstEventID,
+		MaxEventID:  common.FirstEventID + 1,
+		PageSize:    1,
+		ShardID:     &shardID,
+		NamespaceID: namespaceID,
+	})
+	s.NoError(err)
+	s.Len(resp.HistoryEventBlobs, 1)
+	s.Equal(common.EncodingTypeProto3, resp.HistoryEventBlobs[0].Encoding)
+	s.Equal(len(resp.HistoryEventBlobs[0].Data), resp.Size)
+
+	events, err := NewPayloadSerializer().DeserializeBatchEvents(resp.HistoryEventBlobs[0])
+	s.NoError(err)
+	s.Len(events, 1)
+	var input string
+	s.NoError(payload.Decode(events[0].GetWorkflowExecutionStartedEventAttributes().Input, &input))
+	s.Equal("secret input", input)
+}
+
+func (s *historyStoreSuite) TestReadRawHistoryBranch_UndecryptablePayload() {
+	shardID := 1
+	branchToken, err := NewHistoryBranchToken(uuid.NewRandom())
+	s.NoError(err)
+
+	_, err = s.historyManager.AppendHistoryNodes(&AppendHistoryNodesRequest{
+		IsNewBranch: true,
+		BranchToken: branchToken,
+		Events: []*eventpb.HistoryEvent{{
+			EventId:   common.FirstEventID,
+			EventType: eventpb.EventType_WorkflowExecutionStarted,
+			Attributes: &eventpb.HistoryEvent_WorkflowExecutionStartedEventAttributes{WorkflowExecutionStartedEventAttributes: &eventpb.WorkflowExecutionStartedEventAttributes{
+				Input: payload.EncodeString("secret input"),
+			}},
+		}},
+		TransactionID: 1,
+		Encoding:      common.EncodingTypeProto3,
+		ShardID:       &shardID,
+		NamespaceID:   uuid.New(),
+	})
+	s.NoError(err)
+
+	// the payloads are bound to the namespace they were encrypted for
+	resp, err := s.historyManager.ReadRawHistoryBranch(&ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		MinEventID:  common.FirstEventID,
+		MaxEventID:  common.FirstEventID + 1,
+		PageSize:    1,
+		ShardID:     &shardID,
+		NamespaceID: uuid.New(),
+	})
+	s.IsType(&serviceerror.Internal{}, err)
+	s.Nil(resp)
+}
+
+func (s *testHistoryStore) AppendHistoryNodes(request *InternalAppendHistoryNodesRequest) error {
+	s.nodes = append(s.nodes, request.Events)
+	return nil
+}
+
+func (s *testHistoryStore) ReadHistoryBranch(request *InternalReadHistoryBranchRequest) (*InternalReadHistoryBranchResponse, error) {
+	return &InternalReadHistoryBranchResponse{
+		History:           s.nodes,
+		LastNodeID:        int64(len(s.nodes)),
+		LastTransactionID: int64(len(s.nodes)),
+	}, nil
+}
+
+func (testKeyProvider) ActiveKey() (string, []byte, error) {
+	return "test-key", bytes.Repeat([]byte{1}, 32), nil
+}
+
+func (testKeyProvider) Key(keyID string) ([]byte, error) {
+	return bytes.Repeat([]byte{1}, 32), nil
+}
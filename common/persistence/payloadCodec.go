@@ -0,0 +1,104 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"github.com/gogo/protobuf/proto"
+	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/common/payload"
+)
+
+// encodeEvents returns copies of the events with their payloads encoded by the codec, the events
+// are still referenced by the caller so they are never encoded in place
+func encodeEvents(
+	codec payload.Codec,
+	namespaceID string,
+	events []*eventpb.HistoryEvent,
+) ([]*eventpb.HistoryEvent, error) {
+
+	if codec == nil {
+		return events, nil
+	}
+	encoded := make([]*eventpb.HistoryEvent, len(events))
+	for i, event := range events {
+		var err error
+		if encoded[i], err = encodeEvent(codec, namespaceID, event); err != nil {
+			return nil, err
+		}
+	}
+	return encoded, nil
+}
+
+func encodeEvent(
+	codec payload.Codec,
+	namespaceID string,
+	event *eventpb.HistoryEvent,
+) (*eventpb.HistoryEvent, error) {
+
+	if codec == nil || event == nil {
+		return event, nil
+	}
+	encoded := proto.Clone(event).(*eventpb.HistoryEvent)
+	if err := payload.EncodeAll(codec, namespaceID, encoded); err != nil {
+		return nil, err
+	}
+	return encoded, nil
+}
+
+func encodePayload(
+	codec payload.Codec,
+	namespaceID string,
+	data *commonpb.Payload,
+) (*commonpb.Payload, error) {
+
+	if codec == nil || data == nil {
+		return data, nil
+	}
+	encoded := proto.Clone(data).(*commonpb.Payload)
+	if err := codec.Encode(namespaceID, encoded); err != nil {
+		return nil, err
+	}
+	return encoded, nil
+}
+
+// decodePayloads decodes in place the payloads of the namespace reachable from message, which must be
+// freshly deserialized, the read fails when a payload cannot be decoded
+func decodePayloads(
+	codec payload.Codec,
+	namespaceID string,
+	message interface{},
+) error {
+
+	if codec == nil {
+		return nil
+	}
+	if err := payload.DecodeAll(codec, namespaceID, message); err != nil {
+		return serviceerror.NewInternal(err.Error())
+	}
+	return nil
+}
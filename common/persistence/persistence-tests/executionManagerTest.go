@@ -2349,6 +2349,56 @@ func (s *ExecutionManagerSuite) TestTransferTasksComplete() {
 	s.Empty(txTasks, "expected empty task list.")
 }
 
+// TestTransferTasksLifecycleEvent test
+func (s *ExecutionManagerSuite) TestTransferTasksLifecycleEvent() {
+	namespaceID := "3d4b7a5e-9f4c-4d2b-8a3e-1c6f0e2b9a71"
+	workflowExecution := executionpb.WorkflowExecution{
+		WorkflowId: "get-transfer-tasks-test-lifecycle-event",
+		RunId:      "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb",
+	}
+	tasklist := "some random tasklist"
+
+	task0, err0 := s.CreateWorkflowExecution(namespaceID, workflowExecution, tasklist, "wType", 20, 13, 3, 0, 2, nil)
+	s.NoError(err0)
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	tasks1, err1 := s.GetTransferTasks(1, false)
+	s.NoError(err1)
+	s.Equal(1, len(tasks1), "Expected 1 decision task.")
+	err2 := s.CompleteTransferTask(tasks1[0].GetTaskId())
+	s.NoError(err2)
+
+	state0, err1 := s.GetWorkflowExecutionInfo(namespaceID, workflowExecution)
+	s.NoError(err1)
+	info0 := state0.ExecutionInfo
+	s.NotNil(info0, "Valid Workflow info expected.")
+
+	updatedInfo := copyWorkflowExecutionInfo(info0)
+	updatedStats := copyExecutionStats(state0.ExecutionStats)
+	updatedInfo.NextEventID = int64(8)
+	updatedInfo.LastProcessedEvent = int64(2)
+	currentTransferID := s.GetTransferReadLevel()
+	tasks := []p.Task{
+		&p.LifecycleEventTask{time.Now(), currentTransferID + 10001, int64(6), int64(5), 111},
+	}
+	err2 = s.UpdateWorklowStateAndReplication(updatedInfo, updatedStats, nil, nil, int64(3), tasks)
+	s.NoError(err2)
+
+	txTasks, err1 := s.GetTransferTasks(100, false)
+	s.NoError(err1)
+	s.Equal(1, len(txTasks))
+	s.validateTransferTaskHighLevel(txTasks[0], int32(p.TransferTaskTypeLifecycleEvent), namespaceID, workflowExecution)
+	s.Equal(int64(6), txTasks[0].GetScheduleId())
+	s.Equal(int64(5), txTasks[0].GetEventBatchId())
+	s.Equal(int64(111), txTasks[0].Version)
+
+	err2 = s.CompleteTransferTask(txTasks[0].GetTaskId())
+	s.NoError(err2)
+	txTasks, err2 = s.GetTransferTasks(100, false)
+	s.NoError(err2)
+	s.Empty(txTasks, "expected empty task list.")
+}
+
 // TestTransferTasksRangeComplete test
 func (s *ExecutionManagerSuite) TestTransferTasksRangeComplete() {
 	namespaceID := "8bfb47be-5b57-4d55-9109-5fb35e20b1d8"
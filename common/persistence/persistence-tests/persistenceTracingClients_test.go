@@ -0,0 +1,100 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package persistencetests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/mocks"
+	p "github.com/temporalio/temporal/common/persistence"
+)
+
+type VisibilityTracingSuite struct {
+	*require.Assertions // override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test, not merely log an error
+	suite.Suite
+	tracer      *mocktracer.MockTracer
+	client      p.VisibilityManager
+	persistence *mocks.VisibilityManager
+}
+
+func TestVisibilityTracingSuite(t *testing.T) {
+	suite.Run(t, new(VisibilityTracingSuite))
+}
+
+func (s *VisibilityTracingSuite) SetupTest() {
+	s.Assertions = require.New(s.T()) // Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+
+	s.tracer = mocktracer.New()
+	s.persistence = &mocks.VisibilityManager{}
+	s.persistence.On("GetName").Return("cassandra")
+	s.client = p.NewVisibilityPersistenceTracingClient(s.persistence, s.tracer)
+}
+
+func (s *VisibilityTracingSuite) TearDownTest() {
+	s.persistence.AssertExpectations(s.T())
+}
+
+func (s *VisibilityTracingSuite) TestSpanWithoutContext() {
+	request := &p.ListWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceUUID,
+		Namespace:   testNamespace,
+	}
+	s.persistence.On("ListOpenWorkflowExecutions", request).Return(nil, nil).Once()
+	_, err := s.client.ListOpenWorkflowExecutions(request)
+	s.NoError(err)
+
+	spans := s.tracer.FinishedSpans()
+	s.Len(spans, 1)
+	s.Equal("Persistence.ListOpenWorkflowExecutions", spans[0].OperationName)
+	s.Zero(spans[0].ParentID)
+}
+
+func (s *VisibilityTracingSuite) TestSpanWithContext() {
+	request := &p.ListWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceUUID,
+		Namespace:   testNamespace,
+	}
+	s.persistence.On("ListOpenWorkflowExecutions", request).Return(nil, nil).Once()
+	root := s.tracer.StartSpan("root")
+	ctx := opentracing.ContextWithSpan(context.Background(), root)
+	_, err := p.VisibilityManagerWithContext(ctx, s.client).ListOpenWorkflowExecutions(request)
+	s.NoError(err)
+	root.Finish()
+
+	spans := s.tracer.FinishedSpans()
+	s.Len(spans, 2)
+	s.Equal(root.Context().(mocktracer.MockSpanContext).TraceID, spans[0].SpanContext.TraceID)
+	s.Equal(root.Context().(mocktracer.MockSpanContext).SpanID, spans[0].ParentID)
+	s.Equal("cassandra", spans[0].Tag("db.type"))
+}
+
+func (s *VisibilityTracingSuite) TestContextWithoutSpan() {
+	s.Equal(s.client, p.VisibilityManagerWithContext(context.Background(), s.client))
+}
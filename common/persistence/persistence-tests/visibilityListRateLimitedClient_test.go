@@ -0,0 +1,249 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistencetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	executionpb "go.temporal.io/temporal-proto/execution"
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/mocks"
+	p "github.com/temporalio/temporal/common/persistence"
+)
+
+type VisibilityListRateLimitedSuite struct {
+	*require.Assertions // override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test, not merely log an error
+	suite.Suite
+	client      p.VisibilityManager
+	persistence *mocks.VisibilityManager
+	listMaxQPS  int
+}
+
+func TestVisibilityListRateLimitedSuite(t *testing.T) {
+	suite.Run(t, new(VisibilityListRateLimitedSuite))
+}
+
+func (s *VisibilityListRateLimitedSuite) SetupTest() {
+	s.Assertions = require.New(s.T()) // Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+
+	s.persistence = &mocks.VisibilityManager{}
+	s.listMaxQPS = 1
+	listMaxQPS := func(namespace string) int {
+		return s.listMaxQPS
+	}
+	s.client = p.NewVisibilityListRateLimitedClient(s.persistence, listMaxQPS, loggerimpl.NewNopLogger())
+}
+
+func (s *VisibilityListRateLimitedSuite) TearDownTest() {
+	s.persistence.AssertExpectations(s.T())
+}
+
+func (s *VisibilityListRateLimitedSuite) TestListOpenWorkflowExecutions() {
+	request := &p.ListWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceUUID,
+		Namespace:   testNamespace,
+	}
+	s.persistence.On("ListOpenWorkflowExecutions", request).Return(nil, nil).Once()
+	_, err := s.client.ListOpenWorkflowExecutions(request)
+	s.NoError(err)
+
+	// no remaining tokens
+	_, err = s.client.ListOpenWorkflowExecutions(request)
+	s.Error(err)
+	errDetail, ok := err.(*serviceerror.ResourceExhausted)
+	s.True(ok)
+	s.Equal(listErrMsg, errDetail.Message)
+}
+
+func (s *VisibilityListRateLimitedSuite) TestListClosedWorkflowExecutions() {
+	request := &p.ListWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceUUID,
+		Namespace:   testNamespace,
+	}
+	s.persistence.On("ListClosedWorkflowExecutions", request).Return(nil, nil).Once()
+	_, err := s.client.ListClosedWorkflowExecutions(request)
+	s.NoError(err)
+
+	// no remaining tokens
+	_, err = s.client.ListClosedWorkflowExecutions(request)
+	s.Error(err)
+	errDetail, ok := err.(*serviceerror.ResourceExhausted)
+	s.True(ok)
+	s.Equal(listErrMsg, errDetail.Message)
+}
+
+func (s *VisibilityListRateLimitedSuite) TestListOpenWorkflowExecutionsByType() {
+	req := p.ListWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceUUID,
+		Namespace:   testNamespace,
+	}
+	request := &p.ListWorkflowExecutionsByTypeRequest{
+		ListWorkflowExecutionsRequest: req,
+		WorkflowTypeName:              testWorkflowTypeName,
+	}
+	s.persistence.On("ListOpenWorkflowExecutionsByType", request).Return(nil, nil).Once()
+	_, err := s.client.ListOpenWorkflowExecutionsByType(request)
+	s.NoError(err)
+
+	// no remaining tokens
+	_, err = s.client.ListOpenWorkflowExecutionsByType(request)
+	s.Error(err)
+	errDetail, ok := err.(*serviceerror.ResourceExhausted)
+	s.True(ok)
+	s.Equal(listErrMsg, errDetail.Message)
+}
+
+func (s *VisibilityListRateLimitedSuite) TestListClosedWorkflowExecutionsByType() {
+	req := p.ListWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceUUID,
+		Namespace:   testNamespace,
+	}
+	request := &p.ListWorkflowExecutionsByTypeRequest{
+		ListWorkflowExecutionsRequest: req,
+		WorkflowTypeName:              testWorkflowTypeName,
+	}
+	s.persistence.On("ListClosedWorkflowExecutionsByType", request).Return(nil, nil).Once()
+	_, err := s.client.ListClosedWorkflowExecutionsByType(request)
+	s.NoError(err)
+
+	// no remaining tokens
+	_, err = s.client.ListClosedWorkflowExecutionsByType(request)
+	s.Error(err)
+	errDetail, ok := err.(*serviceerror.ResourceExhausted)
+	s.True(ok)
+	s.Equal(listErrMsg, errDetail.Message)
+}
+
+func (s *VisibilityListRateLimitedSuite) TestListOpenWorkflowExecutionsByWorkflowID() {
+	req := p.ListWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceUUID,
+		Namespace:   testNamespace,
+	}
+	request := &p.ListWorkflowExecutionsByWorkflowIDRequest{
+		ListWorkflowExecutionsRequest: req,
+		WorkflowID:                    testWorkflowExecution.GetWorkflowId(),
+	}
+	s.persistence.On("ListOpenWorkflowExecutionsByWorkflowID", request).Return(nil, nil).Once()
+	_, err := s.client.ListOpenWorkflowExecutionsByWorkflowID(request)
+	s.NoError(err)
+
+	// no remaining tokens
+	_, err = s.client.ListOpenWorkflowExecutionsByWorkflowID(request)
+	s.Error(err)
+	errDetail, ok := err.(*serviceerror.ResourceExhausted)
+	s.True(ok)
+	s.Equal(listErrMsg, errDetail.Message)
+}
+
+func (s *VisibilityListRateLimitedSuite) TestListClosedWorkflowExecutionsByWorkflowID() {
+	req := p.ListWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceUUID,
+		Namespace:   testNamespace,
+	}
+	request := &p.ListWorkflowExecutionsByWorkflowIDRequest{
+		ListWorkflowExecutionsRequest: req,
+		WorkflowID:                    testWorkflowExecution.GetWorkflowId(),
+	}
+	s.persistence.On("ListClosedWorkflowExecutionsByWorkflowID", request).Return(nil, nil).Once()
+	_, err := s.client.ListClosedWorkflowExecutionsByWorkflowID(request)
+	s.NoError(err)
+
+	// no remaining tokens
+	_, err = s.client.ListClosedWorkflowExecutionsByWorkflowID(request)
+	s.Error(err)
+	errDetail, ok := err.(*serviceerror.ResourceExhausted)
+	s.True(ok)
+	s.Equal(listErrMsg, errDetail.Message)
+}
+
+func (s *VisibilityListRateLimitedSuite) TestListClosedWorkflowExecutionsByStatus() {
+	req := p.ListWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceUUID,
+		Namespace:   testNamespace,
+	}
+	request := &p.ListClosedWorkflowExecutionsByStatusRequest{
+		ListWorkflowExecutionsRequest: req,
+		Status:                        executionpb.WorkflowExecutionStatus_Failed,
+	}
+	s.persistence.On("ListClosedWorkflowExecutionsByStatus", request).Return(nil, nil).Once()
+	_, err := s.client.ListClosedWorkflowExecutionsByStatus(request)
+	s.NoError(err)
+
+	// no remaining tokens
+	_, err = s.client.ListClosedWorkflowExecutionsByStatus(request)
+	s.Error(err)
+	errDetail, ok := err.(*serviceerror.ResourceExhausted)
+	s.True(ok)
+	s.Equal(listErrMsg, errDetail.Message)
+}
+
+func (s *VisibilityListRateLimitedSuite) TestListWorkflowExecutions() {
+	request := &p.ListWorkflowExecutionsRequestV2{
+		NamespaceID: testNamespaceUUID,
+		Namespace:   testNamespace,
+	}
+	countRequest := &p.CountWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceUUID,
+		Namespace:   testNamespace,
+	}
+	s.persistence.On("ListWorkflowExecutions", request).Return(nil, nil).Twice()
+	s.persistence.On("ScanWorkflowExecutions", request).Return(nil, nil).Twice()
+	s.persistence.On("CountWorkflowExecutions", countRequest).Return(nil, nil).Twice()
+
+	// advanced visibility requests are not limited by the list quota
+	for i := 0; i < 2; i++ {
+		_, err := s.client.ListWorkflowExecutions(request)
+		s.NoError(err)
+		_, err = s.client.ScanWorkflowExecutions(request)
+		s.NoError(err)
+		_, err = s.client.CountWorkflowExecutions(countRequest)
+		s.NoError(err)
+	}
+}
+
+func (s *VisibilityListRateLimitedSuite) TestListMaxQPSUpdated() {
+	request := &p.ListWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceUUID,
+		Namespace:   testNamespace,
+	}
+	s.persistence.On("ListOpenWorkflowExecutions", request).Return(nil, nil).Times(3)
+	_, err := s.client.ListOpenWorkflowExecutions(request)
+	s.NoError(err)
+	_, err = s.client.ListOpenWorkflowExecutions(request)
+	s.Equal(p.ErrPersistenceLimitExceededForList, err)
+
+	// the new quota applies without waiting for the namespace bucket to expire
+	s.listMaxQPS = 2
+	_, err = s.client.ListOpenWorkflowExecutions(request)
+	s.NoError(err)
+	_, err = s.client.ListOpenWorkflowExecutions(request)
+	s.NoError(err)
+	_, err = s.client.ListOpenWorkflowExecutions(request)
+	s.Equal(p.ErrPersistenceLimitExceededForList, err)
+}
@@ -31,7 +31,6 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	executionpb "go.temporal.io/temporal-proto/execution"
-	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/metrics"
@@ -73,7 +72,6 @@ func (s *VisibilitySamplingSuite) SetupTest() {
 	config := &c.VisibilityConfig{
 		VisibilityOpenMaxQPS:   dynamicconfig.GetIntPropertyFilteredByNamespace(1),
 		VisibilityClosedMaxQPS: dynamicconfig.GetIntPropertyFilteredByNamespace(10),
-		VisibilityListMaxQPS:   dynamicconfig.GetIntPropertyFilteredByNamespace(1),
 	}
 	s.metricClient = &mmocks.Client{}
 	s.client = p.NewVisibilitySamplingClient(s.persistence, config, s.metricClient, loggerimpl.NewNopLogger())
@@ -127,142 +125,3 @@ func (s *VisibilitySamplingSuite) TestRecordWorkflowExecutionClosed() {
 	s.metricClient.On("IncCounter", metrics.PersistenceRecordWorkflowExecutionClosedScope, metrics.PersistenceSampledCounter).Once()
 	s.NoError(s.client.RecordWorkflowExecutionClosed(request2))
 }
-
-func (s *VisibilitySamplingSuite) TestListOpenWorkflowExecutions() {
-	request := &p.ListWorkflowExecutionsRequest{
-		NamespaceID: testNamespaceUUID,
-		Namespace:   testNamespace,
-	}
-	s.persistence.On("ListOpenWorkflowExecutions", request).Return(nil, nil).Once()
-	_, err := s.client.ListOpenWorkflowExecutions(request)
-	s.NoError(err)
-
-	// no remaining tokens
-	_, err = s.client.ListOpenWorkflowExecutions(request)
-	s.Error(err)
-	errDetail, ok := err.(*serviceerror.ResourceExhausted)
-	s.True(ok)
-	s.Equal(listErrMsg, errDetail.Message)
-}
-
-func (s *VisibilitySamplingSuite) TestListClosedWorkflowExecutions() {
-	request := &p.ListWorkflowExecutionsRequest{
-		NamespaceID: testNamespaceUUID,
-		Namespace:   testNamespace,
-	}
-	s.persistence.On("ListClosedWorkflowExecutions", request).Return(nil, nil).Once()
-	_, err := s.client.ListClosedWorkflowExecutions(request)
-	s.NoError(err)
-
-	// no remaining tokens
-	_, err = s.client.ListClosedWorkflowExecutions(request)
-	s.Error(err)
-	errDetail, ok := err.(*serviceerror.ResourceExhausted)
-	s.True(ok)
-	s.Equal(listErrMsg, errDetail.Message)
-}
-
-func (s *VisibilitySamplingSuite) TestListOpenWorkflowExecutionsByType() {
-	req := p.ListWorkflowExecutionsRequest{
-		NamespaceID: testNamespaceUUID,
-		Namespace:   testNamespace,
-	}
-	request := &p.ListWorkflowExecutionsByTypeRequest{
-		ListWorkflowExecutionsRequest: req,
-		WorkflowTypeName:              testWorkflowTypeName,
-	}
-	s.persistence.On("ListOpenWorkflowExecutionsByType", request).Return(nil, nil).Once()
-	_, err := s.client.ListOpenWorkflowExecutionsByType(request)
-	s.NoError(err)
-
-	// no remaining tokens
-	_, err = s.client.ListOpenWorkflowExecutionsByType(request)
-	s.Error(err)
-	errDetail, ok := err.(*serviceerror.ResourceExhausted)
-	s.True(ok)
-	s.Equal(listErrMsg, errDetail.Message)
-}
-
-func (s *VisibilitySamplingSuite) TestListClosedWorkflowExecutionsByType() {
-	req := p.ListWorkflowExecutionsRequest{
-		NamespaceID: testNamespaceUUID,
-		Namespace:   testNamespace,
-	}
-	request := &p.ListWorkflowExecutionsByTypeRequest{
-		ListWorkflowExecutionsRequest: req,
-		WorkflowTypeName:              testWorkflowTypeName,
-	}
-	s.persistence.On("ListClosedWorkflowExecutionsByType", request).Return(nil, nil).Once()
-	_, err := s.client.ListClosedWorkflowExecutionsByType(request)
-	s.NoError(err)
-
-	// no remaining tokens
-	_, err = s.client.ListClosedWorkflowExecutionsByType(request)
-	s.Error(err)
-	errDetail, ok := err.(*serviceerror.ResourceExhausted)
-	s.True(ok)
-	s.Equal(listErrMsg, errDetail.Message)
-}
-
-func (s *VisibilitySamplingSuite) TestListOpenWorkflowExecutionsByWorkflowID() {
-	req := p.ListWorkflowExecutionsRequest{
-		NamespaceID: testNamespaceUUID,
-		Namespace:   testNamespace,
-	}
-	request := &p.ListWorkflowExecutionsByWorkflowIDRequest{
-		ListWorkflowExecutionsRequest: req,
-		WorkflowID:                    testWorkflowExecution.GetWorkflowId(),
-	}
-	s.persistence.On("ListOpenWorkflowExecutionsByWorkflowID", request).Return(nil, nil).Once()
-	_, err := s.client.ListOpenWorkflowExecutionsByWorkflowID(request)
-	s.NoError(err)
-
-	// no remaining tokens
-	_, err = s.client.ListOpenWorkflowExecutionsByWorkflowID(request)
-	s.Error(err)
-	errDetail, ok := err.(*serviceerror.ResourceExhausted)
-	s.True(ok)
-	s.Equal(listErrMsg, errDetail.Message)
-}
-
-func (s *VisibilitySamplingSuite) TestListClosedWorkflowExecutionsByWorkflowID() {
-	req := p.ListWorkflowExecutionsRequest{
-		NamespaceID: testNamespaceUUID,
-		Namespace:   testNamespace,
-	}
-	request := &p.ListWorkflowExecutionsByWorkflowIDRequest{
-		ListWorkflowExecutionsRequest: req,
-		WorkflowID:                    testWorkflowExecution.GetWorkflowId(),
-	}
-	s.persistence.On("ListClosedWorkflowExecutionsByWorkflowID", request).Return(nil, nil).Once()
-	_, err := s.client.ListClosedWorkflowExecutionsByWorkflowID(request)
-	s.NoError(err)
-
-	// no remaining tokens
-	_, err = s.client.ListClosedWorkflowExecutionsByWorkflowID(request)
-	s.Error(err)
-	errDetail, ok := err.(*serviceerror.ResourceExhausted)
-	s.True(ok)
-	s.Equal(listErrMsg, errDetail.Message)
-}
-
-func (s *VisibilitySamplingSuite) TestListClosedWorkflowExecutionsByStatus() {
-	req := p.ListWorkflowExecutionsRequest{
-		NamespaceID: testNamespaceUUID,
-		Namespace:   testNamespace,
-	}
-	request := &p.ListClosedWorkflowExecutionsByStatusRequest{
-		ListWorkflowExecutionsRequest: req,
-		Status:                        executionpb.WorkflowExecutionStatus_Failed,
-	}
-	s.persistence.On("ListClosedWorkflowExecutionsByStatus", request).Return(nil, nil).Once()
-	_, err := s.client.ListClosedWorkflowExecutionsByStatus(request)
-	s.NoError(err)
-
-	// no remaining tokens
-	_, err = s.client.ListClosedWorkflowExecutionsByStatus(request)
-	s.Error(err)
-	errDetail, ok := err.(*serviceerror.ResourceExhausted)
-	s.True(ok)
-	s.Equal(listErrMsg, errDetail.Message)
-}
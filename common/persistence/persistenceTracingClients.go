@@ -0,0 +1,893 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+const shardIDSpanTag = "shard.id"
+
+// The persistence calls carry no context, the spans recorded by a tracing client start new traces
+// unless the client is bound to the context of a request by one of the *WithContext functions.
+type (
+	shardTracingPersistenceClient struct {
+		tracer      opentracing.Tracer
+		parent      opentracing.SpanContext
+		persistence ShardManager
+	}
+
+	workflowExecutionTracingPersistenceClient struct {
+		tracer      opentracing.Tracer
+		parent      opentracing.SpanContext
+		persistence ExecutionManager
+	}
+
+	taskTracingPersistenceClient struct {
+		tracer      opentracing.Tracer
+		parent      opentracing.SpanContext
+		persistence TaskManager
+	}
+
+	historyV2TracingPersistenceClient struct {
+		tracer      opentracing.Tracer
+		parent      opentracing.SpanContext
+		persistence HistoryManager
+	}
+
+	metadataTracingPersistenceClient struct {
+		tracer      opentracing.Tracer
+		parent      opentracing.SpanContext
+		persistence MetadataManager
+	}
+
+	clusterMetadataTracingPersistenceClient struct {
+		tracer      opentracing.Tracer
+		parent      opentracing.SpanContext
+		persistence ClusterMetadataManager
+	}
+
+	visibilityTracingPersistenceClient struct {
+		tracer      opentracing.Tracer
+		parent      opentracing.SpanContext
+		persistence VisibilityManager
+	}
+
+	queueTracingPersistenceClient struct {
+		tracer      opentracing.Tracer
+		parent      opentracing.SpanContext
+		persistence Queue
+	}
+)
+
+var _ ShardManager = (*shardTracingPersistenceClient)(nil)
+var _ ExecutionManager = (*workflowExecutionTracingPersistenceClient)(nil)
+var _ TaskManager = (*taskTracingPersistenceClient)(nil)
+var _ HistoryManager = (*historyV2TracingPersistenceClient)(nil)
+var _ MetadataManager = (*metadataTracingPersistenceClient)(nil)
+var _ ClusterMetadataManager = (*clusterMetadataTracingPersistenceClient)(nil)
+var _ VisibilityManager = (*visibilityTracingPersistenceClient)(nil)
+var _ Queue = (*queueTracingPersistenceClient)(nil)
+
+// NewShardPersistenceTracingClient creates a client to manage shards which records a span for every call.
+func NewShardPersistenceTracingClient(persistence ShardManager, tracer opentracing.Tracer) ShardManager {
+	return &shardTracingPersistenceClient{
+		tracer:      tracer,
+		persistence: persistence,
+	}
+}
+
+// NewWorkflowExecutionPersistenceTracingClient creates a client to manage executions which records a span for every call.
+func NewWorkflowExecutionPersistenceTracingClient(persistence ExecutionManager, tracer opentracing.Tracer) ExecutionManager {
+	return &workflowExecutionTracingPersistenceClient{
+		tracer:      tracer,
+		persistence: persistence,
+	}
+}
+
+// NewTaskPersistenceTracingClient creates a client to manage tasks which records a span for every call.
+func NewTaskPersistenceTracingClient(persistence TaskManager, tracer opentracing.Tracer) TaskManager {
+	return &taskTracingPersistenceClient{
+		tracer:      tracer,
+		persistence: persistence,
+	}
+}
+
+// NewHistoryV2PersistenceTracingClient creates a HistoryManager client to manage workflow execution history which records a span for every call.
+func NewHistoryV2PersistenceTracingClient(persistence HistoryManager, tracer opentracing.Tracer) HistoryManager {
+	return &historyV2TracingPersistenceClient{
+		tracer:      tracer,
+		persistence: persistence,
+	}
+}
+
+// NewMetadataPersistenceTracingClient creates a MetadataManager client to manage metadata which records a span for every call.
+func NewMetadataPersistenceTracingClient(persistence MetadataManager, tracer opentracing.Tracer) MetadataManager {
+	return &metadataTracingPersistenceClient{
+		tracer:      tracer,
+		persistence: persistence,
+	}
+}
+
+// NewClusterMetadataPersistenceTracingClient creates a ClusterMetadataManager client to manage cluster metadata which records a span for every call.
+func NewClusterMetadataPersistenceTracingClient(persistence ClusterMetadataManager, tracer opentracing.Tracer) ClusterMetadataManager {
+	return &clusterMetadataTracingPersistenceClient{
+		tracer:      tracer,
+		persistence: persistence,
+	}
+}
+
+// NewVisibilityPersistenceTracingClient creates a client to manage visibility which records a span for every call.
+func NewVisibilityPersistenceTracingClient(persistence VisibilityManager, tracer opentracing.Tracer) VisibilityManager {
+	return &visibilityTracingPersistenceClient{
+		tracer:      tracer,
+		persistence: persistence,
+	}
+}
+
+// NewQueuePersistenceTracingClient creates a client to manage queue which records a span for every call.
+func NewQueuePersistenceTracingClient(persistence Queue, tracer opentracing.Tracer) Queue {
+	return &queueTracingPersistenceClient{
+		tracer:      tracer,
+		persistence: persistence,
+	}
+}
+
+// ExecutionManagerWithContext returns an ExecutionManager whose spans are children of the span of ctx,
+// the manager itself is returned when it records no spans or ctx carries no span.
+func ExecutionManagerWithContext(ctx context.Context, persistence ExecutionManager) ExecutionManager {
+	client, ok := persistence.(*workflowExecutionTracingPersistenceClient)
+	if !ok {
+		return persistence
+	}
+	parent := opentracing.SpanFromContext(ctx)
+	if parent == nil {
+		return persistence
+	}
+	bound := *client
+	bound.parent = parent.Context()
+	return &bound
+}
+
+// HistoryManagerWithContext returns a HistoryManager whose spans are children of the span of ctx,
+// the manager itself is returned when it records no spans or ctx carries no span.
+func HistoryManagerWithContext(ctx context.Context, persistence HistoryManager) HistoryManager {
+	client, ok := persistence.(*historyV2TracingPersistenceClient)
+	if !ok {
+		return persistence
+	}
+	parent := opentracing.SpanFromContext(ctx)
+	if parent == nil {
+		return persistence
+	}
+	bound := *client
+	bound.parent = parent.Context()
+	return &bound
+}
+
+// VisibilityManagerWithContext returns a VisibilityManager whose spans are children of the span of ctx,
+// the manager itself is returned when it records no spans or ctx carries no span.
+func VisibilityManagerWithContext(ctx context.Context, persistence VisibilityManager) VisibilityManager {
+	client, ok := persistence.(*visibilityTracingPersistenceClient)
+	if !ok {
+		return persistence
+	}
+	parent := opentracing.SpanFromContext(ctx)
+	if parent == nil {
+		return persistence
+	}
+	bound := *client
+	bound.parent = parent.Context()
+	return &bound
+}
+
+func (p *shardTracingPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *shardTracingPersistenceClient) CreateShard(request *CreateShardRequest) error {
+	span := p.startSpan("CreateShard")
+	err := p.persistence.CreateShard(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *shardTracingPersistenceClient) GetShard(request *GetShardRequest) (*GetShardResponse, error) {
+	span := p.startSpan("GetShard")
+	response, err := p.persistence.GetShard(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *shardTracingPersistenceClient) UpdateShard(request *UpdateShardRequest) error {
+	span := p.startSpan("UpdateShard")
+	err := p.persistence.UpdateShard(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *shardTracingPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *shardTracingPersistenceClient) startSpan(operation string) opentracing.Span {
+	return startSpan(p.tracer, p.parent, p.persistence.GetName(), operation)
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetShardID() int {
+	return p.persistence.GetShardID()
+}
+
+func (p *workflowExecutionTracingPersistenceClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+	span := p.startSpan("CreateWorkflowExecution")
+	response, err := p.persistence.CreateWorkflowExecution(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+	span := p.startSpan("GetWorkflowExecution")
+	response, err := p.persistence.GetWorkflowExecution(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+	span := p.startSpan("UpdateWorkflowExecution")
+	response, err := p.persistence.UpdateWorkflowExecution(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) ConflictResolveWorkflowExecution(request *ConflictResolveWorkflowExecutionRequest) error {
+	span := p.startSpan("ConflictResolveWorkflowExecution")
+	err := p.persistence.ConflictResolveWorkflowExecution(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error {
+	span := p.startSpan("ResetWorkflowExecution")
+	err := p.persistence.ResetWorkflowExecution(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+	span := p.startSpan("DeleteWorkflowExecution")
+	err := p.persistence.DeleteWorkflowExecution(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
+	span := p.startSpan("DeleteCurrentWorkflowExecution")
+	err := p.persistence.DeleteCurrentWorkflowExecution(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+	span := p.startSpan("GetCurrentExecution")
+	response, err := p.persistence.GetCurrentExecution(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error) {
+	span := p.startSpan("ListConcreteExecutions")
+	response, err := p.persistence.ListConcreteExecutions(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+	span := p.startSpan("GetTransferTasks")
+	response, err := p.persistence.GetTransferTasks(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
+	span := p.startSpan("GetReplicationTasks")
+	response, err := p.persistence.GetReplicationTasks(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
+	span := p.startSpan("CompleteTransferTask")
+	err := p.persistence.CompleteTransferTask(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error {
+	span := p.startSpan("RangeCompleteTransferTask")
+	err := p.persistence.RangeCompleteTransferTask(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
+	span := p.startSpan("CompleteReplicationTask")
+	err := p.persistence.CompleteReplicationTask(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error {
+	span := p.startSpan("RangeCompleteReplicationTask")
+	err := p.persistence.RangeCompleteReplicationTask(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) PutReplicationTaskToDLQ(
+	request *PutReplicationTaskToDLQRequest,
+) error {
+	span := p.startSpan("PutReplicationTaskToDLQ")
+	err := p.persistence.PutReplicationTaskToDLQ(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetReplicationTasksFromDLQ(
+	request *GetReplicationTasksFromDLQRequest,
+) (*GetReplicationTasksFromDLQResponse, error) {
+	span := p.startSpan("GetReplicationTasksFromDLQ")
+	response, err := p.persistence.GetReplicationTasksFromDLQ(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) DeleteReplicationTaskFromDLQ(
+	request *DeleteReplicationTaskFromDLQRequest,
+) error {
+	span := p.startSpan("DeleteReplicationTaskFromDLQ")
+	err := p.persistence.DeleteReplicationTaskFromDLQ(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) RangeDeleteReplicationTaskFromDLQ(
+	request *RangeDeleteReplicationTaskFromDLQRequest,
+) error {
+	span := p.startSpan("RangeDeleteReplicationTaskFromDLQ")
+	err := p.persistence.RangeDeleteReplicationTaskFromDLQ(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+	span := p.startSpan("GetTimerIndexTasks")
+	response, err := p.persistence.GetTimerIndexTasks(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
+	span := p.startSpan("CompleteTimerTask")
+	err := p.persistence.CompleteTimerTask(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error {
+	span := p.startSpan("RangeCompleteTimerTask")
+	err := p.persistence.RangeCompleteTimerTask(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *workflowExecutionTracingPersistenceClient) startSpan(operation string) opentracing.Span {
+	span := startSpan(p.tracer, p.parent, p.persistence.GetName(), operation)
+	span.SetTag(shardIDSpanTag, p.persistence.GetShardID())
+	return span
+}
+
+func (p *taskTracingPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *taskTracingPersistenceClient) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
+	span := p.startSpan("CreateTasks")
+	response, err := p.persistence.CreateTasks(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *taskTracingPersistenceClient) GetTasks(request *GetTasksRequest) (*GetTasksResponse, error) {
+	span := p.startSpan("GetTasks")
+	response, err := p.persistence.GetTasks(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *taskTracingPersistenceClient) CompleteTask(request *CompleteTaskRequest) error {
+	span := p.startSpan("CompleteTask")
+	err := p.persistence.CompleteTask(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *taskTracingPersistenceClient) CompleteTasksLessThan(request *CompleteTasksLessThanRequest) (int, error) {
+	span := p.startSpan("CompleteTasksLessThan")
+	response, err := p.persistence.CompleteTasksLessThan(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *taskTracingPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
+	span := p.startSpan("LeaseTaskList")
+	response, err := p.persistence.LeaseTaskList(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *taskTracingPersistenceClient) UpdateTaskList(request *UpdateTaskListRequest) (*UpdateTaskListResponse, error) {
+	span := p.startSpan("UpdateTaskList")
+	response, err := p.persistence.UpdateTaskList(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *taskTracingPersistenceClient) ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error) {
+	span := p.startSpan("ListTaskList")
+	response, err := p.persistence.ListTaskList(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *taskTracingPersistenceClient) DeleteTaskList(request *DeleteTaskListRequest) error {
+	span := p.startSpan("DeleteTaskList")
+	err := p.persistence.DeleteTaskList(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *taskTracingPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *taskTracingPersistenceClient) startSpan(operation string) opentracing.Span {
+	return startSpan(p.tracer, p.parent, p.persistence.GetName(), operation)
+}
+
+func (p *historyV2TracingPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *historyV2TracingPersistenceClient) AppendHistoryNodes(request *AppendHistoryNodesRequest) (*AppendHistoryNodesResponse, error) {
+	span := p.startSpan("AppendHistoryNodes")
+	response, err := p.persistence.AppendHistoryNodes(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *historyV2TracingPersistenceClient) ReadHistoryBranch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error) {
+	span := p.startSpan("ReadHistoryBranch")
+	response, err := p.persistence.ReadHistoryBranch(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *historyV2TracingPersistenceClient) ReadHistoryBranchByBatch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchByBatchResponse, error) {
+	span := p.startSpan("ReadHistoryBranchByBatch")
+	response, err := p.persistence.ReadHistoryBranchByBatch(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *historyV2TracingPersistenceClient) ReadRawHistoryBranch(request *ReadHistoryBranchRequest) (*ReadRawHistoryBranchResponse, error) {
+	span := p.startSpan("ReadRawHistoryBranch")
+	response, err := p.persistence.ReadRawHistoryBranch(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *historyV2TracingPersistenceClient) ForkHistoryBranch(request *ForkHistoryBranchRequest) (*ForkHistoryBranchResponse, error) {
+	span := p.startSpan("ForkHistoryBranch")
+	response, err := p.persistence.ForkHistoryBranch(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *historyV2TracingPersistenceClient) DeleteHistoryBranch(request *DeleteHistoryBranchRequest) error {
+	span := p.startSpan("DeleteHistoryBranch")
+	err := p.persistence.DeleteHistoryBranch(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *historyV2TracingPersistenceClient) GetHistoryTree(request *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error) {
+	span := p.startSpan("GetHistoryTree")
+	response, err := p.persistence.GetHistoryTree(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *historyV2TracingPersistenceClient) GetAllHistoryTreeBranches(request *GetAllHistoryTreeBranchesRequest) (*GetAllHistoryTreeBranchesResponse, error) {
+	span := p.startSpan("GetAllHistoryTreeBranches")
+	response, err := p.persistence.GetAllHistoryTreeBranches(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *historyV2TracingPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *historyV2TracingPersistenceClient) startSpan(operation string) opentracing.Span {
+	return startSpan(p.tracer, p.parent, p.persistence.GetName(), operation)
+}
+
+func (p *metadataTracingPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *metadataTracingPersistenceClient) CreateNamespace(request *CreateNamespaceRequest) (*CreateNamespaceResponse, error) {
+	span := p.startSpan("CreateNamespace")
+	response, err := p.persistence.CreateNamespace(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *metadataTracingPersistenceClient) GetNamespace(request *GetNamespaceRequest) (*GetNamespaceResponse, error) {
+	span := p.startSpan("GetNamespace")
+	response, err := p.persistence.GetNamespace(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *metadataTracingPersistenceClient) UpdateNamespace(request *UpdateNamespaceRequest) error {
+	span := p.startSpan("UpdateNamespace")
+	err := p.persistence.UpdateNamespace(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *metadataTracingPersistenceClient) DeleteNamespace(request *DeleteNamespaceRequest) error {
+	span := p.startSpan("DeleteNamespace")
+	err := p.persistence.DeleteNamespace(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *metadataTracingPersistenceClient) DeleteNamespaceByName(request *DeleteNamespaceByNameRequest) error {
+	span := p.startSpan("DeleteNamespaceByName")
+	err := p.persistence.DeleteNamespaceByName(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *metadataTracingPersistenceClient) ListNamespaces(request *ListNamespacesRequest) (*ListNamespacesResponse, error) {
+	span := p.startSpan("ListNamespaces")
+	response, err := p.persistence.ListNamespaces(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *metadataTracingPersistenceClient) GetMetadata() (*GetMetadataResponse, error) {
+	span := p.startSpan("GetMetadata")
+	response, err := p.persistence.GetMetadata()
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *metadataTracingPersistenceClient) InitializeSystemNamespaces(currentClusterName string) error {
+	span := p.startSpan("InitializeSystemNamespaces")
+	err := p.persistence.InitializeSystemNamespaces(currentClusterName)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *metadataTracingPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *metadataTracingPersistenceClient) startSpan(operation string) opentracing.Span {
+	return startSpan(p.tracer, p.parent, p.persistence.GetName(), operation)
+}
+
+func (p *clusterMetadataTracingPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *clusterMetadataTracingPersistenceClient) InitializeImmutableClusterMetadata(request *InitializeImmutableClusterMetadataRequest) (*InitializeImmutableClusterMetadataResponse, error) {
+	span := p.startSpan("InitializeImmutableClusterMetadata")
+	response, err := p.persistence.InitializeImmutableClusterMetadata(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *clusterMetadataTracingPersistenceClient) GetImmutableClusterMetadata() (*GetImmutableClusterMetadataResponse, error) {
+	span := p.startSpan("GetImmutableClusterMetadata")
+	response, err := p.persistence.GetImmutableClusterMetadata()
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *clusterMetadataTracingPersistenceClient) GetClusterMembers(request *GetClusterMembersRequest) (*GetClusterMembersResponse, error) {
+	span := p.startSpan("GetClusterMembers")
+	response, err := p.persistence.GetClusterMembers(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *clusterMetadataTracingPersistenceClient) UpsertClusterMembership(request *UpsertClusterMembershipRequest) error {
+	span := p.startSpan("UpsertClusterMembership")
+	err := p.persistence.UpsertClusterMembership(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *clusterMetadataTracingPersistenceClient) PruneClusterMembership(request *PruneClusterMembershipRequest) error {
+	span := p.startSpan("PruneClusterMembership")
+	err := p.persistence.PruneClusterMembership(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *clusterMetadataTracingPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *clusterMetadataTracingPersistenceClient) startSpan(operation string) opentracing.Span {
+	return startSpan(p.tracer, p.parent, p.persistence.GetName(), operation)
+}
+
+func (p *visibilityTracingPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *visibilityTracingPersistenceClient) RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error {
+	span := p.startSpan("RecordWorkflowExecutionStarted")
+	err := p.persistence.RecordWorkflowExecutionStarted(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *visibilityTracingPersistenceClient) RecordWorkflowExecutionClosed(request *RecordWorkflowExecutionClosedRequest) error {
+	span := p.startSpan("RecordWorkflowExecutionClosed")
+	err := p.persistence.RecordWorkflowExecutionClosed(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *visibilityTracingPersistenceClient) UpsertWorkflowExecution(request *UpsertWorkflowExecutionRequest) error {
+	span := p.startSpan("UpsertWorkflowExecution")
+	err := p.persistence.UpsertWorkflowExecution(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *visibilityTracingPersistenceClient) ListOpenWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	span := p.startSpan("ListOpenWorkflowExecutions")
+	response, err := p.persistence.ListOpenWorkflowExecutions(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *visibilityTracingPersistenceClient) ListClosedWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	span := p.startSpan("ListClosedWorkflowExecutions")
+	response, err := p.persistence.ListClosedWorkflowExecutions(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *visibilityTracingPersistenceClient) ListOpenWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	span := p.startSpan("ListOpenWorkflowExecutionsByType")
+	response, err := p.persistence.ListOpenWorkflowExecutionsByType(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *visibilityTracingPersistenceClient) ListClosedWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	span := p.startSpan("ListClosedWorkflowExecutionsByType")
+	response, err := p.persistence.ListClosedWorkflowExecutionsByType(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *visibilityTracingPersistenceClient) ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	span := p.startSpan("ListOpenWorkflowExecutionsByWorkflowID")
+	response, err := p.persistence.ListOpenWorkflowExecutionsByWorkflowID(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *visibilityTracingPersistenceClient) ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	span := p.startSpan("ListClosedWorkflowExecutionsByWorkflowID")
+	response, err := p.persistence.ListClosedWorkflowExecutionsByWorkflowID(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *visibilityTracingPersistenceClient) ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+	span := p.startSpan("ListClosedWorkflowExecutionsByStatus")
+	response, err := p.persistence.ListClosedWorkflowExecutionsByStatus(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *visibilityTracingPersistenceClient) GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
+	span := p.startSpan("GetClosedWorkflowExecution")
+	response, err := p.persistence.GetClosedWorkflowExecution(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *visibilityTracingPersistenceClient) DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error {
+	span := p.startSpan("DeleteWorkflowExecution")
+	err := p.persistence.DeleteWorkflowExecution(request)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *visibilityTracingPersistenceClient) ListWorkflowExecutions(request *ListWorkflowExecutionsRequestV2) (*ListWorkflowExecutionsResponse, error) {
+	span := p.startSpan("ListWorkflowExecutions")
+	response, err := p.persistence.ListWorkflowExecutions(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *visibilityTracingPersistenceClient) ScanWorkflowExecutions(request *ListWorkflowExecutionsRequestV2) (*ListWorkflowExecutionsResponse, error) {
+	span := p.startSpan("ScanWorkflowExecutions")
+	response, err := p.persistence.ScanWorkflowExecutions(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *visibilityTracingPersistenceClient) CountWorkflowExecutions(request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error) {
+	span := p.startSpan("CountWorkflowExecutions")
+	response, err := p.persistence.CountWorkflowExecutions(request)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *visibilityTracingPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *visibilityTracingPersistenceClient) startSpan(operation string) opentracing.Span {
+	return startSpan(p.tracer, p.parent, p.persistence.GetName(), operation)
+}
+
+func (p *queueTracingPersistenceClient) EnqueueMessage(message []byte) error {
+	span := p.startSpan("EnqueueMessage")
+	err := p.persistence.EnqueueMessage(message)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *queueTracingPersistenceClient) ReadMessages(lastMessageID int64, maxCount int) ([]*QueueMessage, error) {
+	span := p.startSpan("ReadMessages")
+	response, err := p.persistence.ReadMessages(lastMessageID, maxCount)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *queueTracingPersistenceClient) UpdateAckLevel(messageID int64, clusterName string) error {
+	span := p.startSpan("UpdateAckLevel")
+	err := p.persistence.UpdateAckLevel(messageID, clusterName)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *queueTracingPersistenceClient) GetAckLevels() (map[string]int64, error) {
+	span := p.startSpan("GetAckLevels")
+	response, err := p.persistence.GetAckLevels()
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *queueTracingPersistenceClient) DeleteMessagesBefore(messageID int64) error {
+	span := p.startSpan("DeleteMessagesBefore")
+	err := p.persistence.DeleteMessagesBefore(messageID)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *queueTracingPersistenceClient) EnqueueMessageToDLQ(message []byte) (int64, error) {
+	span := p.startSpan("EnqueueMessageToDLQ")
+	response, err := p.persistence.EnqueueMessageToDLQ(message)
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *queueTracingPersistenceClient) ReadMessagesFromDLQ(firstMessageID int64, lastMessageID int64, pageSize int, pageToken []byte) ([]*QueueMessage, []byte, error) {
+	span := p.startSpan("ReadMessagesFromDLQ")
+	messages, nextPageToken, err := p.persistence.ReadMessagesFromDLQ(firstMessageID, lastMessageID, pageSize, pageToken)
+	finishSpan(span, err)
+	return messages, nextPageToken, err
+}
+
+func (p *queueTracingPersistenceClient) RangeDeleteMessagesFromDLQ(firstMessageID int64, lastMessageID int64) error {
+	span := p.startSpan("RangeDeleteMessagesFromDLQ")
+	err := p.persistence.RangeDeleteMessagesFromDLQ(firstMessageID, lastMessageID)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *queueTracingPersistenceClient) UpdateDLQAckLevel(messageID int64, clusterName string) error {
+	span := p.startSpan("UpdateDLQAckLevel")
+	err := p.persistence.UpdateDLQAckLevel(messageID, clusterName)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *queueTracingPersistenceClient) GetDLQAckLevels() (map[string]int64, error) {
+	span := p.startSpan("GetDLQAckLevels")
+	response, err := p.persistence.GetDLQAckLevels()
+	finishSpan(span, err)
+	return response, err
+}
+
+func (p *queueTracingPersistenceClient) DeleteMessageFromDLQ(messageID int64) error {
+	span := p.startSpan("DeleteMessageFromDLQ")
+	err := p.persistence.DeleteMessageFromDLQ(messageID)
+	finishSpan(span, err)
+	return err
+}
+
+func (p *queueTracingPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *queueTracingPersistenceClient) startSpan(operation string) opentracing.Span {
+	return startSpan(p.tracer, p.parent, "", operation)
+}
+
+func startSpan(tracer opentracing.Tracer, parent opentracing.SpanContext, store string, operation string) opentracing.Span {
+	opts := []opentracing.StartSpanOption{ext.SpanKindRPCClient}
+	if parent != nil {
+		opts = append(opts, opentracing.ChildOf(parent))
+	}
+	span := tracer.StartSpan("Persistence."+operation, opts...)
+	if store != "" {
+		ext.DBType.Set(span, store)
+	}
+	return span
+}
+
+func finishSpan(span opentracing.Span, err error) {
+	if err != nil {
+		ext.Error.Set(span, true)
+		span.LogKV("error", err.Error())
+	}
+	span.Finish()
+}
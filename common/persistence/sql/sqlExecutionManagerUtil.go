@@ -711,6 +711,10 @@ func createTransferTasks(
 			info.TargetWorkflowId = task.(*p.StartChildExecutionTask).TargetWorkflowID
 			info.ScheduleId = task.(*p.StartChildExecutionTask).InitiatedID
 
+		case p.TransferTaskTypeLifecycleEvent:
+			info.ScheduleId = task.(*p.LifecycleEventTask).EventID
+			info.EventBatchId = task.(*p.LifecycleEventTask).EventBatchID
+
 		case p.TransferTaskTypeCloseExecution,
 			p.TransferTaskTypeRecordWorkflowStarted,
 			p.TransferTaskTypeResetWorkflow,
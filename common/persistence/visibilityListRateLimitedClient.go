@@ -0,0 +1,153 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+const numOfPriorityForList = 1
+
+type visibilityListRateLimitedClient struct {
+	rateLimiters *namespaceToBucketMap
+	persistence  VisibilityManager
+	listMaxQPS   dynamicconfig.IntPropertyFnWithNamespaceFilter
+	logger       log.Logger
+}
+
+var _ VisibilityManager = (*visibilityListRateLimitedClient)(nil)
+
+// NewVisibilityListRateLimitedClient creates a client which limits the list requests of each namespace to
+// listMaxQPS. The advanced visibility list, scan and count requests are not limited
+func NewVisibilityListRateLimitedClient(
+	persistence VisibilityManager,
+	listMaxQPS dynamicconfig.IntPropertyFnWithNamespaceFilter,
+	logger log.Logger,
+) VisibilityManager {
+	return &visibilityListRateLimitedClient{
+		rateLimiters: newNamespaceToBucketMap(),
+		persistence:  persistence,
+		listMaxQPS:   listMaxQPS,
+		logger:       logger,
+	}
+}
+
+func (p *visibilityListRateLimitedClient) allow(namespace string) error {
+	rateLimiter := p.rateLimiters.getRateLimiter(namespace, numOfPriorityForList, p.listMaxQPS(namespace))
+	if ok, _ := rateLimiter.GetToken(0, 1); !ok {
+		return ErrPersistenceLimitExceededForList
+	}
+	return nil
+}
+
+func (p *visibilityListRateLimitedClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *visibilityListRateLimitedClient) RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error {
+	return p.persistence.RecordWorkflowExecutionStarted(request)
+}
+
+func (p *visibilityListRateLimitedClient) RecordWorkflowExecutionClosed(request *RecordWorkflowExecutionClosedRequest) error {
+	return p.persistence.RecordWorkflowExecutionClosed(request)
+}
+
+func (p *visibilityListRateLimitedClient) UpsertWorkflowExecution(request *UpsertWorkflowExecutionRequest) error {
+	return p.persistence.UpsertWorkflowExecution(request)
+}
+
+func (p *visibilityListRateLimitedClient) ListOpenWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.allow(request.Namespace); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListOpenWorkflowExecutions(request)
+}
+
+func (p *visibilityListRateLimitedClient) ListClosedWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.allow(request.Namespace); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListClosedWorkflowExecutions(request)
+}
+
+func (p *visibilityListRateLimitedClient) ListOpenWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.allow(request.Namespace); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListOpenWorkflowExecutionsByType(request)
+}
+
+func (p *visibilityListRateLimitedClient) ListClosedWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.allow(request.Namespace); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListClosedWorkflowExecutionsByType(request)
+}
+
+func (p *visibilityListRateLimitedClient) ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.allow(request.Namespace); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListOpenWorkflowExecutionsByWorkflowID(request)
+}
+
+func (p *visibilityListRateLimitedClient) ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.allow(request.Namespace); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListClosedWorkflowExecutionsByWorkflowID(request)
+}
+
+func (p *visibilityListRateLimitedClient) ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.allow(request.Namespace); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListClosedWorkflowExecutionsByStatus(request)
+}
+
+func (p *visibilityListRateLimitedClient) GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
+	return p.persistence.GetClosedWorkflowExecution(request)
+}
+
+func (p *visibilityListRateLimitedClient) DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error {
+	return p.persistence.DeleteWorkflowExecution(request)
+}
+
+func (p *visibilityListRateLimitedClient) ListWorkflowExecutions(request *ListWorkflowExecutionsRequestV2) (*ListWorkflowExecutionsResponse, error) {
+	return p.persistence.ListWorkflowExecutions(request)
+}
+
+func (p *visibilityListRateLimitedClient) ScanWorkflowExecutions(request *ListWorkflowExecutionsRequestV2) (*ListWorkflowExecutionsResponse, error) {
+	return p.persistence.ScanWorkflowExecutions(request)
+}
+
+func (p *visibilityListRateLimitedClient) CountWorkflowExecutions(request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error) {
+	return p.persistence.CountWorkflowExecutions(request)
+}
+
+func (p *visibilityListRateLimitedClient) Close() {
+	p.persistence.Close()
+}
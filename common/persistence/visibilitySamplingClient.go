@@ -41,13 +41,11 @@ const (
 	// To sample visibility request, open has only 1 bucket, closed has 2
 	numOfPriorityForOpen   = 1
 	numOfPriorityForClosed = 2
-	numOfPriorityForList   = 1
 )
 
 type visibilitySamplingClient struct {
 	rateLimitersForOpen   *namespaceToBucketMap
 	rateLimitersForClosed *namespaceToBucketMap
-	rateLimitersForList   *namespaceToBucketMap
 	persistence           VisibilityManager
 	config                *config.VisibilityConfig
 	metricClient          metrics.Client
@@ -56,13 +54,13 @@ type visibilitySamplingClient struct {
 
 var _ VisibilityManager = (*visibilitySamplingClient)(nil)
 
-// NewVisibilitySamplingClient creates a client to manage visibility with sampling
+// NewVisibilitySamplingClient creates a client to manage visibility with sampling, list requests are
+// limited by NewVisibilityListRateLimitedClient
 func NewVisibilitySamplingClient(persistence VisibilityManager, config *config.VisibilityConfig, metricClient metrics.Client, logger log.Logger) VisibilityManager {
 	return &visibilitySamplingClient{
 		persistence:           persistence,
 		rateLimitersForOpen:   newNamespaceToBucketMap(),
 		rateLimitersForClosed: newNamespaceToBucketMap(),
-		rateLimitersForList:   newNamespaceToBucketMap(),
 		config:                config,
 		metricClient:          metricClient,
 		logger:                logger,
@@ -71,12 +69,19 @@ func NewVisibilitySamplingClient(persistence VisibilityManager, config *config.V
 
 type namespaceToBucketMap struct {
 	sync.RWMutex
-	mappings map[string]tokenbucket.PriorityTokenBucket
+	mappings map[string]*namespaceBucket
+}
+
+// namespaceBucket remembers the qps a bucket was created with, so the bucket
+// is replaced when the dynamic config or the namespace quota changes.
+type namespaceBucket struct {
+	qps    int
+	bucket tokenbucket.PriorityTokenBucket
 }
 
 func newNamespaceToBucketMap() *namespaceToBucketMap {
 	return &namespaceToBucketMap{
-		mappings: make(map[string]tokenbucket.PriorityTokenBucket),
+		mappings: make(map[string]*namespaceBucket),
 	}
 }
 
@@ -85,19 +90,21 @@ func (m *namespaceToBucketMap) getRateLimiter(namespace string, numOfPriority, q
 	rateLimiter, exist := m.mappings[namespace]
 	m.RUnlock()
 
-	if exist {
-		return rateLimiter
+	if exist && rateLimiter.qps == qps {
+		return rateLimiter.bucket
 	}
 
 	m.Lock()
-	if rateLimiter, ok := m.mappings[namespace]; ok { // read again to ensure no duplicate create
-		m.Unlock()
-		return rateLimiter
+	defer m.Unlock()
+	if rateLimiter, ok := m.mappings[namespace]; ok && rateLimiter.qps == qps { // read again to ensure no duplicate create
+		return rateLimiter.bucket
+	}
+	rateLimiter = &namespaceBucket{
+		qps:    qps,
+		bucket: tokenbucket.NewFullPriorityTokenBucket(numOfPriority, qps, clock.NewRealTimeSource()),
 	}
-	rateLimiter = tokenbucket.NewFullPriorityTokenBucket(numOfPriority, qps, clock.NewRealTimeSource())
 	m.mappings[namespace] = rateLimiter
-	m.Unlock()
-	return rateLimiter
+	return rateLimiter.bucket
 }
 
 func (p *visibilitySamplingClient) RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error {
@@ -162,79 +169,30 @@ func (p *visibilitySamplingClient) UpsertWorkflowExecution(request *UpsertWorkfl
 }
 
 func (p *visibilitySamplingClient) ListOpenWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
-	namespace := request.Namespace
-
-	rateLimiter := p.rateLimitersForList.getRateLimiter(namespace, numOfPriorityForList, p.config.VisibilityListMaxQPS(namespace))
-	if ok, _ := rateLimiter.GetToken(0, 1); !ok {
-		return nil, ErrPersistenceLimitExceededForList
-	}
-
 	return p.persistence.ListOpenWorkflowExecutions(request)
 }
 
 func (p *visibilitySamplingClient) ListClosedWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
-	namespace := request.Namespace
-
-	rateLimiter := p.rateLimitersForList.getRateLimiter(namespace, numOfPriorityForList, p.config.VisibilityListMaxQPS(namespace))
-	if ok, _ := rateLimiter.GetToken(0, 1); !ok {
-		return nil, ErrPersistenceLimitExceededForList
-	}
-
 	return p.persistence.ListClosedWorkflowExecutions(request)
 }
 
 func (p *visibilitySamplingClient) ListOpenWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
-	namespace := request.Namespace
-
-	rateLimiter := p.rateLimitersForList.getRateLimiter(namespace, numOfPriorityForList, p.config.VisibilityListMaxQPS(namespace))
-	if ok, _ := rateLimiter.GetToken(0, 1); !ok {
-		return nil, ErrPersistenceLimitExceededForList
-	}
-
 	return p.persistence.ListOpenWorkflowExecutionsByType(request)
 }
 
 func (p *visibilitySamplingClient) ListClosedWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
-	namespace := request.Namespace
-
-	rateLimiter := p.rateLimitersForList.getRateLimiter(namespace, numOfPriorityForList, p.config.VisibilityListMaxQPS(namespace))
-	if ok, _ := rateLimiter.GetToken(0, 1); !ok {
-		return nil, ErrPersistenceLimitExceededForList
-	}
-
 	return p.persistence.ListClosedWorkflowExecutionsByType(request)
 }
 
 func (p *visibilitySamplingClient) ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
-	namespace := request.Namespace
-
-	rateLimiter := p.rateLimitersForList.getRateLimiter(namespace, numOfPriorityForList, p.config.VisibilityListMaxQPS(namespace))
-	if ok, _ := rateLimiter.GetToken(0, 1); !ok {
-		return nil, ErrPersistenceLimitExceededForList
-	}
-
 	return p.persistence.ListOpenWorkflowExecutionsByWorkflowID(request)
 }
 
 func (p *visibilitySamplingClient) ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
-	namespace := request.Namespace
-
-	rateLimiter := p.rateLimitersForList.getRateLimiter(namespace, numOfPriorityForList, p.config.VisibilityListMaxQPS(namespace))
-	if ok, _ := rateLimiter.GetToken(0, 1); !ok {
-		return nil, ErrPersistenceLimitExceededForList
-	}
-
 	return p.persistence.ListClosedWorkflowExecutionsByWorkflowID(request)
 }
 
 func (p *visibilitySamplingClient) ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
-	namespace := request.Namespace
-
-	rateLimiter := p.rateLimitersForList.getRateLimiter(namespace, numOfPriorityForList, p.config.VisibilityListMaxQPS(namespace))
-	if ok, _ := rateLimiter.GetToken(0, 1); !ok {
-		return nil, ErrPersistenceLimitExceededForList
-	}
-
 	return p.persistence.ListClosedWorkflowExecutionsByStatus(request)
 }
 
@@ -0,0 +1,229 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package profiling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+)
+
+type (
+	// Config is the config of the profiles periodically captured to a directory
+	Config struct {
+		// Dir is the directory the profiles are written to
+		Dir string `yaml:"dir"`
+		// Interval is the interval between two captures, defaults to 10 minutes
+		Interval time.Duration `yaml:"interval"`
+		// Types are the types of the captured profiles, defaults to all the types
+		Types []string `yaml:"types"`
+		// CPUDuration is the duration of the cpu profiles, defaults to 30 seconds
+		CPUDuration time.Duration `yaml:"cpuDuration"`
+		// MaxFiles is the max number of files of a profile type kept in the directory, defaults to 24
+		MaxFiles int `yaml:"maxFiles"`
+	}
+
+	// PeriodicProfiler captures the profiles of the process to a directory on an interval, the files
+	// are named <hostname>.<type>.<capture time>.pb.gz and the oldest files of a type are removed
+	// once there are more than MaxFiles of them
+	PeriodicProfiler struct {
+		config   Config
+		hostname string
+		logger   log.Logger
+
+		startOnce sync.Once
+		stopOnce  sync.Once
+		ctx       context.Context
+		cancel    context.CancelFunc
+		stoppedC  chan struct{}
+	}
+)
+
+const (
+	defaultInterval    = 10 * time.Minute
+	defaultCPUDuration = 30 * time.Second
+	defaultMaxFiles    = 24
+
+	fileTimeFormat = "20060102T150405Z"
+	fileSuffix     = ".pb.gz"
+)
+
+var errEmptyDir = errors.New("periodic profiling dir is empty")
+
+// Validate checks the config, a nil config is valid
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.Dir == "" {
+		return errEmptyDir
+	}
+	config := c.withDefaults()
+	for _, profileType := range config.Types {
+		if err := Validate(profileType, config.CPUDuration); err != nil {
+			return fmt.Errorf("invalid periodic profiling config: %v", err)
+		}
+		if profileType == TypeCPU && config.CPUDuration >= config.Interval {
+			return fmt.Errorf("invalid periodic profiling config: cpu duration %v is not shorter than the interval %v", config.CPUDuration, config.Interval)
+		}
+	}
+	return nil
+}
+
+// NewPeriodicProfiler creates the periodic profiler of the config
+func (c *Config) NewPeriodicProfiler(logger log.Logger) (*PeriodicProfiler, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &PeriodicProfiler{
+		config:   c.withDefaults(),
+		hostname: hostname,
+		logger:   logger,
+		ctx:      ctx,
+		cancel:   cancel,
+		stoppedC: make(chan struct{}),
+	}, nil
+}
+
+func (c *Config) withDefaults() Config {
+	config := *c
+	if config.Interval <= 0 {
+		config.Interval = defaultInterval
+	}
+	if len(config.Types) == 0 {
+		config.Types = Types
+	}
+	if config.CPUDuration <= 0 {
+		config.CPUDuration = defaultCPUDuration
+	}
+	if config.MaxFiles <= 0 {
+		config.MaxFiles = defaultMaxFiles
+	}
+	return config
+}
+
+// Start starts capturing the profiles
+func (p *PeriodicProfiler) Start() {
+	p.startOnce.Do(func() {
+		p.logger.Info("Periodic profiling started", tag.Value(p.config.Dir))
+		go p.captureLoop()
+	})
+}
+
+// Stop stops capturing the profiles, it interrupts a cpu profile in progress
+func (p *PeriodicProfiler) Stop() {
+	p.stopOnce.Do(func() {
+		// a profiler which was never started cannot be started anymore
+		p.startOnce.Do(func() { close(p.stoppedC) })
+		p.cancel()
+		<-p.stoppedC
+		p.logger.Info("Periodic profiling stopped")
+	})
+}
+
+func (p *PeriodicProfiler) captureLoop() {
+	defer close(p.stoppedC)
+
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			for _, profileType := range p.config.Types {
+				if err := p.capture(profileType, time.Now().UTC()); err != nil && p.ctx.Err() == nil {
+					p.logger.Warn("Failed to capture periodic profile", tag.Value(profileType), tag.Error(err))
+				}
+			}
+		}
+	}
+}
+
+func (p *PeriodicProfiler) capture(profileType string, now time.Time) error {
+	profile, err := Capture(p.ctx, profileType, p.config.CPUDuration)
+	if err != nil {
+		return err
+	}
+
+	prefix := p.filePrefix(profileType)
+	path := filepath.Join(p.config.Dir, prefix+now.Format(fileTimeFormat)+fileSuffix)
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, profile, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	return p.removeOldFiles(prefix)
+}
+
+func (p *PeriodicProfiler) filePrefix(profileType string) string {
+	return p.hostname + "." + profileType + "."
+}
+
+func (p *PeriodicProfiler) removeOldFiles(prefix string) error {
+	files, err := ioutil.ReadDir(p.config.Dir)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, file := range files {
+		if name := file.Name(); strings.HasPrefix(name, prefix) && strings.HasSuffix(name, fileSuffix) {
+			names = append(names, name)
+		}
+	}
+	if len(names) <= p.config.MaxFiles {
+		return nil
+	}
+
+	sort.Strings(names)
+	for _, name := range names[:len(names)-p.config.MaxFiles] {
+		if err := os.Remove(filepath.Join(p.config.Dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
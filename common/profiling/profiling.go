@@ -0,0 +1,95 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"time"
+)
+
+const (
+	// TypeCPU is the type of the cpu profile, sampled over a duration
+	TypeCPU = "cpu"
+	// TypeHeap is the type of the heap profile
+	TypeHeap = "heap"
+	// TypeGoroutine is the type of the profile of the stack traces of all goroutines
+	TypeGoroutine = "goroutine"
+
+	// MaxCPUDuration is the max duration of a cpu profile
+	MaxCPUDuration = 5 * time.Minute
+)
+
+// Types are the types of the profiles that can be captured
+var Types = []string{TypeCPU, TypeHeap, TypeGoroutine}
+
+// Validate checks the type of a profile, and the duration of a cpu profile
+func Validate(profileType string, duration time.Duration) error {
+	switch profileType {
+	case TypeCPU:
+		if duration <= 0 || duration > MaxCPUDuration {
+			return fmt.Errorf("cpu profile duration must be positive and at most %v", MaxCPUDuration)
+		}
+		return nil
+	case TypeHeap, TypeGoroutine:
+		return nil
+	default:
+		return fmt.Errorf("unknown profile type %q, supported types are %v", profileType, Types)
+	}
+}
+
+// Capture returns a gzipped protobuf profile of the process, which can be read with go tool pprof.
+// A cpu profile samples the process for the duration, or until the context is done, and fails when
+// another cpu profile is in progress. The duration of the other profile types is ignored.
+func Capture(ctx context.Context, profileType string, duration time.Duration) ([]byte, error) {
+	if err := Validate(profileType, duration); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	switch profileType {
+	case TypeCPU:
+		if err := pprof.StartCPUProfile(&buf); err != nil {
+			return nil, err
+		}
+		timer := time.NewTimer(duration)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+		pprof.StopCPUProfile()
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	default:
+		if err := pprof.Lookup(profileType).WriteTo(&buf, 0); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
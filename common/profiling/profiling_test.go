@@ -0,0 +1,127 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package profiling
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+func TestCapture(t *testing.T) {
+	for _, profileType := range Types {
+		profile, err := Capture(context.Background(), profileType, 100*time.Millisecond)
+		require.NoError(t, err, profileType)
+		assert.Equal(t, gzipMagic, profile[:2], profileType)
+	}
+}
+
+func TestCaptureCPUInterrupted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_, err := Capture(ctx, TypeCPU, time.Minute)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(started) < 10*time.Second)
+
+	// the interrupted profile is stopped
+	_, err = Capture(context.Background(), TypeCPU, 10*time.Millisecond)
+	assert.NoError(t, err)
+}
+
+func TestCaptureInvalidRequest(t *testing.T) {
+	_, err := Capture(context.Background(), "threads", 0)
+	assert.Error(t, err)
+	_, err = Capture(context.Background(), TypeCPU, 0)
+	assert.Error(t, err)
+	_, err = Capture(context.Background(), TypeCPU, MaxCPUDuration+time.Second)
+	assert.Error(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	var config *Config
+	assert.NoError(t, config.Validate())
+	assert.Error(t, (&Config{}).Validate())
+	assert.NoError(t, (&Config{Dir: "profiles"}).Validate())
+	assert.Error(t, (&Config{Dir: "profiles", Types: []string{"threads"}}).Validate())
+	assert.Error(t, (&Config{Dir: "profiles", Interval: time.Minute, CPUDuration: time.Minute}).Validate())
+	assert.NoError(t, (&Config{Dir: "profiles", Interval: time.Minute, CPUDuration: time.Minute, Types: []string{TypeHeap}}).Validate())
+}
+
+func TestPeriodicProfilerRemovesOldFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "profiling")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	config := &Config{Dir: dir, MaxFiles: 2}
+	profiler, err := config.NewPeriodicProfiler(loggerimpl.NewNopLogger())
+	require.NoError(t, err)
+	defer profiler.Stop()
+
+	now := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, profiler.capture(TypeGoroutine, now.Add(time.Duration(i)*time.Minute)))
+	}
+	require.NoError(t, profiler.capture(TypeHeap, now))
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+	assert.Equal(t, []string{
+		profiler.hostname + ".goroutine.20200501T000100Z.pb.gz",
+		profiler.hostname + ".goroutine.20200501T000200Z.pb.gz",
+		profiler.hostname + ".heap.20200501T000000Z.pb.gz",
+	}, names)
+}
+
+func TestPeriodicProfilerStop(t *testing.T) {
+	dir, err := ioutil.TempDir("", "profiling")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	config := &Config{Dir: dir}
+	profiler, err := config.NewPeriodicProfiler(loggerimpl.NewNopLogger())
+	require.NoError(t, err)
+	profiler.Stop()
+	profiler.Start()
+	profiler.Stop()
+
+	profiler, err = config.NewPeriodicProfiler(loggerimpl.NewNopLogger())
+	require.NoError(t, err)
+	profiler.Start()
+	profiler.Stop()
+}
@@ -0,0 +1,322 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	_shortLatencyWindow = 10
+	// _longLatencyRecovery lets the long term latency converge quickly once the short term latency
+	// has dropped well below it, so the limit is not held down by a past slowdown
+	_longLatencyRecovery = 0.95
+)
+
+type (
+	// ConcurrencyLimiter limits the number of requests in flight
+	ConcurrencyLimiter interface {
+		// TryAcquire reserves capacity for a request, it returns false without blocking
+		// when the limit has been reached
+		TryAcquire() bool
+		// Release returns the capacity reserved by TryAcquire, latency is the time the request
+		// took and dropped indicates the request failed because the downstream was overloaded
+		Release(latency time.Duration, dropped bool)
+		// Limit returns the current concurrency limit
+		Limit() int
+		// RetryAfter returns a hint of how long a rejected caller should wait before retrying
+		RetryAfter() time.Duration
+	}
+
+	// ConcurrencyLimiterOptions configures a concurrency limiter
+	ConcurrencyLimiterOptions struct {
+		InitialLimit int
+		MinLimit     int
+		MaxLimit     int
+		// BackoffRatio is applied to the limit when a request is dropped
+		BackoffRatio float64
+		// Tolerance is how much the short term latency may exceed the long term
+		// latency before the limit starts shrinking
+		Tolerance float64
+		// Smoothing is the weight of a new limit estimate against the current limit
+		Smoothing float64
+		// LongWindow is the number of samples the long term latency averages over
+		LongWindow int
+	}
+
+	// ConcurrencyLimiterOptionsFunc returns the current options of a concurrency limiter, it is called
+	// on every request so dynamic config changes apply to existing limiters. InitialLimit is only read
+	// when the limiter is created
+	ConcurrencyLimiterOptionsFunc func() ConcurrencyLimiterOptions
+
+	// gradientConcurrencyLimiter adjusts its limit by the gradient between the long term and
+	// the short term latency, the limit shrinks as soon as requests get slower than usual
+	gradientConcurrencyLimiter struct {
+		sync.Mutex
+		options      ConcurrencyLimiterOptionsFunc
+		limit        float64
+		inflight     int
+		shortLatency float64
+		longLatency  float64
+	}
+
+	// aimdConcurrencyLimiter grows its limit additively while requests succeed and shrinks it
+	// multiplicatively when they are dropped, it is meant for requests whose latency says
+	// nothing about the load of the downstream, such as long polls
+	aimdConcurrencyLimiter struct {
+		sync.Mutex
+		options  ConcurrencyLimiterOptionsFunc
+		limit    float64
+		inflight int
+		latency  float64
+	}
+
+	// MultiConcurrencyLimiter holds a concurrency limiter per key, limiters unused for the idle
+	// timeout are evicted so keys which are no longer used do not accumulate
+	MultiConcurrencyLimiter struct {
+		sync.RWMutex
+		limiters    map[string]*multiConcurrencyLimiterEntry
+		factory     func(key string) ConcurrencyLimiter
+		idleTimeout time.Duration
+		lastEvicted time.Time
+		timeSource  func() time.Time
+	}
+
+	multiConcurrencyLimiterEntry struct {
+		limiter  ConcurrencyLimiter
+		lastUsed int64 // unix nanos, updated atomically
+	}
+)
+
+var _ ConcurrencyLimiter = (*gradientConcurrencyLimiter)(nil)
+var _ ConcurrencyLimiter = (*aimdConcurrencyLimiter)(nil)
+
+// DefaultConcurrencyLimiterOptions returns the default options of a concurrency limiter
+func DefaultConcurrencyLimiterOptions() ConcurrencyLimiterOptions {
+	return ConcurrencyLimiterOptions{
+		InitialLimit: 20,
+		MinLimit:     20,
+		MaxLimit:     1000,
+		BackoffRatio: 0.9,
+		Tolerance:    2.0,
+		Smoothing:    0.2,
+		LongWindow:   600,
+	}
+}
+
+// NewGradientConcurrencyLimiter returns a concurrency limiter driven by request latency
+func NewGradientConcurrencyLimiter(options ConcurrencyLimiterOptionsFunc) ConcurrencyLimiter {
+	initial := options()
+	return &gradientConcurrencyLimiter{
+		options: options,
+		limit:   clampLimit(float64(initial.InitialLimit), initial),
+	}
+}
+
+// NewAIMDConcurrencyLimiter returns a concurrency limiter driven by dropped requests only
+func NewAIMDConcurrencyLimiter(options ConcurrencyLimiterOptionsFunc) ConcurrencyLimiter {
+	initial := options()
+	return &aimdConcurrencyLimiter{
+		options: options,
+		limit:   clampLimit(float64(initial.InitialLimit), initial),
+	}
+}
+
+// StaticConcurrencyLimiterOptions returns options which never change
+func StaticConcurrencyLimiterOptions(options ConcurrencyLimiterOptions) ConcurrencyLimiterOptionsFunc {
+	return func() ConcurrencyLimiterOptions {
+		return options
+	}
+}
+
+func (l *gradientConcurrencyLimiter) TryAcquire() bool {
+	options := l.options()
+	l.Lock()
+	defer l.Unlock()
+
+	l.limit = clampLimit(l.limit, options)
+	if l.inflight >= int(l.limit) {
+		return false
+	}
+	l.inflight++
+	return true
+}
+
+func (l *gradientConcurrencyLimiter) Release(latency time.Duration, dropped bool) {
+	options := l.options()
+	l.Lock()
+	defer l.Unlock()
+
+	inflight := l.inflight
+	l.inflight--
+	if dropped {
+		l.limit = clampLimit(l.limit*options.BackoffRatio, options)
+		return
+	}
+
+	sample := float64(latency)
+	if l.longLatency == 0 {
+		l.shortLatency = sample
+		l.longLatency = sample
+	}
+	l.shortLatency += (sample - l.shortLatency) / _shortLatencyWindow
+	l.longLatency += (sample - l.longLatency) / float64(options.LongWindow)
+	if l.longLatency/l.shortLatency > 2 {
+		l.longLatency *= _longLatencyRecovery
+	}
+
+	gradient := math.Max(0.5, math.Min(1.0, options.Tolerance*l.longLatency/l.shortLatency))
+	newLimit := l.limit*gradient + math.Sqrt(l.limit)
+	// the limit is not grown while less than half of it is used, the latency
+	// says nothing about how the downstream would cope with more requests
+	if newLimit > l.limit && float64(inflight) < l.limit/2 {
+		return
+	}
+	l.limit = clampLimit(l.limit*(1-options.Smoothing)+newLimit*options.Smoothing, options)
+}
+
+func (l *gradientConcurrencyLimiter) Limit() int {
+	l.Lock()
+	defer l.Unlock()
+
+	return int(l.limit)
+}
+
+func (l *gradientConcurrencyLimiter) RetryAfter() time.Duration {
+	l.Lock()
+	defer l.Unlock()
+
+	return time.Duration(l.shortLatency)
+}
+
+func (l *aimdConcurrencyLimiter) TryAcquire() bool {
+	options := l.options()
+	l.Lock()
+	defer l.Unlock()
+
+	l.limit = clampLimit(l.limit, options)
+	if l.inflight >= int(l.limit) {
+		return false
+	}
+	l.inflight++
+	return true
+}
+
+func (l *aimdConcurrencyLimiter) Release(latency time.Duration, dropped bool) {
+	options := l.options()
+	l.Lock()
+	defer l.Unlock()
+
+	inflight := l.inflight
+	l.inflight--
+	l.latency += (float64(latency) - l.latency) / _shortLatencyWindow
+	if dropped {
+		l.limit = clampLimit(l.limit*options.BackoffRatio, options)
+		return
+	}
+	if float64(inflight) >= l.limit/2 {
+		l.limit = clampLimit(l.limit+1, options)
+	}
+}
+
+func (l *aimdConcurrencyLimiter) Limit() int {
+	l.Lock()
+	defer l.Unlock()
+
+	return int(l.limit)
+}
+
+func (l *aimdConcurrencyLimiter) RetryAfter() time.Duration {
+	l.Lock()
+	defer l.Unlock()
+
+	return time.Duration(l.latency)
+}
+
+func clampLimit(limit float64, options ConcurrencyLimiterOptions) float64 {
+	return math.Max(float64(options.MinLimit), math.Min(float64(options.MaxLimit), limit))
+}
+
+// NewMultiConcurrencyLimiter returns a concurrency limiter per key, each created by factory on first use
+// and evicted once unused for idleTimeout
+func NewMultiConcurrencyLimiter(
+	factory func(key string) ConcurrencyLimiter,
+	idleTimeout time.Duration,
+) *MultiConcurrencyLimiter {
+	return &MultiConcurrencyLimiter{
+		limiters:    make(map[string]*multiConcurrencyLimiterEntry),
+		factory:     factory,
+		idleTimeout: idleTimeout,
+		lastEvicted: time.Now(),
+		timeSource:  time.Now,
+	}
+}
+
+// Get returns the concurrency limiter of the key
+func (m *MultiConcurrencyLimiter) Get(key string) ConcurrencyLimiter {
+	now := m.timeSource()
+	m.RLock()
+	entry, ok := m.limiters[key]
+	evict := now.Sub(m.lastEvicted) >= m.idleTimeout
+	m.RUnlock()
+	if ok && !evict {
+		atomic.StoreInt64(&entry.lastUsed, now.UnixNano())
+		return entry.limiter
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	if now.Sub(m.lastEvicted) >= m.idleTimeout {
+		m.evictLocked(now)
+	}
+	entry, ok = m.limiters[key]
+	if !ok {
+		entry = &multiConcurrencyLimiterEntry{limiter: m.factory(key)}
+		m.limiters[key] = entry
+	}
+	atomic.StoreInt64(&entry.lastUsed, now.UnixNano())
+	return entry.limiter
+}
+
+// Len returns the number of limiters held
+func (m *MultiConcurrencyLimiter) Len() int {
+	m.RLock()
+	defer m.RUnlock()
+
+	return len(m.limiters)
+}
+
+func (m *MultiConcurrencyLimiter) evictLocked(now time.Time) {
+	m.lastEvicted = now
+	idleSince := now.Add(-m.idleTimeout).UnixNano()
+	for key, entry := range m.limiters {
+		if atomic.LoadInt64(&entry.lastUsed) < idleSince {
+			delete(m.limiters, key)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testConcurrencyLimiterOptions() ConcurrencyLimiterOptions {
+	options := DefaultConcurrencyLimiterOptions()
+	options.InitialLimit = 10
+	options.MinLimit = 2
+	options.MaxLimit = 100
+	options.LongWindow = 100
+	return options
+}
+
+func TestConcurrencyLimiterRejectsAboveLimit(t *testing.T) {
+	limiter := NewGradientConcurrencyLimiter(StaticConcurrencyLimiterOptions(testConcurrencyLimiterOptions()))
+	for i := 0; i < 10; i++ {
+		assert.True(t, limiter.TryAcquire())
+	}
+	assert.False(t, limiter.TryAcquire())
+
+	limiter.Release(time.Millisecond, false)
+	assert.True(t, limiter.TryAcquire())
+}
+
+func TestGradientConcurrencyLimiterShrinksWhenLatencyRises(t *testing.T) {
+	limiter := NewGradientConcurrencyLimiter(StaticConcurrencyLimiterOptions(testConcurrencyLimiterOptions()))
+	runConcurrencyLimiter(limiter, 10*time.Millisecond, 200)
+	steadyLimit := limiter.Limit()
+	assert.True(t, steadyLimit > 10)
+
+	runConcurrencyLimiter(limiter, 100*time.Millisecond, 1)
+	assert.True(t, limiter.Limit() < steadyLimit)
+	assert.True(t, limiter.RetryAfter() > 10*time.Millisecond)
+}
+
+func TestGradientConcurrencyLimiterBacksOffWhenDropped(t *testing.T) {
+	limiter := NewGradientConcurrencyLimiter(StaticConcurrencyLimiterOptions(testConcurrencyLimiterOptions()))
+	for i := 0; i < 20; i++ {
+		assert.True(t, limiter.TryAcquire())
+		limiter.Release(time.Second, true)
+	}
+	assert.Equal(t, 2, limiter.Limit())
+}
+
+func TestAIMDConcurrencyLimiterIgnoresLatency(t *testing.T) {
+	limiter := NewAIMDConcurrencyLimiter(StaticConcurrencyLimiterOptions(testConcurrencyLimiterOptions()))
+	runConcurrencyLimiter(limiter, time.Minute, 50)
+	assert.True(t, limiter.Limit() > 10)
+
+	limit := limiter.Limit()
+	assert.True(t, limiter.TryAcquire())
+	limiter.Release(time.Minute, true)
+	assert.True(t, limiter.Limit() < limit)
+}
+
+func TestConcurrencyLimiterDynamicOptions(t *testing.T) {
+	options := testConcurrencyLimiterOptions()
+	limiter := NewAIMDConcurrencyLimiter(func() ConcurrencyLimiterOptions { return options })
+	assert.Equal(t, 10, limiter.Limit())
+
+	options.MinLimit = 50
+	assert.True(t, limiter.TryAcquire())
+	assert.Equal(t, 50, limiter.Limit())
+	limiter.Release(time.Millisecond, true)
+	assert.Equal(t, 50, limiter.Limit())
+
+	options.MinLimit = 2
+	options.MaxLimit = 5
+	assert.True(t, limiter.TryAcquire())
+	assert.Equal(t, 5, limiter.Limit())
+}
+
+func TestMultiConcurrencyLimiter(t *testing.T) {
+	created := 0
+	limiters := NewMultiConcurrencyLimiter(func(key string) ConcurrencyLimiter {
+		created++
+		return NewGradientConcurrencyLimiter(StaticConcurrencyLimiterOptions(testConcurrencyLimiterOptions()))
+	}, time.Minute)
+	assert.True(t, limiters.Get("a") == limiters.Get("a"))
+	assert.False(t, limiters.Get("a") == limiters.Get("b"))
+	assert.Equal(t, 2, created)
+}
+
+func TestMultiConcurrencyLimiterEviction(t *testing.T) {
+	now := time.Now()
+	limiters := NewMultiConcurrencyLimiter(func(key string) ConcurrencyLimiter {
+		return NewGradientConcurrencyLimiter(StaticConcurrencyLimiterOptions(testConcurrencyLimiterOptions()))
+	}, time.Minute)
+	limiters.timeSource = func() time.Time { return now }
+
+	a := limiters.Get("a")
+	limiters.Get("b")
+	now = now.Add(40 * time.Second)
+	assert.True(t, a == limiters.Get("a"))
+	now = now.Add(40 * time.Second)
+	limiters.Get("a")
+
+	// b has been idle for longer than the idle timeout
+	assert.Equal(t, 1, limiters.Len())
+	assert.True(t, a == limiters.Get("a"))
+}
+
+// runConcurrencyLimiter keeps the limiter saturated for the given rounds of requests
+func runConcurrencyLimiter(limiter ConcurrencyLimiter, latency time.Duration, rounds int) {
+	for i := 0; i < rounds; i++ {
+		acquired := 0
+		for limiter.TryAcquire() {
+			acquired++
+		}
+		for j := 0; j < acquired; j++ {
+			limiter.Release(latency, false)
+		}
+	}
+}
@@ -31,9 +31,11 @@ import (
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/audit"
 	"github.com/temporalio/temporal/common/authorization"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/elasticsearch"
+	"github.com/temporalio/temporal/common/lifecycle"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/membership"
 	"github.com/temporalio/temporal/common/messaging"
@@ -51,6 +53,8 @@ type (
 		InstanceID      string
 		Logger          log.Logger
 		ThrottledLogger log.Logger
+		LogLevels       *config.LogLevels
+		RecentErrors    *config.RecentErrors
 
 		MetricScope                  tally.Scope
 		MembershipFactoryInitializer MembershipFactoryInitializerFunc
@@ -69,6 +73,9 @@ type (
 		ArchivalMetadata             archiver.ArchivalMetadata
 		ArchiverProvider             provider.ArchiverProvider
 		Authorizer                   authorization.Authorizer
+		ClaimMapper                  authorization.ClaimMapper
+		AuditLoggerInitializer       AuditLoggerInitializerFunc
+		LifecyclePublisher           lifecycle.Publisher
 	}
 
 	// MembershipMonitorFactory provides a bootstrapped membership monitor
@@ -80,4 +87,8 @@ type (
 	// MembershipFactoryInitializerFunc is used for deferred initialization of the MembershipFactory
 	// to allow for the PersistenceBean to be constructed further downstream.
 	MembershipFactoryInitializerFunc func(persistenceBean persistenceClient.Bean, logger log.Logger) (MembershipMonitorFactory, error)
+
+	// AuditLoggerInitializerFunc is used for deferred initialization of the audit logger
+	// to allow the persistence sink to use the PersistenceBean of the service.
+	AuditLoggerInitializerFunc func(persistenceBean persistenceClient.Bean, logger log.Logger) (audit.Logger, error)
 )
@@ -0,0 +1,103 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resource
+
+import (
+	"encoding/json"
+
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+var (
+	errLogLevelsNotManaged = serviceerror.NewInvalidArgument("Log levels are not managed by this host.")
+	errLogLevelNotSet      = serviceerror.NewInvalidArgument("Level is not set on request.")
+)
+
+// DescribeRecentErrors returns the errors recently logged by the process of the host of the resource, oldest first
+func DescribeRecentErrors(r Resource) []*commongenpb.LogEntry {
+	var entries []*commongenpb.LogEntry
+	for _, recentError := range r.GetRecentErrors().Entries() {
+		// the fields are encoded by zap and always encode as JSON, a failure only loses them
+		fields, _ := json.Marshal(recentError.Fields)
+		entries = append(entries, &commongenpb.LogEntry{
+			Timestamp: recentError.Time.UnixNano(),
+			Service:   recentError.Service,
+			Level:     recentError.Level,
+			Message:   recentError.Message,
+			Fields:    string(fields),
+		})
+	}
+	return entries
+}
+
+// DescribeLogLevels returns the log levels of the process of the host of the resource
+func DescribeLogLevels(r Resource) (*commongenpb.HostLogLevels, error) {
+	logLevels := r.GetLogLevels()
+	if logLevels == nil {
+		return nil, errLogLevelsNotManaged
+	}
+	return &commongenpb.HostLogLevels{
+		Service:       r.GetServiceName(),
+		HostAddress:   r.GetHostInfo().GetAddress(),
+		Level:         logLevels.Level(),
+		PackageLevels: logLevels.PackageLevels(),
+	}, nil
+}
+
+// UpdateLogLevel changes the log level of a package and its subpackages in the process of the host of the resource,
+// the default level when packagePath is empty, and returns the resulting log levels. The level of the package is
+// removed when level is empty.
+func UpdateLogLevel(r Resource, packagePath string, level string) (*commongenpb.HostLogLevels, error) {
+	logLevels := r.GetLogLevels()
+	if logLevels == nil {
+		return nil, errLogLevelsNotManaged
+	}
+	switch {
+	case level != "":
+		if err := logLevels.SetLevel(packagePath, level); err != nil {
+			return nil, serviceerror.NewInvalidArgument(err.Error())
+		}
+	case packagePath != "":
+		logLevels.ResetLevel(packagePath)
+	default:
+		return nil, errLogLevelNotSet
+	}
+	r.GetLogger().Info("Updated log level.", tag.Key(packagePath), tag.Value(level))
+	return DescribeLogLevels(r)
+}
+
+// SnapshotDynamicConfig returns the dynamic config values of the host of the resource encoded as JSON,
+// it returns nil when the dynamic config client cannot list its values
+func SnapshotDynamicConfig(r Resource) ([]byte, error) {
+	snapshotter, ok := r.GetDynamicConfigClient().(dynamicconfig.Snapshotter)
+	if !ok {
+		return nil, nil
+	}
+	return json.Marshal(snapshotter.Snapshot())
+}
@@ -0,0 +1,76 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/temporalio/temporal/common/health"
+)
+
+// NewHealthChecker creates a health checker for the service of the resource with the membership
+// ring status check registered, which only depends on the state of the host
+func NewHealthChecker(r Resource) *health.Checker {
+	checker := health.NewChecker(r.GetServiceName())
+	checker.Register("membership", func(context.Context) (string, error) {
+		return checkMembership(r)
+	})
+	return checker
+}
+
+// NewPersistenceHealthChecker creates a health checker like NewHealthChecker with the persistence
+// connectivity check also registered, for services that cannot serve any request without persistence
+func NewPersistenceHealthChecker(r Resource) *health.Checker {
+	checker := NewHealthChecker(r)
+	checker.Register("persistence", func(context.Context) (string, error) {
+		if _, err := r.GetMetadataManager().GetMetadata(); err != nil {
+			return "", err
+		}
+		return "", nil
+	})
+	return checker
+}
+
+func checkMembership(r Resource) (string, error) {
+	monitor := r.GetMembershipMonitor()
+	self, err := monitor.WhoAmI()
+	if err != nil {
+		return "", err
+	}
+	resolver, err := monitor.GetResolver(r.GetServiceName())
+	if err != nil {
+		return "", err
+	}
+
+	members := resolver.Members()
+	detail := fmt.Sprintf("%v members in the %v ring", len(members), r.GetServiceName())
+	for _, member := range members {
+		if member.Identity() == self.Identity() {
+			return detail, nil
+		}
+	}
+	return detail, fmt.Errorf("host %v has not joined the %v ring", self.Identity(), r.GetServiceName())
+}
@@ -28,6 +28,7 @@ import (
 	"net"
 
 	sdkclient "go.temporal.io/temporal/client"
+	"google.golang.org/grpc"
 
 	"github.com/temporalio/temporal/client"
 	"github.com/temporalio/temporal/client/admin"
@@ -46,6 +47,8 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
+	"github.com/temporalio/temporal/common/service/config"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
 type (
@@ -69,6 +72,7 @@ type (
 		GetMetricsClient() metrics.Client
 		GetArchiverProvider() provider.ArchiverProvider
 		GetMessagingClient() messaging.Client
+		GetDynamicConfigClient() dynamicconfig.Client
 
 		// membership infos
 
@@ -89,6 +93,7 @@ type (
 		GetHistoryClient() history.Client
 		GetRemoteAdminClient(cluster string) admin.Client
 		GetRemoteFrontendClient(cluster string) frontend.Client
+		GetFrontendAdminClient() admin.Client
 		GetClientBean() client.Bean
 
 		// persistence clients
@@ -106,8 +111,13 @@ type (
 
 		GetLogger() log.Logger
 		GetThrottledLogger() log.Logger
+		GetRecentErrors() *config.RecentErrors
+		GetLogLevels() *config.LogLevels
 
 		// for registering handlers
 		GetGRPCListener() net.Listener
+		GetHealthListener() net.Listener
+		GetFrontendGRPCServerOptions() []grpc.ServerOption
+		GetInternodeGRPCServerOptions() []grpc.ServerOption
 	}
 )
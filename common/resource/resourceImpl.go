@@ -34,6 +34,7 @@ import (
 	"github.com/uber-go/tally"
 	"github.com/uber/tchannel-go"
 	sdkclient "go.temporal.io/temporal/client"
+	"google.golang.org/grpc"
 
 	"github.com/temporalio/temporal/client"
 	"github.com/temporalio/temporal/client/admin"
@@ -54,6 +55,7 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
+	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
@@ -88,6 +90,7 @@ type (
 		messagingClient   messaging.Client
 		archivalMetadata  archiver.ArchivalMetadata
 		archiverProvider  provider.ArchiverProvider
+		dynamicConfig     dynamicconfig.Client
 
 		// membership infos
 
@@ -117,6 +120,8 @@ type (
 
 		logger          log.Logger
 		throttledLogger log.Logger
+		recentErrors    *config.RecentErrors
+		logLevels       *config.LogLevels
 
 		// for registering handlers
 		grpcListener net.Listener
@@ -305,6 +310,7 @@ func New(
 		messagingClient:   params.MessagingClient,
 		archivalMetadata:  params.ArchivalMetadata,
 		archiverProvider:  params.ArchiverProvider,
+		dynamicConfig:     params.DynamicConfig,
 
 		// membership infos
 
@@ -334,6 +340,8 @@ func New(
 
 		logger:          logger,
 		throttledLogger: throttledLogger,
+		recentErrors:    params.RecentErrors,
+		logLevels:       params.LogLevels,
 
 		// for registering grpc handlers
 		grpcListener: grpcListener,
@@ -451,6 +459,11 @@ func (h *Impl) GetMessagingClient() messaging.Client {
 	return h.messagingClient
 }
 
+// GetDynamicConfigClient return the dynamic config client
+func (h *Impl) GetDynamicConfigClient() dynamicconfig.Client {
+	return h.dynamicConfig
+}
+
 // GetArchivalMetadata return archival metadata
 func (h *Impl) GetArchivalMetadata() archiver.ArchivalMetadata {
 	return h.archivalMetadata
@@ -541,6 +554,11 @@ func (h *Impl) GetRemoteFrontendClient(
 	return h.clientBean.GetRemoteFrontendClient(cluster)
 }
 
+// GetFrontendAdminClient return admin client for the frontend hosts of the current cluster
+func (h *Impl) GetFrontendAdminClient() admin.Client {
+	return h.clientBean.GetFrontendAdminClient()
+}
+
 // GetClientBean return RPC client bean
 func (h *Impl) GetClientBean() client.Bean {
 	return h.clientBean
@@ -608,7 +626,32 @@ func (h *Impl) GetThrottledLogger() log.Logger {
 	return h.throttledLogger
 }
 
+// GetRecentErrors return the errors recently logged by the process, nil when they are not recorded
+func (h *Impl) GetRecentErrors() *config.RecentErrors {
+	return h.recentErrors
+}
+
+// GetLogLevels return the log levels of the process, nil when they cannot be changed
+func (h *Impl) GetLogLevels() *config.LogLevels {
+	return h.logLevels
+}
+
 // GetGRPCListener return GRPC listener, used for registering handlers
 func (h *Impl) GetGRPCListener() net.Listener {
 	return h.grpcListener
 }
+
+// GetHealthListener return the listener of the HTTP health endpoints, nil when they are disabled
+func (h *Impl) GetHealthListener() net.Listener {
+	return h.rpcFactory.GetHealthListener()
+}
+
+// GetFrontendGRPCServerOptions return the options of the frontend gRPC server
+func (h *Impl) GetFrontendGRPCServerOptions() []grpc.ServerOption {
+	return h.rpcFactory.GetFrontendGRPCServerOptions()
+}
+
+// GetInternodeGRPCServerOptions return the options of the history and matching gRPC servers
+func (h *Impl) GetInternodeGRPCServerOptions() []grpc.ServerOption {
+	return h.rpcFactory.GetInternodeGRPCServerOptions()
+}
@@ -34,6 +34,7 @@ import (
 	sdkclient "go.temporal.io/temporal/client"
 	sdkmocks "go.temporal.io/temporal/mocks"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservicemock"
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock"
@@ -57,6 +58,8 @@ import (
 	"github.com/temporalio/temporal/common/mocks"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
+	"github.com/temporalio/temporal/common/service/config"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
 type (
@@ -90,6 +93,7 @@ type (
 		HistoryClient        *historyservicemock.MockHistoryServiceClient
 		RemoteAdminClient    *adminservicemock.MockAdminServiceClient
 		RemoteFrontendClient *workflowservicemock.MockWorkflowServiceClient
+		FrontendAdminClient  *adminservicemock.MockAdminServiceClient
 		ClientBean           *client.MockBean
 
 		// persistence clients
@@ -134,12 +138,14 @@ func NewTest(
 	historyClient := historyservicemock.NewMockHistoryServiceClient(controller)
 	remoteFrontendClient := workflowservicemock.NewMockWorkflowServiceClient(controller)
 	remoteAdminClient := adminservicemock.NewMockAdminServiceClient(controller)
+	frontendAdminClient := adminservicemock.NewMockAdminServiceClient(controller)
 	clientBean := client.NewMockBean(controller)
 	clientBean.EXPECT().GetFrontendClient().Return(frontendClient).AnyTimes()
 	clientBean.EXPECT().GetMatchingClient(gomock.Any()).Return(matchingClient, nil).AnyTimes()
 	clientBean.EXPECT().GetHistoryClient().Return(historyClient).AnyTimes()
 	clientBean.EXPECT().GetRemoteAdminClient(gomock.Any()).Return(remoteAdminClient).AnyTimes()
 	clientBean.EXPECT().GetRemoteFrontendClient(gomock.Any()).Return(remoteFrontendClient).AnyTimes()
+	clientBean.EXPECT().GetFrontendAdminClient().Return(frontendAdminClient).AnyTimes()
 
 	metadataMgr := &mocks.MetadataManager{}
 	taskMgr := &mocks.TaskManager{}
@@ -200,6 +206,7 @@ func NewTest(
 		HistoryClient:        historyClient,
 		RemoteAdminClient:    remoteAdminClient,
 		RemoteFrontendClient: remoteFrontendClient,
+		FrontendAdminClient:  frontendAdminClient,
 		ClientBean:           clientBean,
 
 		// persistence clients
@@ -278,6 +285,11 @@ func (s *Test) GetMessagingClient() messaging.Client {
 	panic("user should implement this method for test")
 }
 
+// GetDynamicConfigClient for testing
+func (s *Test) GetDynamicConfigClient() dynamicconfig.Client {
+	panic("user should implement this method for test")
+}
+
 // GetArchivalMetadata for testing
 func (s *Test) GetArchivalMetadata() archiver.ArchivalMetadata {
 	return s.ArchivalMetadata
@@ -368,6 +380,11 @@ func (s *Test) GetRemoteFrontendClient(
 	return s.RemoteFrontendClient
 }
 
+// GetFrontendAdminClient for testing
+func (s *Test) GetFrontendAdminClient() admin.Client {
+	return s.FrontendAdminClient
+}
+
 // GetClientBean for testing
 func (s *Test) GetClientBean() client.Bean {
 	return s.ClientBean
@@ -431,11 +448,36 @@ func (s *Test) GetThrottledLogger() log.Logger {
 	return s.Logger
 }
 
+// GetRecentErrors for testing
+func (s *Test) GetRecentErrors() *config.RecentErrors {
+	return nil
+}
+
+// GetLogLevels for testing
+func (s *Test) GetLogLevels() *config.LogLevels {
+	return nil
+}
+
 // GetGRPCListener for testing
 func (s *Test) GetGRPCListener() net.Listener {
 	panic("user should implement this method for test")
 }
 
+// GetHealthListener for testing
+func (s *Test) GetHealthListener() net.Listener {
+	panic("user should implement this method for test")
+}
+
+// GetFrontendGRPCServerOptions for testing
+func (s *Test) GetFrontendGRPCServerOptions() []grpc.ServerOption {
+	panic("user should implement this method for test")
+}
+
+// GetInternodeGRPCServerOptions for testing
+func (s *Test) GetInternodeGRPCServerOptions() []grpc.ServerOption {
+	panic("user should implement this method for test")
+}
+
 // Finish checks whether expectations are met
 func (s *Test) Finish(
 	t mock.TestingT,
@@ -35,7 +35,18 @@ type (
 	// RPCFactory creates gRPC listener and connection.
 	RPCFactory interface {
 		GetGRPCListener() net.Listener
+		// GetHTTPListener returns the listener of the frontend HTTP gateway, nil when it is disabled
+		GetHTTPListener() net.Listener
+		// GetHealthListener returns the listener of the HTTP health endpoints, nil when they are disabled
+		GetHealthListener() net.Listener
 		GetRingpopChannel() *tchannel.Channel
+		// GetFrontendGRPCServerOptions returns the options of the frontend gRPC server
+		GetFrontendGRPCServerOptions() []grpc.ServerOption
+		// GetInternodeGRPCServerOptions returns the options of the history and matching gRPC servers
+		GetInternodeGRPCServerOptions() []grpc.ServerOption
+		// CreateGRPCConnection creates connection to history and matching
 		CreateGRPCConnection(hostName string) *grpc.ClientConn
+		// CreateFrontendGRPCConnection creates connection to frontend
+		CreateFrontendGRPCConnection(hostName string) *grpc.ClientConn
 	}
 )
@@ -26,10 +26,13 @@ package rpc
 
 import (
 	"context"
+	"crypto/tls"
 
 	"github.com/gogo/status"
 	"go.temporal.io/temporal-proto/serviceerror"
+	sdkclient "go.temporal.io/temporal/client"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/temporalio/temporal/common/headers"
 )
@@ -45,10 +48,16 @@ const (
 // The hostName syntax is defined in
 // https://github.com/grpc/grpc/blob/master/doc/naming.md.
 // e.g. to use dns resolver, a "dns:///" prefix should be applied to the target.
-func Dial(hostName string) (*grpc.ClientConn, error) {
+// The connection is plaintext when transportCredentials is nil.
+func Dial(hostName string, transportCredentials credentials.TransportCredentials) (*grpc.ClientConn, error) {
+	grpcSecureOpt := grpc.WithInsecure()
+	if transportCredentials != nil {
+		grpcSecureOpt = grpc.WithTransportCredentials(transportCredentials)
+	}
 	return grpc.Dial(hostName,
-		grpc.WithInsecure(),
+		grpcSecureOpt,
 		grpc.WithChainUnaryInterceptor(
+			tracingClientInterceptor,
 			versionHeadersInterceptor,
 			errorInterceptor),
 		grpc.WithDefaultServiceConfig(DefaultServiceConfig),
@@ -56,6 +65,31 @@ func Dial(hostName string) (*grpc.ClientConn, error) {
 	)
 }
 
+// NewSDKGRPCDialer returns a dialer of SDK clients connecting with the given credentials,
+// the connection is plaintext when transportCredentials is nil.
+func NewSDKGRPCDialer(transportCredentials credentials.TransportCredentials) sdkclient.GRPCDialer {
+	return func(params sdkclient.GRPCDialerParams) (*grpc.ClientConn, error) {
+		grpcSecureOpt := grpc.WithInsecure()
+		if transportCredentials != nil {
+			grpcSecureOpt = grpc.WithTransportCredentials(transportCredentials)
+		}
+		return grpc.Dial(params.HostPort,
+			grpcSecureOpt,
+			grpc.WithChainUnaryInterceptor(params.RequiredInterceptors...),
+			grpc.WithDefaultServiceConfig(params.DefaultServiceConfig),
+		)
+	}
+}
+
+// ServerOptions returns the gRPC server options of a server using the given TLS config,
+// the server is plaintext when tlsConfig is nil.
+func ServerOptions(tlsConfig *tls.Config) []grpc.ServerOption {
+	if tlsConfig == nil {
+		return nil
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(tlsConfig))}
+}
+
 func errorInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 	err := invoker(ctx, method, req, reply, cc, opts...)
 	err = serviceerror.FromStatus(status.Convert(err))
@@ -0,0 +1,257 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"go.temporal.io/temporal-proto/serviceerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	// serverInterceptors are the interceptors every gRPC server runs before the service specific ones
+	serverInterceptors struct {
+		metricsClient metrics.Client
+		logger        log.Logger
+		logSampleRate dynamicconfig.FloatPropertyFn
+		namespaces    NamespaceResolver
+	}
+
+	// NamespaceResolver resolves the namespace of a request, it is implemented by the namespace cache. Only
+	// the namespaces it knows are tagged on the request metrics, so that callers can not add a metric series
+	// per made up namespace
+	NamespaceResolver interface {
+		GetNamespaceID(name string) (string, error)
+		GetNamespaceName(id string) (string, error)
+	}
+
+	namespaceGetter interface {
+		GetNamespace() string
+	}
+
+	namespaceIDGetter interface {
+		GetNamespaceId() string
+	}
+)
+
+var errDeadlineExpired = serviceerror.NewDeadlineExceeded("Request deadline expired before the request was handled.")
+
+// NewServerInterceptors returns the interceptor chain of a gRPC server: errors are converted to
+// gRPC status, requests are traced, panics are recovered as Internal errors and requests whose
+// deadline already expired are rejected, then the given service specific interceptors, such as
+// authentication and admission control, run in order. The requests they let through are measured
+// and sampled into the log, tagged with the name of their namespace. The internal services resolve the
+// namespace ID of their requests to its name through namespaces.
+func NewServerInterceptors(
+	metricsClient metrics.Client,
+	logger log.Logger,
+	logSampleRate dynamicconfig.FloatPropertyFn,
+	namespaces NamespaceResolver,
+	interceptors ...grpc.UnaryServerInterceptor,
+) []grpc.UnaryServerInterceptor {
+
+	i := &serverInterceptors{
+		metricsClient: metricsClient,
+		logger:        logger,
+		logSampleRate: logSampleRate,
+		namespaces:    namespaces,
+	}
+	chain := []grpc.UnaryServerInterceptor{
+		StatusInterceptor,
+		tracingServerInterceptor,
+		i.recovery,
+		i.deadline,
+	}
+	chain = append(chain, interceptors...)
+	// the handler panics are recovered again inside telemetry, so they are measured as Internal errors
+	return append(chain, i.telemetry, i.recovery)
+}
+
+// StatusInterceptor converts the service errors returned by the handler to gRPC status
+func StatusInterceptor(
+	ctx context.Context,
+	req interface{},
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	resp, err := handler(ctx, req)
+	return resp, serviceerror.ToStatus(err).Err()
+}
+
+func (i *serverInterceptors) telemetry(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	method := MethodName(info.FullMethod)
+	namespace, known := i.resolveNamespace(req)
+	namespaceTag := metrics.NamespaceUnknownTag()
+	if known {
+		namespaceTag = metrics.NamespaceTag(namespace)
+	}
+	scope := i.metricsClient.Scope(metrics.RPCServerScope, metrics.RPCMethodTag(method), namespaceTag)
+	scope.IncCounter(metrics.RPCServerRequests)
+
+	startTime := time.Now()
+	resp, err := handler(ctx, req)
+	latency := time.Since(startTime)
+	scope.RecordTimer(metrics.RPCServerLatency, latency)
+
+	code := codes.OK
+	if err != nil {
+		code = serviceerror.ToStatus(err).Code()
+		scope.Tagged(metrics.RPCCodeTag(code.String())).IncCounter(metrics.RPCServerFailures)
+	}
+
+	switch {
+	case isServerError(code):
+		i.logger.Error("gRPC request failed",
+			tag.RPCMethod(method),
+			tag.WorkflowNamespace(namespace),
+			tag.RPCCode(code.String()),
+			tag.RPCLatency(latency),
+			tag.Error(err),
+		)
+	case rand.Float64() < i.logSampleRate():
+		i.logger.Info("gRPC request",
+			tag.RPCMethod(method),
+			tag.WorkflowNamespace(namespace),
+			tag.RPCCode(code.String()),
+			tag.RPCLatency(latency),
+		)
+	}
+	return resp, err
+}
+
+func (i *serverInterceptors) recovery(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp interface{}, retError error) {
+
+	defer func() {
+		if p := recover(); p != nil {
+			i.metricsClient.Scope(metrics.RPCServerScope, metrics.RPCMethodTag(MethodName(info.FullMethod))).
+				IncCounter(metrics.RPCServerPanics)
+			i.logger.Error("Panic in gRPC handler",
+				tag.RPCMethod(info.FullMethod),
+				tag.Value(p),
+				tag.SysStackTrace(string(debug.Stack())),
+			)
+			// the panic value may hold internal details, so it is not returned to the caller
+			resp, retError = nil, serviceerror.NewInternal(fmt.Sprintf("Internal error handling %v.", MethodName(info.FullMethod)))
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func (i *serverInterceptors) deadline(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		i.metricsClient.Scope(metrics.RPCServerScope, metrics.RPCMethodTag(MethodName(info.FullMethod))).
+			IncCounter(metrics.RPCServerNoDeadline)
+	} else if time.Until(deadline) <= 0 {
+		i.metricsClient.Scope(metrics.RPCServerScope, metrics.RPCMethodTag(MethodName(info.FullMethod))).
+			IncCounter(metrics.RPCServerDeadlineExpired)
+		return nil, errDeadlineExpired
+	}
+	return handler(ctx, req)
+}
+
+// MethodName returns the service and method of a gRPC full method name,
+// e.g. WorkflowService.StartWorkflowExecution for /temporal.workflowservice.WorkflowService/StartWorkflowExecution
+func MethodName(fullMethod string) string {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	service, method := fullMethod, ""
+	if index := strings.LastIndex(fullMethod, "/"); index >= 0 {
+		service, method = fullMethod[:index], fullMethod[index+1:]
+	}
+	if index := strings.LastIndex(service, "."); index >= 0 {
+		service = service[index+1:]
+	}
+	if method == "" {
+		return service
+	}
+	return service + "." + method
+}
+
+func namespaceOf(req interface{}) string {
+	if getter, ok := req.(namespaceGetter); ok {
+		return getter.GetNamespace()
+	}
+	return ""
+}
+
+// resolveNamespace returns the namespace name of a request and whether the namespace is known. Frontend
+// requests carry the namespace name, while the requests of history and matching carry the namespace ID
+func (i *serverInterceptors) resolveNamespace(req interface{}) (string, bool) {
+	if namespace := namespaceOf(req); namespace != "" {
+		if i.namespaces == nil {
+			return namespace, true
+		}
+		_, err := i.namespaces.GetNamespaceID(namespace)
+		return namespace, err == nil
+	}
+	if getter, ok := req.(namespaceIDGetter); ok && getter.GetNamespaceId() != "" && i.namespaces != nil {
+		namespace, err := i.namespaces.GetNamespaceName(getter.GetNamespaceId())
+		if err != nil {
+			return getter.GetNamespaceId(), false
+		}
+		return namespace, true
+	}
+	return "", false
+}
+
+// isServerError returns true for the status codes that mean the server failed, rather than the request
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,251 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"go.temporal.io/temporal-proto/serviceerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	interceptorSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		scope        tally.TestScope
+		interceptors []grpc.UnaryServerInterceptor
+		info         *grpc.UnaryServerInfo
+	}
+)
+
+func TestInterceptorSuite(t *testing.T) {
+	s := new(interceptorSuite)
+	suite.Run(t, s)
+}
+
+func (s *interceptorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.scope = tally.NewTestScope("test", nil)
+	s.interceptors = NewServerInterceptors(
+		metrics.NewClient(s.scope, metrics.Frontend),
+		loggerimpl.NewNopLogger(),
+		dynamicconfig.GetFloatPropertyFn(1.0),
+		testNamespaceResolver{"known-namespace-id": "known-namespace"},
+		s.rejectUnauthenticated,
+	)
+	s.info = &grpc.UnaryServerInfo{FullMethod: "/temporal.workflowservice.WorkflowService/StartWorkflowExecution"}
+}
+
+func (s *interceptorSuite) rejectUnauthenticated(
+	ctx context.Context,
+	req interface{},
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	if req == unauthenticatedRequest {
+		return nil, serviceerror.NewPermissionDenied("unauthenticated")
+	}
+	return handler(ctx, req)
+}
+
+func (s *interceptorSuite) invoke(ctx context.Context, handler grpc.UnaryHandler) (interface{}, error) {
+	return s.invokeWithRequest(ctx, nil, handler)
+}
+
+func (s *interceptorSuite) invokeWithRequest(ctx context.Context, req interface{}, handler grpc.UnaryHandler) (interface{}, error) {
+	for i := len(s.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := s.interceptors[i], handler
+		handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return interceptor(ctx, req, s.info, next)
+		}
+	}
+	return handler(ctx, req)
+}
+
+func (s *interceptorSuite) counterWithTag(name string, key string, value string) int64 {
+	var total int64
+	for _, counter := range s.scope.Snapshot().Counters() {
+		if counter.Name() == "test."+name && counter.Tags()[key] == value {
+			total += counter.Value()
+		}
+	}
+	return total
+}
+
+func (s *interceptorSuite) counter(name string) int64 {
+	var total int64
+	for _, counter := range s.scope.Snapshot().Counters() {
+		if counter.Name() == "test."+name {
+			total += counter.Value()
+		}
+	}
+	return total
+}
+
+func (s *interceptorSuite) TestSuccess() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	resp, err := s.invoke(ctx, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "response", nil
+	})
+	s.NoError(err)
+	s.Equal("response", resp)
+	s.Equal(int64(1), s.counter("rpc_server_requests"))
+	s.Equal(int64(0), s.counter("rpc_server_errors"))
+	s.Equal(int64(0), s.counter("rpc_server_no_deadline"))
+}
+
+func (s *interceptorSuite) TestErrorIsConvertedToStatus() {
+	_, err := s.invoke(context.Background(), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, serviceerror.NewNotFound("not found")
+	})
+	st, ok := status.FromError(err)
+	s.True(ok)
+	s.Equal(codes.NotFound, st.Code())
+	s.Equal(int64(1), s.counter("rpc_server_errors"))
+	s.Equal(int64(1), s.counter("rpc_server_no_deadline"))
+}
+
+func (s *interceptorSuite) TestPanicIsRecovered() {
+	_, err := s.invoke(context.Background(), func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("test panic")
+	})
+	st, ok := status.FromError(err)
+	s.True(ok)
+	s.Equal(codes.Internal, st.Code())
+	s.NotContains(st.Message(), "test panic")
+	s.Equal(int64(1), s.counter("rpc_server_panics"))
+	s.Equal(int64(1), s.counter("rpc_server_errors"))
+}
+
+func (s *interceptorSuite) TestExpiredDeadlineIsRejected() {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	_, err := s.invoke(ctx, func(ctx context.Context, req interface{}) (interface{}, error) {
+		s.Fail("handler must not be called")
+		return nil, nil
+	})
+	s.Equal(codes.DeadlineExceeded, status.Code(err))
+	s.Equal(int64(1), s.counter("rpc_server_deadline_expired"))
+}
+
+func (s *interceptorSuite) TestMethodName() {
+	s.Equal("WorkflowService.StartWorkflowExecution", MethodName("/temporal.workflowservice.WorkflowService/StartWorkflowExecution"))
+	s.Equal("Health.Check", MethodName("/grpc.health.v1.Health/Check"))
+	s.Equal("unknown", MethodName("unknown"))
+}
+
+func (s *interceptorSuite) TestUnknownNamespaceIsNotTagged() {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "response", nil
+	}
+	_, err := s.invokeWithRequest(context.Background(), &namespaceRequest{namespace: "known-namespace"}, handler)
+	s.NoError(err)
+	_, err = s.invokeWithRequest(context.Background(), &namespaceRequest{namespace: "made-up-namespace"}, handler)
+	s.NoError(err)
+
+	s.Equal(int64(1), s.counterWithTag("rpc_server_requests", "namespace", "known-namespace"))
+	s.Equal(int64(0), s.counterWithTag("rpc_server_requests", "namespace", "made-up-namespace"))
+	s.Equal(int64(2), s.counter("rpc_server_requests"))
+}
+
+func (s *interceptorSuite) TestRejectedRequestIsNotMeasured() {
+	_, err := s.invokeWithRequest(context.Background(), unauthenticatedRequest, func(ctx context.Context, req interface{}) (interface{}, error) {
+		s.Fail("handler must not be called")
+		return nil, nil
+	})
+	s.Equal(codes.PermissionDenied, status.Code(err))
+	s.Equal(int64(0), s.counter("rpc_server_requests"))
+}
+
+func (s *interceptorSuite) TestNamespaceIDIsResolved() {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "response", nil
+	}
+	_, err := s.invokeWithRequest(context.Background(), &namespaceIDRequest{namespaceID: "known-namespace-id"}, handler)
+	s.NoError(err)
+	_, err = s.invokeWithRequest(context.Background(), &namespaceIDRequest{namespaceID: "made-up-namespace-id"}, handler)
+	s.NoError(err)
+
+	s.Equal(int64(1), s.counterWithTag("rpc_server_requests", "namespace", "known-namespace"))
+	s.Equal(int64(0), s.counterWithTag("rpc_server_requests", "namespace", "known-namespace-id"))
+	s.Equal(int64(0), s.counterWithTag("rpc_server_requests", "namespace", "made-up-namespace-id"))
+	s.Equal(int64(2), s.counter("rpc_server_requests"))
+}
+
+type (
+	namespaceRequest struct {
+		namespace string
+	}
+
+	namespaceIDRequest struct {
+		namespaceID string
+	}
+
+	// testNamespaceResolver maps the namespace IDs to the names of the known namespaces
+	testNamespaceResolver map[string]string
+)
+
+func (r *namespaceRequest) GetNamespace() string {
+	return r.namespace
+}
+
+func (r *namespaceIDRequest) GetNamespaceId() string {
+	return r.namespaceID
+}
+
+func (r testNamespaceResolver) GetNamespaceID(name string) (string, error) {
+	for id, namespace := range r {
+		if namespace == name {
+			return id, nil
+		}
+	}
+	return "", serviceerror.NewNotFound("namespace not found")
+}
+
+func (r testNamespaceResolver) GetNamespaceName(id string) (string, error) {
+	if namespace, ok := r[id]; ok {
+		return namespace, nil
+	}
+	return "", serviceerror.NewNotFound("namespace not found")
+}
+
+var unauthenticatedRequest = &namespaceRequest{namespace: "known-namespace"}
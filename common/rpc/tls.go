@@ -0,0 +1,354 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/temporalio/temporal/common/auth"
+)
+
+const (
+	defaultTLSRefreshInterval = time.Minute
+)
+
+type (
+	// TLSConfigProvider builds TLS configs for gRPC servers and clients, certificates are
+	// reloaded from disk when the files change so they can be rotated without restart.
+	// A nil provider returns nil configs, which means plaintext connections
+	TLSConfigProvider struct {
+		config          *auth.RootTLS
+		refreshInterval time.Duration
+
+		sync.Mutex
+		files map[string]*tlsFiles
+	}
+
+	// reloadingCredentials are gRPC client credentials building a new TLS config on
+	// every handshake, so that rotated client certificates and root CAs are picked up
+	reloadingCredentials struct {
+		credentials.TransportCredentials
+		newConfig func() (*tls.Config, error)
+	}
+
+	// tlsFiles caches the content of a set of certificate files
+	tlsFiles struct {
+		sync.Mutex
+		paths     []string
+		modTimes  []time.Time
+		lastCheck time.Time
+		load      func(paths []string) (interface{}, error)
+		value     interface{}
+	}
+)
+
+// NewTLSConfigProvider creates a TLSConfigProvider, all configured certificates are loaded
+// once to fail fast on invalid configuration
+func NewTLSConfigProvider(config *auth.RootTLS) (*TLSConfigProvider, error) {
+	refreshInterval := config.RefreshInterval
+	if refreshInterval <= 0 {
+		refreshInterval = defaultTLSRefreshInterval
+	}
+	p := &TLSConfigProvider{
+		config:          config,
+		refreshInterval: refreshInterval,
+		files:           make(map[string]*tlsFiles),
+	}
+	for _, group := range []*auth.GroupTLS{&config.Internode, &config.Frontend} {
+		if !group.IsEnabled() {
+			continue
+		}
+		if err := p.validate(group); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// GetInternodeServerConfig returns the TLS config of history and matching servers
+func (p *TLSConfigProvider) GetInternodeServerConfig() *tls.Config {
+	if p == nil || !p.config.Internode.IsEnabled() {
+		return nil
+	}
+	return p.newServerConfig(&p.config.Internode.Server)
+}
+
+// GetInternodeClientCredentials returns the credentials used to connect to history and matching
+func (p *TLSConfigProvider) GetInternodeClientCredentials() (credentials.TransportCredentials, error) {
+	if p == nil || !p.config.Internode.IsEnabled() {
+		return nil, nil
+	}
+	return p.newClientCredentials(&p.config.Internode.Client)
+}
+
+// GetFrontendServerConfig returns the TLS config of frontend servers
+func (p *TLSConfigProvider) GetFrontendServerConfig() *tls.Config {
+	if p == nil || !p.config.Frontend.IsEnabled() {
+		return nil
+	}
+	return p.newServerConfig(&p.config.Frontend.Server)
+}
+
+// GetFrontendClientCredentials returns the credentials used to connect to frontend
+func (p *TLSConfigProvider) GetFrontendClientCredentials() (credentials.TransportCredentials, error) {
+	if p == nil || !p.config.Frontend.IsEnabled() {
+		return nil, nil
+	}
+	return p.newClientCredentials(&p.config.Frontend.Client)
+}
+
+// NewClientCredentials returns the credentials of a client connecting to a TLS endpoint
+// outside of a server process, such as tctl. A nil config means a plaintext connection
+func NewClientCredentials(config *auth.ClientTLS) (credentials.TransportCredentials, error) {
+	if config == nil {
+		return nil, nil
+	}
+	p, err := NewTLSConfigProvider(&auth.RootTLS{})
+	if err != nil {
+		return nil, err
+	}
+	return p.newClientCredentials(config)
+}
+
+func (p *TLSConfigProvider) validate(group *auth.GroupTLS) error {
+	if _, err := p.certificate(group.Server.CertFile, group.Server.KeyFile); err != nil {
+		return err
+	}
+	if group.Server.RequireClientAuth && len(group.Server.ClientCAFiles) == 0 {
+		return fmt.Errorf("clientCaFiles must be set when requireClientAuth is enabled")
+	}
+	if len(group.Server.ClientCAFiles) > 0 {
+		if _, err := p.certPool(group.Server.ClientCAFiles); err != nil {
+			return err
+		}
+	}
+	if group.Client.CertFile != "" {
+		if _, err := p.certificate(group.Client.CertFile, group.Client.KeyFile); err != nil {
+			return err
+		}
+	}
+	_, err := p.newClientConfig(&group.Client)
+	return err
+}
+
+// newServerConfig builds the config on every handshake, which picks up rotated certificates
+func (p *TLSConfigProvider) newServerConfig(config *auth.ServerTLS) *tls.Config {
+	return &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, err := p.certificate(config.CertFile, config.KeyFile)
+			if err != nil {
+				return nil, err
+			}
+			serverConfig := &tls.Config{
+				Certificates: []tls.Certificate{*cert},
+				MinVersion:   tls.VersionTLS12,
+			}
+			if len(config.ClientCAFiles) > 0 {
+				pool, err := p.certPool(config.ClientCAFiles)
+				if err != nil {
+					return nil, err
+				}
+				serverConfig.ClientCAs = pool
+				serverConfig.ClientAuth = tls.VerifyClientCertIfGiven
+			}
+			if config.RequireClientAuth {
+				serverConfig.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return serverConfig, nil
+		},
+	}
+}
+
+// newClientCredentials validates the client config once to fail fast on invalid configuration
+func (p *TLSConfigProvider) newClientCredentials(config *auth.ClientTLS) (credentials.TransportCredentials, error) {
+	clientConfig, err := p.newClientConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &reloadingCredentials{
+		TransportCredentials: credentials.NewTLS(clientConfig),
+		newConfig: func() (*tls.Config, error) {
+			return p.newClientConfig(config)
+		},
+	}, nil
+}
+
+// newClientConfig builds a client config, both root CAs and the client certificate
+// are served from the reloaded certificate files
+func (p *TLSConfigProvider) newClientConfig(config *auth.ClientTLS) (*tls.Config, error) {
+	clientConfig := &tls.Config{
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.DisableHostVerification,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if len(config.RootCAFiles) > 0 {
+		pool, err := p.certPool(config.RootCAFiles)
+		if err != nil {
+			return nil, err
+		}
+		clientConfig.RootCAs = pool
+	}
+	if config.CertFile != "" {
+		clientConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return p.certificate(config.CertFile, config.KeyFile)
+		}
+	}
+	return clientConfig, nil
+}
+
+// ClientHandshake performs the handshake with the TLS config built from the current certificate files
+func (c *reloadingCredentials) ClientHandshake(
+	ctx context.Context,
+	authority string,
+	rawConn net.Conn,
+) (net.Conn, credentials.AuthInfo, error) {
+
+	config, err := c.newConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	return credentials.NewTLS(config).ClientHandshake(ctx, authority, rawConn)
+}
+
+// Clone returns a copy of the credentials
+func (c *reloadingCredentials) Clone() credentials.TransportCredentials {
+	return &reloadingCredentials{
+		TransportCredentials: c.TransportCredentials.Clone(),
+		newConfig:            c.newConfig,
+	}
+}
+
+func (p *TLSConfigProvider) certificate(certFile string, keyFile string) (*tls.Certificate, error) {
+	value, err := p.getFiles([]string{certFile, keyFile}, loadCertificate).get(p.refreshInterval)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*tls.Certificate), nil
+}
+
+func (p *TLSConfigProvider) certPool(caFiles []string) (*x509.CertPool, error) {
+	value, err := p.getFiles(caFiles, loadCertPool).get(p.refreshInterval)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*x509.CertPool), nil
+}
+
+func (p *TLSConfigProvider) getFiles(paths []string, load func([]string) (interface{}, error)) *tlsFiles {
+	key := strings.Join(paths, ",")
+	p.Lock()
+	defer p.Unlock()
+	files, ok := p.files[key]
+	if !ok {
+		files = &tlsFiles{paths: paths, load: load}
+		p.files[key] = files
+	}
+	return files
+}
+
+// get returns the cached value, files are reloaded when their modification time changed
+// since the last load. Modification times are checked at most once per refresh interval
+func (f *tlsFiles) get(refreshInterval time.Duration) (interface{}, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	now := time.Now()
+	if f.value != nil && now.Sub(f.lastCheck) < refreshInterval {
+		return f.value, nil
+	}
+
+	modTimes := make([]time.Time, len(f.paths))
+	for i, path := range f.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			if f.value != nil {
+				// keep serving the last good certificate while the files are being replaced
+				return f.value, nil
+			}
+			return nil, err
+		}
+		modTimes[i] = info.ModTime()
+	}
+	f.lastCheck = now
+	if f.value != nil && equalTimes(f.modTimes, modTimes) {
+		return f.value, nil
+	}
+
+	value, err := f.load(f.paths)
+	if err != nil {
+		if f.value != nil {
+			return f.value, nil
+		}
+		return nil, err
+	}
+	f.value = value
+	f.modTimes = modTimes
+	return f.value, nil
+}
+
+func loadCertificate(paths []string) (interface{}, error) {
+	cert, err := tls.LoadX509KeyPair(paths[0], paths[1])
+	if err != nil {
+		return nil, fmt.Errorf("unable to load certificate %v: %v", paths[0], err)
+	}
+	return &cert, nil
+}
+
+func loadCertPool(paths []string) (interface{}, error) {
+	pool := x509.NewCertPool()
+	for _, path := range paths {
+		pem, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("unable to parse CA certificates from %v", path)
+		}
+	}
+	return pool, nil
+}
+
+func equalTimes(a []time.Time, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,226 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/temporalio/temporal/common/auth"
+)
+
+type (
+	tlsSuite struct {
+		suite.Suite
+		dir string
+	}
+)
+
+func TestTLSSuite(t *testing.T) {
+	suite.Run(t, new(tlsSuite))
+}
+
+func (s *tlsSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "tls")
+	s.NoError(err)
+	s.dir = dir
+}
+
+func (s *tlsSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *tlsSuite) TestNilProvider() {
+	var p *TLSConfigProvider
+	s.Nil(p.GetFrontendServerConfig())
+	s.Nil(p.GetInternodeServerConfig())
+	clientCredentials, err := p.GetInternodeClientCredentials()
+	s.NoError(err)
+	s.Nil(clientCredentials)
+}
+
+func (s *tlsSuite) TestInvalidConfig() {
+	_, err := NewTLSConfigProvider(&auth.RootTLS{
+		Internode: auth.GroupTLS{Server: auth.ServerTLS{CertFile: filepath.Join(s.dir, "missing.pem")}},
+	})
+	s.Error(err)
+
+	cert, key := s.writeCertificate("server", 1)
+	_, err = NewTLSConfigProvider(&auth.RootTLS{
+		Internode: auth.GroupTLS{Server: auth.ServerTLS{CertFile: cert, KeyFile: key, RequireClientAuth: true}},
+	})
+	s.Error(err)
+}
+
+func (s *tlsSuite) TestMutualTLS() {
+	cert, key := s.writeCertificate("server", 1)
+	p, err := NewTLSConfigProvider(&auth.RootTLS{
+		Internode: auth.GroupTLS{
+			Server: auth.ServerTLS{CertFile: cert, KeyFile: key, ClientCAFiles: []string{cert}, RequireClientAuth: true},
+			Client: auth.ClientTLS{CertFile: cert, KeyFile: key, RootCAFiles: []string{cert}, ServerName: "server"},
+		},
+	})
+	s.NoError(err)
+	s.Nil(p.GetFrontendServerConfig())
+
+	clientCredentials, err := p.GetInternodeClientCredentials()
+	s.NoError(err)
+	s.NoError(s.handshake(p.GetInternodeServerConfig(), clientCredentials))
+
+	// client without certificate is rejected
+	clientCredentials, err = NewClientCredentials(&auth.ClientTLS{RootCAFiles: []string{cert}, ServerName: "server"})
+	s.NoError(err)
+	s.Error(s.handshake(p.GetInternodeServerConfig(), clientCredentials))
+}
+
+func (s *tlsSuite) TestRootCAReload() {
+	cert, key := s.writeCertificate("server", 1)
+	otherCert, _ := s.writeCertificate("other", 1)
+	caFile := filepath.Join(s.dir, "ca.pem")
+	s.copyFile(otherCert, caFile)
+
+	p, err := NewTLSConfigProvider(&auth.RootTLS{
+		RefreshInterval: time.Nanosecond,
+		Frontend: auth.GroupTLS{
+			Server: auth.ServerTLS{CertFile: cert, KeyFile: key},
+			Client: auth.ClientTLS{RootCAFiles: []string{caFile}},
+		},
+	})
+	s.NoError(err)
+	clientCredentials, err := p.GetFrontendClientCredentials()
+	s.NoError(err)
+	s.Error(s.handshake(p.GetFrontendServerConfig(), clientCredentials))
+
+	// rotated root CAs are picked up by existing credentials
+	s.copyFile(cert, caFile)
+	future := time.Now().Add(time.Minute)
+	s.NoError(os.Chtimes(caFile, future, future))
+	s.NoError(s.handshake(p.GetFrontendServerConfig(), clientCredentials))
+}
+
+func (s *tlsSuite) TestCertificateReload() {
+	cert, key := s.writeCertificate("server", 1)
+	p, err := NewTLSConfigProvider(&auth.RootTLS{
+		RefreshInterval: time.Nanosecond,
+		Frontend: auth.GroupTLS{
+			Server: auth.ServerTLS{CertFile: cert, KeyFile: key},
+		},
+	})
+	s.NoError(err)
+
+	loaded, err := p.certificate(cert, key)
+	s.NoError(err)
+	s.Equal(int64(1), s.serialNumber(loaded))
+
+	s.writeCertificate("server", 2)
+	future := time.Now().Add(time.Minute)
+	s.NoError(os.Chtimes(cert, future, future))
+	loaded, err = p.certificate(cert, key)
+	s.NoError(err)
+	s.Equal(int64(2), s.serialNumber(loaded))
+}
+
+func (s *tlsSuite) handshake(serverConfig *tls.Config, clientCredentials credentials.TransportCredentials) error {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	s.NoError(err)
+	defer listener.Close()
+
+	serverErrCh := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErrCh <- err
+			return
+		}
+		defer conn.Close()
+		serverErrCh <- conn.(*tls.Conn).Handshake()
+	}()
+
+	rawConn, err := net.Dial("tcp", listener.Addr().String())
+	s.NoError(err)
+	conn, _, err := clientCredentials.ClientHandshake(context.Background(), "server", rawConn)
+	if err == nil {
+		defer conn.Close()
+	} else {
+		rawConn.Close()
+	}
+	if serverErr := <-serverErrCh; serverErr != nil {
+		return serverErr
+	}
+	return err
+}
+
+func (s *tlsSuite) copyFile(src string, dst string) {
+	content, err := ioutil.ReadFile(src)
+	s.NoError(err)
+	s.NoError(ioutil.WriteFile(dst, content, 0600))
+}
+
+func (s *tlsSuite) serialNumber(cert *tls.Certificate) int64 {
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	s.NoError(err)
+	return parsed.SerialNumber.Int64()
+}
+
+// writeCertificate writes a self signed certificate which is also used as its own CA
+func (s *tlsSuite) writeCertificate(name string, serialNumber int64) (string, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serialNumber),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{name},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	s.NoError(err)
+
+	certFile := filepath.Join(s.dir, name+".pem")
+	keyFile := filepath.Join(s.dir, name+".key")
+	s.NoError(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	s.NoError(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600))
+	return certFile, keyFile
+}
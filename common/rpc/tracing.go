@@ -0,0 +1,135 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"go.temporal.io/temporal-proto/serviceerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	namespaceSpanTag = "namespace"
+	codeSpanTag      = "rpc.code"
+)
+
+// metadataCarrier lets an opentracing tracer read and write the span context in gRPC metadata
+type metadataCarrier metadata.MD
+
+// tracingServerInterceptor continues the trace of the caller carried in the request metadata, or
+// starts a new one, with a span covering the handling of the request. The span is passed to the
+// handler in the context. Nothing is done until a global tracer is registered.
+func tracingServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	if !opentracing.IsGlobalTracerRegistered() {
+		return handler(ctx, req)
+	}
+
+	tracer := opentracing.GlobalTracer()
+	opts := []opentracing.StartSpanOption{ext.SpanKindRPCServer}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if parent, err := tracer.Extract(opentracing.TextMap, metadataCarrier(md)); err == nil {
+			opts = append(opts, opentracing.ChildOf(parent))
+		}
+	}
+	span := tracer.StartSpan(MethodName(info.FullMethod), opts...)
+	defer span.Finish()
+	if namespace := namespaceOf(req); namespace != "" {
+		span.SetTag(namespaceSpanTag, namespace)
+	}
+
+	resp, err := handler(opentracing.ContextWithSpan(ctx, span), req)
+	setSpanError(span, err)
+	return resp, err
+}
+
+// tracingClientInterceptor records a span for the outgoing request and passes its context to the
+// server in the request metadata, requests made outside of a trace are not traced
+func tracingClientInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+
+	parent := opentracing.SpanFromContext(ctx)
+	if parent == nil {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	tracer := parent.Tracer()
+	span := tracer.StartSpan(MethodName(method), opentracing.ChildOf(parent.Context()), ext.SpanKindRPCClient)
+	defer span.Finish()
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	if err := tracer.Inject(span.Context(), opentracing.TextMap, metadataCarrier(md)); err == nil {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+
+	err := invoker(opentracing.ContextWithSpan(ctx, span), method, req, reply, cc, opts...)
+	setSpanError(span, err)
+	return err
+}
+
+func setSpanError(span opentracing.Span, err error) {
+	if err == nil {
+		return
+	}
+	ext.Error.Set(span, true)
+	span.SetTag(codeSpanTag, serviceerror.ToStatus(err).Code().String())
+}
+
+// Set implements opentracing.TextMapWriter
+func (c metadataCarrier) Set(key, val string) {
+	metadata.MD(c).Set(key, val)
+}
+
+// ForeachKey implements opentracing.TextMapReader
+func (c metadataCarrier) ForeachKey(handler func(key, val string) error) error {
+	for key, values := range c {
+		for _, value := range values {
+			if err := handler(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
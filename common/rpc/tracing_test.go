@@ -0,0 +1,123 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/temporal-proto/serviceerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type (
+	tracingSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		tracer *mocktracer.MockTracer
+	}
+)
+
+func TestTracingSuite(t *testing.T) {
+	s := new(tracingSuite)
+	suite.Run(t, s)
+}
+
+func (s *tracingSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.tracer = mocktracer.New()
+	opentracing.SetGlobalTracer(s.tracer)
+}
+
+func (s *tracingSuite) TearDownTest() {
+	opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+}
+
+func (s *tracingSuite) TestPropagation() {
+	root := s.tracer.StartSpan("root")
+	ctx := opentracing.ContextWithSpan(context.Background(), root)
+
+	// the invoker hands the outgoing metadata to the server like the transport would
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		serverCtx := metadata.NewIncomingContext(context.Background(), md)
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+		_, err := tracingServerInterceptor(serverCtx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			s.NotNil(opentracing.SpanFromContext(ctx))
+			return nil, serviceerror.NewNotFound("not found")
+		})
+		return err
+	}
+	err := tracingClientInterceptor(ctx, "/temporal.historyservice.HistoryService/StartWorkflowExecution", nil, nil, nil, invoker)
+	s.Error(err)
+	root.Finish()
+
+	spans := s.tracer.FinishedSpans()
+	s.Len(spans, 3)
+	server, client, rootSpan := spans[0], spans[1], spans[2]
+	s.Equal("HistoryService.StartWorkflowExecution", server.OperationName)
+	s.Equal("HistoryService.StartWorkflowExecution", client.OperationName)
+	s.Equal(rootSpan.SpanContext.TraceID, client.SpanContext.TraceID)
+	s.Equal(rootSpan.SpanContext.TraceID, server.SpanContext.TraceID)
+	s.Equal(rootSpan.SpanContext.SpanID, client.ParentID)
+	s.Equal(client.SpanContext.SpanID, server.ParentID)
+	s.Equal(ext.SpanKindRPCServerEnum, server.Tag(string(ext.SpanKind)))
+	s.Equal(true, server.Tag("error"))
+	s.Equal("NotFound", server.Tag(codeSpanTag))
+}
+
+func (s *tracingSuite) TestClientWithoutTrace() {
+	called := false
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		called = true
+		_, ok := metadata.FromOutgoingContext(ctx)
+		s.False(ok)
+		return nil
+	}
+	s.NoError(tracingClientInterceptor(context.Background(), "/temporal.historyservice.HistoryService/StartWorkflowExecution", nil, nil, nil, invoker))
+	s.True(called)
+	s.Empty(s.tracer.FinishedSpans())
+}
+
+func (s *tracingSuite) TestServerStartsTrace() {
+	info := &grpc.UnaryServerInfo{FullMethod: "/temporal.workflowservice.WorkflowService/StartWorkflowExecution"}
+	_, err := tracingServerInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	s.NoError(err)
+
+	spans := s.tracer.FinishedSpans()
+	s.Len(spans, 1)
+	s.Equal("WorkflowService.StartWorkflowExecution", spans[0].OperationName)
+	s.Zero(spans[0].ParentID)
+	s.Nil(spans[0].Tag("error"))
+}
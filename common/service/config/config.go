@@ -31,10 +31,15 @@ import (
 	"github.com/uber-go/tally/m3"
 	"github.com/uber-go/tally/prometheus"
 
+	"github.com/temporalio/temporal/common/audit"
 	"github.com/temporalio/temporal/common/auth"
 	"github.com/temporalio/temporal/common/elasticsearch"
+	"github.com/temporalio/temporal/common/lifecycle"
 	"github.com/temporalio/temporal/common/messaging"
+	"github.com/temporalio/temporal/common/payload"
+	"github.com/temporalio/temporal/common/profiling"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 const (
@@ -84,12 +89,18 @@ type (
 	PProf struct {
 		// Port is the port on which the PProf will bind to
 		Port int `yaml:"port"`
+		// Periodic is the config of the profiles periodically captured to a directory, disabled when nil
+		Periodic *profiling.Config `yaml:"periodic"`
 	}
 
 	// RPC contains the rpc config items
 	RPC struct {
 		// GRPCPort is the port  on which gRPC will listen
 		GRPCPort int `yaml:"grpcPort"`
+		// HTTPPort is the port on which the frontend HTTP gateway will listen, the gateway is disabled when zero
+		HTTPPort int `yaml:"httpPort"`
+		// HealthPort is the port on which the HTTP liveness and readiness endpoints will listen, they are disabled when zero
+		HealthPort int `yaml:"healthPort"`
 		// Port used for membership listener
 		MembershipPort int `yaml:"membershipPort"`
 		// BindOnLocalHost is true if localhost is the bind address
@@ -110,6 +121,51 @@ type (
 		Ringpop Ringpop `yaml:"ringpop"`
 		// PProf is the PProf configuration
 		PProf PProf `yaml:"pprof"`
+		// TLS is the TLS configuration of the gRPC endpoints
+		TLS auth.RootTLS `yaml:"tls"`
+		// Authorization is the authentication and authorization configuration of the frontend
+		Authorization Authorization `yaml:"authorization"`
+		// Audit is the audit log configuration of the frontend
+		Audit audit.Config `yaml:"audit"`
+		// Tracing is the distributed tracing configuration of the services
+		Tracing tracing.Config `yaml:"tracing"`
+		// LifecycleEvents is the configuration of the workflow lifecycle events published by the history service
+		LifecycleEvents lifecycle.Config `yaml:"lifecycleEvents"`
+	}
+
+	// Authorization contains the config items of frontend authentication and authorization
+	Authorization struct {
+		// JWTKeyProvider configures the keys used to validate JWT bearer tokens,
+		// authentication is enabled when key source files are set or the frontend
+		// verifies client certificates
+		JWTKeyProvider JWTKeyProvider `yaml:"jwtKeyProvider"`
+		// TokenIssuer is the iss claim bearer tokens must have, required when key source files are set
+		TokenIssuer string `yaml:"tokenIssuer"`
+		// TokenAudience must be the aud claim, or one of the aud claims, of bearer tokens,
+		// required when key source files are set
+		TokenAudience string `yaml:"tokenAudience"`
+		// PermissionsClaimName is the token claim holding the permissions of the caller
+		PermissionsClaimName string `yaml:"permissionsClaimName"`
+		// PeerPermissions are the "<namespace>:<role>" permissions of callers authenticated
+		// by client certificate, keyed by certificate common name. The frontend client certificate
+		// of the cluster is granted system admin, remote clusters using another certificate
+		// must be granted "system:admin" to replicate
+		PeerPermissions map[string][]string `yaml:"peerPermissions"`
+		// Authorizer is the authorizer of frontend requests, "default" for the role based
+		// authorizer, all requests are allowed when empty
+		Authorizer string `yaml:"authorizer"`
+		// PolicyFile is the optional policy file of the default authorizer
+		PolicyFile string `yaml:"policyFile"`
+		// PolicyRefreshInterval is how often the policy file is reloaded, it is not reloaded when zero
+		PolicyRefreshInterval time.Duration `yaml:"policyRefreshInterval"`
+	}
+
+	// JWTKeyProvider contains the config items of the token signing keys
+	JWTKeyProvider struct {
+		// KeySourceFiles are paths of JWKS files holding the public keys
+		KeySourceFiles []string `yaml:"keySourceFiles"`
+		// RefreshInterval is how often the key files are reloaded, files are not reloaded when zero
+		RefreshInterval time.Duration `yaml:"refreshInterval"`
 	}
 
 	// Ringpop contains the ringpop config items
@@ -144,6 +200,8 @@ type (
 		VisibilityConfig *VisibilityConfig `yaml:"-" json:"-"`
 		// TransactionSizeLimit is the largest allowed transaction size
 		TransactionSizeLimit dynamicconfig.IntPropertyFn `yaml:"-" json:"-"`
+		// PayloadEncryption is the config of payload encryption at rest, payloads are stored unencrypted when nil
+		PayloadEncryption *payload.EncryptionConfig `yaml:"payloadEncryption"`
 	}
 
 	// DataStore is the configuration for a single datastore
@@ -246,6 +304,36 @@ type (
 		Level string `yaml:"level"`
 		// OutputFile is the path to the log output file
 		OutputFile string `yaml:"outputFile"`
+		// Encoding is the log encoding, json (default) or console
+		Encoding string `yaml:"encoding"`
+		// Levels overrides the log level of packages and their subpackages, keyed by package path
+		// in the module, e.g. service/matching
+		Levels map[string]string `yaml:"levels"`
+		// Sampling is the sampling of repeated log messages, disabled when nil
+		Sampling *LogSampling `yaml:"sampling"`
+		// Rotation is the rotation of the log output file, disabled when nil
+		Rotation *LogRotation `yaml:"rotation"`
+	}
+
+	// LogSampling contains the config items for log sampling, every second the first Initial
+	// entries with the same level and message are logged, then every Thereafter-th one
+	LogSampling struct {
+		// Initial is the number of entries logged per second before sampling starts
+		Initial int `yaml:"initial"`
+		// Thereafter is the sampling rate once sampling started
+		Thereafter int `yaml:"thereafter"`
+	}
+
+	// LogRotation contains the config items for rotating the log output file
+	LogRotation struct {
+		// MaxSizeMB is the size in megabytes at which the file is rotated, defaults to 100
+		MaxSizeMB int `yaml:"maxSizeMB"`
+		// MaxBackups is the number of rotated files to keep, all are kept when 0
+		MaxBackups int `yaml:"maxBackups"`
+		// MaxAgeDays is the number of days to keep rotated files, all are kept when 0
+		MaxAgeDays int `yaml:"maxAgeDays"`
+		// Compress is true if rotated files need to be gzipped
+		Compress bool `yaml:"compress"`
 	}
 
 	// ClusterMetadata contains the all cluster which participated in cross DC
@@ -425,6 +513,12 @@ type (
 
 // Validate validates this config
 func (c *Config) Validate() error {
+	if err := c.Log.Validate(); err != nil {
+		return err
+	}
+	if err := c.Server.PProf.Periodic.Validate(); err != nil {
+		return err
+	}
 	if err := c.Persistence.Validate(); err != nil {
 		return err
 	}
@@ -25,18 +25,47 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 const fileMode = os.FileMode(0644)
 
+const (
+	logEncodingJSON    = "json"
+	logEncodingConsole = "console"
+
+	defaultLogRotationMaxSizeMB = 100
+
+	// samplingTick is the sampling period of zap.Config
+	samplingTick = time.Second
+)
+
+var (
+	// rotatingFiles are shared by the loggers writing to the same file, so they rotate it once
+	rotatingFilesLock sync.Mutex
+	rotatingFiles     = make(map[string]*lumberjack.Logger)
+
+	logLevelNames = []string{"debug", "info", "warn", "error", "fatal"}
+)
+
 // NewZapLogger builds and returns a new zap
 // logger for this logging configuration
 func (cfg *Logger) NewZapLogger() *zap.Logger {
+	return cfg.NewLeveledZapLogger(cfg.NewLogLevels())
+}
+
+// NewLeveledZapLogger builds and returns a new zap logger for this logging configuration
+// whose entries are filtered by the given levels, which can be shared by the loggers of a process
+func (cfg *Logger) NewLeveledZapLogger(levels *LogLevels) *zap.Logger {
 	encodeConfig := zapcore.EncoderConfig{
 		TimeKey:        "ts",
 		LevelKey:       "level",
@@ -51,6 +80,11 @@ func (cfg *Logger) NewZapLogger() *zap.Logger {
 		EncodeCaller:   nil,
 	}
 
+	encoding := logEncodingJSON
+	if cfg.Encoding == logEncodingConsole {
+		encoding = logEncodingConsole
+	}
+
 	outputPath := "stderr"
 	if len(cfg.OutputFile) > 0 {
 		outputPath = cfg.OutputFile
@@ -59,19 +93,101 @@ func (cfg *Logger) NewZapLogger() *zap.Logger {
 		}
 	}
 
+	if cfg.Rotation != nil && outputPath == cfg.OutputFile {
+		return cfg.newRotatingZapLogger(levels, encoding, encodeConfig)
+	}
+
+	var sampling *zap.SamplingConfig
+	if cfg.Sampling != nil {
+		sampling = &zap.SamplingConfig{
+			Initial:    cfg.Sampling.Initial,
+			Thereafter: cfg.Sampling.Thereafter,
+		}
+	}
+
+	// the levels decide which entries are logged, the zap level lets all of them through
 	config := zap.Config{
-		Level:            zap.NewAtomicLevelAt(parseZapLevel(cfg.Level)),
+		Level:            zap.NewAtomicLevelAt(zapcore.DebugLevel),
 		Development:      false,
-		Sampling:         nil, // consider exposing this to config for our external customer
-		Encoding:         "json",
+		Sampling:         sampling,
+		Encoding:         encoding,
 		EncoderConfig:    encodeConfig,
 		OutputPaths:      []string{outputPath},
 		ErrorOutputPaths: []string{outputPath},
 	}
-	logger, _ := config.Build()
+	logger, _ := config.Build(zap.WrapCore(levels.wrap))
 	return logger
 }
 
+// Validate validates the logging configuration
+func (cfg *Logger) Validate() error {
+	switch cfg.Encoding {
+	case "", logEncodingJSON, logEncodingConsole:
+	default:
+		return fmt.Errorf("unknown log encoding %q, must be %v or %v", cfg.Encoding, logEncodingJSON, logEncodingConsole)
+	}
+	if len(cfg.Level) > 0 {
+		if _, err := parseStrictZapLevel(cfg.Level); err != nil {
+			return err
+		}
+	}
+	if err := validatePackageLevels(cfg.Levels); err != nil {
+		return err
+	}
+	if cfg.Sampling != nil && (cfg.Sampling.Initial <= 0 || cfg.Sampling.Thereafter <= 0) {
+		return errors.New("log sampling initial and thereafter must be positive")
+	}
+	if cfg.Rotation != nil && len(cfg.OutputFile) == 0 {
+		return errors.New("log rotation requires an output file")
+	}
+	return nil
+}
+
+// newRotatingZapLogger builds the logger writing to a rotated output file, which zap.Config
+// cannot express
+func (cfg *Logger) newRotatingZapLogger(
+	levels *LogLevels,
+	encoding string,
+	encodeConfig zapcore.EncoderConfig,
+) *zap.Logger {
+
+	var encoder zapcore.Encoder
+	if encoding == logEncodingConsole {
+		encoder = zapcore.NewConsoleEncoder(encodeConfig)
+	} else {
+		encoder = zapcore.NewJSONEncoder(encodeConfig)
+	}
+
+	output := zapcore.AddSync(cfg.rotatingFile())
+	core := zapcore.NewCore(encoder, output, zapcore.DebugLevel)
+	if cfg.Sampling != nil {
+		core = zapcore.NewSampler(core, samplingTick, cfg.Sampling.Initial, cfg.Sampling.Thereafter)
+	}
+	return zap.New(levels.wrap(core), zap.ErrorOutput(output), zap.AddStacktrace(zapcore.ErrorLevel))
+}
+
+func (cfg *Logger) rotatingFile() *lumberjack.Logger {
+	rotatingFilesLock.Lock()
+	defer rotatingFilesLock.Unlock()
+
+	if file, ok := rotatingFiles[cfg.OutputFile]; ok {
+		return file
+	}
+	maxSize := cfg.Rotation.MaxSizeMB
+	if maxSize <= 0 {
+		maxSize = defaultLogRotationMaxSizeMB
+	}
+	file := &lumberjack.Logger{
+		Filename:   cfg.OutputFile,
+		MaxSize:    maxSize,
+		MaxBackups: cfg.Rotation.MaxBackups,
+		MaxAge:     cfg.Rotation.MaxAgeDays,
+		Compress:   cfg.Rotation.Compress,
+	}
+	rotatingFiles[cfg.OutputFile] = file
+	return file
+}
+
 func parseZapLevel(level string) zapcore.Level {
 	switch strings.ToLower(level) {
 	case "debug":
@@ -88,3 +204,13 @@ func parseZapLevel(level string) zapcore.Level {
 		return zap.InfoLevel
 	}
 }
+
+// parseStrictZapLevel is parseZapLevel rejecting unknown levels
+func parseStrictZapLevel(level string) (zapcore.Level, error) {
+	for _, name := range logLevelNames {
+		if strings.EqualFold(level, name) {
+			return parseZapLevel(name), nil
+		}
+	}
+	return zap.InfoLevel, fmt.Errorf("unknown log level %q, must be one of %v", level, strings.Join(logLevelNames, ", "))
+}
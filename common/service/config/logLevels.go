@@ -0,0 +1,247 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package config
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// modulePath is trimmed from the package paths of the callers, so package levels are keyed
+	// by the path of the package in the module, e.g. service/matching
+	modulePath = "github.com/temporalio/temporal/"
+
+	// maxCallerDepth is the number of frames searched for the caller of the logger
+	maxCallerDepth = 32
+
+	zapPackage = "go.uber.org/zap"
+)
+
+type (
+	// LogLevels holds the log levels of the process, a default level and the levels of packages
+	// and their subpackages, so they can be changed without restarting the process
+	LogLevels struct {
+		sync.RWMutex
+		level    zapcore.Level
+		packages map[string]zapcore.Level
+		// minLevel is the lowest of the levels, entries below it are dropped without looking up
+		// the package of their caller
+		minLevel zap.AtomicLevel
+	}
+
+	// logLevelsCore drops the entries below the level of the package of their caller
+	logLevelsCore struct {
+		zapcore.Core
+		levels *LogLevels
+	}
+)
+
+// loggingPackages are the packages skipped when looking up the caller of a logger
+var loggingPackages = []string{
+	zapPackage,
+	modulePath + "common/log",
+}
+
+// NewLogLevels returns the log levels of the logging configuration
+func (cfg *Logger) NewLogLevels() *LogLevels {
+	l := &LogLevels{
+		level:    parseZapLevel(cfg.Level),
+		packages: make(map[string]zapcore.Level, len(cfg.Levels)),
+		minLevel: zap.NewAtomicLevel(),
+	}
+	for pkg, level := range cfg.Levels {
+		l.packages[strings.Trim(pkg, "/")] = parseZapLevel(level)
+	}
+	l.updateMinLevel()
+	return l
+}
+
+// Level returns the level of the packages without a level of their own
+func (l *LogLevels) Level() string {
+	l.RLock()
+	defer l.RUnlock()
+	return l.level.String()
+}
+
+// PackageLevels returns the levels of the packages, keyed by package path in the module
+func (l *LogLevels) PackageLevels() map[string]string {
+	l.RLock()
+	defer l.RUnlock()
+
+	levels := make(map[string]string, len(l.packages))
+	for pkg, level := range l.packages {
+		levels[pkg] = level.String()
+	}
+	return levels
+}
+
+// SetLevel changes the level of the package and its subpackages, or the default level when
+// pkg is empty
+func (l *LogLevels) SetLevel(pkg string, level string) error {
+	zapLevel, err := parseStrictZapLevel(level)
+	if err != nil {
+		return err
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	pkg = strings.Trim(strings.TrimPrefix(pkg, modulePath), "/")
+	if len(pkg) == 0 {
+		l.level = zapLevel
+	} else {
+		l.packages[pkg] = zapLevel
+	}
+	l.updateMinLevel()
+	return nil
+}
+
+// ResetLevel removes the level of the package, it logs at the level of its closest parent
+// package with a level again
+func (l *LogLevels) ResetLevel(pkg string) {
+	l.Lock()
+	defer l.Unlock()
+
+	delete(l.packages, strings.Trim(strings.TrimPrefix(pkg, modulePath), "/"))
+	l.updateMinLevel()
+}
+
+// wrap returns a core dropping the entries below the levels, the core needs to be enabled at all levels
+func (l *LogLevels) wrap(core zapcore.Core) zapcore.Core {
+	return &logLevelsCore{Core: core, levels: l}
+}
+
+func (l *LogLevels) updateMinLevel() {
+	minLevel := l.level
+	for _, level := range l.packages {
+		if level < minLevel {
+			minLevel = level
+		}
+	}
+	l.minLevel.SetLevel(minLevel)
+}
+
+// enabled returns true if the entry of the caller of the logger is logged at the given level
+func (l *LogLevels) enabled(level zapcore.Level) bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	if len(l.packages) == 0 {
+		return level >= l.level
+	}
+	return level >= l.packageLevel(callerPackage())
+}
+
+// packageLevel returns the level of the closest package with a level, the package itself first
+func (l *LogLevels) packageLevel(pkg string) zapcore.Level {
+	pkg = strings.TrimPrefix(pkg, modulePath)
+	for len(pkg) > 0 {
+		if level, ok := l.packages[pkg]; ok {
+			return level
+		}
+		slash := strings.LastIndex(pkg, "/")
+		if slash < 0 {
+			break
+		}
+		pkg = pkg[:slash]
+	}
+	return l.level
+}
+
+// Enabled implements zapcore.LevelEnabler
+func (c *logLevelsCore) Enabled(level zapcore.Level) bool {
+	return c.levels.minLevel.Enabled(level)
+}
+
+// With implements zapcore.Core
+func (c *logLevelsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &logLevelsCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+// Check implements zapcore.Core, it runs on the goroutine of the caller of the logger
+func (c *logLevelsCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(entry.Level) || !c.levels.enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// callerPackage returns the import path of the package which called the logger, the first
+// package outside of the logging packages once the frames of zap are reached
+func callerPackage() string {
+	pcs := make([]uintptr, maxCallerDepth)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	inZap := false
+	for {
+		frame, more := frames.Next()
+		pkg := functionPackage(frame.Function)
+		if strings.HasPrefix(pkg, zapPackage) {
+			inZap = true
+		} else if inZap && !isLoggingPackage(pkg) {
+			return pkg
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// functionPackage returns the import path of the package of a function name returned by runtime.FuncForPC,
+// e.g. github.com/temporalio/temporal/service/matching for github.com/temporalio/temporal/service/matching.(*Handler).Start
+func functionPackage(function string) string {
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot < 0 {
+		return function
+	}
+	return function[:slash+1+dot]
+}
+
+func isLoggingPackage(pkg string) bool {
+	for _, loggingPackage := range loggingPackages {
+		if pkg == loggingPackage || strings.HasPrefix(pkg, loggingPackage+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePackageLevels validates the levels of packages of the logging configuration
+func validatePackageLevels(levels map[string]string) error {
+	for pkg, level := range levels {
+		if len(strings.Trim(pkg, "/")) == 0 {
+			return fmt.Errorf("package of log level %v is empty", level)
+		}
+		if _, err := parseStrictZapLevel(level); err != nil {
+			return fmt.Errorf("package %v: %v", pkg, err)
+		}
+	}
+	return nil
+}
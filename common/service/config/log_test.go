@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 type LogSuite struct {
@@ -72,3 +73,91 @@ func (s *LogSuite) TestNewLogger() {
 	_, err = os.Stat(dir + "/test.log")
 	s.Nil(err)
 }
+
+func (s *LogSuite) TestNewLoggerWithRotation() {
+
+	dir, err := ioutil.TempDir("", "config.testNewLoggerWithRotation")
+	s.Nil(err)
+	defer os.RemoveAll(dir)
+
+	config := &Logger{
+		Level:      "info",
+		OutputFile: dir + "/test.log",
+		Encoding:   "console",
+		Sampling:   &LogSampling{Initial: 10, Thereafter: 10},
+		Rotation:   &LogRotation{MaxBackups: 2},
+	}
+	s.NoError(config.Validate())
+
+	log := config.NewZapLogger()
+	s.NotNil(log)
+	log.Info("rotated")
+	_, err = os.Stat(dir + "/test.log")
+	s.Nil(err)
+}
+
+func (s *LogSuite) TestValidate() {
+	s.NoError((&Logger{}).Validate())
+	s.NoError((&Logger{Level: "debug", Encoding: "json", Levels: map[string]string{"service/matching": "DEBUG"}}).Validate())
+	s.Error((&Logger{Encoding: "xml"}).Validate())
+	s.Error((&Logger{Level: "verbose"}).Validate())
+	s.Error((&Logger{Levels: map[string]string{"service/matching": "verbose"}}).Validate())
+	s.Error((&Logger{Levels: map[string]string{"/": "debug"}}).Validate())
+	s.Error((&Logger{Sampling: &LogSampling{Initial: 0, Thereafter: 1}}).Validate())
+	s.Error((&Logger{Rotation: &LogRotation{}}).Validate())
+}
+
+func (s *LogSuite) TestPackageLogLevels() {
+	config := &Logger{
+		Level:  "info",
+		Levels: map[string]string{"service/matching/": "debug"},
+	}
+	levels := config.NewLogLevels()
+	s.Equal("info", levels.Level())
+	s.Equal(map[string]string{"service/matching": "debug"}, levels.PackageLevels())
+	s.Equal(zap.DebugLevel, levels.packageLevel(modulePath+"service/matching"))
+	s.Equal(zap.DebugLevel, levels.packageLevel(modulePath+"service/matching/internal"))
+	s.Equal(zap.InfoLevel, levels.packageLevel(modulePath+"service/matchingx"))
+	s.Equal(zap.InfoLevel, levels.packageLevel(modulePath+"service/history"))
+
+	s.NoError(levels.SetLevel(modulePath+"service", "warn"))
+	s.Equal(zap.DebugLevel, levels.packageLevel(modulePath+"service/matching"))
+	s.Equal(zap.WarnLevel, levels.packageLevel(modulePath+"service/history"))
+	s.True(levels.minLevel.Enabled(zap.DebugLevel))
+
+	levels.ResetLevel("service/matching")
+	s.Equal(zap.WarnLevel, levels.packageLevel(modulePath+"service/matching"))
+	s.False(levels.minLevel.Enabled(zap.DebugLevel))
+
+	s.NoError(levels.SetLevel("", "error"))
+	s.Equal("error", levels.Level())
+	s.Error(levels.SetLevel("service/history", "verbose"))
+}
+
+func (s *LogSuite) TestPackageLogLevelsFilterEntries() {
+	core, logs := observer.New(zap.DebugLevel)
+	levels := (&Logger{Level: "info"}).NewLogLevels()
+	logger := zap.New(levels.wrap(core))
+
+	logger.Debug("below the default level")
+	s.NoError(levels.SetLevel("common/service/config", "debug"))
+	logger.Debug("at the level of the package")
+	s.NoError(levels.SetLevel("common/service", "error"))
+	logger.Debug("at the level of the package, not of its parent")
+	levels.ResetLevel("common/service/config")
+	logger.Warn("below the level of the parent")
+
+	var messages []string
+	for _, entry := range logs.All() {
+		messages = append(messages, entry.Message)
+	}
+	s.Equal([]string{"at the level of the package", "at the level of the package, not of its parent"}, messages)
+}
+
+func (s *LogSuite) TestFunctionPackage() {
+	s.Equal(modulePath+"service/matching", functionPackage(modulePath+"service/matching.(*Handler).Start"))
+	s.Equal(modulePath+"common", functionPackage(modulePath+"common.IsValidContext"))
+	s.Equal("main", functionPackage("main.main"))
+	s.True(isLoggingPackage(functionPackage("go.uber.org/zap/zapcore.(*CheckedEntry).Write")))
+	s.False(isLoggingPackage(modulePath + "common/logging"))
+}
@@ -35,6 +35,7 @@ import (
 
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
 	statsdreporter "github.com/temporalio/temporal/common/metrics/tally/statsd"
 )
 
@@ -126,7 +127,9 @@ func (c *Metrics) newStatsdScope(logger log.Logger) tally.Scope {
 }
 
 // newPrometheusScope returns a new prometheus scope with
-// a default reporting interval of a second
+// a default reporting interval of a second, every metric
+// carries the standard labels so its label set is the same
+// whichever scope emits it
 func (c *Metrics) newPrometheusScope(logger log.Logger) tally.Scope {
 	reporter, err := c.Prometheus.NewReporter(
 		prometheus.ConfigurationOptions{
@@ -141,7 +144,7 @@ func (c *Metrics) newPrometheusScope(logger log.Logger) tally.Scope {
 	}
 	scopeOpts := tally.ScopeOptions{
 		Tags:            c.Tags,
-		CachedReporter:  reporter,
+		CachedReporter:  metrics.NewLabelNormalizingReporter(reporter),
 		Separator:       prometheus.DefaultSeparator,
 		SanitizeOptions: &sanitizeOptions,
 		Prefix:          c.Prefix,
@@ -53,6 +53,9 @@ const (
 // otherwise, the caller / worker will experience weird issue
 var pprofStatus = pprofNotInitialized
 
+// the periodic profiling runs once per process as well
+var periodicProfilingStatus = pprofNotInitialized
+
 // NewInitializer create a new instance of PProf Initializer
 func (cfg *PProf) NewInitializer(logger log.Logger) *PProfInitializerImpl {
 	return &PProfInitializerImpl{
@@ -61,8 +64,16 @@ func (cfg *PProf) NewInitializer(logger log.Logger) *PProfInitializerImpl {
 	}
 }
 
-// Start the pprof based on config
+// Start the pprof and the periodic profiling based on config
 func (initializer *PProfInitializerImpl) Start() error {
+	if initializer.PProf.Periodic != nil && atomic.CompareAndSwapInt32(&periodicProfilingStatus, pprofNotInitialized, pprofInitialized) {
+		profiler, err := initializer.PProf.Periodic.NewPeriodicProfiler(initializer.Logger)
+		if err != nil {
+			return err
+		}
+		profiler.Start()
+	}
+
 	port := initializer.PProf.Port
 	if port == 0 {
 		initializer.Logger.Info("PProf not started due to port not set")
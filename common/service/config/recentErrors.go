@@ -0,0 +1,152 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	// RecentErrors keeps the last entries logged at error level or above by the service
+	// loggers of the process, so they can be collected for diagnostics
+	RecentErrors struct {
+		sync.Mutex
+		entries []RecentError
+		next    int
+		full    bool
+	}
+
+	// RecentError is an entry logged at error level or above
+	RecentError struct {
+		Time    time.Time
+		Service string
+		Level   string
+		Message string
+		Fields  map[string]interface{}
+	}
+
+	// recentErrorsCore is the zapcore.Core recording the entries of a service logger
+	recentErrorsCore struct {
+		recentErrors *RecentErrors
+		service      string
+		fields       []zapcore.Field
+	}
+)
+
+// DefaultRecentErrorsSize is the number of recent errors kept per process
+const DefaultRecentErrorsSize = 100
+
+var _ zapcore.Core = (*recentErrorsCore)(nil)
+
+// NewRecentErrors returns an empty set of recent errors keeping the last size entries
+func NewRecentErrors(size int) *RecentErrors {
+	return &RecentErrors{
+		entries: make([]RecentError, size),
+	}
+}
+
+// Wrap returns a logger also recording the entries of the given service logger at error
+// level or above, independently of its level
+func (r *RecentErrors) Wrap(service string, logger *zap.Logger) *zap.Logger {
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, &recentErrorsCore{recentErrors: r, service: service})
+	}))
+}
+
+// Entries returns the recent errors, oldest first
+func (r *RecentErrors) Entries() []RecentError {
+	if r == nil {
+		return nil
+	}
+	r.Lock()
+	defer r.Unlock()
+
+	if !r.full {
+		return append([]RecentError(nil), r.entries[:r.next]...)
+	}
+	entries := make([]RecentError, 0, len(r.entries))
+	entries = append(entries, r.entries[r.next:]...)
+	return append(entries, r.entries[:r.next]...)
+}
+
+func (r *RecentErrors) add(entry RecentError) {
+	r.Lock()
+	defer r.Unlock()
+
+	if len(r.entries) == 0 {
+		return
+	}
+	r.entries[r.next] = entry
+	r.next++
+	if r.next == len(r.entries) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+func (c *recentErrorsCore) Enabled(level zapcore.Level) bool {
+	return level >= zapcore.ErrorLevel
+}
+
+func (c *recentErrorsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &recentErrorsCore{
+		recentErrors: c.recentErrors,
+		service:      c.service,
+		fields:       append(append([]zapcore.Field(nil), c.fields...), fields...),
+	}
+}
+
+func (c *recentErrorsCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *recentErrorsCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	encoder := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(encoder)
+	}
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+	c.recentErrors.add(RecentError{
+		Time:    entry.Time,
+		Service: c.service,
+		Level:   entry.Level.String(),
+		Message: entry.Message,
+		Fields:  encoder.Fields,
+	})
+	return nil
+}
+
+func (c *recentErrorsCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,83 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type RecentErrorsSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestRecentErrorsSuite(t *testing.T) {
+	suite.Run(t, new(RecentErrorsSuite))
+}
+
+func (s *RecentErrorsSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *RecentErrorsSuite) TestWrap() {
+	recentErrors := NewRecentErrors(10)
+	logger := recentErrors.Wrap("history", zap.NewNop()).With(zap.Int("shard-id", 3))
+
+	logger.Info("not recorded")
+	logger.Warn("not recorded")
+	logger.Error("failed to acquire shard", zap.Error(errors.New("timeout")))
+
+	entries := recentErrors.Entries()
+	s.Len(entries, 1)
+	s.Equal("history", entries[0].Service)
+	s.Equal("error", entries[0].Level)
+	s.Equal("failed to acquire shard", entries[0].Message)
+	s.Equal(map[string]interface{}{"shard-id": int64(3), "error": "timeout"}, entries[0].Fields)
+	s.False(entries[0].Time.IsZero())
+}
+
+func (s *RecentErrorsSuite) TestEntriesKeepsLastErrors() {
+	recentErrors := NewRecentErrors(3)
+	logger := recentErrors.Wrap("matching", zap.NewNop())
+	for _, message := range []string{"1", "2", "3", "4", "5"} {
+		logger.Error(message)
+	}
+
+	var messages []string
+	for _, entry := range recentErrors.Entries() {
+		messages = append(messages, entry.Message)
+	}
+	s.Equal([]string{"3", "4", "5"}, messages)
+}
+
+func (s *RecentErrorsSuite) TestNil() {
+	var recentErrors *RecentErrors
+	s.Nil(recentErrors.Entries())
+}
@@ -25,12 +25,14 @@
 package config
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"sync"
 
 	"github.com/uber/tchannel-go"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
@@ -42,20 +44,23 @@ type RPCFactory struct {
 	config      *RPC
 	serviceName string
 	logger      log.Logger
+	tlsProvider *rpc.TLSConfigProvider
 
 	sync.Mutex
 	grpcListener   net.Listener
+	httpListener   net.Listener
+	healthListener net.Listener
 	ringpopChannel *tchannel.Channel
 }
 
 // NewFactory builds a new RPCFactory
 // conforming to the underlying configuration
-func (cfg *RPC) NewFactory(sName string, logger log.Logger) *RPCFactory {
-	return newRPCFactory(cfg, sName, logger)
+func (cfg *RPC) NewFactory(sName string, logger log.Logger, tlsProvider *rpc.TLSConfigProvider) *RPCFactory {
+	return newRPCFactory(cfg, sName, logger, tlsProvider)
 }
 
-func newRPCFactory(cfg *RPC, sName string, logger log.Logger) *RPCFactory {
-	factory := &RPCFactory{config: cfg, serviceName: sName, logger: logger}
+func newRPCFactory(cfg *RPC, sName string, logger log.Logger, tlsProvider *rpc.TLSConfigProvider) *RPCFactory {
+	factory := &RPCFactory{config: cfg, serviceName: sName, logger: logger, tlsProvider: tlsProvider}
 	return factory
 }
 
@@ -82,6 +87,63 @@ func (d *RPCFactory) GetGRPCListener() net.Listener {
 	return d.grpcListener
 }
 
+// GetHTTPListener returns cached listener for the frontend HTTP gateway or creates one,
+// the listener serves TLS when frontend TLS is configured and is nil when no HTTP port is set
+func (d *RPCFactory) GetHTTPListener() net.Listener {
+	if d.config.HTTPPort == 0 {
+		return nil
+	}
+	if d.httpListener != nil {
+		return d.httpListener
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	if d.httpListener == nil {
+		hostAddress := fmt.Sprintf("%v:%v", d.getListenIP(), d.config.HTTPPort)
+		listener, err := net.Listen("tcp", hostAddress)
+		if err != nil {
+			d.logger.Fatal("Failed to start HTTP listener", tag.Error(err), tag.Service(d.serviceName), tag.Address(hostAddress))
+		}
+		if tlsConfig := d.tlsProvider.GetFrontendServerConfig(); tlsConfig != nil {
+			listener = tls.NewListener(listener, tlsConfig)
+		}
+		d.httpListener = listener
+
+		d.logger.Info("Created HTTP listener", tag.Service(d.serviceName), tag.Address(hostAddress))
+	}
+
+	return d.httpListener
+}
+
+// GetHealthListener returns cached listener for the HTTP health endpoints or creates one,
+// the listener is plain TCP so that probes need no client certificates and is nil when no health port is set
+func (d *RPCFactory) GetHealthListener() net.Listener {
+	if d.config.HealthPort == 0 {
+		return nil
+	}
+	if d.healthListener != nil {
+		return d.healthListener
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	if d.healthListener == nil {
+		hostAddress := fmt.Sprintf("%v:%v", d.getListenIP(), d.config.HealthPort)
+		var err error
+		d.healthListener, err = net.Listen("tcp", hostAddress)
+		if err != nil {
+			d.logger.Fatal("Failed to start health listener", tag.Error(err), tag.Service(d.serviceName), tag.Address(hostAddress))
+		}
+
+		d.logger.Info("Created health listener", tag.Service(d.serviceName), tag.Address(hostAddress))
+	}
+
+	return d.healthListener
+}
+
 // GetRingpopChannel return a cached ringpop dispatcher
 func (d *RPCFactory) GetRingpopChannel() *tchannel.Channel {
 	if d.ringpopChannel != nil {
@@ -133,9 +195,36 @@ func (d *RPCFactory) getListenIP() net.IP {
 	return ip
 }
 
-// CreateGRPCConnection creates connection for gRPC calls
+// GetFrontendGRPCServerOptions returns the options of the frontend gRPC server
+func (d *RPCFactory) GetFrontendGRPCServerOptions() []grpc.ServerOption {
+	return rpc.ServerOptions(d.tlsProvider.GetFrontendServerConfig())
+}
+
+// GetInternodeGRPCServerOptions returns the options of the history and matching gRPC servers
+func (d *RPCFactory) GetInternodeGRPCServerOptions() []grpc.ServerOption {
+	return rpc.ServerOptions(d.tlsProvider.GetInternodeServerConfig())
+}
+
+// CreateGRPCConnection creates connection for gRPC calls to history and matching
 func (d *RPCFactory) CreateGRPCConnection(hostName string) *grpc.ClientConn {
-	connection, err := rpc.Dial(hostName)
+	transportCredentials, err := d.tlsProvider.GetInternodeClientCredentials()
+	if err != nil {
+		d.logger.Fatal("Failed to create internode TLS config", tag.Error(err))
+	}
+	return d.dial(hostName, transportCredentials)
+}
+
+// CreateFrontendGRPCConnection creates connection for gRPC calls to frontend
+func (d *RPCFactory) CreateFrontendGRPCConnection(hostName string) *grpc.ClientConn {
+	transportCredentials, err := d.tlsProvider.GetFrontendClientCredentials()
+	if err != nil {
+		d.logger.Fatal("Failed to create frontend TLS config", tag.Error(err))
+	}
+	return d.dial(hostName, transportCredentials)
+}
+
+func (d *RPCFactory) dial(hostName string, transportCredentials credentials.TransportCredentials) *grpc.ClientConn {
+	connection, err := rpc.Dial(hostName, transportCredentials)
 	if err != nil {
 		d.logger.Fatal("Failed to create gRPC connection", tag.Error(err))
 	}
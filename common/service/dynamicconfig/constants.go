@@ -74,6 +74,11 @@ var keys = map[Key]string{
 	EnableParentClosePolicyWorker:          "system.enableParentClosePolicyWorker",
 	EnableStickyQuery:                      "system.enableStickyQuery",
 	EnablePriorityTaskProcessor:            "system.enablePriorityTaskProcessor",
+	RPCRequestLogSampleRate:                "system.rpcRequestLogSampleRate",
+	EnableTypeTaggedMetrics:                "system.enableTypeTaggedMetrics",
+	WorkflowTypeTaggedMetricsAllowlist:     "system.workflowTypeTaggedMetricsAllowlist",
+	ActivityTypeTaggedMetricsAllowlist:     "system.activityTypeTaggedMetricsAllowlist",
+	TypeTaggedMetricsLimit:                 "system.typeTaggedMetricsLimit",
 
 	// size limit
 	BlobSizeLimitError:     "limit.blobSize.error",
@@ -85,30 +90,35 @@ var keys = map[Key]string{
 	MaxIDLengthLimit:       "limit.maxIDLength",
 
 	// frontend settings
-	FrontendPersistenceMaxQPS:             "frontend.persistenceMaxQPS",
-	FrontendPersistenceGlobalMaxQPS:       "frontend.persistenceGlobalMaxQPS",
-	FrontendVisibilityMaxPageSize:         "frontend.visibilityMaxPageSize",
-	FrontendVisibilityListMaxQPS:          "frontend.visibilityListMaxQPS",
-	FrontendESVisibilityListMaxQPS:        "frontend.esVisibilityListMaxQPS",
-	FrontendMaxBadBinaries:                "frontend.maxBadBinaries",
-	FrontendESIndexMaxResultWindow:        "frontend.esIndexMaxResultWindow",
-	FrontendHistoryMaxPageSize:            "frontend.historyMaxPageSize",
-	FrontendRPS:                           "frontend.rps",
-	FrontendMaxNamespaceRPSPerInstance:    "frontend.namespacerps",
-	FrontendGlobalNamespaceRPS:            "frontend.globalNamespacerps",
-	FrontendHistoryMgrNumConns:            "frontend.historyMgrNumConns",
-	FrontendShutdownDrainDuration:         "frontend.shutdownDrainDuration",
-	DisableListVisibilityByFilter:         "frontend.disableListVisibilityByFilter",
-	FrontendThrottledLogRPS:               "frontend.throttledLogRPS",
-	EnableClientVersionCheck:              "frontend.enableClientVersionCheck",
-	ValidSearchAttributes:                 "frontend.validSearchAttributes",
-	SendRawWorkflowHistory:                "frontend.sendRawWorkflowHistory",
-	SearchAttributesNumberOfKeysLimit:     "frontend.searchAttributesNumberOfKeysLimit",
-	SearchAttributesSizeOfValueLimit:      "frontend.searchAttributesSizeOfValueLimit",
-	SearchAttributesTotalSizeLimit:        "frontend.searchAttributesTotalSizeLimit",
-	VisibilityArchivalQueryMaxPageSize:    "frontend.visibilityArchivalQueryMaxPageSize",
-	VisibilityArchivalQueryMaxRangeInDays: "frontend.visibilityArchivalQueryMaxRangeInDays",
-	VisibilityArchivalQueryMaxQPS:         "frontend.visibilityArchivalQueryMaxQPS",
+	FrontendPersistenceMaxQPS:                  "frontend.persistenceMaxQPS",
+	FrontendPersistenceGlobalMaxQPS:            "frontend.persistenceGlobalMaxQPS",
+	FrontendVisibilityMaxPageSize:              "frontend.visibilityMaxPageSize",
+	FrontendVisibilityListMaxQPS:               "frontend.visibilityListMaxQPS",
+	FrontendESVisibilityListMaxQPS:             "frontend.esVisibilityListMaxQPS",
+	FrontendMaxBadBinaries:                     "frontend.maxBadBinaries",
+	FrontendESIndexMaxResultWindow:             "frontend.esIndexMaxResultWindow",
+	FrontendHistoryMaxPageSize:                 "frontend.historyMaxPageSize",
+	FrontendRPS:                                "frontend.rps",
+	FrontendMaxNamespaceRPSPerInstance:         "frontend.namespacerps",
+	FrontendGlobalNamespaceRPS:                 "frontend.globalNamespacerps",
+	FrontendEnableAdmissionControl:             "frontend.enableAdmissionControl",
+	FrontendAdmissionControlMinConcurrency:     "frontend.admissionControlMinConcurrency",
+	FrontendAdmissionControlMaxConcurrency:     "frontend.admissionControlMaxConcurrency",
+	FrontendAdmissionControlPollMinConcurrency: "frontend.admissionControlPollMinConcurrency",
+	FrontendAdmissionControlLatencyTolerance:   "frontend.admissionControlLatencyTolerance",
+	FrontendHistoryMgrNumConns:                 "frontend.historyMgrNumConns",
+	FrontendShutdownDrainDuration:              "frontend.shutdownDrainDuration",
+	DisableListVisibilityByFilter:              "frontend.disableListVisibilityByFilter",
+	FrontendThrottledLogRPS:                    "frontend.throttledLogRPS",
+	EnableClientVersionCheck:                   "frontend.enableClientVersionCheck",
+	ValidSearchAttributes:                      "frontend.validSearchAttributes",
+	SendRawWorkflowHistory:                     "frontend.sendRawWorkflowHistory",
+	SearchAttributesNumberOfKeysLimit:          "frontend.searchAttributesNumberOfKeysLimit",
+	SearchAttributesSizeOfValueLimit:           "frontend.searchAttributesSizeOfValueLimit",
+	SearchAttributesTotalSizeLimit:             "frontend.searchAttributesTotalSizeLimit",
+	VisibilityArchivalQueryMaxPageSize:         "frontend.visibilityArchivalQueryMaxPageSize",
+	VisibilityArchivalQueryMaxRangeInDays:      "frontend.visibilityArchivalQueryMaxRangeInDays",
+	VisibilityArchivalQueryMaxQPS:              "frontend.visibilityArchivalQueryMaxQPS",
 
 	// matching settings
 	MatchingRPS:                             "matching.rps",
@@ -132,6 +142,11 @@ var keys = map[Key]string{
 	MatchingForwarderMaxRatePerSecond:       "matching.forwarderMaxRatePerSecond",
 	MatchingForwarderMaxChildrenPerNode:     "matching.forwarderMaxChildrenPerNode",
 	MatchingShutdownDrainDuration:           "matching.shutdownDrainDuration",
+	MatchingMaxOutstandingTasksPerPoller:    "matching.maxOutstandingTasksPerPoller",
+	MatchingMaxOutstandingTasksPerHost:      "matching.maxOutstandingTasksPerHost",
+	MatchingPollerOutstandingTaskTTL:        "matching.pollerOutstandingTaskTTL",
+	MatchingAffinityOwnerTimeout:            "matching.affinityOwnerTimeout",
+	MatchingEnableTaskListHandoff:           "matching.enableTaskListHandoff",
 
 	// history settings
 	HistoryRPS:                                             "history.rps",
@@ -227,6 +242,8 @@ var keys = map[Key]string{
 	DefaultExecutionStartToCloseTimeout:                    "history.defaultWorkflowExecutionTimeout",
 	MaxExecutionStartToCloseTimeout:                        "history.maximumWorkflowExecutionTimeout",
 	DecisionHeartbeatTimeout:                               "history.decisionHeartbeatTimeout",
+	MaxEagerActivityTasksPerDecision:                       "history.maxEagerActivityTasksPerDecision",
+	MaxEagerActivityTasksSizePerDecision:                   "history.maxEagerActivityTasksSizePerDecision",
 	DefaultDecisionTaskStartToCloseTimeout:                 "history.defaultDecisionTaskStartToCloseTimeout",
 	ParentClosePolicyThreshold:                             "history.parentClosePolicyThreshold",
 	NumParentClosePolicySystemWorkflows:                    "history.numParentClosePolicySystemWorkflows",
@@ -246,6 +263,8 @@ var keys = map[Key]string{
 	MutableStateChecksumVerifyProbability:                  "history.mutableStateChecksumVerifyProbability",
 	MutableStateChecksumInvalidateBefore:                   "history.mutableStateChecksumInvalidateBefore",
 	ReplicationEventsFromCurrentCluster:                    "history.ReplicationEventsFromCurrentCluster",
+	LifecycleEventsEnabled:                                 "history.lifecycleEventsEnabled",
+	LifecycleEventTypes:                                    "history.lifecycleEventTypes",
 
 	WorkerPersistenceMaxQPS:                         "worker.persistenceMaxQPS",
 	WorkerPersistenceGlobalMaxQPS:                   "worker.persistenceGlobalMaxQPS",
@@ -334,6 +353,21 @@ const (
 	DisallowQuery
 	// EnablePriorityTaskProcessor is the key for enabling priority task processor
 	EnablePriorityTaskProcessor
+	// RPCRequestLogSampleRate is the fraction of successful gRPC requests logged by the servers of all services
+	RPCRequestLogSampleRate
+	// EnableTypeTaggedMetrics is the key for tagging history and matching metrics of a namespace with
+	// the workflow and activity types
+	EnableTypeTaggedMetrics
+	// WorkflowTypeTaggedMetricsAllowlist is the comma separated list of workflow types of a namespace
+	// that metrics are tagged with, other types are tagged as _other_. When empty TypeTaggedMetricsLimit applies
+	WorkflowTypeTaggedMetricsAllowlist
+	// ActivityTypeTaggedMetricsAllowlist is the comma separated list of activity types of a namespace
+	// that metrics are tagged with, other types are tagged as _other_. When empty TypeTaggedMetricsLimit applies
+	ActivityTypeTaggedMetricsAllowlist
+	// TypeTaggedMetricsLimit is the max number of distinct workflow types, and of activity types, of a
+	// namespace that metrics are tagged with on a host, the types seen most often recently are tagged and
+	// the others as _other_
+	TypeTaggedMetricsLimit
 
 	// BlobSizeLimitError is the per event blob size limit
 	BlobSizeLimitError
@@ -374,6 +408,16 @@ const (
 	FrontendMaxNamespaceRPSPerInstance
 	// FrontendGlobalNamespaceRPS is workflow namespace rate limit per second for the whole cluster
 	FrontendGlobalNamespaceRPS
+	// FrontendEnableAdmissionControl enables the adaptive concurrency limits of a namespace
+	FrontendEnableAdmissionControl
+	// FrontendAdmissionControlMinConcurrency is the lowest concurrency limit per namespace and API class
+	FrontendAdmissionControlMinConcurrency
+	// FrontendAdmissionControlMaxConcurrency is the highest concurrency limit per namespace and API class
+	FrontendAdmissionControlMaxConcurrency
+	// FrontendAdmissionControlPollMinConcurrency is the lowest concurrency limit of long polls per namespace
+	FrontendAdmissionControlPollMinConcurrency
+	// FrontendAdmissionControlLatencyTolerance is how much slower than usual requests may get before the concurrency limits shrink
+	FrontendAdmissionControlLatencyTolerance
 	// FrontendHistoryMgrNumConns is for persistence cluster.NumConns
 	FrontendHistoryMgrNumConns
 	// FrontendThrottledLogRPS is the rate limit on number of log messages emitted per second for throttled logger
@@ -446,6 +490,18 @@ const (
 	MatchingForwarderMaxChildrenPerNode
 	// MatchingShutdownDrainDuration is the duration of traffic drain during shutdown
 	MatchingShutdownDrainDuration
+	// MatchingMaxOutstandingTasksPerPoller is the max number of tasks in flight, dispatched by a task list partition to a
+	// single poller identity and not yet completed, failed or timed out
+	MatchingMaxOutstandingTasksPerPoller
+	// MatchingMaxOutstandingTasksPerHost is the max number of tasks in flight dispatched to the pollers of a single host
+	MatchingMaxOutstandingTasksPerHost
+	// MatchingPollerOutstandingTaskTTL is the max duration a decision task counts against the limits of its poller when
+	// its completion is not reported, activity tasks count until their start to close timeout at most
+	MatchingPollerOutstandingTaskTTL
+	// MatchingAffinityOwnerTimeout is the duration after which the owner of an affinity task list is considered gone if it does not poll
+	MatchingAffinityOwnerTimeout
+	// MatchingEnableTaskListHandoff enables handing off task lists to their new owner on shutdown or ring change
+	MatchingEnableTaskListHandoff
 
 	// key for history
 
@@ -639,6 +695,12 @@ const (
 	StickyTTL
 	// DecisionHeartbeatTimeout for decision heartbeat
 	DecisionHeartbeatTimeout
+	// MaxEagerActivityTasksPerDecision is the max number of activity tasks returned to the worker completing a decision
+	// which asked for eager activity dispatch, zero disables eager activity dispatch
+	MaxEagerActivityTasksPerDecision
+	// MaxEagerActivityTasksSizePerDecision is the max total encoded size in bytes of the activity tasks returned to the
+	// worker completing a decision, the activities which do not fit are dispatched through matching
+	MaxEagerActivityTasksSizePerDecision
 	// DefaultExecutionStartToCloseTimeout for a workflow execution
 	DefaultExecutionStartToCloseTimeout
 	// Maximum allowed workflow execution timeout
@@ -747,6 +809,12 @@ const (
 	//ReplicationEventsFromCurrentCluster is a feature flag to allow cross DC replicate events that generated from the current cluster
 	ReplicationEventsFromCurrentCluster
 
+	// LifecycleEventsEnabled is whether the lifecycle events of the workflows of a namespace are published,
+	// it has no effect unless a lifecycle events sink is configured
+	LifecycleEventsEnabled
+	// LifecycleEventTypes is the comma separated lifecycle event types published for a namespace, all types when empty
+	LifecycleEventTypes
+
 	// lastKeyForTest must be the last one in this const group for testing purpose
 	lastKeyForTest
 )
@@ -70,6 +70,18 @@ func (s *fileBasedClientSuite) TestGetValue() {
 	s.Equal(false, v)
 }
 
+func (s *fileBasedClientSuite) TestSnapshot() {
+	snapshot := s.client.(Snapshotter).Snapshot()
+	s.Equal([]ConstrainedValue{
+		{Value: false, Constraints: map[string]interface{}{}},
+		{Value: true, Constraints: map[string]interface{}{"namespace": "global-samples-namespace"}},
+		{Value: true, Constraints: map[string]interface{}{"namespace": "samples-namespace"}},
+	}, snapshot[testGetBoolPropertyKey.String()])
+	s.Equal([]ConstrainedValue{
+		{Value: map[string]interface{}{"NamespaceId": 1}, Constraints: map[string]interface{}{}},
+	}, snapshot[ValidSearchAttributes.String()])
+}
+
 func (s *fileBasedClientSuite) TestGetValue_NonExistKey() {
 	defaultValue := true
 	v, err := s.client.GetValue(lastKeyForTest, defaultValue)
@@ -0,0 +1,57 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+type (
+	// Snapshotter is implemented by the clients able to list all the values they hold
+	Snapshotter interface {
+		// Snapshot returns the values of every key set, keyed by key name
+		Snapshot() map[string][]ConstrainedValue
+	}
+
+	// ConstrainedValue is a value of a key along with the filters it applies to, it applies
+	// to all callers when there is no constraint
+	ConstrainedValue struct {
+		Value       interface{}            `json:"value"`
+		Constraints map[string]interface{} `json:"constraints,omitempty"`
+	}
+)
+
+var _ Snapshotter = (*fileBasedClient)(nil)
+
+// Snapshot returns the values last loaded from the config file
+func (fc *fileBasedClient) Snapshot() map[string][]ConstrainedValue {
+	values := fc.values.Load().(map[string][]*constrainedValue)
+	snapshot := make(map[string][]ConstrainedValue, len(values))
+	for key, constrainedValues := range values {
+		for _, cv := range constrainedValues {
+			snapshot[key] = append(snapshot[key], ConstrainedValue{
+				Value:       cv.Value,
+				Constraints: cv.Constraints,
+			})
+		}
+	}
+	return snapshot
+}
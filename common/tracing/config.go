@@ -0,0 +1,133 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/uber/jaeger-client-go"
+	jaegerconfig "github.com/uber/jaeger-client-go/config"
+
+	"github.com/temporalio/temporal/common/log"
+)
+
+type (
+	// Config is the config of the tracing subsystem, a no-op tracer is used when no exporter is set
+	Config struct {
+		// Jaeger is the config of the exporter sending finished spans to Jaeger
+		Jaeger *JaegerExporter `yaml:"jaeger"`
+		// SampleRate is the fraction of the traces started by this process which are recorded,
+		// all traces are recorded when zero
+		SampleRate float64 `yaml:"sampleRate"`
+		// Tags is the set of key-value pairs added to every recorded span
+		Tags map[string]string `yaml:"tags"`
+	}
+
+	// JaegerExporter contains the config items of the Jaeger exporter, the spans are sent to the
+	// collector when its endpoint is set and to the agent otherwise
+	JaegerExporter struct {
+		// AgentHostPort is the host:port of the Jaeger agent, the default agent address is used when empty
+		AgentHostPort string `yaml:"agentHostPort"`
+		// CollectorEndpoint is the URL of the HTTP endpoint of the Jaeger collector
+		CollectorEndpoint string `yaml:"collectorEndpoint"`
+		// LogSpans enables logging of every reported span
+		LogSpans bool `yaml:"logSpans"`
+	}
+
+	jaegerLogger struct {
+		logger log.Logger
+	}
+)
+
+// IsEnabled returns true if an exporter is configured
+func (c *Config) IsEnabled() bool {
+	return c != nil && c.Jaeger != nil
+}
+
+// NewTracer creates the tracer of a service from the config, the returned closer flushes the
+// exporter. The tracer is a no-op tracer when no exporter is configured. Span contexts are
+// propagated in the Jaeger format, e.g. in the uber-trace-id gRPC header.
+func (c *Config) NewTracer(serviceName string, logger log.Logger) (opentracing.Tracer, io.Closer, error) {
+	if !c.IsEnabled() {
+		return opentracing.NoopTracer{}, nopCloser{}, nil
+	}
+
+	sampler := &jaegerconfig.SamplerConfig{Type: "const", Param: 1}
+	if c.SampleRate > 0 && c.SampleRate < 1 {
+		sampler = &jaegerconfig.SamplerConfig{Type: "probabilistic", Param: c.SampleRate}
+	}
+
+	cfg := jaegerconfig.Configuration{
+		ServiceName: serviceName,
+		Sampler:     sampler,
+		Reporter: &jaegerconfig.ReporterConfig{
+			LocalAgentHostPort: c.Jaeger.AgentHostPort,
+			CollectorEndpoint:  c.Jaeger.CollectorEndpoint,
+			LogSpans:           c.Jaeger.LogSpans,
+		},
+		Tags: c.tags(),
+	}
+	return cfg.NewTracer(jaegerconfig.Logger(&jaegerLogger{logger: logger}))
+}
+
+// IsPropagationHeader returns true if the lower case header name carries a span context or
+// baggage propagated in the Jaeger format
+func IsPropagationHeader(name string) bool {
+	return name == jaeger.TraceContextHeaderName ||
+		name == jaeger.JaegerDebugHeader ||
+		name == jaeger.JaegerBaggageHeader ||
+		strings.HasPrefix(name, jaeger.TraceBaggageHeaderPrefix)
+}
+
+func (c *Config) tags() []opentracing.Tag {
+	tags := make([]opentracing.Tag, 0, len(c.Tags))
+	for key, value := range c.Tags {
+		tags = append(tags, opentracing.Tag{Key: key, Value: value})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Key < tags[j].Key
+	})
+	return tags
+}
+
+// Error implements jaeger.Logger
+func (l *jaegerLogger) Error(msg string) {
+	l.logger.Error(msg)
+}
+
+// Infof implements jaeger.Logger
+func (l *jaegerLogger) Infof(msg string, args ...interface{}) {
+	l.logger.Info(fmt.Sprintf(msg, args...))
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,73 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/jaeger-client-go"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+)
+
+func TestNewTracerDisabled(t *testing.T) {
+	var cfg *Config
+	tracer, closer, err := cfg.NewTracer("temporal", loggerimpl.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, opentracing.NoopTracer{}, tracer)
+	require.NoError(t, closer.Close())
+}
+
+func TestNewTracerPropagation(t *testing.T) {
+	cfg := &Config{
+		Jaeger: &JaegerExporter{CollectorEndpoint: "http://localhost:14268/api/traces"},
+		Tags:   map[string]string{"cluster": "active"},
+	}
+	tracer, closer, err := cfg.NewTracer("temporal", loggerimpl.NewNopLogger())
+	require.NoError(t, err)
+	defer closer.Close()
+
+	span := tracer.StartSpan("root")
+	carrier := opentracing.TextMapCarrier{}
+	require.NoError(t, tracer.Inject(span.Context(), opentracing.TextMap, carrier))
+	require.NotEmpty(t, carrier["uber-trace-id"])
+
+	parent, err := tracer.Extract(opentracing.TextMap, carrier)
+	require.NoError(t, err)
+	child := tracer.StartSpan("child", opentracing.ChildOf(parent))
+	require.Equal(t, span.Context().(jaeger.SpanContext).TraceID(), child.Context().(jaeger.SpanContext).TraceID())
+	require.Equal(t, span.Context().(jaeger.SpanContext).SpanID(), child.Context().(jaeger.SpanContext).ParentID())
+}
+
+func TestIsPropagationHeader(t *testing.T) {
+	require.True(t, IsPropagationHeader("uber-trace-id"))
+	require.True(t, IsPropagationHeader("uberctx-tenant"))
+	require.True(t, IsPropagationHeader("jaeger-debug-id"))
+	require.True(t, IsPropagationHeader("jaeger-baggage"))
+	require.False(t, IsPropagationHeader("authorization"))
+	require.False(t, IsPropagationHeader("temporal-client-version"))
+}
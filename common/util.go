@@ -29,6 +29,7 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -398,6 +399,41 @@ func ValidateRetryPolicy(policy *commonpb.RetryPolicy) error {
 	return nil
 }
 
+// ValidateActivityStartDelay validates the start delay requested through the activity header.
+// The delay is a header field rather than a typed field of ScheduleActivityTaskDecisionAttributes
+// as the public API has no such field, so it is only honored for workers which set the header
+// explicitly and it is passed on to the activity with the rest of the header.
+func ValidateActivityStartDelay(header *commonpb.Header) error {
+	if _, err := parseActivityStartDelay(header); err != nil {
+		return serviceerror.NewInvalidArgument(err.Error())
+	}
+	return nil
+}
+
+// GetActivityStartDelay returns the start delay requested through the activity header.
+// Malformed values are ignored, so that the events already in history can always be replayed.
+// The delay is read from the header of the scheduled event, so replaying the event always
+// yields the same scheduled time.
+func GetActivityStartDelay(header *commonpb.Header) time.Duration {
+	startDelay, err := parseActivityStartDelay(header)
+	if err != nil {
+		return 0
+	}
+	return startDelay
+}
+
+func parseActivityStartDelay(header *commonpb.Header) (time.Duration, error) {
+	value, ok := header.GetFields()[ActivityStartDelayHeader]
+	if !ok {
+		return 0, nil
+	}
+	seconds, err := strconv.ParseInt(string(value), 10, 32)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("Invalid activity start delay %q in header %v.", value, ActivityStartDelayHeader)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
 // CreateHistoryStartWorkflowRequest create a start workflow request for history
 func CreateHistoryStartWorkflowRequest(
 	namespaceID string,
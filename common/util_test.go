@@ -0,0 +1,52 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	commonpb "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/serviceerror"
+)
+
+func TestActivityStartDelay(t *testing.T) {
+	header := func(value string) *commonpb.Header {
+		return &commonpb.Header{Fields: map[string][]byte{ActivityStartDelayHeader: []byte(value)}}
+	}
+
+	assert.NoError(t, ValidateActivityStartDelay(nil))
+	assert.Equal(t, time.Duration(0), GetActivityStartDelay(nil))
+
+	assert.NoError(t, ValidateActivityStartDelay(header("30")))
+	assert.Equal(t, 30*time.Second, GetActivityStartDelay(header("30")))
+
+	for _, value := range []string{"-1", "30s"} {
+		assert.IsType(t, &serviceerror.InvalidArgument{}, ValidateActivityStartDelay(header(value)))
+		// malformed values already in history must not fail replay
+		assert.Equal(t, time.Duration(0), GetActivityStartDelay(header(value)))
+	}
+}
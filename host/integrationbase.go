@@ -77,7 +77,9 @@ func (s *IntegrationBase) setupSuite(defaultClusterConfigFile string) {
 	if clusterConfig.FrontendAddress != "" {
 		s.Logger.Info("Running integration test against specified frontend", tag.Address(TestFlags.FrontendAddr))
 
-		connection, err := rpc.Dial(TestFlags.FrontendAddrGRPC)
+		transportCredentials, err := rpc.NewClientCredentials(clusterConfig.FrontendTLS)
+		s.Require().NoError(err)
+		connection, err := rpc.Dial(TestFlags.FrontendAddrGRPC, transportCredentials)
 		if err != nil {
 			s.Require().NoError(err)
 		}
@@ -497,7 +497,7 @@ func (c *cadenceImpl) startHistory(
 		// However current interface for getting history client doesn't specify which client it needs and the tests that use this API
 		// depends on the fact that there's only one history host.
 		// Need to change those tests and modify the interface for getting history client.
-		historyConnection, err := rpc.Dial(c.HistoryServiceAddress(3)[0])
+		historyConnection, err := rpc.Dial(c.HistoryServiceAddress(3)[0], nil)
 		if err != nil {
 			c.logger.Fatal("Failed to create connection for history", tag.Error(err))
 		}
@@ -804,6 +804,14 @@ func (c *rpcFactoryImpl) GetGRPCListener() net.Listener {
 	return c.listener
 }
 
+func (c *rpcFactoryImpl) GetHTTPListener() net.Listener {
+	return nil
+}
+
+func (c *rpcFactoryImpl) GetHealthListener() net.Listener {
+	return nil
+}
+
 func (c *rpcFactoryImpl) GetRingpopChannel() *tchannel.Channel {
 	if c.ringpopChannel != nil {
 		return c.ringpopChannel
@@ -830,9 +838,22 @@ func (c *rpcFactoryImpl) GetRingpopChannel() *tchannel.Channel {
 	return c.ringpopChannel
 }
 
+func (c *rpcFactoryImpl) GetFrontendGRPCServerOptions() []grpc.ServerOption {
+	return nil
+}
+
+func (c *rpcFactoryImpl) GetInternodeGRPCServerOptions() []grpc.ServerOption {
+	return nil
+}
+
+// CreateFrontendGRPCConnection creates connection for gRPC calls to frontend
+func (c *rpcFactoryImpl) CreateFrontendGRPCConnection(hostName string) *grpc.ClientConn {
+	return c.CreateGRPCConnection(hostName)
+}
+
 // CreateGRPCConnection creates connection for gRPC calls
 func (c *rpcFactoryImpl) CreateGRPCConnection(hostName string) *grpc.ClientConn {
-	connection, err := rpc.Dial(hostName)
+	connection, err := rpc.Dial(hostName, nil)
 	if err != nil {
 		c.logger.Fatal("Failed to create gRPC connection", tag.Error(err))
 	}
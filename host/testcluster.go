@@ -36,6 +36,7 @@ import (
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/filestore"
 	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/auth"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/elasticsearch"
@@ -73,6 +74,7 @@ type (
 	// TestClusterConfig are config for a test cluster
 	TestClusterConfig struct {
 		FrontendAddress       string
+		FrontendTLS           *auth.ClientTLS
 		EnableNDC             bool
 		EnableArchival        bool
 		IsMasterCluster       bool
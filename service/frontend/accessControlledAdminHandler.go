@@ -0,0 +1,430 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/common/authorization"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+// adminAPINamePrefix distinguishes admin APIs from workflow APIs of the same name, e.g. DescribeWorkflowExecution
+const adminAPINamePrefix = "Admin"
+
+// adminReplicationPollAPIs are polled continuously by the replication task fetchers of remote clusters,
+// so their authorized calls are logged at debug level rather than with every other admin call
+var adminReplicationPollAPIs = map[string]struct{}{
+	"GetReplicationMessages":          {},
+	"GetNamespaceReplicationMessages": {},
+	"GetDLQReplicationMessages":       {},
+}
+
+var _ adminservice.AdminServiceServer = (*AccessControlledAdminHandler)(nil)
+
+type (
+	// AccessControlledAdminHandler admin handler wrapper for authorization and audit logging
+	AccessControlledAdminHandler struct {
+		adminHandler  adminservice.AdminServiceServer
+		authorizer    authorization.Authorizer
+		metricsClient metrics.Client
+		logger        log.Logger
+	}
+)
+
+// NewAccessControlledAdminHandler creates admin handler which authorizes every call with the authorizer
+func NewAccessControlledAdminHandler(
+	adminHandler adminservice.AdminServiceServer,
+	authorizer authorization.Authorizer,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) *AccessControlledAdminHandler {
+	if authorizer == nil {
+		authorizer = authorization.NewNopAuthorizer()
+	}
+
+	return &AccessControlledAdminHandler{
+		adminHandler:  adminHandler,
+		authorizer:    authorizer,
+		metricsClient: metricsClient,
+		logger:        logger,
+	}
+}
+
+// DescribeWorkflowExecution API call
+func (a *AccessControlledAdminHandler) DescribeWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.DescribeWorkflowExecutionRequest,
+) (*adminservice.DescribeWorkflowExecutionResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminDescribeWorkflowExecutionScope, "DescribeWorkflowExecution", request.GetNamespace()); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.DescribeWorkflowExecution(ctx, request)
+}
+
+// DescribeHistoryHost API call
+func (a *AccessControlledAdminHandler) DescribeHistoryHost(
+	ctx context.Context,
+	request *adminservice.DescribeHistoryHostRequest,
+) (*adminservice.DescribeHistoryHostResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminDescribeHistoryHostScope, "DescribeHistoryHost", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.DescribeHistoryHost(ctx, request)
+}
+
+// CloseShard API call
+func (a *AccessControlledAdminHandler) CloseShard(
+	ctx context.Context,
+	request *adminservice.CloseShardRequest,
+) (*adminservice.CloseShardResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminCloseShardTaskScope, "CloseShard", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.CloseShard(ctx, request)
+}
+
+// RemoveTask API call
+func (a *AccessControlledAdminHandler) RemoveTask(
+	ctx context.Context,
+	request *adminservice.RemoveTaskRequest,
+) (*adminservice.RemoveTaskResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminRemoveTaskScope, "RemoveTask", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.RemoveTask(ctx, request)
+}
+
+// GetWorkflowExecutionRawHistory API call
+func (a *AccessControlledAdminHandler) GetWorkflowExecutionRawHistory(
+	ctx context.Context,
+	request *adminservice.GetWorkflowExecutionRawHistoryRequest,
+) (*adminservice.GetWorkflowExecutionRawHistoryResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminGetWorkflowExecutionRawHistoryScope, "GetWorkflowExecutionRawHistory", request.GetNamespace()); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.GetWorkflowExecutionRawHistory(ctx, request)
+}
+
+// GetWorkflowExecutionRawHistoryV2 API call
+func (a *AccessControlledAdminHandler) GetWorkflowExecutionRawHistoryV2(
+	ctx context.Context,
+	request *adminservice.GetWorkflowExecutionRawHistoryV2Request,
+) (*adminservice.GetWorkflowExecutionRawHistoryV2Response, error) {
+
+	if err := a.authorize(ctx, metrics.AdminGetWorkflowExecutionRawHistoryV2Scope, "GetWorkflowExecutionRawHistoryV2", request.GetNamespace()); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.GetWorkflowExecutionRawHistoryV2(ctx, request)
+}
+
+// AddSearchAttribute API call
+func (a *AccessControlledAdminHandler) AddSearchAttribute(
+	ctx context.Context,
+	request *adminservice.AddSearchAttributeRequest,
+) (*adminservice.AddSearchAttributeResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminAddSearchAttributeScope, "AddSearchAttribute", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.AddSearchAttribute(ctx, request)
+}
+
+// DescribeCluster API call
+func (a *AccessControlledAdminHandler) DescribeCluster(
+	ctx context.Context,
+	request *adminservice.DescribeClusterRequest,
+) (*adminservice.DescribeClusterResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminDescribeClusterScope, "DescribeCluster", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.DescribeCluster(ctx, request)
+}
+
+// GetReplicationMessages API call
+func (a *AccessControlledAdminHandler) GetReplicationMessages(
+	ctx context.Context,
+	request *adminservice.GetReplicationMessagesRequest,
+) (*adminservice.GetReplicationMessagesResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminGetReplicationMessagesScope, "GetReplicationMessages", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.GetReplicationMessages(ctx, request)
+}
+
+// GetNamespaceReplicationMessages API call
+func (a *AccessControlledAdminHandler) GetNamespaceReplicationMessages(
+	ctx context.Context,
+	request *adminservice.GetNamespaceReplicationMessagesRequest,
+) (*adminservice.GetNamespaceReplicationMessagesResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminGetNamespaceReplicationMessagesScope, "GetNamespaceReplicationMessages", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.GetNamespaceReplicationMessages(ctx, request)
+}
+
+// GetDLQReplicationMessages API call
+func (a *AccessControlledAdminHandler) GetDLQReplicationMessages(
+	ctx context.Context,
+	request *adminservice.GetDLQReplicationMessagesRequest,
+) (*adminservice.GetDLQReplicationMessagesResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminGetDLQReplicationMessagesScope, "GetDLQReplicationMessages", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.GetDLQReplicationMessages(ctx, request)
+}
+
+// ReapplyEvents API call
+func (a *AccessControlledAdminHandler) ReapplyEvents(
+	ctx context.Context,
+	request *adminservice.ReapplyEventsRequest,
+) (*adminservice.ReapplyEventsResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminReapplyEventsScope, "ReapplyEvents", request.GetNamespace()); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.ReapplyEvents(ctx, request)
+}
+
+// ReadDLQMessages API call
+func (a *AccessControlledAdminHandler) ReadDLQMessages(
+	ctx context.Context,
+	request *adminservice.ReadDLQMessagesRequest,
+) (*adminservice.ReadDLQMessagesResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminReadDLQMessagesScope, "ReadDLQMessages", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.ReadDLQMessages(ctx, request)
+}
+
+// PurgeDLQMessages API call
+func (a *AccessControlledAdminHandler) PurgeDLQMessages(
+	ctx context.Context,
+	request *adminservice.PurgeDLQMessagesRequest,
+) (*adminservice.PurgeDLQMessagesResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminPurgeDLQMessagesScope, "PurgeDLQMessages", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.PurgeDLQMessages(ctx, request)
+}
+
+// MergeDLQMessages API call
+func (a *AccessControlledAdminHandler) MergeDLQMessages(
+	ctx context.Context,
+	request *adminservice.MergeDLQMessagesRequest,
+) (*adminservice.MergeDLQMessagesResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminMergeDLQMessagesScope, "MergeDLQMessages", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.MergeDLQMessages(ctx, request)
+}
+
+// RefreshWorkflowTasks API call
+func (a *AccessControlledAdminHandler) RefreshWorkflowTasks(
+	ctx context.Context,
+	request *adminservice.RefreshWorkflowTasksRequest,
+) (*adminservice.RefreshWorkflowTasksResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminRefreshWorkflowTasksScope, "RefreshWorkflowTasks", request.GetNamespace()); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.RefreshWorkflowTasks(ctx, request)
+}
+
+// DescribeLogLevels API call
+func (a *AccessControlledAdminHandler) DescribeLogLevels(
+	ctx context.Context,
+	request *adminservice.DescribeLogLevelsRequest,
+) (*adminservice.DescribeLogLevelsResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminDescribeLogLevelsScope, "DescribeLogLevels", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.DescribeLogLevels(ctx, request)
+}
+
+// UpdateLogLevel API call
+func (a *AccessControlledAdminHandler) UpdateLogLevel(
+	ctx context.Context,
+	request *adminservice.UpdateLogLevelRequest,
+) (*adminservice.UpdateLogLevelResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminUpdateLogLevelScope, "UpdateLogLevel", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.UpdateLogLevel(ctx, request)
+}
+
+// DescribeNamespaceQuotas API call
+func (a *AccessControlledAdminHandler) DescribeNamespaceQuotas(
+	ctx context.Context,
+	request *adminservice.DescribeNamespaceQuotasRequest,
+) (*adminservice.DescribeNamespaceQuotasResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminDescribeNamespaceQuotasScope, "DescribeNamespaceQuotas", request.GetNamespace()); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.DescribeNamespaceQuotas(ctx, request)
+}
+
+// UpdateNamespaceQuotas API call
+func (a *AccessControlledAdminHandler) UpdateNamespaceQuotas(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceQuotasRequest,
+) (*adminservice.UpdateNamespaceQuotasResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminUpdateNamespaceQuotasScope, "UpdateNamespaceQuotas", request.GetNamespace()); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.UpdateNamespaceQuotas(ctx, request)
+}
+
+// CaptureProfile API call
+func (a *AccessControlledAdminHandler) CaptureProfile(
+	ctx context.Context,
+	request *adminservice.CaptureProfileRequest,
+) (*adminservice.CaptureProfileResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminCaptureProfileScope, "CaptureProfile", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.CaptureProfile(ctx, request)
+}
+
+// GetHostDiagnostics API call
+func (a *AccessControlledAdminHandler) GetHostDiagnostics(
+	ctx context.Context,
+	request *adminservice.GetHostDiagnosticsRequest,
+) (*adminservice.GetHostDiagnosticsResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminGetHostDiagnosticsScope, "GetHostDiagnostics", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.GetHostDiagnostics(ctx, request)
+}
+
+// DescribeShardQueues API call
+func (a *AccessControlledAdminHandler) DescribeShardQueues(
+	ctx context.Context,
+	request *adminservice.DescribeShardQueuesRequest,
+) (*adminservice.DescribeShardQueuesResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminDescribeShardQueuesScope, "DescribeShardQueues", ""); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.DescribeShardQueues(ctx, request)
+}
+
+// DescribeTaskList API call
+func (a *AccessControlledAdminHandler) DescribeTaskList(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListRequest,
+) (*adminservice.DescribeTaskListResponse, error) {
+
+	if err := a.authorize(ctx, metrics.AdminDescribeTaskListScope, "DescribeTaskList", request.GetNamespace()); err != nil {
+		return nil, err
+	}
+
+	return a.adminHandler.DescribeTaskList(ctx, request)
+}
+
+// authorize checks the caller against the authorizer and logs the caller of every admin call, allowed or not
+func (a *AccessControlledAdminHandler) authorize(
+	ctx context.Context,
+	scopeIdx int,
+	api string,
+	namespace string,
+) error {
+	scope := getMetricsScopeWithNamespace(scopeIdx, namespace, a.metricsClient)
+	sw := scope.StartTimer(metrics.ServiceAuthorizationLatency)
+	defer sw.Stop()
+
+	attr := &authorization.Attributes{
+		APIName:   adminAPINamePrefix + api,
+		Namespace: namespace,
+	}
+	if claims := authorization.GetClaims(ctx); claims != nil {
+		attr.Actor = claims.Subject
+	}
+	result, err := a.authorizer.Authorize(ctx, attr)
+	if err != nil {
+		scope.IncCounter(metrics.ServiceErrAuthorizeFailedCounter)
+		a.logger.Error("Admin request authorization failed",
+			tag.Actor(attr.Actor), tag.WorkflowNamespace(namespace), tag.Name(attr.APIName), tag.Error(err))
+		return err
+	}
+	if result.Decision != authorization.DecisionAllow {
+		scope.IncCounter(metrics.ServiceErrUnauthorizedCounter)
+		a.logger.Warn("Admin request is not authorized",
+			tag.Actor(attr.Actor), tag.WorkflowNamespace(namespace), tag.Name(attr.APIName))
+		return errUnauthorized
+	}
+	if _, ok := adminReplicationPollAPIs[api]; ok {
+		a.logger.Debug("Admin request authorized",
+			tag.Actor(attr.Actor), tag.WorkflowNamespace(namespace), tag.Name(attr.APIName))
+		return nil
+	}
+	a.logger.Info("Admin request authorized",
+		tag.Actor(attr.Actor), tag.WorkflowNamespace(namespace), tag.Name(attr.APIName))
+	return nil
+}
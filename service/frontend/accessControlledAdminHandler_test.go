@@ -0,0 +1,132 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/.gen/proto/adminservicemock"
+	"github.com/temporalio/temporal/common/authorization"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+type (
+	accessControlledAdminHandlerSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller       *gomock.Controller
+		mockAdminHandler *adminservicemock.MockAdminServiceServer
+		mockAuthorizer   *authorization.MockAuthorizer
+
+		handler *AccessControlledAdminHandler
+	}
+)
+
+func TestAccessControlledAdminHandlerSuite(t *testing.T) {
+	s := new(accessControlledAdminHandlerSuite)
+	suite.Run(t, s)
+}
+
+func (s *accessControlledAdminHandlerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.controller = gomock.NewController(s.T())
+
+	s.mockAdminHandler = adminservicemock.NewMockAdminServiceServer(s.controller)
+	s.mockAuthorizer = authorization.NewMockAuthorizer(s.controller)
+	s.handler = NewAccessControlledAdminHandler(
+		s.mockAdminHandler,
+		s.mockAuthorizer,
+		metrics.NewClient(tally.NoopScope, metrics.Frontend),
+		loggerimpl.NewNopLogger(),
+	)
+}
+
+func (s *accessControlledAdminHandlerSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *accessControlledAdminHandlerSuite) TestAuthorized() {
+	ctx := context.Background()
+	request := &adminservice.PurgeDLQMessagesRequest{}
+	response := &adminservice.PurgeDLQMessagesResponse{}
+
+	s.mockAuthorizer.EXPECT().Authorize(ctx, &authorization.Attributes{APIName: "AdminPurgeDLQMessages"}).
+		Return(authorization.Result{Decision: authorization.DecisionAllow}, nil).Times(1)
+	s.mockAdminHandler.EXPECT().PurgeDLQMessages(ctx, request).Return(response, nil).Times(1)
+
+	resp, err := s.handler.PurgeDLQMessages(ctx, request)
+	s.NoError(err)
+	s.Equal(response, resp)
+}
+
+func (s *accessControlledAdminHandlerSuite) TestAuthorized_LogsCaller() {
+	logger := &log.MockLogger{}
+	handler := NewAccessControlledAdminHandler(
+		s.mockAdminHandler,
+		s.mockAuthorizer,
+		metrics.NewClient(tally.NoopScope, metrics.Frontend),
+		logger,
+	)
+	ctx := context.Background()
+
+	s.mockAuthorizer.EXPECT().Authorize(ctx, gomock.Any()).
+		Return(authorization.Result{Decision: authorization.DecisionAllow}, nil).Times(2)
+	s.mockAdminHandler.EXPECT().CloseShard(ctx, gomock.Any()).Return(&adminservice.CloseShardResponse{}, nil).Times(1)
+	s.mockAdminHandler.EXPECT().GetReplicationMessages(ctx, gomock.Any()).Return(&adminservice.GetReplicationMessagesResponse{}, nil).Times(1)
+	logger.On("Info", "Admin request authorized", mock.Anything).Once()
+	logger.On("Debug", "Admin request authorized", mock.Anything).Once()
+
+	_, err := handler.CloseShard(ctx, &adminservice.CloseShardRequest{})
+	s.NoError(err)
+	// replication polls of remote clusters are not logged at info level
+	_, err = handler.GetReplicationMessages(ctx, &adminservice.GetReplicationMessagesRequest{})
+	s.NoError(err)
+	logger.AssertExpectations(s.T())
+}
+
+func (s *accessControlledAdminHandlerSuite) TestUnauthorized() {
+	ctx := context.Background()
+	request := &adminservice.DescribeWorkflowExecutionRequest{Namespace: "test-namespace"}
+
+	s.mockAuthorizer.EXPECT().Authorize(ctx, &authorization.Attributes{
+		APIName:   "AdminDescribeWorkflowExecution",
+		Namespace: "test-namespace",
+	}).Return(authorization.Result{Decision: authorization.DecisionDeny}, nil).Times(1)
+
+	resp, err := s.handler.DescribeWorkflowExecution(ctx, request)
+	s.Equal(errUnauthorized, err)
+	s.Nil(resp)
+}
@@ -30,8 +30,12 @@ import (
 	"go.temporal.io/temporal-proto/workflowservice"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/authorization"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/primitives"
 	"github.com/temporalio/temporal/common/resource"
 )
 
@@ -41,6 +45,8 @@ import (
 type AccessControlledWorkflowHandler struct {
 	frontendHandler Handler
 	authorizer      authorization.Authorizer
+	tokenSerializer common.TaskTokenSerializer
+	logger          log.Logger
 }
 
 var _ Handler = (*AccessControlledWorkflowHandler)(nil)
@@ -54,6 +60,8 @@ func NewAccessControlledHandlerImpl(wfHandler Handler, authorizer authorization.
 	return &AccessControlledWorkflowHandler{
 		frontendHandler: wfHandler,
 		authorizer:      authorizer,
+		tokenSerializer: common.NewProtoTaskTokenSerializer(),
+		logger:          wfHandler.GetResource().GetLogger(),
 	}
 }
 
@@ -172,6 +180,7 @@ func (a *AccessControlledWorkflowHandler) DescribeTaskList(
 	attr := &authorization.Attributes{
 		APIName:   "DescribeTaskList",
 		Namespace: request.GetNamespace(),
+		TaskList:  request.TaskList.GetName(),
 	}
 	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
 	if err != nil {
@@ -363,6 +372,7 @@ func (a *AccessControlledWorkflowHandler) PollForActivityTask(
 	attr := &authorization.Attributes{
 		APIName:   "PollForActivityTask",
 		Namespace: request.GetNamespace(),
+		TaskList:  request.TaskList.GetName(),
 	}
 	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
 	if err != nil {
@@ -386,6 +396,7 @@ func (a *AccessControlledWorkflowHandler) PollForDecisionTask(
 	attr := &authorization.Attributes{
 		APIName:   "PollForDecisionTask",
 		Namespace: request.GetNamespace(),
+		TaskList:  request.TaskList.GetName(),
 	}
 	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
 	if err != nil {
@@ -434,7 +445,25 @@ func (a *AccessControlledWorkflowHandler) RecordActivityTaskHeartbeat(
 	ctx context.Context,
 	request *workflowservice.RecordActivityTaskHeartbeatRequest,
 ) (*workflowservice.RecordActivityTaskHeartbeatResponse, error) {
-	// TODO(vancexu): add auth check for service API
+
+	namespace, err := a.getTaskTokenNamespace(request.GetTaskToken())
+	if err != nil {
+		return nil, err
+	}
+	scope := a.getMetricsScopeWithNamespace(metrics.FrontendRecordActivityTaskHeartbeatScope, namespace)
+
+	attr := &authorization.Attributes{
+		APIName:   "RecordActivityTaskHeartbeat",
+		Namespace: namespace,
+	}
+	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuthorized {
+		return nil, errUnauthorized
+	}
+
 	return a.frontendHandler.RecordActivityTaskHeartbeat(ctx, request)
 }
 
@@ -443,6 +472,21 @@ func (a *AccessControlledWorkflowHandler) RecordActivityTaskHeartbeatById(
 	ctx context.Context,
 	request *workflowservice.RecordActivityTaskHeartbeatByIdRequest,
 ) (*workflowservice.RecordActivityTaskHeartbeatByIdResponse, error) {
+
+	scope := a.getMetricsScopeWithNamespace(metrics.FrontendRecordActivityTaskHeartbeatByIdScope, request.GetNamespace())
+
+	attr := &authorization.Attributes{
+		APIName:   "RecordActivityTaskHeartbeatById",
+		Namespace: request.GetNamespace(),
+	}
+	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuthorized {
+		return nil, errUnauthorized
+	}
+
 	return a.frontendHandler.RecordActivityTaskHeartbeatById(ctx, request)
 }
 
@@ -543,6 +587,25 @@ func (a *AccessControlledWorkflowHandler) RespondActivityTaskCanceled(
 	ctx context.Context,
 	request *workflowservice.RespondActivityTaskCanceledRequest,
 ) (*workflowservice.RespondActivityTaskCanceledResponse, error) {
+
+	namespace, err := a.getTaskTokenNamespace(request.GetTaskToken())
+	if err != nil {
+		return nil, err
+	}
+	scope := a.getMetricsScopeWithNamespace(metrics.FrontendRespondActivityTaskCanceledScope, namespace)
+
+	attr := &authorization.Attributes{
+		APIName:   "RespondActivityTaskCanceled",
+		Namespace: namespace,
+	}
+	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuthorized {
+		return nil, errUnauthorized
+	}
+
 	return a.frontendHandler.RespondActivityTaskCanceled(ctx, request)
 }
 
@@ -551,6 +614,21 @@ func (a *AccessControlledWorkflowHandler) RespondActivityTaskCanceledById(
 	ctx context.Context,
 	request *workflowservice.RespondActivityTaskCanceledByIdRequest,
 ) (*workflowservice.RespondActivityTaskCanceledByIdResponse, error) {
+
+	scope := a.getMetricsScopeWithNamespace(metrics.FrontendRespondActivityTaskCanceledByIdScope, request.GetNamespace())
+
+	attr := &authorization.Attributes{
+		APIName:   "RespondActivityTaskCanceledById",
+		Namespace: request.GetNamespace(),
+	}
+	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuthorized {
+		return nil, errUnauthorized
+	}
+
 	return a.frontendHandler.RespondActivityTaskCanceledById(ctx, request)
 }
 
@@ -559,6 +637,25 @@ func (a *AccessControlledWorkflowHandler) RespondActivityTaskCompleted(
 	ctx context.Context,
 	request *workflowservice.RespondActivityTaskCompletedRequest,
 ) (*workflowservice.RespondActivityTaskCompletedResponse, error) {
+
+	namespace, err := a.getTaskTokenNamespace(request.GetTaskToken())
+	if err != nil {
+		return nil, err
+	}
+	scope := a.getMetricsScopeWithNamespace(metrics.FrontendRespondActivityTaskCompletedScope, namespace)
+
+	attr := &authorization.Attributes{
+		APIName:   "RespondActivityTaskCompleted",
+		Namespace: namespace,
+	}
+	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuthorized {
+		return nil, errUnauthorized
+	}
+
 	return a.frontendHandler.RespondActivityTaskCompleted(ctx, request)
 }
 
@@ -567,6 +664,21 @@ func (a *AccessControlledWorkflowHandler) RespondActivityTaskCompletedById(
 	ctx context.Context,
 	request *workflowservice.RespondActivityTaskCompletedByIdRequest,
 ) (*workflowservice.RespondActivityTaskCompletedByIdResponse, error) {
+
+	scope := a.getMetricsScopeWithNamespace(metrics.FrontendRespondActivityTaskCompletedByIdScope, request.GetNamespace())
+
+	attr := &authorization.Attributes{
+		APIName:   "RespondActivityTaskCompletedById",
+		Namespace: request.GetNamespace(),
+	}
+	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuthorized {
+		return nil, errUnauthorized
+	}
+
 	return a.frontendHandler.RespondActivityTaskCompletedById(ctx, request)
 }
 
@@ -575,6 +687,25 @@ func (a *AccessControlledWorkflowHandler) RespondActivityTaskFailed(
 	ctx context.Context,
 	request *workflowservice.RespondActivityTaskFailedRequest,
 ) (*workflowservice.RespondActivityTaskFailedResponse, error) {
+
+	namespace, err := a.getTaskTokenNamespace(request.GetTaskToken())
+	if err != nil {
+		return nil, err
+	}
+	scope := a.getMetricsScopeWithNamespace(metrics.FrontendRespondActivityTaskFailedScope, namespace)
+
+	attr := &authorization.Attributes{
+		APIName:   "RespondActivityTaskFailed",
+		Namespace: namespace,
+	}
+	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuthorized {
+		return nil, errUnauthorized
+	}
+
 	return a.frontendHandler.RespondActivityTaskFailed(ctx, request)
 }
 
@@ -583,6 +714,21 @@ func (a *AccessControlledWorkflowHandler) RespondActivityTaskFailedById(
 	ctx context.Context,
 	request *workflowservice.RespondActivityTaskFailedByIdRequest,
 ) (*workflowservice.RespondActivityTaskFailedByIdResponse, error) {
+
+	scope := a.getMetricsScopeWithNamespace(metrics.FrontendRespondActivityTaskFailedByIdScope, request.GetNamespace())
+
+	attr := &authorization.Attributes{
+		APIName:   "RespondActivityTaskFailedById",
+		Namespace: request.GetNamespace(),
+	}
+	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuthorized {
+		return nil, errUnauthorized
+	}
+
 	return a.frontendHandler.RespondActivityTaskFailedById(ctx, request)
 }
 
@@ -642,8 +788,10 @@ func (a *AccessControlledWorkflowHandler) SignalWithStartWorkflowExecution(
 	scope := a.getMetricsScopeWithNamespace(metrics.FrontendSignalWithStartWorkflowExecutionScope, request.GetNamespace())
 
 	attr := &authorization.Attributes{
-		APIName:   "SignalWithStartWorkflowExecution",
-		Namespace: request.GetNamespace(),
+		APIName:      "SignalWithStartWorkflowExecution",
+		Namespace:    request.GetNamespace(),
+		WorkflowType: request.WorkflowType.GetName(),
+		TaskList:     request.TaskList.GetName(),
 	}
 	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
 	if err != nil {
@@ -688,8 +836,10 @@ func (a *AccessControlledWorkflowHandler) StartWorkflowExecution(
 	scope := a.getMetricsScopeWithNamespace(metrics.FrontendStartWorkflowExecutionScope, request.GetNamespace())
 
 	attr := &authorization.Attributes{
-		APIName:   "StartWorkflowExecution",
-		Namespace: request.GetNamespace(),
+		APIName:      "StartWorkflowExecution",
+		Namespace:    request.GetNamespace(),
+		WorkflowType: request.WorkflowType.GetName(),
+		TaskList:     request.TaskList.GetName(),
 	}
 	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
 	if err != nil {
@@ -736,6 +886,7 @@ func (a *AccessControlledWorkflowHandler) ListTaskListPartitions(
 	attr := &authorization.Attributes{
 		APIName:   "ListTaskListPartitions",
 		Namespace: request.GetNamespace(),
+		TaskList:  request.TaskList.GetName(),
 	}
 	isAuthorized, err := a.isAuthorized(ctx, attr, scope)
 	if err != nil {
@@ -779,6 +930,9 @@ func (a *AccessControlledWorkflowHandler) isAuthorized(
 	sw := scope.StartTimer(metrics.ServiceAuthorizationLatency)
 	defer sw.Stop()
 
+	if claims := authorization.GetClaims(ctx); claims != nil {
+		attr.Actor = claims.Subject
+	}
 	result, err := a.authorizer.Authorize(ctx, attr)
 	if err != nil {
 		scope.IncCounter(metrics.ServiceErrAuthorizeFailedCounter)
@@ -787,10 +941,24 @@ func (a *AccessControlledWorkflowHandler) isAuthorized(
 	isAuth := result.Decision == authorization.DecisionAllow
 	if !isAuth {
 		scope.IncCounter(metrics.ServiceErrUnauthorizedCounter)
+		a.logger.Warn("Request is not authorized",
+			tag.Actor(attr.Actor), tag.WorkflowNamespace(attr.Namespace), tag.Name(attr.APIName))
 	}
 	return isAuth, nil
 }
 
+// getTaskTokenNamespace returns the name of the namespace the task token was issued for
+func (a *AccessControlledWorkflowHandler) getTaskTokenNamespace(taskToken []byte) (string, error) {
+	if taskToken == nil {
+		return "", errTaskTokenNotSet
+	}
+	token, err := a.tokenSerializer.Deserialize(taskToken)
+	if err != nil {
+		return "", errInvalidTaskToken
+	}
+	return a.GetResource().GetNamespaceCache().GetNamespaceName(primitives.UUIDString(token.GetNamespaceId()))
+}
+
 // getMetricsScopeWithNamespace return metrics scope with namespace tag
 func (a *AccessControlledWorkflowHandler) getMetricsScopeWithNamespace(
 	scope int,
@@ -32,11 +32,15 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.temporal.io/temporal-proto/workflowservice"
 	"go.temporal.io/temporal-proto/workflowservicemock"
 
+	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/authorization"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/metrics/mocks"
+	"github.com/temporalio/temporal/common/primitives"
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
@@ -47,6 +51,7 @@ type (
 		*require.Assertions
 
 		controller          *gomock.Controller
+		mockResource        *resource.Test
 		mockFrontendHandler *workflowservicemock.MockWorkflowServiceServer
 		mockAuthorizer      *authorization.MockAuthorizer
 		mockMetricsScope    *mocks.Scope
@@ -64,10 +69,10 @@ func (s *accessControlledHandlerSuite) SetupTest() {
 	s.Assertions = require.New(s.T())
 	s.controller = gomock.NewController(s.T())
 
-	mockResource := resource.NewTest(s.controller, metrics.Frontend)
-	config := NewConfig(dynamicconfig.NewCollection(dynamicconfig.NewNopClient(), mockResource.GetLogger()), 0, false)
+	s.mockResource = resource.NewTest(s.controller, metrics.Frontend)
+	config := NewConfig(dynamicconfig.NewCollection(dynamicconfig.NewNopClient(), s.mockResource.GetLogger()), 0, false)
 
-	frontendHandlerGRPC := NewWorkflowHandler(mockResource, config, nil)
+	frontendHandlerGRPC := NewWorkflowHandler(s.mockResource, config, nil)
 	s.mockFrontendHandler = workflowservicemock.NewMockWorkflowServiceServer(s.controller)
 	s.mockAuthorizer = authorization.NewMockAuthorizer(s.controller)
 	s.mockMetricsScope = &mocks.Scope{}
@@ -76,6 +81,7 @@ func (s *accessControlledHandlerSuite) SetupTest() {
 
 func (s *accessControlledHandlerSuite) TearDownTest() {
 	s.controller.Finish()
+	s.mockResource.Finish(s.T())
 	s.mockMetricsScope.AssertExpectations(s.T())
 }
 
@@ -124,3 +130,47 @@ func (s *accessControlledHandlerSuite) TestIsAuthorized_Unauthorized() {
 	s.False(res)
 	s.NoError(err)
 }
+
+func (s *accessControlledHandlerSuite) TestGetTaskTokenNamespace() {
+	taskToken, err := common.NewProtoTaskTokenSerializer().Serialize(&tokengenpb.Task{
+		NamespaceId: testNamespaceID,
+		WorkflowId:  "wid",
+		ScheduleId:  5,
+	})
+	s.NoError(err)
+	s.mockResource.NamespaceCache.EXPECT().GetNamespaceName(primitives.UUIDString(testNamespaceID)).
+		Return("payments", nil).Times(1)
+
+	namespace, err := s.handler.getTaskTokenNamespace(taskToken)
+	s.NoError(err)
+	s.Equal("payments", namespace)
+}
+
+func (s *accessControlledHandlerSuite) TestGetTaskTokenNamespace_InvalidToken() {
+	_, err := s.handler.getTaskTokenNamespace(nil)
+	s.Equal(errTaskTokenNotSet, err)
+
+	_, err = s.handler.getTaskTokenNamespace([]byte("not a task token"))
+	s.Equal(errInvalidTaskToken, err)
+}
+
+func (s *accessControlledHandlerSuite) TestRespondActivityTaskCompleted_Unauthorized() {
+	taskToken, err := common.NewProtoTaskTokenSerializer().Serialize(&tokengenpb.Task{
+		NamespaceId: testNamespaceID,
+		WorkflowId:  "wid",
+		ScheduleId:  5,
+	})
+	s.NoError(err)
+	s.mockResource.NamespaceCache.EXPECT().GetNamespaceName(primitives.UUIDString(testNamespaceID)).
+		Return("payments", nil).Times(1)
+	s.mockAuthorizer.EXPECT().Authorize(gomock.Any(), &authorization.Attributes{
+		APIName:   "RespondActivityTaskCompleted",
+		Namespace: "payments",
+	}).Return(authorization.Result{Decision: authorization.DecisionDeny}, nil).Times(1)
+
+	resp, err := s.handler.RespondActivityTaskCompleted(context.Background(), &workflowservice.RespondActivityTaskCompletedRequest{
+		TaskToken: taskToken,
+	})
+	s.Nil(resp)
+	s.Equal(errUnauthorized, err)
+}
@@ -27,6 +27,8 @@ package frontend
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -36,11 +38,14 @@ import (
 	eventpb "go.temporal.io/temporal-proto/event"
 	"go.temporal.io/temporal-proto/serviceerror"
 	versionpb "go.temporal.io/temporal-proto/version"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	clustergenpb "github.com/temporalio/temporal/.gen/proto/cluster"
 	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
 	"github.com/temporalio/temporal/common"
@@ -49,10 +54,12 @@ import (
 	"github.com/temporalio/temporal/common/headers"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/membership"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/namespace"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/primitives"
+	"github.com/temporalio/temporal/common/profiling"
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/service/history"
@@ -72,6 +79,7 @@ type (
 		params                *resource.BootstrapParams
 		config                *Config
 		namespaceDLQHandler   namespace.DLQMessageHandler
+		namespaceQuotaHandler namespace.QuotaHandler
 	}
 )
 
@@ -102,6 +110,10 @@ func NewAdminHandler(
 			resource.GetNamespaceReplicationQueue(),
 			resource.GetLogger(),
 		),
+		namespaceQuotaHandler: namespace.NewQuotaHandler(
+			resource.GetMetadataManager(),
+			resource.GetLogger(),
+		),
 	}
 }
 
@@ -263,7 +275,7 @@ func (adh *AdminHandler) DescribeHistoryHost(ctx context.Context, request *admin
 	scope, sw := adh.startRequestProfile(metrics.AdminDescribeHistoryHostScope)
 	defer sw.Stop()
 
-	if request == nil || request.ExecutionForHost == nil {
+	if request == nil {
 		return nil, adh.error(errRequestNotSet, scope)
 	}
 
@@ -394,6 +406,7 @@ func (adh *AdminHandler) GetWorkflowExecutionRawHistory(ctx context.Context, req
 	_, historyBatches, continuationToken.PersistenceToken, size, err = history.PaginateHistory(
 		adh.GetHistoryManager(),
 		true, // this means that we are getting history by batch
+		namespaceID,
 		continuationToken.GetBranchToken(),
 		continuationToken.GetFirstEventId(),
 		continuationToken.GetNextEventId(),
@@ -527,7 +540,7 @@ func (adh *AdminHandler) GetWorkflowExecutionRawHistoryV2(ctx context.Context, r
 		execution.GetWorkflowId(),
 		adh.numberOfHistoryShards,
 	)
-	rawHistoryResponse, err := adh.GetHistoryManager().ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
+	rawHistoryResponse, err := persistence.HistoryManagerWithContext(ctx, adh.GetHistoryManager()).ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
 		BranchToken: targetVersionHistory.GetBranchToken(),
 		// GetWorkflowExecutionRawHistoryV2 is exclusive exclusive.
 		// ReadRawHistoryBranch is inclusive exclusive.
@@ -536,6 +549,7 @@ func (adh *AdminHandler) GetWorkflowExecutionRawHistoryV2(ctx context.Context, r
 		PageSize:      pageSize,
 		NextPageToken: pageToken.PersistenceToken,
 		ShardID:       &shardID,
+		NamespaceID:   namespaceID,
 	})
 	if err != nil {
 		if _, ok := err.(*serviceerror.NotFound); ok {
@@ -583,7 +597,7 @@ func (adh *AdminHandler) GetWorkflowExecutionRawHistoryV2(ctx context.Context, r
 func (adh *AdminHandler) DescribeCluster(ctx context.Context, _ *adminservice.DescribeClusterRequest) (_ *adminservice.DescribeClusterResponse, retError error) {
 	defer log.CapturePanic(adh.GetLogger(), &retError)
 
-	scope, sw := adh.startRequestProfile(metrics.AdminGetWorkflowExecutionRawHistoryV2Scope)
+	scope, sw := adh.startRequestProfile(metrics.AdminDescribeClusterScope)
 	defer sw.Stop()
 
 	membershipInfo := &clustergenpb.MembershipInfo{}
@@ -987,6 +1001,470 @@ func (adh *AdminHandler) RefreshWorkflowTasks(
 	return &adminservice.RefreshWorkflowTasksResponse{}, nil
 }
 
+// DescribeLogLevels returns the log levels of the processes of a frontend, history or matching host,
+// of all hosts of a service when no host is set, and of all hosts of these services when no service is set
+func (adh *AdminHandler) DescribeLogLevels(
+	ctx context.Context,
+	request *adminservice.DescribeLogLevelsRequest,
+) (_ *adminservice.DescribeLogLevelsResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminDescribeLogLevelsScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	hosts, err := adh.fanOutLogLevels(request.GetService(), request.GetHostAddress(), func(service string, hostAddress string) (*commongenpb.HostLogLevels, error) {
+		switch service {
+		case common.FrontendServiceName:
+			if hostAddress == adh.GetHostInfo().GetAddress() {
+				return resource.DescribeLogLevels(adh)
+			}
+			var resp *adminservice.DescribeLogLevelsResponse
+			err := adh.callFrontendAdmin(ctx, hostAddress, func(ctx context.Context, client adminservice.AdminServiceClient) error {
+				var err error
+				resp, err = client.DescribeLogLevels(ctx, &adminservice.DescribeLogLevelsRequest{
+					Service:     service,
+					HostAddress: hostAddress,
+				})
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+			return singleHostLogLevels(resp.GetHosts(), hostAddress)
+		case common.HistoryServiceName:
+			resp, err := adh.GetHistoryClient().DescribeLogLevels(ctx, &historyservice.DescribeLogLevelsRequest{
+				HostAddress: hostAddress,
+			})
+			return resp.GetLogLevels(), err
+		default:
+			resp, err := adh.GetMatchingClient().DescribeLogLevels(ctx, &matchingservice.DescribeLogLevelsRequest{
+				HostAddress: hostAddress,
+			})
+			return resp.GetLogLevels(), err
+		}
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.DescribeLogLevelsResponse{
+		Hosts: hosts,
+	}, nil
+}
+
+// UpdateLogLevel changes the log level of a package, or the default log level when no package is set, in the processes
+// of a frontend, history or matching host until they restart. Like DescribeLogLevels it applies to all hosts of the
+// service when no host is set, and to all hosts of these services when no service is set.
+func (adh *AdminHandler) UpdateLogLevel(
+	ctx context.Context,
+	request *adminservice.UpdateLogLevelRequest,
+) (_ *adminservice.UpdateLogLevelResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminUpdateLogLevelScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetPackagePath() == "" && request.GetLevel() == "" {
+		return nil, adh.error(errLogLevelNotSet, scope)
+	}
+	hosts, err := adh.fanOutLogLevels(request.GetService(), request.GetHostAddress(), func(service string, hostAddress string) (*commongenpb.HostLogLevels, error) {
+		switch service {
+		case common.FrontendServiceName:
+			if hostAddress == adh.GetHostInfo().GetAddress() {
+				return resource.UpdateLogLevel(adh, request.GetPackagePath(), request.GetLevel())
+			}
+			var resp *adminservice.UpdateLogLevelResponse
+			err := adh.callFrontendAdmin(ctx, hostAddress, func(ctx context.Context, client adminservice.AdminServiceClient) error {
+				var err error
+				resp, err = client.UpdateLogLevel(ctx, &adminservice.UpdateLogLevelRequest{
+					Service:     service,
+					HostAddress: hostAddress,
+					PackagePath: request.GetPackagePath(),
+					Level:       request.GetLevel(),
+				})
+				return err
+			})
+			if err != nil {
+				return nil, err
+			}
+			return singleHostLogLevels(resp.GetHosts(), hostAddress)
+		case common.HistoryServiceName:
+			resp, err := adh.GetHistoryClient().UpdateLogLevel(ctx, &historyservice.UpdateLogLevelRequest{
+				HostAddress: hostAddress,
+				PackagePath: request.GetPackagePath(),
+				Level:       request.GetLevel(),
+			})
+			return resp.GetLogLevels(), err
+		default:
+			resp, err := adh.GetMatchingClient().UpdateLogLevel(ctx, &matchingservice.UpdateLogLevelRequest{
+				HostAddress: hostAddress,
+				PackagePath: request.GetPackagePath(),
+				Level:       request.GetLevel(),
+			})
+			return resp.GetLogLevels(), err
+		}
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.UpdateLogLevelResponse{
+		Hosts: hosts,
+	}, nil
+}
+
+// fanOutLogLevels calls op for the host of a service, for all hosts of the service when hostAddress is empty,
+// and for all hosts of the frontend, history and matching services when service is empty
+func (adh *AdminHandler) fanOutLogLevels(
+	service string,
+	hostAddress string,
+	op func(service string, hostAddress string) (*commongenpb.HostLogLevels, error),
+) ([]*commongenpb.HostLogLevels, error) {
+	var services []string
+	switch service {
+	case common.FrontendServiceName, common.HistoryServiceName, common.MatchingServiceName:
+		services = []string{service}
+	case "":
+		if hostAddress != "" {
+			return nil, errLogLevelServiceNotSet
+		}
+		services = []string{common.FrontendServiceName, common.HistoryServiceName, common.MatchingServiceName}
+	default:
+		return nil, errLogLevelServiceNotSupported
+	}
+
+	var hosts []*commongenpb.HostLogLevels
+	for _, service := range services {
+		hostAddresses := []string{hostAddress}
+		if hostAddress == "" {
+			hostAddresses = adh.serviceHostAddresses(service)
+		}
+		for _, address := range hostAddresses {
+			logLevels, err := op(service, address)
+			if err != nil {
+				adh.GetLogger().Warn("Failed to call log levels of host.",
+					tag.Service(service),
+					tag.Address(address),
+					tag.Error(err))
+				return nil, err
+			}
+			hosts = append(hosts, logLevels)
+		}
+	}
+	return hosts, nil
+}
+
+// serviceHostAddresses returns the sorted addresses of the hosts of a frontend, history or matching service
+func (adh *AdminHandler) serviceHostAddresses(service string) []string {
+	var resolver membership.ServiceResolver
+	switch service {
+	case common.FrontendServiceName:
+		resolver = adh.GetFrontendServiceResolver()
+	case common.HistoryServiceName:
+		resolver = adh.GetHistoryServiceResolver()
+	default:
+		resolver = adh.GetMatchingServiceResolver()
+	}
+	var hostAddresses []string
+	for _, host := range resolver.Members() {
+		hostAddresses = append(hostAddresses, host.GetAddress())
+	}
+	sort.Strings(hostAddresses)
+	return hostAddresses
+}
+
+// callFrontendAdmin calls the admin service of another frontend host with the metadata of the incoming request
+func (adh *AdminHandler) callFrontendAdmin(
+	ctx context.Context,
+	hostAddress string,
+	op func(ctx context.Context, client adminservice.AdminServiceClient) error,
+) error {
+	connection := adh.params.RPCFactory.CreateGRPCConnection(hostAddress)
+	defer func() { _ = connection.Close() }()
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+	return op(ctx, adminservice.NewAdminServiceClient(connection))
+}
+
+// singleHostLogLevels returns the log levels of the only host of a response targeting one host
+func singleHostLogLevels(hosts []*commongenpb.HostLogLevels, hostAddress string) (*commongenpb.HostLogLevels, error) {
+	if len(hosts) != 1 {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("Expected log levels of host %v, got %v hosts.", hostAddress, len(hosts)))
+	}
+	return hosts[0], nil
+}
+
+// DescribeNamespaceQuotas returns the quotas of a namespace in this cluster
+func (adh *AdminHandler) DescribeNamespaceQuotas(
+	ctx context.Context,
+	request *adminservice.DescribeNamespaceQuotasRequest,
+) (_ *adminservice.DescribeNamespaceQuotasResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminDescribeNamespaceQuotasScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	quotas, err := adh.namespaceQuotaHandler.Describe(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.DescribeNamespaceQuotasResponse{
+		Quotas: quotas,
+	}, nil
+}
+
+// UpdateNamespaceQuotas replaces the quotas of a namespace in this cluster
+func (adh *AdminHandler) UpdateNamespaceQuotas(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceQuotasRequest,
+) (_ *adminservice.UpdateNamespaceQuotasResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminUpdateNamespaceQuotasScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	quotas, err := adh.namespaceQuotaHandler.Update(request.GetNamespace(), request.GetQuotas())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.UpdateNamespaceQuotasResponse{
+		Quotas: quotas,
+	}, nil
+}
+
+// CaptureProfile captures a profile of the process of a frontend, history or matching host
+func (adh *AdminHandler) CaptureProfile(
+	ctx context.Context,
+	request *adminservice.CaptureProfileRequest,
+) (_ *adminservice.CaptureProfileResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminCaptureProfileScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	duration := time.Duration(request.GetDurationInSeconds()) * time.Second
+	if err := profiling.Validate(request.GetProfileType(), duration); err != nil {
+		return nil, adh.error(serviceerror.NewInvalidArgument(err.Error()), scope)
+	}
+	if request.GetService() != common.FrontendServiceName && request.GetHostAddress() == "" {
+		return nil, adh.error(errHostAddressNotSet, scope)
+	}
+
+	switch request.GetService() {
+	case common.FrontendServiceName:
+		hostAddress := adh.GetHostInfo().Identity()
+		if request.GetHostAddress() != "" && request.GetHostAddress() != hostAddress {
+			if !adh.isFrontendHost(request.GetHostAddress()) {
+				return nil, adh.error(errFrontendHostNotFound, scope)
+			}
+			resp, err := adh.GetFrontendAdminClient().CaptureProfile(ctx, request)
+			if err != nil {
+				return nil, adh.error(err, scope)
+			}
+			return resp, nil
+		}
+		profile, err := profiling.Capture(ctx, request.GetProfileType(), duration)
+		if err != nil {
+			return nil, adh.error(serviceerror.NewInternal(err.Error()), scope)
+		}
+		return &adminservice.CaptureProfileResponse{
+			HostAddress: hostAddress,
+			Profile:     profile,
+		}, nil
+	case common.HistoryServiceName:
+		resp, err := adh.GetHistoryClient().CaptureProfile(ctx, &historyservice.CaptureProfileRequest{
+			HostAddress:       request.GetHostAddress(),
+			ProfileType:       request.GetProfileType(),
+			DurationInSeconds: request.GetDurationInSeconds(),
+		})
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		return &adminservice.CaptureProfileResponse{
+			HostAddress: resp.GetHostAddress(),
+			Profile:     resp.GetProfile(),
+		}, nil
+	case common.MatchingServiceName:
+		resp, err := adh.GetMatchingClient().CaptureProfile(ctx, &matchingservice.CaptureProfileRequest{
+			HostAddress:       request.GetHostAddress(),
+			ProfileType:       request.GetProfileType(),
+			DurationInSeconds: request.GetDurationInSeconds(),
+		})
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		return &adminservice.CaptureProfileResponse{
+			HostAddress: resp.GetHostAddress(),
+			Profile:     resp.GetProfile(),
+		}, nil
+	default:
+		return nil, adh.error(errProfileServiceNotSupported, scope)
+	}
+}
+
+// isFrontendHost returns true when the given address is a member of the frontend membership ring
+func (adh *AdminHandler) isFrontendHost(hostAddress string) bool {
+	for _, host := range adh.GetFrontendServiceResolver().Members() {
+		if host.GetAddress() == hostAddress {
+			return true
+		}
+	}
+	return false
+}
+
+// GetHostDiagnostics returns the recent errors and the dynamic config of the process of a frontend, history or
+// matching host, along with the busiest task lists of a matching host
+func (adh *AdminHandler) GetHostDiagnostics(
+	ctx context.Context,
+	request *adminservice.GetHostDiagnosticsRequest,
+) (_ *adminservice.GetHostDiagnosticsResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminGetHostDiagnosticsScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetService() != common.FrontendServiceName && request.GetHostAddress() == "" {
+		return nil, adh.error(errHostAddressNotSet, scope)
+	}
+
+	switch request.GetService() {
+	case common.FrontendServiceName:
+		hostAddress := adh.GetHostInfo().Identity()
+		if request.GetHostAddress() != "" && request.GetHostAddress() != hostAddress {
+			return nil, adh.error(errFrontendDiagnosticsOnOtherHost, scope)
+		}
+		dynamicConfig, err := resource.SnapshotDynamicConfig(adh)
+		if err != nil {
+			return nil, adh.error(serviceerror.NewInternal(err.Error()), scope)
+		}
+		return &adminservice.GetHostDiagnosticsResponse{
+			HostAddress:   hostAddress,
+			RecentErrors:  resource.DescribeRecentErrors(adh),
+			DynamicConfig: dynamicConfig,
+		}, nil
+	case common.HistoryServiceName:
+		resp, err := adh.GetHistoryClient().GetHostDiagnostics(ctx, &historyservice.GetHostDiagnosticsRequest{
+			HostAddress: request.GetHostAddress(),
+		})
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		return &adminservice.GetHostDiagnosticsResponse{
+			HostAddress:   resp.GetHostAddress(),
+			RecentErrors:  resp.GetRecentErrors(),
+			DynamicConfig: resp.GetDynamicConfig(),
+		}, nil
+	case common.MatchingServiceName:
+		resp, err := adh.GetMatchingClient().GetHostDiagnostics(ctx, &matchingservice.GetHostDiagnosticsRequest{
+			HostAddress:  request.GetHostAddress(),
+			MaxTaskLists: request.GetMaxTaskLists(),
+		})
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		return &adminservice.GetHostDiagnosticsResponse{
+			HostAddress:   resp.GetHostAddress(),
+			RecentErrors:  resp.GetRecentErrors(),
+			DynamicConfig: resp.GetDynamicConfig(),
+			TaskLists:     resp.GetTaskLists(),
+		}, nil
+	default:
+		return nil, adh.error(errDiagnosticsServiceNotSupported, scope)
+	}
+}
+
+// DescribeShardQueues returns the levels and the failing tasks of the transfer, timer and replication queues of a
+// history shard
+func (adh *AdminHandler) DescribeShardQueues(
+	ctx context.Context,
+	request *adminservice.DescribeShardQueuesRequest,
+) (_ *adminservice.DescribeShardQueuesResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminDescribeShardQueuesScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetShardId() < 0 || int(request.GetShardId()) >= adh.numberOfHistoryShards {
+		return nil, adh.error(errInvalidShardID, scope)
+	}
+
+	resp, err := adh.GetHistoryClient().DescribeShardQueues(ctx, &historyservice.DescribeShardQueuesRequest{
+		ShardId: request.GetShardId(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.DescribeShardQueuesResponse{
+		ShardId:     resp.GetShardId(),
+		HostAddress: resp.GetHostAddress(),
+		Queues:      resp.GetQueues(),
+	}, nil
+}
+
+// DescribeTaskList returns the pollers and the status of a task list, along with the outstanding tasks of its
+// pollers and the limits enforced on them
+func (adh *AdminHandler) DescribeTaskList(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListRequest,
+) (_ *adminservice.DescribeTaskListResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminDescribeTaskListScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if request.GetTaskList().GetName() == "" {
+		return nil, adh.error(errTaskListNotSet, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.GetMatchingClient().DescribeTaskList(ctx, &matchingservice.DescribeTaskListRequest{
+		NamespaceId: namespaceID,
+		DescRequest: &workflowservice.DescribeTaskListRequest{
+			Namespace:             request.GetNamespace(),
+			TaskList:              request.GetTaskList(),
+			TaskListType:          request.GetTaskListType(),
+			IncludeTaskListStatus: request.GetIncludeTaskListStatus(),
+		},
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.DescribeTaskListResponse{
+		Pollers:        resp.GetPollers(),
+		TaskListStatus: resp.GetTaskListStatus(),
+		PollerLoads:    resp.GetPollerLoads(),
+		PollerLimits:   resp.GetPollerLimits(),
+	}, nil
+}
+
 func (adh *AdminHandler) validateGetWorkflowExecutionRawHistoryV2Request(
 	request *adminservice.GetWorkflowExecutionRawHistoryV2Request,
 ) error {
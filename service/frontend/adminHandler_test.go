@@ -41,18 +41,24 @@ import (
 	commonpb "go.temporal.io/temporal-proto/common"
 	executionpb "go.temporal.io/temporal-proto/execution"
 	"go.temporal.io/temporal-proto/serviceerror"
+	tasklistpb "go.temporal.io/temporal-proto/tasklist"
+	"go.temporal.io/temporal-proto/workflowservice"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock"
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/elasticsearch"
 	esmock "github.com/temporalio/temporal/common/elasticsearch/mocks"
+	"github.com/temporalio/temporal/common/membership"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/profiling"
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
@@ -441,6 +447,78 @@ func (s *adminHandlerSuite) Test_SetRequestDefaultValueAndGetTargetVersionHistor
 	s.NoError(err)
 }
 
+func (s *adminHandlerSuite) Test_DescribeTaskList() {
+	ctx := context.Background()
+	_, err := s.handler.DescribeTaskList(ctx, &adminservice.DescribeTaskListRequest{Namespace: s.namespace})
+	s.Equal(errTaskListNotSet, err)
+
+	taskList := &tasklistpb.TaskList{Name: "some random task list"}
+	matchingResponse := &matchingservice.DescribeTaskListResponse{
+		Pollers: []*tasklistpb.PollerInfo{{Identity: "1@host0"}},
+		PollerLoads: []*tasklistgenpb.PollerLoadInfo{
+			{Identity: "1@host0", Host: "host0", OutstandingTasks: 2, OutstandingHostTasks: 2},
+		},
+		PollerLimits: &tasklistgenpb.PollerLimits{MaxOutstandingTasksPerPoller: 2, OutstandingTaskTTLSeconds: 60},
+	}
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil)
+	s.mockResource.MatchingClient.EXPECT().DescribeTaskList(gomock.Any(), &matchingservice.DescribeTaskListRequest{
+		NamespaceId: s.namespaceID,
+		DescRequest: &workflowservice.DescribeTaskListRequest{
+			Namespace:    s.namespace,
+			TaskList:     taskList,
+			TaskListType: tasklistpb.TaskListType_Activity,
+		},
+	}).Return(matchingResponse, nil)
+
+	resp, err := s.handler.DescribeTaskList(ctx, &adminservice.DescribeTaskListRequest{
+		Namespace:    s.namespace,
+		TaskList:     taskList,
+		TaskListType: tasklistpb.TaskListType_Activity,
+	})
+	s.NoError(err)
+	s.Equal(matchingResponse.Pollers, resp.GetPollers())
+	s.Equal(matchingResponse.PollerLoads, resp.GetPollerLoads())
+	s.Equal(matchingResponse.PollerLimits, resp.GetPollerLimits())
+}
+
+func (s *adminHandlerSuite) Test_CaptureProfile_FrontendHost() {
+	ctx := context.Background()
+	otherHost := "10.0.0.2:7233"
+	s.mockResource.FrontendServiceResolver.EXPECT().Members().Return([]*membership.HostInfo{
+		membership.NewHostInfo(s.mockResource.GetHostInfo().GetAddress(), nil),
+		membership.NewHostInfo(otherHost, nil),
+	}).AnyTimes()
+
+	// a profile of the frontend host serving the request is captured locally
+	resp, err := s.handler.CaptureProfile(ctx, &adminservice.CaptureProfileRequest{
+		Service:     common.FrontendServiceName,
+		ProfileType: profiling.TypeGoroutine,
+	})
+	s.NoError(err)
+	s.Equal(s.mockResource.GetHostInfo().Identity(), resp.GetHostAddress())
+	s.NotEmpty(resp.GetProfile())
+
+	// a profile of another frontend host is forwarded to that host
+	request := &adminservice.CaptureProfileRequest{
+		Service:     common.FrontendServiceName,
+		HostAddress: otherHost,
+		ProfileType: profiling.TypeGoroutine,
+	}
+	remoteResponse := &adminservice.CaptureProfileResponse{HostAddress: otherHost, Profile: []byte("profile")}
+	s.mockResource.FrontendAdminClient.EXPECT().CaptureProfile(gomock.Any(), request).Return(remoteResponse, nil)
+	resp, err = s.handler.CaptureProfile(ctx, request)
+	s.NoError(err)
+	s.Equal(remoteResponse, resp)
+
+	// an address outside of the frontend membership ring is rejected
+	_, err = s.handler.CaptureProfile(ctx, &adminservice.CaptureProfileRequest{
+		Service:     common.FrontendServiceName,
+		HostAddress: "10.0.0.3:7233",
+		ProfileType: profiling.TypeGoroutine,
+	})
+	s.Equal(errFrontendHostNotFound, err)
+}
+
 func (s *adminHandlerSuite) Test_AddSearchAttribute_Validate() {
 	handler := s.handler
 	handler.params = &resource.BootstrapParams{}
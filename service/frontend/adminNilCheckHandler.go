@@ -197,3 +197,75 @@ func (adh *AdminNilCheckHandler) RefreshWorkflowTasks(ctx context.Context, reque
 	}
 	return resp, err
 }
+
+// DescribeLogLevels returns the log levels of the services running on the host
+func (adh *AdminNilCheckHandler) DescribeLogLevels(ctx context.Context, request *adminservice.DescribeLogLevelsRequest) (*adminservice.DescribeLogLevelsResponse, error) {
+	resp, err := adh.parentHandler.DescribeLogLevels(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.DescribeLogLevelsResponse{}
+	}
+	return resp, err
+}
+
+// DescribeNamespaceQuotas returns the quotas of a namespace
+func (adh *AdminNilCheckHandler) DescribeNamespaceQuotas(ctx context.Context, request *adminservice.DescribeNamespaceQuotasRequest) (*adminservice.DescribeNamespaceQuotasResponse, error) {
+	resp, err := adh.parentHandler.DescribeNamespaceQuotas(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.DescribeNamespaceQuotasResponse{}
+	}
+	return resp, err
+}
+
+// UpdateNamespaceQuotas replaces the quotas of a namespace
+func (adh *AdminNilCheckHandler) UpdateNamespaceQuotas(ctx context.Context, request *adminservice.UpdateNamespaceQuotasRequest) (*adminservice.UpdateNamespaceQuotasResponse, error) {
+	resp, err := adh.parentHandler.UpdateNamespaceQuotas(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.UpdateNamespaceQuotasResponse{}
+	}
+	return resp, err
+}
+
+// CaptureProfile captures a profile of the process of a host
+func (adh *AdminNilCheckHandler) CaptureProfile(ctx context.Context, request *adminservice.CaptureProfileRequest) (*adminservice.CaptureProfileResponse, error) {
+	resp, err := adh.parentHandler.CaptureProfile(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.CaptureProfileResponse{}
+	}
+	return resp, err
+}
+
+// GetHostDiagnostics returns the diagnostics of a host
+func (adh *AdminNilCheckHandler) GetHostDiagnostics(ctx context.Context, request *adminservice.GetHostDiagnosticsRequest) (*adminservice.GetHostDiagnosticsResponse, error) {
+	resp, err := adh.parentHandler.GetHostDiagnostics(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.GetHostDiagnosticsResponse{}
+	}
+	return resp, err
+}
+
+// DescribeShardQueues returns the queue states of a history shard
+func (adh *AdminNilCheckHandler) DescribeShardQueues(ctx context.Context, request *adminservice.DescribeShardQueuesRequest) (*adminservice.DescribeShardQueuesResponse, error) {
+	resp, err := adh.parentHandler.DescribeShardQueues(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.DescribeShardQueuesResponse{}
+	}
+	return resp, err
+}
+
+// DescribeTaskList returns the pollers, status and poller loads of a task list
+func (adh *AdminNilCheckHandler) DescribeTaskList(ctx context.Context, request *adminservice.DescribeTaskListRequest) (*adminservice.DescribeTaskListResponse, error) {
+	resp, err := adh.parentHandler.DescribeTaskList(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.DescribeTaskListResponse{}
+	}
+	return resp, err
+}
+
+// UpdateLogLevel changes the log level of services running on the host
+func (adh *AdminNilCheckHandler) UpdateLogLevel(ctx context.Context, request *adminservice.UpdateLogLevelRequest) (*adminservice.UpdateLogLevelResponse, error) {
+	resp, err := adh.parentHandler.UpdateLogLevel(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.UpdateLogLevelResponse{}
+	}
+	return resp, err
+}
@@ -0,0 +1,227 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.temporal.io/temporal-proto/serviceerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/quotas"
+)
+
+const (
+	apiClassPoll  = "poll"
+	apiClassStart = "start"
+	apiClassQuery = "query"
+	apiClassOther = "other"
+
+	// retryAfterHeader carries the retry hint, in milliseconds, of a request rejected by admission control
+	retryAfterHeader   = "retry-after-ms"
+	minAdmissionRetry  = 100 * time.Millisecond
+	maxAdmissionRetry  = 10 * time.Second
+	admissionKeySuffix = "/"
+	// admissionLimiterIdleTimeout is how long the limiter of a namespace and API class is kept unused
+	admissionLimiterIdleTimeout = 10 * time.Minute
+)
+
+type (
+	// AdmissionController sheds load before it reaches the handler, it keeps an adaptive concurrency
+	// limit per namespace and API class that shrinks when requests get slower or the downstream
+	// services report overload, so retries do not amplify a slowdown of history or matching.
+	// Requests of unknown namespaces are passed to the handler, which rejects them
+	AdmissionController struct {
+		config         *Config
+		namespaceCache cache.NamespaceCache
+		limiters       *quotas.MultiConcurrencyLimiter
+		metricsClient  metrics.Client
+		logger         log.Logger
+	}
+
+	namespaceGetter interface {
+		GetNamespace() string
+	}
+)
+
+// NewAdmissionController creates the admission controller of the frontend
+func NewAdmissionController(
+	config *Config,
+	namespaceCache cache.NamespaceCache,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) *AdmissionController {
+
+	a := &AdmissionController{
+		config:         config,
+		namespaceCache: namespaceCache,
+		metricsClient:  metricsClient,
+		logger:         logger,
+	}
+	a.limiters = quotas.NewMultiConcurrencyLimiter(a.newLimiter, admissionLimiterIdleTimeout)
+	return a
+}
+
+// Intercept is the gRPC unary interceptor of the admission controller
+func (a *AdmissionController) Intercept(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	if !strings.HasPrefix(info.FullMethod, workflowServiceMethodPrefix) {
+		return handler(ctx, req)
+	}
+	var namespace string
+	if getter, ok := req.(namespaceGetter); ok {
+		namespace = getter.GetNamespace()
+	}
+	if namespace == "" || !a.config.EnableAdmissionControl(namespace) {
+		return handler(ctx, req)
+	}
+	namespaceID, err := a.namespaceCache.GetNamespaceID(namespace)
+	if err != nil {
+		return handler(ctx, req)
+	}
+
+	class := apiClass(strings.TrimPrefix(info.FullMethod, workflowServiceMethodPrefix))
+	limiter := a.limiters.Get(namespaceID + admissionKeySuffix + class)
+	scope := a.metricsClient.Scope(
+		metrics.FrontendAdmissionControlScope,
+		metrics.NamespaceTag(namespace),
+		metrics.APIClassTag(class),
+	)
+	if !limiter.TryAcquire() {
+		scope.IncCounter(metrics.ServiceAdmissionRejectedCounter)
+		return nil, a.reject(ctx, namespace, class, limiter)
+	}
+
+	startTime := time.Now()
+	resp, err := handler(ctx, req)
+	limiter.Release(time.Since(startTime), isOverloaded(err))
+	scope.UpdateGauge(metrics.ServiceConcurrencyLimitGauge, float64(limiter.Limit()))
+	return resp, err
+}
+
+func (a *AdmissionController) newLimiter(key string) quotas.ConcurrencyLimiter {
+	index := strings.LastIndex(key, admissionKeySuffix)
+	namespaceID, class := key[:index], key[index+1:]
+	namespace, err := a.namespaceCache.GetNamespaceName(namespaceID)
+	if err != nil {
+		a.logger.Warn("Failed to get namespace name of admission limiter", tag.WorkflowNamespaceID(namespaceID), tag.Error(err))
+	}
+
+	options := func() quotas.ConcurrencyLimiterOptions {
+		options := quotas.DefaultConcurrencyLimiterOptions()
+		options.MinLimit = a.config.AdmissionControlMinConcurrency(namespace)
+		options.MaxLimit = a.config.AdmissionControlMaxConcurrency(namespace)
+		// a worker fleet keeps many long polls open, they get a floor of their own
+		if class == apiClassPoll {
+			options.MinLimit = a.config.AdmissionControlPollMinConcurrency(namespace)
+			if options.MaxLimit < options.MinLimit {
+				options.MaxLimit = options.MinLimit
+			}
+		}
+		options.InitialLimit = options.MinLimit
+		options.Tolerance = a.config.AdmissionControlLatencyTolerance()
+		return options
+	}
+	// the latency of a long poll is how long it waited for a task, not how loaded the downstream is
+	if class == apiClassPoll {
+		return quotas.NewAIMDConcurrencyLimiter(options)
+	}
+	return quotas.NewGradientConcurrencyLimiter(options)
+}
+
+func (a *AdmissionController) reject(
+	ctx context.Context,
+	namespace string,
+	class string,
+	limiter quotas.ConcurrencyLimiter,
+) error {
+
+	retryAfter := limiter.RetryAfter()
+	if retryAfter < minAdmissionRetry {
+		retryAfter = minAdmissionRetry
+	}
+	if retryAfter > maxAdmissionRetry {
+		retryAfter = maxAdmissionRetry
+	}
+	retryAfter = retryAfter.Round(time.Millisecond)
+	// the header is best effort, there is no transport stream when the handler is called directly
+	_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterHeader, strconv.FormatInt(int64(retryAfter/time.Millisecond), 10)))
+
+	a.logger.Debug("Request rejected by admission control",
+		tag.WorkflowNamespace(namespace),
+		tag.Name(class),
+		tag.Counter(limiter.Limit()),
+	)
+	return serviceerror.NewResourceExhausted(fmt.Sprintf(
+		"Too many concurrent %v requests, retry after %v.", class, retryAfter))
+}
+
+// apiClass groups the WorkflowService APIs by their cost for admission control
+func apiClass(method string) string {
+	switch {
+	case strings.HasPrefix(method, "Poll"):
+		return apiClassPoll
+	case method == "StartWorkflowExecution" || method == "SignalWithStartWorkflowExecution":
+		return apiClassStart
+	case method == "QueryWorkflow" ||
+		strings.HasPrefix(method, "Describe") ||
+		strings.HasPrefix(method, "Get") ||
+		strings.HasPrefix(method, "List") ||
+		strings.HasPrefix(method, "Scan") ||
+		strings.HasPrefix(method, "Count"):
+		return apiClassQuery
+	default:
+		return apiClassOther
+	}
+}
+
+// isOverloaded returns true when err means the request failed because the services behind the
+// frontend are overloaded, the rate limit of the frontend itself is not a sign of that
+func isOverloaded(err error) bool {
+	if err == nil || err == errServiceBusy {
+		return false
+	}
+	switch serviceerror.ToStatus(err).Code() {
+	case codes.DeadlineExceeded, codes.Unavailable, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,188 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"go.temporal.io/temporal-proto/serviceerror"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	admissionControlSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		mockController     *gomock.Controller
+		mockNamespaceCache *cache.MockNamespaceCache
+		config             *Config
+		controller         *AdmissionController
+	}
+)
+
+func TestAdmissionControlSuite(t *testing.T) {
+	s := new(admissionControlSuite)
+	suite.Run(t, s)
+}
+
+func (s *admissionControlSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.mockController = gomock.NewController(s.T())
+	s.mockNamespaceCache = cache.NewMockNamespaceCache(s.mockController)
+	namespaceIDs := map[string]string{"test-namespace": "test-namespace-id", "other-namespace": "other-namespace-id"}
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(gomock.Any()).DoAndReturn(func(name string) (string, error) {
+		if id, ok := namespaceIDs[name]; ok {
+			return id, nil
+		}
+		return "", errors.New("namespace not found")
+	}).AnyTimes()
+	s.mockNamespaceCache.EXPECT().GetNamespaceName(gomock.Any()).DoAndReturn(func(id string) (string, error) {
+		for name, namespaceID := range namespaceIDs {
+			if namespaceID == id {
+				return name, nil
+			}
+		}
+		return "", errors.New("namespace not found")
+	}).AnyTimes()
+	s.config = &Config{
+		EnableAdmissionControl:             dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true),
+		AdmissionControlMinConcurrency:     dynamicconfig.GetIntPropertyFilteredByNamespace(2),
+		AdmissionControlMaxConcurrency:     dynamicconfig.GetIntPropertyFilteredByNamespace(10),
+		AdmissionControlPollMinConcurrency: dynamicconfig.GetIntPropertyFilteredByNamespace(100),
+		AdmissionControlLatencyTolerance:   dynamicconfig.GetFloatPropertyFn(2.0),
+	}
+	s.controller = NewAdmissionController(s.config, s.mockNamespaceCache, metrics.NewClient(tally.NoopScope, metrics.Frontend), loggerimpl.NewNopLogger())
+}
+
+func (s *admissionControlSuite) TearDownTest() {
+	s.mockController.Finish()
+}
+
+func (s *admissionControlSuite) TestRejectsAboveLimit() {
+	block := make(chan struct{})
+	started := make(chan struct{}, 2)
+	blocking := func(ctx context.Context, req interface{}) (interface{}, error) {
+		started <- struct{}{}
+		<-block
+		return &workflowservice.StartWorkflowExecutionResponse{}, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: workflowServiceMethodPrefix + "StartWorkflowExecution"}
+	request := &workflowservice.StartWorkflowExecutionRequest{Namespace: "test-namespace"}
+
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := s.controller.Intercept(context.Background(), request, info, blocking)
+			done <- err
+		}()
+		<-started
+	}
+
+	_, err := s.controller.Intercept(context.Background(), request, info, blocking)
+	s.IsType(&serviceerror.ResourceExhausted{}, err)
+
+	// other namespaces and API classes have limits of their own
+	_, err = s.controller.Intercept(context.Background(), &workflowservice.StartWorkflowExecutionRequest{Namespace: "other-namespace"}, info, s.noop)
+	s.NoError(err)
+	_, err = s.controller.Intercept(
+		context.Background(),
+		&workflowservice.QueryWorkflowRequest{Namespace: "test-namespace"},
+		&grpc.UnaryServerInfo{FullMethod: workflowServiceMethodPrefix + "QueryWorkflow"},
+		s.noop,
+	)
+	s.NoError(err)
+
+	close(block)
+	s.NoError(<-done)
+	s.NoError(<-done)
+}
+
+func (s *admissionControlSuite) TestUnknownNamespace() {
+	info := &grpc.UnaryServerInfo{FullMethod: workflowServiceMethodPrefix + "StartWorkflowExecution"}
+	for i := 0; i < 10; i++ {
+		_, err := s.controller.Intercept(context.Background(), &workflowservice.StartWorkflowExecutionRequest{Namespace: "unknown"}, info, s.noop)
+		s.NoError(err)
+	}
+	s.Equal(0, s.controller.limiters.Len())
+}
+
+func (s *admissionControlSuite) TestPollFloor() {
+	info := &grpc.UnaryServerInfo{FullMethod: workflowServiceMethodPrefix + "PollForDecisionTask"}
+	_, err := s.controller.Intercept(context.Background(), &workflowservice.PollForDecisionTaskRequest{Namespace: "test-namespace"}, info, s.noop)
+	s.NoError(err)
+	s.Equal(100, s.controller.limiters.Get("test-namespace-id"+admissionKeySuffix+apiClassPoll).Limit())
+
+	// limits follow dynamic config changes
+	s.config.AdmissionControlPollMinConcurrency = dynamicconfig.GetIntPropertyFilteredByNamespace(200)
+	limiter := s.controller.limiters.Get("test-namespace-id" + admissionKeySuffix + apiClassPoll)
+	s.True(limiter.TryAcquire())
+	s.Equal(200, limiter.Limit())
+}
+
+func (s *admissionControlSuite) TestDisabled() {
+	s.config.EnableAdmissionControl = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false)
+	info := &grpc.UnaryServerInfo{FullMethod: workflowServiceMethodPrefix + "StartWorkflowExecution"}
+	overloaded := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, serviceerror.NewUnavailable("history is overloaded")
+	}
+	for i := 0; i < 10; i++ {
+		_, err := s.controller.Intercept(context.Background(), &workflowservice.StartWorkflowExecutionRequest{}, info, overloaded)
+		s.IsType(&serviceerror.Unavailable{}, err)
+	}
+}
+
+func (s *admissionControlSuite) TestAPIClass() {
+	s.Equal(apiClassPoll, apiClass("PollForDecisionTask"))
+	s.Equal(apiClassStart, apiClass("SignalWithStartWorkflowExecution"))
+	s.Equal(apiClassQuery, apiClass("QueryWorkflow"))
+	s.Equal(apiClassQuery, apiClass("GetWorkflowExecutionHistory"))
+	s.Equal(apiClassOther, apiClass("RespondDecisionTaskCompleted"))
+}
+
+func (s *admissionControlSuite) TestIsOverloaded() {
+	s.False(isOverloaded(nil))
+	s.False(isOverloaded(errServiceBusy))
+	s.False(isOverloaded(serviceerror.NewNotFound("not found")))
+	s.True(isOverloaded(serviceerror.NewUnavailable("unavailable")))
+	s.True(isOverloaded(serviceerror.NewDeadlineExceeded("timeout")))
+	s.True(isOverloaded(context.DeadlineExceeded))
+}
+
+func (s *admissionControlSuite) noop(ctx context.Context, req interface{}) (interface{}, error) {
+	return nil, nil
+}
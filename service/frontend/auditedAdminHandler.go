@@ -0,0 +1,191 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/common/audit"
+)
+
+// AuditedAdminHandler admin handler wrapper which records the mutating admin API calls in the audit log,
+// other calls are passed through to the wrapped handler
+type AuditedAdminHandler struct {
+	adminservice.AdminServiceServer
+	auditLogger audit.Logger
+}
+
+var _ adminservice.AdminServiceServer = (*AuditedAdminHandler)(nil)
+
+// NewAuditedAdminHandler creates admin handler with audit log support
+func NewAuditedAdminHandler(adminHandler adminservice.AdminServiceServer, auditLogger audit.Logger) *AuditedAdminHandler {
+	return &AuditedAdminHandler{
+		AdminServiceServer: adminHandler,
+		auditLogger:        auditLogger,
+	}
+}
+
+// AddSearchAttribute API call
+func (a *AuditedAdminHandler) AddSearchAttribute(
+	ctx context.Context,
+	request *adminservice.AddSearchAttributeRequest,
+) (*adminservice.AddSearchAttributeResponse, error) {
+
+	resp, err := a.AdminServiceServer.AddSearchAttribute(ctx, request)
+	logAudit(ctx, a.auditLogger, &audit.Entry{
+		API:     adminAPINamePrefix + "AddSearchAttribute",
+		Request: request,
+		Error:   err,
+	})
+	return resp, err
+}
+
+// CloseShard API call
+func (a *AuditedAdminHandler) CloseShard(
+	ctx context.Context,
+	request *adminservice.CloseShardRequest,
+) (*adminservice.CloseShardResponse, error) {
+
+	resp, err := a.AdminServiceServer.CloseShard(ctx, request)
+	logAudit(ctx, a.auditLogger, &audit.Entry{
+		API:     adminAPINamePrefix + "CloseShard",
+		Request: request,
+		Error:   err,
+	})
+	return resp, err
+}
+
+// RemoveTask API call
+func (a *AuditedAdminHandler) RemoveTask(
+	ctx context.Context,
+	request *adminservice.RemoveTaskRequest,
+) (*adminservice.RemoveTaskResponse, error) {
+
+	resp, err := a.AdminServiceServer.RemoveTask(ctx, request)
+	logAudit(ctx, a.auditLogger, &audit.Entry{
+		API:     adminAPINamePrefix + "RemoveTask",
+		Request: request,
+		Error:   err,
+	})
+	return resp, err
+}
+
+// ReapplyEvents API call
+func (a *AuditedAdminHandler) ReapplyEvents(
+	ctx context.Context,
+	request *adminservice.ReapplyEventsRequest,
+) (*adminservice.ReapplyEventsResponse, error) {
+
+	resp, err := a.AdminServiceServer.ReapplyEvents(ctx, request)
+	logAudit(ctx, a.auditLogger, &audit.Entry{
+		API:        adminAPINamePrefix + "ReapplyEvents",
+		Namespace:  request.GetNamespace(),
+		WorkflowID: request.GetWorkflowExecution().GetWorkflowId(),
+		RunID:      request.GetWorkflowExecution().GetRunId(),
+		Request:    request,
+		Error:      err,
+	})
+	return resp, err
+}
+
+// PurgeDLQMessages API call
+func (a *AuditedAdminHandler) PurgeDLQMessages(
+	ctx context.Context,
+	request *adminservice.PurgeDLQMessagesRequest,
+) (*adminservice.PurgeDLQMessagesResponse, error) {
+
+	resp, err := a.AdminServiceServer.PurgeDLQMessages(ctx, request)
+	logAudit(ctx, a.auditLogger, &audit.Entry{
+		API:     adminAPINamePrefix + "PurgeDLQMessages",
+		Request: request,
+		Error:   err,
+	})
+	return resp, err
+}
+
+// MergeDLQMessages API call
+func (a *AuditedAdminHandler) MergeDLQMessages(
+	ctx context.Context,
+	request *adminservice.MergeDLQMessagesRequest,
+) (*adminservice.MergeDLQMessagesResponse, error) {
+
+	resp, err := a.AdminServiceServer.MergeDLQMessages(ctx, request)
+	logAudit(ctx, a.auditLogger, &audit.Entry{
+		API:     adminAPINamePrefix + "MergeDLQMessages",
+		Request: request,
+		Error:   err,
+	})
+	return resp, err
+}
+
+// RefreshWorkflowTasks API call
+func (a *AuditedAdminHandler) RefreshWorkflowTasks(
+	ctx context.Context,
+	request *adminservice.RefreshWorkflowTasksRequest,
+) (*adminservice.RefreshWorkflowTasksResponse, error) {
+
+	resp, err := a.AdminServiceServer.RefreshWorkflowTasks(ctx, request)
+	logAudit(ctx, a.auditLogger, &audit.Entry{
+		API:        adminAPINamePrefix + "RefreshWorkflowTasks",
+		Namespace:  request.GetNamespace(),
+		WorkflowID: request.GetExecution().GetWorkflowId(),
+		RunID:      request.GetExecution().GetRunId(),
+		Request:    request,
+		Error:      err,
+	})
+	return resp, err
+}
+
+// UpdateLogLevel API call
+func (a *AuditedAdminHandler) UpdateLogLevel(
+	ctx context.Context,
+	request *adminservice.UpdateLogLevelRequest,
+) (*adminservice.UpdateLogLevelResponse, error) {
+
+	resp, err := a.AdminServiceServer.UpdateLogLevel(ctx, request)
+	logAudit(ctx, a.auditLogger, &audit.Entry{
+		API:     adminAPINamePrefix + "UpdateLogLevel",
+		Request: request,
+		Error:   err,
+	})
+	return resp, err
+}
+
+// UpdateNamespaceQuotas API call
+func (a *AuditedAdminHandler) UpdateNamespaceQuotas(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceQuotasRequest,
+) (*adminservice.UpdateNamespaceQuotasResponse, error) {
+
+	resp, err := a.AdminServiceServer.UpdateNamespaceQuotas(ctx, request)
+	logAudit(ctx, a.auditLogger, &audit.Entry{
+		API:       adminAPINamePrefix + "UpdateNamespaceQuotas",
+		Namespace: request.GetNamespace(),
+		Request:   request,
+		Error:     err,
+	})
+	return resp, err
+}
@@ -0,0 +1,219 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	"go.temporal.io/temporal-proto/workflowservice"
+
+	"github.com/temporalio/temporal/common/audit"
+	"github.com/temporalio/temporal/common/authorization"
+)
+
+// AuditedWorkflowHandler frontend handler wrapper which records the mutating API calls in the audit log,
+// other calls are passed through to the wrapped handler
+type AuditedWorkflowHandler struct {
+	Handler
+	auditLogger audit.Logger
+}
+
+var _ Handler = (*AuditedWorkflowHandler)(nil)
+
+// NewAuditedHandler creates frontend handler with audit log support
+func NewAuditedHandler(wfHandler Handler, auditLogger audit.Logger) *AuditedWorkflowHandler {
+	return &AuditedWorkflowHandler{
+		Handler:     wfHandler,
+		auditLogger: auditLogger,
+	}
+}
+
+// StartWorkflowExecution API call
+func (a *AuditedWorkflowHandler) StartWorkflowExecution(
+	ctx context.Context,
+	request *workflowservice.StartWorkflowExecutionRequest,
+) (*workflowservice.StartWorkflowExecutionResponse, error) {
+
+	resp, err := a.Handler.StartWorkflowExecution(ctx, request)
+	a.audit(ctx, &audit.Entry{
+		API:        "StartWorkflowExecution",
+		Namespace:  request.GetNamespace(),
+		WorkflowID: request.GetWorkflowId(),
+		RunID:      resp.GetRunId(),
+		Request:    request,
+		Error:      err,
+	})
+	return resp, err
+}
+
+// SignalWorkflowExecution API call
+func (a *AuditedWorkflowHandler) SignalWorkflowExecution(
+	ctx context.Context,
+	request *workflowservice.SignalWorkflowExecutionRequest,
+) (*workflowservice.SignalWorkflowExecutionResponse, error) {
+
+	resp, err := a.Handler.SignalWorkflowExecution(ctx, request)
+	a.audit(ctx, &audit.Entry{
+		API:        "SignalWorkflowExecution",
+		Namespace:  request.GetNamespace(),
+		WorkflowID: request.GetWorkflowExecution().GetWorkflowId(),
+		RunID:      request.GetWorkflowExecution().GetRunId(),
+		Request:    request,
+		Error:      err,
+	})
+	return resp, err
+}
+
+// SignalWithStartWorkflowExecution API call
+func (a *AuditedWorkflowHandler) SignalWithStartWorkflowExecution(
+	ctx context.Context,
+	request *workflowservice.SignalWithStartWorkflowExecutionRequest,
+) (*workflowservice.SignalWithStartWorkflowExecutionResponse, error) {
+
+	resp, err := a.Handler.SignalWithStartWorkflowExecution(ctx, request)
+	a.audit(ctx, &audit.Entry{
+		API:        "SignalWithStartWorkflowExecution",
+		Namespace:  request.GetNamespace(),
+		WorkflowID: request.GetWorkflowId(),
+		RunID:      resp.GetRunId(),
+		Request:    request,
+		Error:      err,
+	})
+	return resp, err
+}
+
+// RequestCancelWorkflowExecution API call
+func (a *AuditedWorkflowHandler) RequestCancelWorkflowExecution(
+	ctx context.Context,
+	request *workflowservice.RequestCancelWorkflowExecutionRequest,
+) (*workflowservice.RequestCancelWorkflowExecutionResponse, error) {
+
+	resp, err := a.Handler.RequestCancelWorkflowExecution(ctx, request)
+	a.audit(ctx, &audit.Entry{
+		API:        "RequestCancelWorkflowExecution",
+		Namespace:  request.GetNamespace(),
+		WorkflowID: request.GetWorkflowExecution().GetWorkflowId(),
+		RunID:      request.GetWorkflowExecution().GetRunId(),
+		Request:    request,
+		Error:      err,
+	})
+	return resp, err
+}
+
+// TerminateWorkflowExecution API call
+func (a *AuditedWorkflowHandler) TerminateWorkflowExecution(
+	ctx context.Context,
+	request *workflowservice.TerminateWorkflowExecutionRequest,
+) (*workflowservice.TerminateWorkflowExecutionResponse, error) {
+
+	resp, err := a.Handler.TerminateWorkflowExecution(ctx, request)
+	a.audit(ctx, &audit.Entry{
+		API:        "TerminateWorkflowExecution",
+		Namespace:  request.GetNamespace(),
+		WorkflowID: request.GetWorkflowExecution().GetWorkflowId(),
+		RunID:      request.GetWorkflowExecution().GetRunId(),
+		Request:    request,
+		Error:      err,
+	})
+	return resp, err
+}
+
+// ResetWorkflowExecution API call
+func (a *AuditedWorkflowHandler) ResetWorkflowExecution(
+	ctx context.Context,
+	request *workflowservice.ResetWorkflowExecutionRequest,
+) (*workflowservice.ResetWorkflowExecutionResponse, error) {
+
+	resp, err := a.Handler.ResetWorkflowExecution(ctx, request)
+	a.audit(ctx, &audit.Entry{
+		API:        "ResetWorkflowExecution",
+		Namespace:  request.GetNamespace(),
+		WorkflowID: request.GetWorkflowExecution().GetWorkflowId(),
+		RunID:      request.GetWorkflowExecution().GetRunId(),
+		Request:    request,
+		Error:      err,
+	})
+	return resp, err
+}
+
+// RegisterNamespace API call
+func (a *AuditedWorkflowHandler) RegisterNamespace(
+	ctx context.Context,
+	request *workflowservice.RegisterNamespaceRequest,
+) (*workflowservice.RegisterNamespaceResponse, error) {
+
+	resp, err := a.Handler.RegisterNamespace(ctx, request)
+	a.audit(ctx, &audit.Entry{
+		API:       "RegisterNamespace",
+		Namespace: request.GetName(),
+		Request:   request,
+		Error:     err,
+	})
+	return resp, err
+}
+
+// UpdateNamespace API call
+func (a *AuditedWorkflowHandler) UpdateNamespace(
+	ctx context.Context,
+	request *workflowservice.UpdateNamespaceRequest,
+) (*workflowservice.UpdateNamespaceResponse, error) {
+
+	resp, err := a.Handler.UpdateNamespace(ctx, request)
+	a.audit(ctx, &audit.Entry{
+		API:       "UpdateNamespace",
+		Namespace: request.GetName(),
+		Request:   request,
+		Error:     err,
+	})
+	return resp, err
+}
+
+// DeprecateNamespace API call
+func (a *AuditedWorkflowHandler) DeprecateNamespace(
+	ctx context.Context,
+	request *workflowservice.DeprecateNamespaceRequest,
+) (*workflowservice.DeprecateNamespaceResponse, error) {
+
+	resp, err := a.Handler.DeprecateNamespace(ctx, request)
+	a.audit(ctx, &audit.Entry{
+		API:       "DeprecateNamespace",
+		Namespace: request.GetName(),
+		Request:   request,
+		Error:     err,
+	})
+	return resp, err
+}
+
+func (a *AuditedWorkflowHandler) audit(ctx context.Context, entry *audit.Entry) {
+	logAudit(ctx, a.auditLogger, entry)
+}
+
+// logAudit records the entry with the authenticated caller as actor
+func logAudit(ctx context.Context, auditLogger audit.Logger, entry *audit.Entry) {
+	if claims := authorization.GetClaims(ctx); claims != nil {
+		entry.Actor = claims.Subject
+	}
+	auditLogger.Log(entry)
+}
@@ -28,9 +28,14 @@ import "go.temporal.io/temporal-proto/serviceerror"
 
 var (
 	errNamespaceNotSet                                    = serviceerror.NewInvalidArgument("Namespace not set on request.")
+	errNamespaceNameReserved                              = serviceerror.NewInvalidArgument("Namespace name is reserved for permissions on the whole cluster.")
 	errTaskTokenNotSet                                    = serviceerror.NewInvalidArgument("Task token not set on request.")
+	errInvalidEagerActivityDispatch                       = serviceerror.NewInvalidArgument("Invalid eager activity dispatch header, it must be a non negative number of activity tasks.")
 	errInvalidTaskToken                                   = serviceerror.NewInvalidArgument("Invalid TaskToken.")
 	errTaskListNotSet                                     = serviceerror.NewInvalidArgument("TaskList is not set on request.")
+	errAffinityTaskListNotAllowed                         = serviceerror.NewInvalidArgument("Affinity TaskList can only be used by activities.")
+	errAffinityKeyNotSet                                  = serviceerror.NewInvalidArgument("Affinity key is not set on affinity TaskList.")
+	errAffinityIdentityNotSet                             = serviceerror.NewInvalidArgument("Identity is required to poll an affinity TaskList.")
 	errExecutionNotSet                                    = serviceerror.NewInvalidArgument("Execution is not set on request.")
 	errWorkflowIDNotSet                                   = serviceerror.NewInvalidArgument("WorkflowId is not set on request.")
 	errActivityIDNotSet                                   = serviceerror.NewInvalidArgument("ActivityId is not set on request.")
@@ -41,6 +46,7 @@ var (
 	errQueryNotSet                                        = serviceerror.NewInvalidArgument("WorkflowQuery is not set on request.")
 	errQueryTypeNotSet                                    = serviceerror.NewInvalidArgument("QueryType is not set on request.")
 	errRequestNotSet                                      = serviceerror.NewInvalidArgument("Request is nil.")
+	errReservedPayloadMetadata                            = serviceerror.NewInvalidArgument("Payload metadata keys starting with temporal-server-encryption are reserved.")
 	errRequestIDNotSet                                    = serviceerror.NewInvalidArgument("RequestId is not set on request.")
 	errWorkflowTypeNotSet                                 = serviceerror.NewInvalidArgument("WorkflowType is not set on request.")
 	errInvalidRetention                                   = serviceerror.NewInvalidArgument("RetentionDays is invalid.")
@@ -76,6 +82,15 @@ var (
 	errInvalidEventQueryRange                             = serviceerror.NewInvalidArgument("Invalid event query range.")
 	errUnknownValueType                                   = serviceerror.NewInvalidArgument("Unknown value type, %v.")
 	errDLQTypeIsNotSupported                              = serviceerror.NewInvalidArgument("The DLQ type is not supported.")
+	errLogLevelNotSet                                     = serviceerror.NewInvalidArgument("Level is not set on request.")
+	errLogLevelServiceNotSet                              = serviceerror.NewInvalidArgument("Service is not set on request with a host address.")
+	errLogLevelServiceNotSupported                        = serviceerror.NewInvalidArgument("Log levels can only be managed on frontend, history and matching hosts.")
+	errHostAddressNotSet                                  = serviceerror.NewInvalidArgument("HostAddress is not set on request.")
+	errFrontendHostNotFound                               = serviceerror.NewInvalidArgument("Host address is not a frontend host of the cluster.")
+	errProfileServiceNotSupported                         = serviceerror.NewInvalidArgument("Profiles can only be captured on frontend, history and matching hosts.")
+	errFrontendDiagnosticsOnOtherHost                     = serviceerror.NewInvalidArgument("Frontend diagnostics can only be returned by the frontend host serving the request.")
+	errDiagnosticsServiceNotSupported                     = serviceerror.NewInvalidArgument("Diagnostics can only be returned by frontend, history and matching hosts.")
+	errInvalidShardID                                     = serviceerror.NewInvalidArgument("ShardId is out of the range of history shards.")
 	errShuttingDown                                       = serviceerror.NewInternal("Shutting down")
 
 	errFailedUpdateDynamicConfig = serviceerror.NewInternal("Failed to update dynamic config, err: %v.")
@@ -0,0 +1,557 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	executionpb "go.temporal.io/temporal-proto/execution"
+	querypb "go.temporal.io/temporal-proto/query"
+	"go.temporal.io/temporal-proto/serviceerror"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/temporalio/temporal/common/codec"
+	"github.com/temporalio/temporal/common/headers"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/tracing"
+)
+
+const (
+	httpAPIPrefix = "/api/v1"
+	// httpOpenAPIPath serves the OpenAPI description of the HTTP gateway
+	httpOpenAPIPath = httpAPIPrefix + "/openapi.json"
+	// httpMaxRequestSize matches the default max message size of the gRPC server
+	httpMaxRequestSize = 4 * 1024 * 1024
+	// httpRequestTimeout is the deadline of gateway requests, long enough for long polls
+	httpRequestTimeout = time.Minute
+
+	httpReadHeaderTimeout = 10 * time.Second
+	httpReadTimeout       = 30 * time.Second
+	httpWriteTimeout      = httpRequestTimeout + 10*time.Second
+	httpIdleTimeout       = 2 * time.Minute
+
+	workflowServiceMethodPrefix = "/temporal.workflowservice.WorkflowService/"
+)
+
+// httpForwardedHeaders are the HTTP headers carried as gRPC metadata along with the tracing headers
+var httpForwardedHeaders = map[string]bool{
+	"authorization":                        true,
+	headers.ClientVersionHeaderName:        true,
+	headers.ClientFeatureVersionHeaderName: true,
+	headers.ClientImplHeaderName:           true,
+}
+
+type (
+	// HTTPGateway serves the WorkflowService as a JSON API over HTTP, requests go through the same
+	// interceptors and handler chain as gRPC requests so authentication, authorization, rate limiting
+	// and client version checks apply to both
+	HTTPGateway struct {
+		handler      workflowservice.WorkflowServiceServer
+		interceptors []grpc.UnaryServerInterceptor
+		encoder      *codec.JSONPBEncoder
+		routes       []*httpRoute
+		openAPI      []byte
+		logger       log.Logger
+	}
+
+	httpRoute struct {
+		method    string
+		path      string
+		segments  []string
+		operation string
+		summary   string
+		// query lists the query parameters of the route
+		query      []string
+		hasBody    bool
+		newRequest func() proto.Message
+		invoke     func(ctx context.Context, handler workflowservice.WorkflowServiceServer, request proto.Message, params httpParams) (proto.Message, error)
+	}
+
+	// httpParams are the path and query parameters of a request
+	httpParams map[string]string
+
+	// httpTransportStream lets the handler chain set response headers with grpc.SetHeader
+	httpTransportStream struct {
+		method string
+		header http.Header
+	}
+
+	httpError struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+var errHTTPRouteNotFound = serviceerror.NewNotFound("No route matches the request.")
+
+// NewHTTPGateway creates the HTTP gateway of the handler
+func NewHTTPGateway(
+	handler workflowservice.WorkflowServiceServer,
+	interceptors []grpc.UnaryServerInterceptor,
+	logger log.Logger,
+) *HTTPGateway {
+
+	g := &HTTPGateway{
+		handler:      handler,
+		interceptors: interceptors,
+		encoder:      codec.NewJSONPBEncoder(),
+		routes:       newHTTPRoutes(),
+		logger:       logger,
+	}
+	openAPI, err := json.Marshal(newOpenAPIDocument(g.routes))
+	if err != nil {
+		logger.Fatal("Failed to generate OpenAPI description", tag.Error(err))
+	}
+	g.openAPI = openAPI
+	return g
+}
+
+// NewHTTPServer creates the HTTP server of the gateway, with timeouts so slow or idle clients
+// cannot hold connections forever
+func NewHTTPServer(gateway *HTTPGateway) *http.Server {
+	return &http.Server{
+		Handler:           gateway,
+		ReadHeaderTimeout: httpReadHeaderTimeout,
+		ReadTimeout:       httpReadTimeout,
+		WriteTimeout:      httpWriteTimeout,
+		IdleTimeout:       httpIdleTimeout,
+	}
+}
+
+// ServeHTTP serves a single HTTP request
+func (g *HTTPGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == httpOpenAPIPath {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(g.openAPI)
+		return
+	}
+
+	route, params := g.match(r)
+	if route == nil {
+		g.writeError(w, errHTTPRouteNotFound)
+		return
+	}
+	request := route.newRequest()
+	if route.hasBody {
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, httpMaxRequestSize))
+		if err != nil {
+			g.writeError(w, serviceerror.NewInvalidArgument(err.Error()))
+			return
+		}
+		if len(body) > 0 {
+			if err := g.encoder.Decode(body, request); err != nil {
+				g.writeError(w, serviceerror.NewInvalidArgument("Invalid JSON request: "+err.Error()))
+				return
+			}
+		}
+	}
+	for _, name := range route.query {
+		if value := r.URL.Query().Get(name); value != "" {
+			params[name] = value
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(g.newContext(r), httpRequestTimeout)
+	defer cancel()
+	ctx = grpc.NewContextWithServerTransportStream(ctx, &httpTransportStream{
+		method: workflowServiceMethodPrefix + route.operation,
+		header: w.Header(),
+	})
+	response, err := g.invoke(ctx, route, request, params)
+	if err != nil {
+		g.writeError(w, err)
+		return
+	}
+	data, err := g.encoder.Encode(response)
+	if err != nil {
+		g.writeError(w, serviceerror.NewInternal(err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// match finds the route of the request, the escaped path is split so path parameters such as
+// workflow IDs may contain an escaped "/"
+func (g *HTTPGateway) match(r *http.Request) (*httpRoute, httpParams) {
+	segments := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, nil
+		}
+		segments[i] = unescaped
+	}
+	for _, route := range g.routes {
+		if route.method != r.Method || len(route.segments) != len(segments) {
+			continue
+		}
+		params := make(httpParams)
+		matched := true
+		for i, segment := range route.segments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && segments[i] != "" {
+				params[segment[1:len(segment)-1]] = segments[i]
+			} else if segment != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return route, params
+		}
+	}
+	return nil, nil
+}
+
+// newContext carries the allowed HTTP headers as gRPC metadata and the client certificate as gRPC peer,
+// the other headers are dropped so HTTP clients cannot set metadata reserved for the server
+func (g *HTTPGateway) newContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for name, values := range r.Header {
+		name = strings.ToLower(name)
+		if httpForwardedHeaders[name] || tracing.IsPropagationHeader(name) {
+			md.Append(name, values...)
+		}
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	if r.TLS != nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: *r.TLS}})
+	}
+	return ctx
+}
+
+// invoke calls the route through the interceptors, in the order of the gRPC server
+func (g *HTTPGateway) invoke(
+	ctx context.Context,
+	route *httpRoute,
+	request proto.Message,
+	params httpParams,
+) (proto.Message, error) {
+
+	info := &grpc.UnaryServerInfo{
+		Server:     g.handler,
+		FullMethod: workflowServiceMethodPrefix + route.operation,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return route.invoke(ctx, g.handler, req.(proto.Message), params)
+	}
+	for i := len(g.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := g.interceptors[i], handler
+		handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+	response, err := handler(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return response.(proto.Message), nil
+}
+
+func (g *HTTPGateway) writeError(w http.ResponseWriter, err error) {
+	st := serviceerror.ToStatus(err)
+	data, _ := json.Marshal(&httpError{Code: st.Code().String(), Message: st.Message()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatusFromCode(st.Code()))
+	_, _ = w.Write(data)
+}
+
+func (s *httpTransportStream) Method() string {
+	return s.method
+}
+
+func (s *httpTransportStream) SetHeader(md metadata.MD) error {
+	for name, values := range md {
+		for _, value := range values {
+			s.header.Add(name, value)
+		}
+	}
+	if retryAfter := md.Get(retryAfterHeader); len(retryAfter) > 0 {
+		if ms, err := strconv.ParseInt(retryAfter[0], 10, 64); err == nil {
+			s.header.Set("Retry-After", strconv.FormatInt((ms+999)/1000, 10))
+		}
+	}
+	return nil
+}
+
+func (s *httpTransportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *httpTransportStream) SetTrailer(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (p httpParams) execution() *executionpb.WorkflowExecution {
+	return &executionpb.WorkflowExecution{
+		WorkflowId: p["workflowId"],
+		RunId:      p["runId"],
+	}
+}
+
+func (p httpParams) pageSize() int32 {
+	pageSize, _ := strconv.ParseInt(p["pageSize"], 10, 32)
+	return int32(pageSize)
+}
+
+func (p httpParams) nextPageToken() []byte {
+	token, _ := base64.StdEncoding.DecodeString(p["nextPageToken"])
+	return token
+}
+
+func newHTTPRoutes() []*httpRoute {
+	routes := []*httpRoute{
+		{
+			method:     http.MethodGet,
+			path:       "/namespaces",
+			operation:  "ListNamespaces",
+			summary:    "List namespaces",
+			query:      []string{"pageSize", "nextPageToken"},
+			newRequest: func() proto.Message { return &workflowservice.ListNamespacesRequest{} },
+			invoke: func(ctx context.Context, h workflowservice.WorkflowServiceServer, req proto.Message, p httpParams) (proto.Message, error) {
+				request := req.(*workflowservice.ListNamespacesRequest)
+				request.PageSize = p.pageSize()
+				request.NextPageToken = p.nextPageToken()
+				return h.ListNamespaces(ctx, request)
+			},
+		},
+		{
+			method:     http.MethodGet,
+			path:       "/namespaces/{namespace}",
+			operation:  "DescribeNamespace",
+			summary:    "Describe a namespace",
+			newRequest: func() proto.Message { return &workflowservice.DescribeNamespaceRequest{} },
+			invoke: func(ctx context.Context, h workflowservice.WorkflowServiceServer, req proto.Message, p httpParams) (proto.Message, error) {
+				request := req.(*workflowservice.DescribeNamespaceRequest)
+				request.Name = p["namespace"]
+				return h.DescribeNamespace(ctx, request)
+			},
+		},
+		{
+			method:     http.MethodGet,
+			path:       "/namespaces/{namespace}/workflows",
+			operation:  "ListWorkflowExecutions",
+			summary:    "List workflow executions matching a visibility query",
+			query:      []string{"query", "pageSize", "nextPageToken"},
+			newRequest: func() proto.Message { return &workflowservice.ListWorkflowExecutionsRequest{} },
+			invoke: func(ctx context.Context, h workflowservice.WorkflowServiceServer, req proto.Message, p httpParams) (proto.Message, error) {
+				request := req.(*workflowservice.ListWorkflowExecutionsRequest)
+				request.Namespace = p["namespace"]
+				request.Query = p["query"]
+				request.PageSize = p.pageSize()
+				request.NextPageToken = p.nextPageToken()
+				return h.ListWorkflowExecutions(ctx, request)
+			},
+		},
+		{
+			method:     http.MethodPost,
+			path:       "/namespaces/{namespace}/workflows/{workflowId}",
+			operation:  "StartWorkflowExecution",
+			summary:    "Start a workflow execution",
+			hasBody:    true,
+			newRequest: func() proto.Message { return &workflowservice.StartWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, h workflowservice.WorkflowServiceServer, req proto.Message, p httpParams) (proto.Message, error) {
+				request := req.(*workflowservice.StartWorkflowExecutionRequest)
+				request.Namespace = p["namespace"]
+				request.WorkflowId = p["workflowId"]
+				return h.StartWorkflowExecution(ctx, request)
+			},
+		},
+		{
+			method:     http.MethodGet,
+			path:       "/namespaces/{namespace}/workflows/{workflowId}",
+			operation:  "DescribeWorkflowExecution",
+			summary:    "Describe a workflow execution, the latest run when no run ID is given",
+			query:      []string{"runId"},
+			newRequest: func() proto.Message { return &workflowservice.DescribeWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, h workflowservice.WorkflowServiceServer, req proto.Message, p httpParams) (proto.Message, error) {
+				request := req.(*workflowservice.DescribeWorkflowExecutionRequest)
+				request.Namespace = p["namespace"]
+				request.Execution = p.execution()
+				return h.DescribeWorkflowExecution(ctx, request)
+			},
+		},
+		{
+			method:     http.MethodGet,
+			path:       "/namespaces/{namespace}/workflows/{workflowId}/history",
+			operation:  "GetWorkflowExecutionHistory",
+			summary:    "Get the history of a workflow execution",
+			query:      []string{"runId", "pageSize", "nextPageToken"},
+			newRequest: func() proto.Message { return &workflowservice.GetWorkflowExecutionHistoryRequest{} },
+			invoke: func(ctx context.Context, h workflowservice.WorkflowServiceServer, req proto.Message, p httpParams) (proto.Message, error) {
+				request := req.(*workflowservice.GetWorkflowExecutionHistoryRequest)
+				request.Namespace = p["namespace"]
+				request.Execution = p.execution()
+				request.MaximumPageSize = p.pageSize()
+				request.NextPageToken = p.nextPageToken()
+				return h.GetWorkflowExecutionHistory(ctx, request)
+			},
+		},
+		{
+			method:     http.MethodPost,
+			path:       "/namespaces/{namespace}/workflows/{workflowId}/signal/{signalName}",
+			operation:  "SignalWorkflowExecution",
+			summary:    "Signal a workflow execution",
+			query:      []string{"runId"},
+			hasBody:    true,
+			newRequest: func() proto.Message { return &workflowservice.SignalWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, h workflowservice.WorkflowServiceServer, req proto.Message, p httpParams) (proto.Message, error) {
+				request := req.(*workflowservice.SignalWorkflowExecutionRequest)
+				request.Namespace = p["namespace"]
+				request.WorkflowExecution = p.execution()
+				request.SignalName = p["signalName"]
+				return h.SignalWorkflowExecution(ctx, request)
+			},
+		},
+		{
+			method:     http.MethodPost,
+			path:       "/namespaces/{namespace}/workflows/{workflowId}/signal-with-start/{signalName}",
+			operation:  "SignalWithStartWorkflowExecution",
+			summary:    "Signal a workflow execution, starting it if it is not running",
+			hasBody:    true,
+			newRequest: func() proto.Message { return &workflowservice.SignalWithStartWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, h workflowservice.WorkflowServiceServer, req proto.Message, p httpParams) (proto.Message, error) {
+				request := req.(*workflowservice.SignalWithStartWorkflowExecutionRequest)
+				request.Namespace = p["namespace"]
+				request.WorkflowId = p["workflowId"]
+				request.SignalName = p["signalName"]
+				return h.SignalWithStartWorkflowExecution(ctx, request)
+			},
+		},
+		{
+			method:     http.MethodPost,
+			path:       "/namespaces/{namespace}/workflows/{workflowId}/query/{queryType}",
+			operation:  "QueryWorkflow",
+			summary:    "Query a workflow execution",
+			query:      []string{"runId"},
+			hasBody:    true,
+			newRequest: func() proto.Message { return &workflowservice.QueryWorkflowRequest{} },
+			invoke: func(ctx context.Context, h workflowservice.WorkflowServiceServer, req proto.Message, p httpParams) (proto.Message, error) {
+				request := req.(*workflowservice.QueryWorkflowRequest)
+				request.Namespace = p["namespace"]
+				request.Execution = p.execution()
+				if request.Query == nil {
+					request.Query = &querypb.WorkflowQuery{}
+				}
+				request.Query.QueryType = p["queryType"]
+				return h.QueryWorkflow(ctx, request)
+			},
+		},
+		{
+			method:     http.MethodPost,
+			path:       "/namespaces/{namespace}/workflows/{workflowId}/cancel",
+			operation:  "RequestCancelWorkflowExecution",
+			summary:    "Request cancellation of a workflow execution",
+			query:      []string{"runId"},
+			hasBody:    true,
+			newRequest: func() proto.Message { return &workflowservice.RequestCancelWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, h workflowservice.WorkflowServiceServer, req proto.Message, p httpParams) (proto.Message, error) {
+				request := req.(*workflowservice.RequestCancelWorkflowExecutionRequest)
+				request.Namespace = p["namespace"]
+				request.WorkflowExecution = p.execution()
+				return h.RequestCancelWorkflowExecution(ctx, request)
+			},
+		},
+		{
+			method:     http.MethodPost,
+			path:       "/namespaces/{namespace}/workflows/{workflowId}/terminate",
+			operation:  "TerminateWorkflowExecution",
+			summary:    "Terminate a workflow execution",
+			query:      []string{"runId"},
+			hasBody:    true,
+			newRequest: func() proto.Message { return &workflowservice.TerminateWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, h workflowservice.WorkflowServiceServer, req proto.Message, p httpParams) (proto.Message, error) {
+				request := req.(*workflowservice.TerminateWorkflowExecutionRequest)
+				request.Namespace = p["namespace"]
+				request.WorkflowExecution = p.execution()
+				return h.TerminateWorkflowExecution(ctx, request)
+			},
+		},
+		{
+			method:     http.MethodPost,
+			path:       "/namespaces/{namespace}/workflows/{workflowId}/reset",
+			operation:  "ResetWorkflowExecution",
+			summary:    "Reset a workflow execution to a decision task",
+			query:      []string{"runId"},
+			hasBody:    true,
+			newRequest: func() proto.Message { return &workflowservice.ResetWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, h workflowservice.WorkflowServiceServer, req proto.Message, p httpParams) (proto.Message, error) {
+				request := req.(*workflowservice.ResetWorkflowExecutionRequest)
+				request.Namespace = p["namespace"]
+				request.WorkflowExecution = p.execution()
+				return h.ResetWorkflowExecution(ctx, request)
+			},
+		},
+	}
+	for _, route := range routes {
+		route.path = httpAPIPrefix + route.path
+		route.segments = strings.Split(strings.Trim(route.path, "/"), "/")
+	}
+	return routes
+}
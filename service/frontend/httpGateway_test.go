@@ -0,0 +1,212 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	executionpb "go.temporal.io/temporal-proto/execution"
+	"go.temporal.io/temporal-proto/serviceerror"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"go.temporal.io/temporal-proto/workflowservicemock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/temporalio/temporal/common/headers"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/rpc"
+)
+
+type (
+	httpGatewaySuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller  *gomock.Controller
+		mockHandler *workflowservicemock.MockWorkflowServiceServer
+		intercepted []string
+
+		gateway *HTTPGateway
+	}
+)
+
+func TestHTTPGatewaySuite(t *testing.T) {
+	s := new(httpGatewaySuite)
+	suite.Run(t, s)
+}
+
+func (s *httpGatewaySuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.controller = gomock.NewController(s.T())
+	s.mockHandler = workflowservicemock.NewMockWorkflowServiceServer(s.controller)
+	s.intercepted = nil
+
+	recorder := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		s.intercepted = append(s.intercepted, info.FullMethod)
+		return handler(ctx, req)
+	}
+	s.gateway = NewHTTPGateway(s.mockHandler, []grpc.UnaryServerInterceptor{rpc.StatusInterceptor, recorder}, loggerimpl.NewNopLogger())
+}
+
+func (s *httpGatewaySuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *httpGatewaySuite) serve(method string, target string, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	r.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+	s.gateway.ServeHTTP(w, r)
+	return w
+}
+
+func (s *httpGatewaySuite) TestDescribeWorkflowExecution() {
+	s.mockHandler.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request *workflowservice.DescribeWorkflowExecutionRequest) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+			s.Equal("test-namespace", request.Namespace)
+			s.Equal(&executionpb.WorkflowExecution{WorkflowId: "wid", RunId: "rid"}, request.Execution)
+			md, ok := metadata.FromIncomingContext(ctx)
+			s.True(ok)
+			s.Equal([]string{"Bearer token"}, md.Get("authorization"))
+			return &workflowservice.DescribeWorkflowExecutionResponse{
+				WorkflowExecutionInfo: &executionpb.WorkflowExecutionInfo{Execution: request.Execution},
+			}, nil
+		})
+
+	w := s.serve(http.MethodGet, "/api/v1/namespaces/test-namespace/workflows/wid?runId=rid", "")
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal([]string{workflowServiceMethodPrefix + "DescribeWorkflowExecution"}, s.intercepted)
+
+	response := &workflowservice.DescribeWorkflowExecutionResponse{}
+	s.NoError(s.gateway.encoder.Decode(w.Body.Bytes(), response))
+	s.Equal("wid", response.WorkflowExecutionInfo.Execution.WorkflowId)
+}
+
+func (s *httpGatewaySuite) TestForwardedHeaders() {
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/test-namespace", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	r.Header.Set(headers.ClientVersionHeaderName, "1.0.0")
+	r.Header.Set(headers.ClientImplHeaderName, "temporal-go")
+	r.Header.Set("Uber-Trace-Id", "1:2:0:1")
+	r.Header.Set("Uberctx-Tenant", "orders")
+	r.Header.Set(headers.EagerActivityDispatchHeaderName, "10")
+	r.Header.Set("X-Forwarded-For", "10.0.0.1")
+
+	md, ok := metadata.FromIncomingContext(s.gateway.newContext(r))
+	s.True(ok)
+	s.Equal(metadata.Pairs(
+		"authorization", "Bearer token",
+		headers.ClientVersionHeaderName, "1.0.0",
+		headers.ClientImplHeaderName, "temporal-go",
+		"uber-trace-id", "1:2:0:1",
+		"uberctx-tenant", "orders",
+	), md)
+}
+
+func (s *httpGatewaySuite) TestEscapedWorkflowID() {
+	s.mockHandler.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request *workflowservice.SignalWorkflowExecutionRequest) (*workflowservice.SignalWorkflowExecutionResponse, error) {
+			s.Equal("orders/42 %2F", request.WorkflowExecution.WorkflowId)
+			s.Equal("test signal", request.SignalName)
+			_, ok := ctx.Deadline()
+			s.True(ok)
+			return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+		})
+
+	w := s.serve(http.MethodPost, "/api/v1/namespaces/test-namespace/workflows/orders%2F42%20%252F/signal/test%20signal", "")
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *httpGatewaySuite) TestSignalWorkflowExecution() {
+	s.mockHandler.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request *workflowservice.SignalWorkflowExecutionRequest) (*workflowservice.SignalWorkflowExecutionResponse, error) {
+			s.Equal("test-namespace", request.Namespace)
+			s.Equal("wid", request.WorkflowExecution.WorkflowId)
+			s.Equal("test-signal", request.SignalName)
+			s.Equal("test-identity", request.Identity)
+			return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+		})
+
+	w := s.serve(http.MethodPost, "/api/v1/namespaces/test-namespace/workflows/wid/signal/test-signal", `{"identity": "test-identity"}`)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *httpGatewaySuite) TestListWorkflowExecutions() {
+	s.mockHandler.EXPECT().ListWorkflowExecutions(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+			s.Equal("test-namespace", request.Namespace)
+			s.Equal("WorkflowType='test'", request.Query)
+			s.Equal(int32(10), request.PageSize)
+			return &workflowservice.ListWorkflowExecutionsResponse{}, nil
+		})
+
+	w := s.serve(http.MethodGet, "/api/v1/namespaces/test-namespace/workflows?pageSize=10&query=WorkflowType%3D%27test%27", "")
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *httpGatewaySuite) TestErrorStatus() {
+	s.mockHandler.EXPECT().DescribeNamespace(gomock.Any(), gomock.Any()).
+		Return(nil, serviceerror.NewNotFound("namespace not found"))
+
+	w := s.serve(http.MethodGet, "/api/v1/namespaces/test-namespace", "")
+	s.Equal(http.StatusNotFound, w.Code)
+	var body httpError
+	s.NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	s.Equal("NotFound", body.Code)
+	s.Equal("namespace not found", body.Message)
+
+	w = s.serve(http.MethodPost, "/api/v1/namespaces/test-namespace/workflows/wid/terminate", `{invalid`)
+	s.Equal(http.StatusBadRequest, w.Code)
+
+	w = s.serve(http.MethodDelete, "/api/v1/namespaces/test-namespace", "")
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func (s *httpGatewaySuite) TestOpenAPI() {
+	w := s.serve(http.MethodGet, httpOpenAPIPath, "")
+	s.Equal(http.StatusOK, w.Code)
+
+	var doc openAPIDocument
+	s.NoError(json.Unmarshal(w.Body.Bytes(), &doc))
+	s.Equal(openAPIVersion, doc.OpenAPI)
+	for _, route := range s.gateway.routes {
+		operation := doc.Paths[route.path][strings.ToLower(route.method)]
+		s.NotNil(operation, route.path)
+		s.Equal(route.operation, operation.OperationID)
+	}
+	schema := doc.Components.Schemas["workflowservice_SignalWorkflowExecutionRequest"]
+	s.NotNil(schema)
+	s.Contains(schema.Properties, "signalName")
+	s.Contains(schema.Properties, "workflowExecution")
+}
@@ -0,0 +1,220 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"reflect"
+	"strings"
+
+	"go.temporal.io/temporal-proto/workflowservice"
+)
+
+const openAPIVersion = "3.0.3"
+
+type (
+	openAPIDocument struct {
+		OpenAPI    string                                  `json:"openapi"`
+		Info       openAPIInfo                             `json:"info"`
+		Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+		Components openAPIComponents                       `json:"components"`
+	}
+
+	openAPIInfo struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	}
+
+	openAPIOperation struct {
+		OperationID string                  `json:"operationId"`
+		Summary     string                  `json:"summary"`
+		Parameters  []*openAPIParameter     `json:"parameters,omitempty"`
+		RequestBody *openAPIBody            `json:"requestBody,omitempty"`
+		Responses   map[string]*openAPIBody `json:"responses"`
+	}
+
+	openAPIParameter struct {
+		Name     string         `json:"name"`
+		In       string         `json:"in"`
+		Required bool           `json:"required"`
+		Schema   *openAPISchema `json:"schema"`
+	}
+
+	openAPIBody struct {
+		Description string                       `json:"description,omitempty"`
+		Content     map[string]*openAPIMediaType `json:"content,omitempty"`
+	}
+
+	openAPIMediaType struct {
+		Schema *openAPISchema `json:"schema"`
+	}
+
+	openAPISchema struct {
+		Ref                  string                    `json:"$ref,omitempty"`
+		Type                 string                    `json:"type,omitempty"`
+		Format               string                    `json:"format,omitempty"`
+		Items                *openAPISchema            `json:"items,omitempty"`
+		Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+		AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+	}
+
+	openAPIComponents struct {
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	}
+)
+
+var (
+	protoEnumType = reflect.TypeOf((*interface{ EnumDescriptor() ([]byte, []int) })(nil)).Elem()
+	errorSchema   = &openAPISchema{
+		Type: "object",
+		Properties: map[string]*openAPISchema{
+			"code":    {Type: "string"},
+			"message": {Type: "string"},
+		},
+	}
+)
+
+// newOpenAPIDocument describes the routes of the HTTP gateway, the schemas are derived from the
+// request and response messages the same way jsonpb encodes them
+func newOpenAPIDocument(routes []*httpRoute) *openAPIDocument {
+	doc := &openAPIDocument{
+		OpenAPI:    openAPIVersion,
+		Info:       openAPIInfo{Title: "Temporal WorkflowService", Version: "v1"},
+		Paths:      make(map[string]map[string]*openAPIOperation),
+		Components: openAPIComponents{Schemas: map[string]*openAPISchema{"Error": errorSchema}},
+	}
+
+	for _, route := range routes {
+		operation := &openAPIOperation{
+			OperationID: route.operation,
+			Summary:     route.summary,
+			Responses: map[string]*openAPIBody{
+				"200":     jsonBody("", doc.schemaOf(responseTypeOf(route))),
+				"default": jsonBody("Error", &openAPISchema{Ref: "#/components/schemas/Error"}),
+			},
+		}
+		for _, segment := range route.segments {
+			if strings.HasPrefix(segment, "{") {
+				operation.Parameters = append(operation.Parameters, &openAPIParameter{
+					Name:     segment[1 : len(segment)-1],
+					In:       "path",
+					Required: true,
+					Schema:   &openAPISchema{Type: "string"},
+				})
+			}
+		}
+		for _, name := range route.query {
+			operation.Parameters = append(operation.Parameters, &openAPIParameter{
+				Name:   name,
+				In:     "query",
+				Schema: &openAPISchema{Type: "string"},
+			})
+		}
+		if route.hasBody {
+			operation.RequestBody = jsonBody("", doc.schemaOf(reflect.TypeOf(route.newRequest())))
+		}
+
+		if doc.Paths[route.path] == nil {
+			doc.Paths[route.path] = make(map[string]*openAPIOperation)
+		}
+		doc.Paths[route.path][strings.ToLower(route.method)] = operation
+	}
+	return doc
+}
+
+func jsonBody(description string, schema *openAPISchema) *openAPIBody {
+	return &openAPIBody{
+		Description: description,
+		Content:     map[string]*openAPIMediaType{"application/json": {Schema: schema}},
+	}
+}
+
+// responseTypeOf returns the response type of the handler method the route invokes
+func responseTypeOf(route *httpRoute) reflect.Type {
+	method, _ := reflect.TypeOf((*workflowservice.WorkflowServiceServer)(nil)).Elem().MethodByName(route.operation)
+	return method.Type.Out(0)
+}
+
+// schemaOf returns the schema of t, messages are added to the components and referenced
+func (d *openAPIDocument) schemaOf(t reflect.Type) *openAPISchema {
+	if t.Implements(protoEnumType) {
+		return &openAPISchema{Type: "string"}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return d.schemaOf(t.Elem())
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int32, reflect.Uint32:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		// jsonpb encodes 64 bit integers as strings
+		return &openAPISchema{Type: "string", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &openAPISchema{Type: "number"}
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &openAPISchema{Type: "string", Format: "byte"}
+		}
+		return &openAPISchema{Type: "array", Items: d.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: d.schemaOf(t.Elem())}
+	case reflect.Struct:
+		name := strings.Replace(t.String(), ".", "_", -1)
+		ref := &openAPISchema{Ref: "#/components/schemas/" + name}
+		if _, ok := d.Components.Schemas[name]; ok {
+			return ref
+		}
+		schema := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+		// registered before the fields are walked so recursive messages terminate
+		d.Components.Schemas[name] = schema
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := field.Tag.Get("protobuf")
+			if tag == "" || field.Tag.Get("protobuf_oneof") != "" {
+				continue
+			}
+			schema.Properties[jsonFieldName(field, tag)] = d.schemaOf(field.Type)
+		}
+		return ref
+	default:
+		return &openAPISchema{}
+	}
+}
+
+// jsonFieldName mirrors jsonpb, the json name of the protobuf tag falls back to the proto name
+func jsonFieldName(field reflect.StructField, tag string) string {
+	name := field.Name
+	for _, part := range strings.Split(tag, ",") {
+		if strings.HasPrefix(part, "json=") {
+			return strings.TrimPrefix(part, "json=")
+		}
+		if strings.HasPrefix(part, "name=") {
+			name = strings.TrimPrefix(part, "name=")
+		}
+	}
+	return name
+}
@@ -26,18 +26,23 @@ package frontend
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"sync/atomic"
 	"time"
 
 	"github.com/stretchr/testify/mock"
-	"go.temporal.io/temporal-proto/serviceerror"
 	"go.temporal.io/temporal-proto/workflowservice"
 	"google.golang.org/grpc"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/audit"
+	"github.com/temporalio/temporal/common/authorization"
+	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/definition"
+	"github.com/temporalio/temporal/common/health"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/messaging"
@@ -47,6 +52,7 @@ import (
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
 	espersistence "github.com/temporalio/temporal/common/persistence/elasticsearch"
 	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/common/rpc"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
@@ -87,7 +93,15 @@ type Config struct {
 	BlobSizeLimitError dynamicconfig.IntPropertyFnWithNamespaceFilter
 	BlobSizeLimitWarn  dynamicconfig.IntPropertyFnWithNamespaceFilter
 
-	ThrottledLogRPS dynamicconfig.IntPropertyFn
+	ThrottledLogRPS         dynamicconfig.IntPropertyFn
+	RPCRequestLogSampleRate dynamicconfig.FloatPropertyFn
+
+	// adaptive concurrency limits per namespace and API class
+	EnableAdmissionControl             dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	AdmissionControlMinConcurrency     dynamicconfig.IntPropertyFnWithNamespaceFilter
+	AdmissionControlMaxConcurrency     dynamicconfig.IntPropertyFnWithNamespaceFilter
+	AdmissionControlPollMinConcurrency dynamicconfig.IntPropertyFnWithNamespaceFilter
+	AdmissionControlLatencyTolerance   dynamicconfig.FloatPropertyFn
 
 	// Namespace specific config
 	EnableNamespaceNotActiveAutoForwarding dynamicconfig.BoolPropertyFnWithNamespaceFilter
@@ -130,6 +144,12 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, enableReadFro
 		BlobSizeLimitError:                     dc.GetIntPropertyFilteredByNamespace(dynamicconfig.BlobSizeLimitError, 2*1024*1024),
 		BlobSizeLimitWarn:                      dc.GetIntPropertyFilteredByNamespace(dynamicconfig.BlobSizeLimitWarn, 256*1024),
 		ThrottledLogRPS:                        dc.GetIntProperty(dynamicconfig.FrontendThrottledLogRPS, 20),
+		RPCRequestLogSampleRate:                dc.GetFloat64Property(dynamicconfig.RPCRequestLogSampleRate, 0),
+		EnableAdmissionControl:                 dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.FrontendEnableAdmissionControl, false),
+		AdmissionControlMinConcurrency:         dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendAdmissionControlMinConcurrency, 20),
+		AdmissionControlMaxConcurrency:         dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendAdmissionControlMaxConcurrency, 1000),
+		AdmissionControlPollMinConcurrency:     dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendAdmissionControlPollMinConcurrency, 1000),
+		AdmissionControlLatencyTolerance:       dc.GetFloat64Property(dynamicconfig.FrontendAdmissionControlLatencyTolerance, 2.0),
 		ShutdownDrainDuration:                  dc.GetDurationProperty(dynamicconfig.FrontendShutdownDrainDuration, 0),
 		EnableNamespaceNotActiveAutoForwarding: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableNamespaceNotActiveAutoForwarding, true),
 		EnableClientVersionCheck:               dc.GetBoolProperty(dynamicconfig.EnableClientVersionCheck, false),
@@ -154,7 +174,10 @@ type Service struct {
 
 	handler      Handler
 	adminHandler *AdminHandler
+	auditLogger  audit.Logger
 	server       *grpc.Server
+	httpServer   *http.Server
+	healthServer *health.Server
 }
 
 // NewService builds a new frontend service
@@ -165,6 +188,25 @@ func NewService(
 	isAdvancedVisExistInConfig := len(params.PersistenceConfig.AdvancedVisibilityStore) != 0
 	serviceConfig := NewConfig(dynamicconfig.NewCollection(params.DynamicConfig, params.Logger), params.PersistenceConfig.NumHistoryShards, isAdvancedVisExistInConfig)
 
+	// quotas set on the namespace take precedence over dynamic config, the namespace
+	// cache is created by resource.New so it is looked up when a quota is read
+	var namespaceCache cache.NamespaceCache
+	namespaceQuotaFn := func(
+		quota cache.NamespaceQuota,
+		defaultFn dynamicconfig.IntPropertyFnWithNamespaceFilter,
+	) dynamicconfig.IntPropertyFnWithNamespaceFilter {
+		return func(namespace string) int {
+			if namespaceCache == nil {
+				return defaultFn(namespace)
+			}
+			return cache.NamespaceQuotaFn(namespaceCache, quota, defaultFn)(namespace)
+		}
+	}
+	serviceConfig.MaxNamespaceRPSPerInstance = namespaceQuotaFn(cache.QuotaRPS, serviceConfig.MaxNamespaceRPSPerInstance)
+	serviceConfig.GlobalNamespaceRPS = namespaceQuotaFn(cache.QuotaGlobalRPS, serviceConfig.GlobalNamespaceRPS)
+	serviceConfig.VisibilityListMaxQPS = namespaceQuotaFn(cache.QuotaVisibilityListQPS, serviceConfig.VisibilityListMaxQPS)
+	serviceConfig.ESVisibilityListMaxQPS = namespaceQuotaFn(cache.QuotaVisibilityListQPS, serviceConfig.ESVisibilityListMaxQPS)
+
 	params.PersistenceConfig.HistoryMaxConns = serviceConfig.HistoryMgrNumConns()
 	params.PersistenceConfig.VisibilityConfig = &config.VisibilityConfig{
 		VisibilityListMaxQPS:            serviceConfig.VisibilityListMaxQPS,
@@ -210,11 +252,22 @@ func NewService(
 		return nil, err
 	}
 
+	namespaceCache = serviceResource.GetNamespaceCache()
+
+	var auditLogger audit.Logger
+	if params.AuditLoggerInitializer != nil {
+		auditLogger, err = params.AuditLoggerInitializer(serviceResource.GetPersistenceBean(), serviceResource.GetLogger())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &Service{
-		Resource: serviceResource,
-		status:   common.DaemonStatusInitialized,
-		config:   serviceConfig,
-		params:   params,
+		Resource:    serviceResource,
+		status:      common.DaemonStatusInitialized,
+		config:      serviceConfig,
+		params:      params,
+		auditLogger: auditLogger,
 	}, nil
 }
 
@@ -246,20 +299,48 @@ func (s *Service) Start() {
 		replicationMessageSink.(*mocks.KafkaProducer).On("Publish", mock.Anything).Return(nil)
 	}
 
-	s.server = grpc.NewServer(grpc.UnaryInterceptor(interceptor))
+	var serviceInterceptors []grpc.UnaryServerInterceptor
+	if s.params.ClaimMapper != nil {
+		serviceInterceptors = append(serviceInterceptors, authorization.NewAuthenticationInterceptor(s.params.ClaimMapper, logger))
+	}
+	serviceInterceptors = append(serviceInterceptors, validatePayloadMetadata)
+	serviceInterceptors = append(serviceInterceptors, NewAdmissionController(s.config, s.GetNamespaceCache(), s.GetMetricsClient(), logger).Intercept)
+	interceptors := rpc.NewServerInterceptors(
+		s.GetMetricsClient(),
+		logger,
+		s.config.RPCRequestLogSampleRate,
+		s.GetNamespaceCache(),
+		serviceInterceptors...,
+	)
+	opts := append(s.GetFrontendGRPCServerOptions(), grpc.ChainUnaryInterceptor(interceptors...))
+	s.server = grpc.NewServer(opts...)
 
 	wfHandler := NewWorkflowHandler(s, s.config, replicationMessageSink)
 	s.handler = NewDCRedirectionHandler(wfHandler, s.params.DCRedirectionPolicy)
 	if s.params.Authorizer != nil {
 		s.handler = NewAccessControlledHandlerImpl(s.handler, s.params.Authorizer)
 	}
+	if s.auditLogger != nil {
+		s.handler = NewAuditedHandler(s.handler, s.auditLogger)
+	}
 	workflowNilCheckHandler := NewWorkflowNilCheckHandler(s.handler)
 
 	workflowservice.RegisterWorkflowServiceServer(s.server, workflowNilCheckHandler)
-	healthpb.RegisterHealthServer(s.server, s.handler)
+	// frontend readiness only depends on the state of the host, persistence being down must not take
+	// every frontend host out of rotation
+	healthChecker := resource.NewHealthChecker(s)
+	healthChecker.Register("handler", s.checkHandlerHealth)
+	healthpb.RegisterHealthServer(s.server, healthChecker)
 
 	s.adminHandler = NewAdminHandler(s, s.params, s.config)
-	adminNilCheckHandler := NewAdminNilCheckHandler(s.adminHandler)
+	var adminHandler adminservice.AdminServiceServer = s.adminHandler
+	if s.params.Authorizer != nil {
+		adminHandler = NewAccessControlledAdminHandler(adminHandler, s.params.Authorizer, s.GetMetricsClient(), s.GetLogger())
+	}
+	if s.auditLogger != nil {
+		adminHandler = NewAuditedAdminHandler(adminHandler, s.auditLogger)
+	}
+	adminNilCheckHandler := NewAdminNilCheckHandler(adminHandler)
 
 	adminservice.RegisterAdminServiceServer(s.server, adminNilCheckHandler)
 
@@ -267,6 +348,19 @@ func (s *Service) Start() {
 	s.Resource.Start()
 	s.adminHandler.Start()
 
+	if httpListener := s.params.RPCFactory.GetHTTPListener(); httpListener != nil {
+		s.httpServer = NewHTTPServer(NewHTTPGateway(workflowNilCheckHandler, interceptors, logger))
+		go func() {
+			logger.Info("Starting to serve on frontend HTTP listener")
+			if err := s.httpServer.Serve(httpListener); err != nil && err != http.ErrServerClosed {
+				logger.Fatal("Failed to serve on frontend HTTP listener", tag.Error(err))
+			}
+		}()
+	}
+
+	s.healthServer = health.NewServer(healthChecker, s.GetHealthListener(), logger)
+	s.healthServer.Start()
+
 	listener := s.GetGRPCListener()
 	logger.Info("Starting to serve on frontend listener")
 	if err := s.server.Serve(listener); err != nil {
@@ -274,6 +368,18 @@ func (s *Service) Start() {
 	}
 }
 
+// checkHandlerHealth fails once the handler starts shutting down
+func (s *Service) checkHandlerHealth(ctx context.Context) (string, error) {
+	response, err := s.handler.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return "", err
+	}
+	if response.Status != healthpb.HealthCheckResponse_SERVING {
+		return "", errors.New("handler is shutting down")
+	}
+	return "", nil
+}
+
 // Stop stops the service
 func (s *Service) Stop() {
 	if !atomic.CompareAndSwapInt32(&s.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
@@ -301,12 +407,18 @@ func (s *Service) Stop() {
 	s.GetLogger().Info("ShutdownHandler: Draining traffic")
 	time.Sleep(requestDrainTime)
 
+	if s.httpServer != nil {
+		if err := s.httpServer.Shutdown(context.Background()); err != nil {
+			s.GetLogger().Warn("Failed to shut down frontend HTTP server", tag.Error(err))
+		}
+	}
 	s.server.GracefulStop()
+	s.healthServer.Stop()
+	if s.auditLogger != nil {
+		if err := s.auditLogger.Close(); err != nil {
+			s.GetLogger().Warn("Failed to close audit log", tag.Error(err))
+		}
+	}
 	s.Resource.Stop()
 	s.params.Logger.Info("frontend stopped")
 }
-
-func interceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	resp, err := handler(ctx, req)
-	return resp, serviceerror.ToStatus(err).Err()
-}
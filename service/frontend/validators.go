@@ -25,8 +25,14 @@
 package frontend
 
 import (
+	"context"
+	"strings"
+
 	"github.com/pborman/uuid"
 	executionpb "go.temporal.io/temporal-proto/execution"
+	"google.golang.org/grpc"
+
+	"github.com/temporalio/temporal/common/payload"
 )
 
 func validateExecution(w *executionpb.WorkflowExecution) error {
@@ -41,3 +47,18 @@ func validateExecution(w *executionpb.WorkflowExecution) error {
 	}
 	return nil
 }
+
+// validatePayloadMetadata rejects WorkflowService requests with payload metadata keys reserved for
+// the server, clients could otherwise skip the encryption of payloads at rest or make them unreadable
+func validatePayloadMetadata(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	if strings.HasPrefix(info.FullMethod, workflowServiceMethodPrefix) && payload.HasReservedMetadata(req) {
+		return nil, errReservedPayloadMetadata
+	}
+	return handler(ctx, req)
+}
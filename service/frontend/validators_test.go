@@ -0,0 +1,62 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+
+	"github.com/temporalio/temporal/common/payload"
+)
+
+func TestValidatePayloadMetadata(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: workflowServiceMethodPrefix + "SignalWorkflowExecution"}
+
+	request := &workflowservice.SignalWorkflowExecutionRequest{
+		Input: payload.EncodeString("input"),
+	}
+	_, err := validatePayloadMetadata(context.Background(), request, info, handler)
+	require.NoError(t, err)
+
+	request.Input.Items = append(request.Input.Items, &commonpb.PayloadItem{
+		Metadata: map[string][]byte{payload.MetadataServerEncryption: []byte(payload.ServerEncryptionAlgorithm)},
+		Data:     []byte("not encrypted"),
+	})
+	_, err = validatePayloadMetadata(context.Background(), request, info, handler)
+	require.Equal(t, errReservedPayloadMetadata, err)
+
+	// requests of the other services are not checked
+	info = &grpc.UnaryServerInfo{FullMethod: "/temporal.adminservice.AdminService/ReapplyEvents"}
+	_, err = validatePayloadMetadata(context.Background(), request, info, handler)
+	require.NoError(t, err)
+}
@@ -27,6 +27,8 @@ package frontend
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -37,6 +39,7 @@ import (
 	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/authorization"
 	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/convert"
@@ -62,7 +65,9 @@ import (
 	versionpb "go.temporal.io/temporal-proto/version"
 	"go.temporal.io/temporal-proto/workflowservice"
 
+	"google.golang.org/grpc"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
@@ -230,6 +235,11 @@ func (wh *WorkflowHandler) RegisterNamespace(ctx context.Context, request *workf
 		return nil, errNamespaceNotSet
 	}
 
+	// permissions on namespace "system" apply to the whole cluster
+	if request.GetName() == authorization.SystemNamespace {
+		return nil, errNamespaceNameReserved
+	}
+
 	resp, err := wh.namespaceHandler.RegisterNamespace(ctx, request)
 	if err != nil {
 		return nil, wh.error(err, scope)
@@ -430,7 +440,7 @@ func (wh *WorkflowHandler) StartWorkflowExecution(ctx context.Context, request *
 		return nil, wh.error(errWorkflowTypeTooLong, scope)
 	}
 
-	if err := wh.validateTaskList(request.TaskList, scope); err != nil {
+	if err := wh.validateWorkflowTaskList(request.TaskList, scope); err != nil {
 		return nil, err
 	}
 
@@ -647,6 +657,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 		if !isWorkflowRunning {
 			if rawHistoryQueryEnabled {
 				historyBlob, _, err = wh.getRawHistory(
+					ctx,
 					scope,
 					namespaceID,
 					*execution,
@@ -665,6 +676,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 				historyBlob = historyBlob[len(historyBlob)-1 : len(historyBlob)]
 			} else {
 				history, _, err = wh.getHistory(
+					ctx,
 					scope,
 					namespaceID,
 					*execution,
@@ -699,6 +711,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 		} else {
 			if rawHistoryQueryEnabled {
 				historyBlob, continuationToken.PersistenceToken, err = wh.getRawHistory(
+					ctx,
 					scope,
 					namespaceID,
 					*execution,
@@ -711,6 +724,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 				)
 			} else {
 				history, continuationToken.PersistenceToken, err = wh.getHistory(
+					ctx,
 					scope,
 					namespaceID,
 					*execution,
@@ -790,7 +804,7 @@ func (wh *WorkflowHandler) PollForDecisionTask(ctx context.Context, request *wor
 		return nil, wh.error(errIdentityTooLong, scope, tagsForErrorLog...)
 	}
 
-	if err := wh.validateTaskList(request.TaskList, scope); err != nil {
+	if err := wh.validateWorkflowTaskList(request.TaskList, scope); err != nil {
 		return nil, err
 	}
 
@@ -897,18 +911,36 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(ctx context.Context, req
 		return nil, errShuttingDown
 	}
 
-	histResp, err := wh.GetHistoryClient().RespondDecisionTaskCompleted(ctx, &historyservice.RespondDecisionTaskCompletedRequest{
-		NamespaceId:     namespaceId,
-		CompleteRequest: request},
-	)
-	if err != nil {
-		return nil, wh.error(err, scope)
+	for _, decision := range request.GetDecisions() {
+		if attributes := decision.GetScheduleActivityTaskDecisionAttributes(); attributes != nil {
+			if err := common.ValidateActivityStartDelay(attributes.GetHeader()); err != nil {
+				return nil, wh.error(err, scope)
+			}
+		}
 	}
 
 	if len(request.GetIdentity()) > wh.config.MaxIDLengthLimit() {
 		return nil, wh.error(errIdentityTooLong, scope)
 	}
 
+	maxEagerActivityTasks, err := getMaxEagerActivityTasks(ctx)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	histResp, err := wh.GetHistoryClient().RespondDecisionTaskCompleted(ctx, &historyservice.RespondDecisionTaskCompletedRequest{
+		NamespaceId:           namespaceId,
+		CompleteRequest:       request,
+		MaxEagerActivityTasks: maxEagerActivityTasks,
+	})
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+	// the activities are started once history returns, their tasks are sent to the worker even if building the new
+	// decision task fails below, as the header is sent along with the error status
+	wh.sendEagerActivityTasks(ctx, histResp.GetActivityTasks())
+	wh.releaseInFlightTask(ctx, taskToken, persistence.TaskListTypeDecision)
+
 	completedResp := &workflowservice.RespondDecisionTaskCompletedResponse{}
 	if request.GetReturnNewDecisionTask() && histResp != nil && histResp.StartedResponse != nil {
 		taskToken := &tokengenpb.Task{
@@ -1009,6 +1041,7 @@ func (wh *WorkflowHandler) RespondDecisionTaskFailed(ctx context.Context, reques
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	wh.releaseInFlightTask(ctx, taskToken, persistence.TaskListTypeDecision)
 
 	return &workflowservice.RespondDecisionTaskFailedResponse{}, nil
 }
@@ -1053,7 +1086,7 @@ func (wh *WorkflowHandler) PollForActivityTask(ctx context.Context, request *wor
 		return nil, wh.error(errNamespaceTooLong, scope)
 	}
 
-	if err := wh.validateTaskList(request.TaskList, scope); err != nil {
+	if err := wh.validateActivityTaskList(request.TaskList, request.GetIdentity(), scope); err != nil {
 		return nil, err
 	}
 	if len(request.GetIdentity()) > wh.config.MaxIDLengthLimit() {
@@ -1195,6 +1228,7 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeat(ctx context.Context, requ
 		if err != nil {
 			return nil, wh.error(err, scope)
 		}
+		wh.releaseInFlightTask(ctx, taskToken, persistence.TaskListTypeActivity)
 		return &workflowservice.RecordActivityTaskHeartbeatResponse{CancelRequested: true}, nil
 	}
 
@@ -1408,6 +1442,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(ctx context.Context, req
 			return nil, wh.error(err, scope)
 		}
 	}
+	wh.releaseInFlightTask(ctx, taskToken, persistence.TaskListTypeActivity)
 
 	return &workflowservice.RespondActivityTaskCompletedResponse{}, nil
 }
@@ -1604,6 +1639,7 @@ func (wh *WorkflowHandler) RespondActivityTaskFailed(ctx context.Context, reques
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	wh.releaseInFlightTask(ctx, taskToken, persistence.TaskListTypeActivity)
 	return &workflowservice.RespondActivityTaskFailedResponse{}, nil
 }
 
@@ -1800,6 +1836,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(ctx context.Context, requ
 			return nil, wh.error(err, scope)
 		}
 	}
+	wh.releaseInFlightTask(ctx, taskToken, persistence.TaskListTypeActivity)
 
 	return &workflowservice.RespondActivityTaskCanceledResponse{}, nil
 }
@@ -2108,7 +2145,7 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 		return nil, wh.error(errWorkflowTypeTooLong, scope)
 	}
 
-	if err := wh.validateTaskList(request.TaskList, scope); err != nil {
+	if err := wh.validateWorkflowTaskList(request.TaskList, scope); err != nil {
 		return nil, err
 	}
 
@@ -2349,7 +2386,7 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context, reque
 		if wh.config.DisableListVisibilityByFilter(namespace) {
 			err = errNoPermission
 		} else {
-			persistenceResp, err = wh.GetVisibilityManager().ListOpenWorkflowExecutionsByWorkflowID(
+			persistenceResp, err = persistence.VisibilityManagerWithContext(ctx, wh.GetVisibilityManager()).ListOpenWorkflowExecutionsByWorkflowID(
 				&persistence.ListWorkflowExecutionsByWorkflowIDRequest{
 					ListWorkflowExecutionsRequest: baseReq,
 					WorkflowID:                    request.GetExecutionFilter().GetWorkflowId(),
@@ -2361,7 +2398,7 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context, reque
 		if wh.config.DisableListVisibilityByFilter(namespace) {
 			err = errNoPermission
 		} else {
-			persistenceResp, err = wh.GetVisibilityManager().ListOpenWorkflowExecutionsByType(&persistence.ListWorkflowExecutionsByTypeRequest{
+			persistenceResp, err = persistence.VisibilityManagerWithContext(ctx, wh.GetVisibilityManager()).ListOpenWorkflowExecutionsByType(&persistence.ListWorkflowExecutionsByTypeRequest{
 				ListWorkflowExecutionsRequest: baseReq,
 				WorkflowTypeName:              request.GetTypeFilter().GetName(),
 			})
@@ -2369,7 +2406,7 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context, reque
 		wh.GetLogger().Info("List open workflow with filter",
 			tag.WorkflowNamespace(request.GetNamespace()), tag.WorkflowListWorkflowFilterByType)
 	} else {
-		persistenceResp, err = wh.GetVisibilityManager().ListOpenWorkflowExecutions(&baseReq)
+		persistenceResp, err = persistence.VisibilityManagerWithContext(ctx, wh.GetVisibilityManager()).ListOpenWorkflowExecutions(&baseReq)
 	}
 
 	if err != nil {
@@ -2445,7 +2482,7 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context, req
 		if wh.config.DisableListVisibilityByFilter(namespace) {
 			err = errNoPermission
 		} else {
-			persistenceResp, err = wh.GetVisibilityManager().ListClosedWorkflowExecutionsByWorkflowID(
+			persistenceResp, err = persistence.VisibilityManagerWithContext(ctx, wh.GetVisibilityManager()).ListClosedWorkflowExecutionsByWorkflowID(
 				&persistence.ListWorkflowExecutionsByWorkflowIDRequest{
 					ListWorkflowExecutionsRequest: baseReq,
 					WorkflowID:                    request.GetExecutionFilter().GetWorkflowId(),
@@ -2457,7 +2494,7 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context, req
 		if wh.config.DisableListVisibilityByFilter(namespace) {
 			err = errNoPermission
 		} else {
-			persistenceResp, err = wh.GetVisibilityManager().ListClosedWorkflowExecutionsByType(&persistence.ListWorkflowExecutionsByTypeRequest{
+			persistenceResp, err = persistence.VisibilityManagerWithContext(ctx, wh.GetVisibilityManager()).ListClosedWorkflowExecutionsByType(&persistence.ListWorkflowExecutionsByTypeRequest{
 				ListWorkflowExecutionsRequest: baseReq,
 				WorkflowTypeName:              request.GetTypeFilter().GetName(),
 			})
@@ -2468,7 +2505,7 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context, req
 		if wh.config.DisableListVisibilityByFilter(namespace) {
 			err = errNoPermission
 		} else {
-			persistenceResp, err = wh.GetVisibilityManager().ListClosedWorkflowExecutionsByStatus(&persistence.ListClosedWorkflowExecutionsByStatusRequest{
+			persistenceResp, err = persistence.VisibilityManagerWithContext(ctx, wh.GetVisibilityManager()).ListClosedWorkflowExecutionsByStatus(&persistence.ListClosedWorkflowExecutionsByStatusRequest{
 				ListWorkflowExecutionsRequest: baseReq,
 				Status:                        request.GetStatusFilter().GetStatus(),
 			})
@@ -2476,7 +2513,7 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context, req
 		wh.GetLogger().Info("List closed workflow with filter",
 			tag.WorkflowNamespace(request.GetNamespace()), tag.WorkflowListWorkflowFilterByStatus)
 	} else {
-		persistenceResp, err = wh.GetVisibilityManager().ListClosedWorkflowExecutions(&baseReq)
+		persistenceResp, err = persistence.VisibilityManagerWithContext(ctx, wh.GetVisibilityManager()).ListClosedWorkflowExecutions(&baseReq)
 	}
 
 	if err != nil {
@@ -2541,7 +2578,7 @@ func (wh *WorkflowHandler) ListWorkflowExecutions(ctx context.Context, request *
 		NextPageToken: request.NextPageToken,
 		Query:         request.GetQuery(),
 	}
-	persistenceResp, err := wh.GetVisibilityManager().ListWorkflowExecutions(req)
+	persistenceResp, err := persistence.VisibilityManagerWithContext(ctx, wh.GetVisibilityManager()).ListWorkflowExecutions(req)
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -2692,7 +2729,7 @@ func (wh *WorkflowHandler) ScanWorkflowExecutions(ctx context.Context, request *
 		NextPageToken: request.NextPageToken,
 		Query:         request.GetQuery(),
 	}
-	persistenceResp, err := wh.GetVisibilityManager().ScanWorkflowExecutions(req)
+	persistenceResp, err := persistence.VisibilityManagerWithContext(ctx, wh.GetVisibilityManager()).ScanWorkflowExecutions(req)
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -2746,7 +2783,7 @@ func (wh *WorkflowHandler) CountWorkflowExecutions(ctx context.Context, request
 		Namespace:   namespace,
 		Query:       request.GetQuery(),
 	}
-	persistenceResp, err := wh.GetVisibilityManager().CountWorkflowExecutions(req)
+	persistenceResp, err := persistence.VisibilityManagerWithContext(ctx, wh.GetVisibilityManager()).CountWorkflowExecutions(req)
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -3085,6 +3122,8 @@ func (wh *WorkflowHandler) DescribeTaskList(ctx context.Context, request *workfl
 		return nil, wh.error(err, scope)
 	}
 
+	// the outstanding tasks of the pollers and the poller limits are returned by AdminService.DescribeTaskList,
+	// the WorkflowService response has no fields for them
 	return &workflowservice.DescribeTaskListResponse{
 		Pollers:        matchingResponse.Pollers,
 		TaskListStatus: matchingResponse.TaskListStatus,
@@ -3151,6 +3190,7 @@ func (wh *WorkflowHandler) ListTaskListPartitions(ctx context.Context, request *
 }
 
 func (wh *WorkflowHandler) getRawHistory(
+	ctx context.Context,
 	scope metrics.Scope,
 	namespaceID string,
 	execution executionpb.WorkflowExecution,
@@ -3164,13 +3204,14 @@ func (wh *WorkflowHandler) getRawHistory(
 	var rawHistory []*commonpb.DataBlob
 	shardID := common.WorkflowIDToHistoryShard(execution.GetWorkflowId(), wh.config.NumHistoryShards)
 
-	resp, err := wh.GetHistoryManager().ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
+	resp, err := persistence.HistoryManagerWithContext(ctx, wh.GetHistoryManager()).ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
 		BranchToken:   branchToken,
 		MinEventID:    firstEventID,
 		MaxEventID:    nextEventID,
 		PageSize:      int(pageSize),
 		NextPageToken: nextPageToken,
 		ShardID:       convert.IntPtr(shardID),
+		NamespaceID:   namespaceID,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -3225,6 +3266,7 @@ func (wh *WorkflowHandler) getRawHistory(
 }
 
 func (wh *WorkflowHandler) getHistory(
+	ctx context.Context,
 	scope metrics.Scope,
 	namespaceID string,
 	execution executionpb.WorkflowExecution,
@@ -3241,13 +3283,14 @@ func (wh *WorkflowHandler) getHistory(
 	shardID := common.WorkflowIDToHistoryShard(execution.GetWorkflowId(), wh.config.NumHistoryShards)
 	var err error
 	var historyEvents []*eventpb.HistoryEvent
-	historyEvents, size, nextPageToken, err = persistence.ReadFullPageV2Events(wh.GetHistoryManager(), &persistence.ReadHistoryBranchRequest{
+	historyEvents, size, nextPageToken, err = persistence.ReadFullPageV2Events(persistence.HistoryManagerWithContext(ctx, wh.GetHistoryManager()), &persistence.ReadHistoryBranchRequest{
 		BranchToken:   branchToken,
 		MinEventID:    firstEventID,
 		MaxEventID:    nextEventID,
 		PageSize:      int(pageSize),
 		NextPageToken: nextPageToken,
 		ShardID:       convert.IntPtr(shardID),
+		NamespaceID:   namespaceID,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -3290,6 +3333,69 @@ func (wh *WorkflowHandler) getHistory(
 	return executionHistory, nextPageToken, nil
 }
 
+// getMaxEagerActivityTasks returns the number of activity tasks the worker asked to be returned eagerly on decision
+// completion. Eager dispatch is only requested from history when the tasks can be sent back in the gRPC response
+// header, activity tasks started eagerly are otherwise lost until they time out
+func getMaxEagerActivityTasks(ctx context.Context) (int32, error) {
+	value := headers.GetValues(ctx, headers.EagerActivityDispatchHeaderName)[0]
+	if value == "" {
+		return 0, nil
+	}
+	maxTasks, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || maxTasks < 0 {
+		return 0, errInvalidEagerActivityDispatch
+	}
+	if grpc.ServerTransportStreamFromContext(ctx) == nil {
+		return 0, nil
+	}
+	return int32(maxTasks), nil
+}
+
+// releaseInFlightTask lets matching know that the task of the token, which was dispatched by the task list partition
+// of the token, is no longer in flight on its poller. Otherwise the task counts against the limits of its poller until
+// it times out, so a failure is only logged
+func (wh *WorkflowHandler) releaseInFlightTask(ctx context.Context, taskToken *tokengenpb.Task, taskListType int32) {
+	if taskToken.GetTaskList() == "" {
+		return
+	}
+	_, err := wh.GetMatchingClient().ReleaseInFlightTask(ctx, &matchingservice.ReleaseInFlightTaskRequest{
+		NamespaceId:  primitives.UUIDString(taskToken.GetNamespaceId()),
+		TaskListType: taskListType,
+		TaskList:     &tasklistpb.TaskList{Name: taskToken.GetTaskList()},
+		RunId:        taskToken.GetRunId(),
+		ScheduleId:   taskToken.GetScheduleId(),
+	})
+	if err != nil {
+		wh.GetLogger().Warn("Unable to release in flight task.",
+			tag.WorkflowTaskListName(taskToken.GetTaskList()), tag.WorkflowID(taskToken.GetWorkflowId()), tag.Error(err))
+	}
+}
+
+// sendEagerActivityTasks returns the activity tasks started eagerly on decision completion to the worker through the
+// gRPC response header, as RespondDecisionTaskCompletedResponse has no field for them
+func (wh *WorkflowHandler) sendEagerActivityTasks(
+	ctx context.Context,
+	tasks []*workflowservice.PollForActivityTaskResponse,
+) {
+	if len(tasks) == 0 {
+		return
+	}
+
+	values := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		data, err := task.Marshal()
+		if err != nil {
+			wh.GetLogger().Error("Unable to serialize eager activity task.", tag.WorkflowID(task.GetWorkflowExecution().GetWorkflowId()), tag.Error(err))
+			continue
+		}
+		values = append(values, string(data))
+	}
+	if err := grpc.SetHeader(ctx, metadata.MD{headers.EagerActivityTasksHeaderName: values}); err != nil {
+		// the activity tasks were started already, they time out and are retried according to their retry policy
+		wh.GetLogger().Error("Unable to send eager activity tasks.", tag.Error(err))
+	}
+}
+
 func (wh *WorkflowHandler) validateTransientDecisionEvents(
 	expectedNextEventID int64,
 	decision *eventgenpb.TransientDecisionInfo,
@@ -3388,6 +3494,36 @@ func (wh *WorkflowHandler) validateTaskList(t *tasklistpb.TaskList, scope metric
 	return nil
 }
 
+// validateWorkflowTaskList validates a task list decisions are dispatched to,
+// affinity task lists can only be used by activities
+func (wh *WorkflowHandler) validateWorkflowTaskList(t *tasklistpb.TaskList, scope metrics.Scope) error {
+	if err := wh.validateTaskList(t, scope); err != nil {
+		return err
+	}
+	if strings.HasPrefix(t.GetName(), common.TaskListAffinityPrefix) {
+		return wh.error(errAffinityTaskListNotAllowed, scope)
+	}
+	return nil
+}
+
+// validateActivityTaskList validates a task list activities are polled from,
+// an affinity task list needs an affinity key and a poller identity to bind to
+func (wh *WorkflowHandler) validateActivityTaskList(t *tasklistpb.TaskList, identity string, scope metrics.Scope) error {
+	if err := wh.validateTaskList(t, scope); err != nil {
+		return err
+	}
+	if !strings.HasPrefix(t.GetName(), common.TaskListAffinityPrefix) {
+		return nil
+	}
+	if t.GetName() == common.TaskListAffinityPrefix {
+		return wh.error(errAffinityKeyNotSet, scope)
+	}
+	if identity == "" {
+		return wh.error(errAffinityIdentityNotSet, scope)
+	}
+	return nil
+}
+
 func (wh *WorkflowHandler) validateExecutionAndEmitMetrics(w *executionpb.WorkflowExecution, scope metrics.Scope) error {
 	err := validateExecution(w)
 	if err != nil {
@@ -3440,6 +3576,7 @@ func (wh *WorkflowHandler) createPollForDecisionTaskResponse(
 		}
 		scope = scope.Tagged(metrics.NamespaceTag(namespace.GetInfo().Name))
 		history, persistenceToken, err = wh.getHistory(
+			ctx,
 			scope,
 			namespaceID,
 			*matchingResp.GetWorkflowExecution(),
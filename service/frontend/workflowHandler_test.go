@@ -35,14 +35,17 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/convert"
+	"github.com/temporalio/temporal/common/headers"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
@@ -60,6 +63,8 @@ import (
 	"go.temporal.io/temporal-proto/serviceerror"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
@@ -392,6 +397,37 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_TaskListNotSet(
 	s.Equal(errTaskListNotSet, err)
 }
 
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_AffinityTaskList() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	wh := s.getWorkflowHandler(config)
+
+	startWorkflowExecutionRequest := &workflowservice.StartWorkflowExecutionRequest{
+		Namespace:  "test-namespace",
+		WorkflowId: "workflow-id",
+		WorkflowType: &commonpb.WorkflowType{
+			Name: "workflow-type",
+		},
+		TaskList: &tasklistpb.TaskList{
+			Name: common.TaskListAffinityPrefix + "host-1",
+		},
+		RequestId: uuid.New(),
+	}
+	_, err := wh.StartWorkflowExecution(context.Background(), startWorkflowExecutionRequest)
+	s.Error(err)
+	s.Equal(errAffinityTaskListNotAllowed, err)
+}
+
+func (s *workflowHandlerSuite) TestValidateActivityTaskList() {
+	wh := s.getWorkflowHandler(s.newConfig())
+	scope := metrics.NoopScope(metrics.Frontend)
+
+	s.NoError(wh.validateActivityTaskList(&tasklistpb.TaskList{Name: "tl-1"}, "", scope))
+	s.NoError(wh.validateActivityTaskList(&tasklistpb.TaskList{Name: common.TaskListAffinityPrefix + "host-1"}, "worker-1", scope))
+	s.Equal(errAffinityKeyNotSet, wh.validateActivityTaskList(&tasklistpb.TaskList{Name: common.TaskListAffinityPrefix}, "worker-1", scope))
+	s.Equal(errAffinityIdentityNotSet, wh.validateActivityTaskList(&tasklistpb.TaskList{Name: common.TaskListAffinityPrefix + "host-1"}, "", scope))
+}
+
 func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidExecutionStartToCloseTimeout() {
 	config := s.newConfig()
 	config.RPS = dc.GetIntPropertyFn(10)
@@ -563,6 +599,15 @@ func (s *workflowHandlerSuite) TestRegisterNamespace_Success_NotEnabled() {
 	s.NoError(err)
 }
 
+func (s *workflowHandlerSuite) TestRegisterNamespace_Failure_ReservedName() {
+	wh := s.getWorkflowHandler(s.newConfig())
+
+	req := registerNamespaceRequest(namespacepb.ArchivalStatus_Default, "", namespacepb.ArchivalStatus_Default, "")
+	req.Name = "system"
+	_, err := wh.RegisterNamespace(context.Background(), req)
+	s.Equal(errNamespaceNameReserved, err)
+}
+
 func (s *workflowHandlerSuite) TestDescribeNamespace_Success_ArchivalDisabled() {
 	getNamespaceResp := persistenceGetNamespaceResponse(
 		&namespace.ArchivalState{Status: namespacepb.ArchivalStatus_Disabled, URI: ""},
@@ -987,6 +1032,7 @@ func (s *workflowHandlerSuite) TestGetHistory() {
 		PageSize:      0,
 		NextPageToken: []byte{},
 		ShardID:       convert.IntPtr(shardID),
+		NamespaceID:   namespaceID,
 	}
 	s.mockHistoryV2Mgr.On("ReadHistoryBranch", req).Return(&persistence.ReadHistoryBranchResponse{
 		HistoryEvents: []*eventpb.HistoryEvent{
@@ -1237,6 +1283,95 @@ func (s *workflowHandlerSuite) TestConvertIndexedKeyToThrift() {
 	})
 }
 
+func (s *workflowHandlerSuite) TestGetMaxEagerActivityTasks() {
+	withHeader := func(ctx context.Context, value string) context.Context {
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(headers.EagerActivityDispatchHeaderName, value))
+	}
+	grpcCtx := grpc.NewContextWithServerTransportStream(context.Background(), &testServerTransportStream{})
+
+	maxTasks, err := getMaxEagerActivityTasks(grpcCtx)
+	s.NoError(err)
+	s.Equal(int32(0), maxTasks)
+
+	maxTasks, err = getMaxEagerActivityTasks(withHeader(grpcCtx, "2"))
+	s.NoError(err)
+	s.Equal(int32(2), maxTasks)
+
+	_, err = getMaxEagerActivityTasks(withHeader(grpcCtx, "-1"))
+	s.Equal(errInvalidEagerActivityDispatch, err)
+	_, err = getMaxEagerActivityTasks(withHeader(grpcCtx, "all"))
+	s.Equal(errInvalidEagerActivityDispatch, err)
+
+	// the tasks can not be returned without a gRPC stream
+	maxTasks, err = getMaxEagerActivityTasks(withHeader(context.Background(), "2"))
+	s.NoError(err)
+	s.Equal(int32(0), maxTasks)
+}
+
+func (s *workflowHandlerSuite) TestRespondDecisionTaskCompleted_EagerActivityTasks_IdentityTooLong() {
+	config := s.newConfig()
+	config.MaxIDLengthLimit = dc.GetIntPropertyFn(10)
+	wh := s.getWorkflowHandler(config)
+
+	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(
+		&persistenceblobs.NamespaceInfo{Name: s.testNamespace}, &persistenceblobs.NamespaceConfig{}, "", nil)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testNamespaceID).Return(namespaceEntry, nil).Times(1)
+	taskToken, err := common.NewProtoTaskTokenSerializer().Serialize(&tokengenpb.Task{
+		NamespaceId: primitives.MustParseUUID(s.testNamespaceID),
+		WorkflowId:  testWorkflowID,
+		ScheduleId:  2,
+	})
+	s.NoError(err)
+
+	// the request is rejected before history starts any activity
+	stream := &testServerTransportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(headers.EagerActivityDispatchHeaderName, "2"))
+	resp, err := wh.RespondDecisionTaskCompleted(ctx, &workflowservice.RespondDecisionTaskCompletedRequest{
+		TaskToken: taskToken,
+		Identity:  "identity too long",
+	})
+	s.Nil(resp)
+	s.Equal(errIdentityTooLong, err)
+	s.Empty(stream.header)
+}
+
+func (s *workflowHandlerSuite) TestRespondDecisionTaskCompleted_EagerActivityTasks_NewDecisionTaskFailed() {
+	wh := s.getWorkflowHandler(s.newConfig())
+
+	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(
+		&persistenceblobs.NamespaceInfo{Name: s.testNamespace}, &persistenceblobs.NamespaceConfig{}, "", nil)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testNamespaceID).Return(namespaceEntry, nil).Times(1)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testNamespaceID).Return(nil, errors.New("error getting namespace")).Times(1)
+	taskToken, err := common.NewProtoTaskTokenSerializer().Serialize(&tokengenpb.Task{
+		NamespaceId: primitives.MustParseUUID(s.testNamespaceID),
+		WorkflowId:  testWorkflowID,
+		ScheduleId:  2,
+	})
+	s.NoError(err)
+	activityTask := &workflowservice.PollForActivityTaskResponse{ActivityId: "activity1"}
+	s.mockHistoryClient.EXPECT().RespondDecisionTaskCompleted(gomock.Any(), gomock.Any()).Return(&historyservice.RespondDecisionTaskCompletedResponse{
+		StartedResponse: &historyservice.RecordDecisionTaskStartedResponse{ScheduledEventId: 6, NextEventId: 8},
+		ActivityTasks:   []*workflowservice.PollForActivityTaskResponse{activityTask},
+	}, nil).Times(1)
+
+	// the activity was started by history, its task is sent to the worker along with the error
+	stream := &testServerTransportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(headers.EagerActivityDispatchHeaderName, "2"))
+	resp, err := wh.RespondDecisionTaskCompleted(ctx, &workflowservice.RespondDecisionTaskCompletedRequest{
+		TaskToken:             taskToken,
+		ReturnNewDecisionTask: true,
+	})
+	s.Nil(resp)
+	s.Error(err)
+	values := stream.header.Get(headers.EagerActivityTasksHeaderName)
+	s.Len(values, 1)
+	sentTask := &workflowservice.PollForActivityTaskResponse{}
+	s.NoError(sentTask.Unmarshal([]byte(values[0])))
+	s.Equal(activityTask, sentTask)
+}
+
 func (s *workflowHandlerSuite) TestVerifyHistoryIsComplete() {
 	wh := s.getWorkflowHandler(s.newConfig())
 
@@ -1392,3 +1527,24 @@ func listArchivedWorkflowExecutionsTestRequest() *workflowservice.ListArchivedWo
 		Query:     "some random query string",
 	}
 }
+
+type testServerTransportStream struct {
+	header metadata.MD
+}
+
+func (s *testServerTransportStream) Method() string {
+	return "/temporal.workflowservice.WorkflowService/RespondDecisionTaskCompleted"
+}
+
+func (s *testServerTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *testServerTransportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *testServerTransportStream) SetTrailer(metadata.MD) error {
+	return nil
+}
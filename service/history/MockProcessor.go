@@ -59,6 +59,11 @@ func (_m *MockProcessor) complete(task *taskInfo) {
 	_m.Called(task)
 }
 
+// recordFailure is mock implementation for recordFailure of Processor
+func (_m *MockProcessor) recordFailure(task *taskInfo, err error) {
+	_m.Called(task, err)
+}
+
 // getTaskFilter is mock implementation for process of Processor
 func (_m *MockProcessor) getTaskFilter() taskFilter {
 	ret := _m.Called()
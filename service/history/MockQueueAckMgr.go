@@ -26,6 +26,8 @@ package history
 
 import (
 	"github.com/stretchr/testify/mock"
+
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
 )
 
 // MockQueueAckMgr is used as mock implementation for QueueAckMgr
@@ -125,3 +127,28 @@ func (_m *MockQueueAckMgr) updateQueueAckLevel() error {
 	}
 	return r0
 }
+
+// recordQueueTaskFailure is mock implementation for recordQueueTaskFailure of QueueAckMgr
+func (_m *MockQueueAckMgr) recordQueueTaskFailure(task queueTaskInfo, attempt int, err error) {
+	_m.Called(task, attempt, err)
+}
+
+// describeQueue is mock implementation for describeQueue of QueueAckMgr
+func (_m *MockQueueAckMgr) describeQueue() *historygenpb.QueueState {
+	ret := _m.Called()
+
+	var r0 *historygenpb.QueueState
+	if rf, ok := ret.Get(0).(func() *historygenpb.QueueState); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*historygenpb.QueueState)
+		}
+	}
+	return r0
+}
+
+// emitQueueState is mock implementation for emitQueueState of QueueAckMgr
+func (_m *MockQueueAckMgr) emitQueueState() {
+	_m.Called()
+}
@@ -68,6 +68,11 @@ func (_m *MockTimerProcessor) complete(task *taskInfo) {
 	_m.Called(task)
 }
 
+// recordFailure is mock implementation for recordFailure of timerProcessor
+func (_m *MockTimerProcessor) recordFailure(task *taskInfo, err error) {
+	_m.Called(task, err)
+}
+
 // getTaskFilter is mock implementation for process of timerProcessor
 func (_m *MockTimerProcessor) getTaskFilter() taskFilter {
 	ret := _m.Called()
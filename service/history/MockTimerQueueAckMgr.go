@@ -27,6 +27,7 @@ package history
 import (
 	"github.com/stretchr/testify/mock"
 
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 )
 
@@ -134,3 +135,25 @@ func (_m *MockTimerQueueAckMgr) updateAckLevel() error {
 	}
 	return r0
 }
+
+func (_m *MockTimerQueueAckMgr) recordTimerTaskFailure(timerTask *persistenceblobs.TimerTaskInfo, attempt int, err error) {
+	_m.Called(timerTask, attempt, err)
+}
+
+func (_m *MockTimerQueueAckMgr) describeQueue() *historygenpb.QueueState {
+	ret := _m.Called()
+
+	var r0 *historygenpb.QueueState
+	if rf, ok := ret.Get(0).(func() *historygenpb.QueueState); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*historygenpb.QueueState)
+		}
+	}
+	return r0
+}
+
+func (_m *MockTimerQueueAckMgr) emitQueueState() {
+	_m.Called()
+}
@@ -207,6 +207,7 @@ func (r *conflictResolverImpl) getHistory(namespaceID string, execution executio
 		PageSize:      defaultHistoryPageSize,
 		NextPageToken: nextPageToken,
 		ShardID:       convert.IntPtr(r.shard.GetShardID()),
+		NamespaceID:   namespaceID,
 	})
 	if err != nil {
 		return nil, 0, 0, nil, err
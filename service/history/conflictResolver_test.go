@@ -185,6 +185,7 @@ func (s *conflictResolverSuite) TestReset() {
 		PageSize:      defaultHistoryPageSize,
 		NextPageToken: nil,
 		ShardID:       &shardId,
+		NamespaceID:   namespaceID,
 	}).Return(&persistence.ReadHistoryBranchResponse{
 		HistoryEvents:    []*eventpb.HistoryEvent{event1, event2},
 		NextPageToken:    nil,
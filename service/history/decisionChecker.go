@@ -27,6 +27,7 @@ package history
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pborman/uuid"
 	commonpb "go.temporal.io/temporal-proto/common"
@@ -239,6 +240,12 @@ func (v *decisionAttrValidator) validateActivityScheduleAttributes(
 		return serviceerror.NewInvalidArgument("Namespace exceeds length limit.")
 	}
 
+	// the start delay header is validated by frontend
+	startDelay := common.GetActivityStartDelay(attributes.Header)
+	if wfTimeout > 0 && startDelay >= time.Duration(wfTimeout)*time.Second {
+		return serviceerror.NewInvalidArgument("Activity start delay exceeds workflow timeout.")
+	}
+
 	// Only attempt to deduce and fill in unspecified timeouts only when all timeouts are non-negative.
 	if attributes.GetScheduleToCloseTimeoutSeconds() < 0 || attributes.GetScheduleToStartTimeoutSeconds() < 0 ||
 		attributes.GetStartToCloseTimeoutSeconds() < 0 || attributes.GetHeartbeatTimeoutSeconds() < 0 {
@@ -521,6 +528,9 @@ func (v *decisionAttrValidator) validateContinueAsNewWorkflowExecutionAttributes
 	if err != nil {
 		return err
 	}
+	if err := v.validateWorkflowTaskList(taskList); err != nil {
+		return err
+	}
 	attributes.TaskList = taskList
 
 	// Inherit workflow timeout from previous execution if not provided on decision
@@ -596,6 +606,9 @@ func (v *decisionAttrValidator) validateStartChildExecutionAttributes(
 	if err != nil {
 		return err
 	}
+	if err := v.validateWorkflowTaskList(taskList); err != nil {
+		return err
+	}
 	attributes.TaskList = taskList
 
 	// Inherit workflow timeout from parent workflow execution if not provided on decision
@@ -640,6 +653,17 @@ func (v *decisionAttrValidator) validatedTaskList(
 	return taskList, nil
 }
 
+func (v *decisionAttrValidator) validateWorkflowTaskList(
+	taskList *tasklistpb.TaskList,
+) error {
+
+	// only activities can be scheduled on affinity task lists
+	if strings.HasPrefix(taskList.GetName(), common.TaskListAffinityPrefix) {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("workflow task list name cannot start with affinity prefix %v", common.TaskListAffinityPrefix))
+	}
+	return nil
+}
+
 func (v *decisionAttrValidator) validateCrossNamespaceCall(
 	namespaceID string,
 	targetNamespaceID string,
@@ -37,6 +37,7 @@ import (
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/definition"
@@ -506,6 +507,11 @@ func (s *decisionAttrValidatorSuite) TestValidateCrossNamespaceCall_GlobalToGlob
 	s.Nil(err)
 }
 
+func (s *decisionAttrValidatorSuite) TestValidateWorkflowTaskList() {
+	s.NoError(s.validator.validateWorkflowTaskList(&tasklistpb.TaskList{Name: "tl-1"}))
+	s.Error(s.validator.validateWorkflowTaskList(&tasklistpb.TaskList{Name: common.TaskListAffinityPrefix + "host-1"}))
+}
+
 func (s *decisionAttrValidatorSuite) TestValidateTaskListName() {
 	taskList := func(name string) *tasklistpb.TaskList {
 		return &tasklistpb.TaskList{Name: name, Kind: tasklistpb.TaskListKind_Normal}
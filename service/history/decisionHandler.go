@@ -29,6 +29,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/pborman/uuid"
 	eventpb "go.temporal.io/temporal-proto/event"
 	executionpb "go.temporal.io/temporal-proto/execution"
 	querypb "go.temporal.io/temporal-proto/query"
@@ -38,6 +39,7 @@ import (
 
 	eventgenpb "github.com/temporalio/temporal/.gen/proto/event"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
+	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/clock"
@@ -168,6 +170,7 @@ func (handler *decisionHandlerImpl) handleDecisionTaskStarted(
 	requestID := req.GetRequestId()
 
 	var resp *historyservice.RecordDecisionTaskStartedResponse
+	var span *workflowSpan
 	err = handler.historyEngine.updateWorkflowExecutionWithAction(ctx, primitives.UUIDString(namespaceID), execution,
 		func(context workflowExecutionContext, mutableState mutableState) (*updateWorkflowAction, error) {
 			if !mutableState.IsWorkflowExecutionRunning() {
@@ -216,6 +219,7 @@ func (handler *decisionHandlerImpl) handleDecisionTaskStarted(
 				// Unable to add DecisionTaskStarted event to history
 				return nil, serviceerror.NewInternal("Unable to add DecisionTaskStarted event to history.")
 			}
+			span = newDecisionSpan(mutableState, decision, "DecisionTask.ScheduleToStart", decision.ScheduledTimestamp)
 
 			resp, err = handler.createRecordDecisionTaskStartedResponse(primitives.UUIDString(namespaceID), mutableState, decision, req.PollRequest.GetIdentity())
 			if err != nil {
@@ -227,6 +231,7 @@ func (handler *decisionHandlerImpl) handleDecisionTaskStarted(
 	if err != nil {
 		return nil, err
 	}
+	span.recordAt(time.Unix(0, resp.StartedTimestamp))
 	return resp, nil
 }
 
@@ -252,7 +257,8 @@ func (handler *decisionHandlerImpl) handleDecisionTaskFailed(
 		RunId:      primitives.UUIDString(token.GetRunId()),
 	}
 
-	return handler.historyEngine.updateWorkflowExecution(ctx, primitives.UUIDString(namespaceID), workflowExecution, true,
+	var span *workflowSpan
+	err = handler.historyEngine.updateWorkflowExecution(ctx, primitives.UUIDString(namespaceID), workflowExecution, true,
 		func(context workflowExecutionContext, mutableState mutableState) error {
 			if !mutableState.IsWorkflowExecutionRunning() {
 				return ErrWorkflowCompleted
@@ -264,10 +270,16 @@ func (handler *decisionHandlerImpl) handleDecisionTaskFailed(
 				return serviceerror.NewNotFound("Decision task not found.")
 			}
 
+			span = newDecisionSpan(mutableState, decision, "DecisionTask", decision.StartedTimestamp).
+				setTag(outcomeSpanTag, spanOutcomeFailed)
 			_, err := mutableState.AddDecisionTaskFailedEvent(decision.ScheduleID, decision.StartedID, request.GetCause(), request.Details,
 				request.GetIdentity(), "", request.GetBinaryChecksum(), "", "", 0)
 			return err
 		})
+	if err == nil {
+		span.record()
+	}
+	return err
 }
 
 func (handler *decisionHandlerImpl) handleDecisionTaskCompleted(
@@ -337,6 +349,8 @@ Update_History_Loop:
 		}
 
 		startedID := currentDecision.StartedID
+		span := newDecisionSpan(msBuilder, currentDecision, "DecisionTask", currentDecision.StartedTimestamp).
+			setTag(outcomeSpanTag, spanOutcomeCompleted)
 		maxResetPoints := handler.config.MaxAutoResetPoints(namespaceEntry.GetInfo().Name)
 		if msBuilder.GetExecutionInfo().AutoResetPoints != nil && maxResetPoints == len(msBuilder.GetExecutionInfo().AutoResetPoints.Points) {
 			handler.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.AutoResetPointsLimitExceededCounter)
@@ -374,6 +388,8 @@ Update_History_Loop:
 			failDecision                *failDecisionInfo
 			activityNotStartedCancelled bool
 			continueAsNewBuilder        mutableState
+			scheduledActivityEvents     []*eventpb.HistoryEvent
+			eagerActivityEvents         []*eventpb.HistoryEvent
 
 			hasUnhandledEvents bool
 		)
@@ -444,11 +460,17 @@ Update_History_Loop:
 
 			continueAsNewBuilder = decisionTaskHandler.continueAsNewBuilder
 
+			scheduledActivityEvents = decisionTaskHandler.scheduledActivityEvents
+
 			hasUnhandledEvents = decisionTaskHandler.hasUnhandledEventsBeforeDecisions
 		}
 
 		if failDecision != nil {
-			handler.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.FailedDecisionsCounter)
+			handler.metricsClient.Scope(
+				metrics.HistoryRespondDecisionTaskCompletedScope,
+				metrics.NamespaceTag(namespaceEntry.GetInfo().Name),
+				handler.config.TypeTagLimiter.WorkflowTypeTag(namespaceEntry.GetInfo().Name, msBuilder.GetWorkflowType().GetName()),
+			).IncCounter(metrics.FailedDecisionsCounter)
 			handler.logger.Info("Failing the decision.", tag.WorkflowDecisionFailCause(int64(failDecision.cause)),
 				tag.WorkflowID(token.GetWorkflowId()),
 				tag.WorkflowRunIDBytes(token.GetRunId()),
@@ -459,6 +481,25 @@ Update_History_Loop:
 			}
 			hasUnhandledEvents = true
 			continueAsNewBuilder = nil
+			scheduledActivityEvents = nil
+		}
+
+		if continueAsNewBuilder == nil && msBuilder.IsWorkflowExecutionRunning() {
+			maxEagerActivityTasks := common.MinInt(
+				int(req.GetMaxEagerActivityTasks()),
+				handler.config.MaxEagerActivityTasksPerDecision(namespaceEntry.GetInfo().Name),
+			)
+			eagerActivityEvents, err = handler.startEagerActivities(
+				namespaceEntry,
+				msBuilder,
+				scheduledActivityEvents,
+				maxEagerActivityTasks,
+				handler.config.MaxEagerActivityTasksSizePerDecision(namespaceEntry.GetInfo().Name),
+				request.GetIdentity(),
+			)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		createNewDecisionTask := msBuilder.IsWorkflowExecutionRunning() && (hasUnhandledEvents || request.GetForceCreateNewDecisionTask() || activityNotStartedCancelled)
@@ -554,6 +595,13 @@ Update_History_Loop:
 			return nil, updateErr
 		}
 
+		span.record()
+		namespace := namespaceEntry.GetInfo().Name
+		handler.metricsClient.Scope(
+			metrics.HistoryRespondDecisionTaskCompletedScope,
+			metrics.NamespaceTag(namespace),
+			handler.config.TypeTagLimiter.WorkflowTypeTag(namespace, msBuilder.GetWorkflowType().GetName()),
+		).RecordTimer(metrics.DecisionE2ELatency, time.Since(time.Unix(0, currentDecision.StartedTimestamp)))
 		handler.handleBufferedQueries(msBuilder, req.GetCompleteRequest().GetQueryResults(), createNewDecisionTask, namespaceEntry, decisionHeartbeating)
 
 		if decisionHeartbeatTimeout {
@@ -571,6 +619,19 @@ Update_History_Loop:
 			// sticky is always enabled when worker request for new decision task from RespondDecisionTaskCompleted
 			resp.StartedResponse.StickyExecutionEnabled = true
 		}
+		for _, event := range eagerActivityEvents {
+			activityTask, err := handler.createPollForActivityTaskResponse(namespaceEntry, msBuilder, event)
+			if err != nil {
+				return nil, err
+			}
+			resp.ActivityTasks = append(resp.ActivityTasks, activityTask)
+		}
+		if len(resp.ActivityTasks) > 0 {
+			handler.metricsClient.Scope(
+				metrics.HistoryRespondDecisionTaskCompletedScope,
+				metrics.NamespaceTag(namespace),
+			).AddCounter(metrics.EagerActivityTaskCounter, int64(len(resp.ActivityTasks)))
+		}
 
 		return resp, nil
 	}
@@ -578,6 +639,105 @@ Update_History_Loop:
 	return nil, ErrMaxAttemptsExceeded
 }
 
+// startEagerActivities starts the first maxTasks activities scheduled by the decision on the task list of the
+// workflow on behalf of the worker which completed it, so that they are returned to that worker instead of being
+// dispatched through matching. Activities with a start delay or on another task list or namespace, and activities
+// whose tasks do not fit in maxSize bytes, are left to matching
+func (handler *decisionHandlerImpl) startEagerActivities(
+	namespaceEntry *cache.NamespaceCacheEntry,
+	msBuilder mutableState,
+	scheduledEvents []*eventpb.HistoryEvent,
+	maxTasks int,
+	maxSize int,
+	identity string,
+) ([]*eventpb.HistoryEvent, error) {
+
+	executionInfo := msBuilder.GetExecutionInfo()
+	var startedEvents []*eventpb.HistoryEvent
+	size := 0
+	for _, event := range scheduledEvents {
+		if len(startedEvents) >= maxTasks {
+			break
+		}
+		attributes := event.GetActivityTaskScheduledEventAttributes()
+		if attributes.GetTaskList().GetName() != executionInfo.TaskList ||
+			common.GetActivityStartDelay(attributes.GetHeader()) > 0 {
+			continue
+		}
+		ai, ok := msBuilder.GetActivityInfo(event.GetEventId())
+		if !ok || ai.NamespaceID != executionInfo.NamespaceID {
+			continue
+		}
+		// the task is built before the activity is started to check it fits, only the started time differs then
+		activityTask, err := handler.createPollForActivityTaskResponse(namespaceEntry, msBuilder, event)
+		if err != nil {
+			return nil, err
+		}
+		activityTask.StartedTimestamp = handler.timeSource.Now().UnixNano()
+		if size+activityTask.Size() > maxSize {
+			continue
+		}
+		size += activityTask.Size()
+
+		if _, err := msBuilder.AddActivityTaskStartedEvent(ai, ai.ScheduleID, uuid.New(), identity); err != nil {
+			return nil, err
+		}
+		startedEvents = append(startedEvents, event)
+	}
+	return startedEvents, nil
+}
+
+// createPollForActivityTaskResponse creates the activity task of an activity started eagerly, as matching would have
+// returned it to a poller
+func (handler *decisionHandlerImpl) createPollForActivityTaskResponse(
+	namespaceEntry *cache.NamespaceCacheEntry,
+	msBuilder mutableState,
+	scheduledEvent *eventpb.HistoryEvent,
+) (*workflowservice.PollForActivityTaskResponse, error) {
+
+	attributes := scheduledEvent.GetActivityTaskScheduledEventAttributes()
+	ai, ok := msBuilder.GetActivityInfo(scheduledEvent.GetEventId())
+	if !ok {
+		return nil, ErrMissingActivityInfo
+	}
+	executionInfo := msBuilder.GetExecutionInfo()
+
+	taskToken := &tokengenpb.Task{
+		NamespaceId:     primitives.MustParseUUID(executionInfo.NamespaceID),
+		WorkflowId:      executionInfo.WorkflowID,
+		RunId:           primitives.MustParseUUID(executionInfo.RunID),
+		ScheduleId:      ai.ScheduleID,
+		ScheduleAttempt: int64(ai.Attempt),
+		ActivityId:      ai.ActivityID,
+		ActivityType:    attributes.GetActivityType().GetName(),
+	}
+	serializedToken, err := handler.tokenSerializer.Serialize(taskToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflowservice.PollForActivityTaskResponse{
+		TaskToken: serializedToken,
+		WorkflowExecution: &executionpb.WorkflowExecution{
+			WorkflowId: executionInfo.WorkflowID,
+			RunId:      executionInfo.RunID,
+		},
+		ActivityId:                      ai.ActivityID,
+		ActivityType:                    attributes.GetActivityType(),
+		Header:                          attributes.GetHeader(),
+		Input:                           attributes.GetInput(),
+		ScheduledTimestamp:              scheduledEvent.GetTimestamp(),
+		ScheduledTimestampOfThisAttempt: ai.ScheduledTime.UnixNano(),
+		StartedTimestamp:                ai.StartedTime.UnixNano(),
+		ScheduleToCloseTimeoutSeconds:   attributes.GetScheduleToCloseTimeoutSeconds(),
+		StartToCloseTimeoutSeconds:      attributes.GetStartToCloseTimeoutSeconds(),
+		HeartbeatTimeoutSeconds:         attributes.GetHeartbeatTimeoutSeconds(),
+		Attempt:                         ai.Attempt,
+		WorkflowType:                    msBuilder.GetWorkflowType(),
+		WorkflowNamespace:               namespaceEntry.GetInfo().Name,
+	}, nil
+}
+
 func (handler *decisionHandlerImpl) createRecordDecisionTaskStartedResponse(
 	namespaceID string,
 	msBuilder mutableState,
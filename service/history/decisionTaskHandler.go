@@ -56,6 +56,7 @@ type (
 		failDecisionInfo                  *failDecisionInfo
 		activityNotStartedCancelled       bool
 		continueAsNewBuilder              mutableState
+		scheduledActivityEvents           []*eventpb.HistoryEvent
 		stopProcessing                    bool // should stop processing any more decisions
 		mutableState                      mutableState
 
@@ -223,9 +224,10 @@ func (handler *decisionTaskHandlerImpl) handleDecisionScheduleActivity(
 		return err
 	}
 
-	_, _, err = handler.mutableState.AddActivityTaskScheduledEvent(handler.decisionTaskCompletedID, attr)
+	event, _, err := handler.mutableState.AddActivityTaskScheduledEvent(handler.decisionTaskCompletedID, attr)
 	switch err.(type) {
 	case nil:
+		handler.scheduledActivityEvents = append(handler.scheduledActivityEvents, event)
 		return nil
 	case *serviceerror.InvalidArgument:
 		return handler.handlerFailDecision(
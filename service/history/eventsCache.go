@@ -182,6 +182,7 @@ func (e *eventsCacheImpl) getHistoryEventFromStore(namespaceID, workflowID, runI
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       e.shardID,
+		NamespaceID:   namespaceID,
 	})
 
 	if err != nil {
@@ -147,6 +147,7 @@ func (s *eventsCacheSuite) TestEventsCacheMissMultiEventsBatchV2Success() {
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       &shardId,
+		NamespaceID:   namespaceID,
 	}).Return(&persistence.ReadHistoryBranchResponse{
 		HistoryEvents:    []*eventpb.HistoryEvent{event1, event2, event3, event4, event5, event6},
 		NextPageToken:    nil,
@@ -174,6 +175,7 @@ func (s *eventsCacheSuite) TestEventsCacheMissV2Failure() {
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       &shardId,
+		NamespaceID:   namespaceID,
 	}).Return(nil, expectedErr)
 
 	actualEvent, err := s.cache.getEvent(namespaceID, workflowID, runID, int64(11), int64(14),
@@ -205,6 +207,7 @@ func (s *eventsCacheSuite) TestEventsCacheDisableSuccess() {
 		PageSize:      1,
 		NextPageToken: nil,
 		ShardID:       &shardId,
+		NamespaceID:   namespaceID,
 	}).Return(&persistence.ReadHistoryBranchResponse{
 		HistoryEvents:    []*eventpb.HistoryEvent{event2},
 		NextPageToken:    nil,
@@ -43,6 +43,8 @@ import (
 	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/definition"
+	"github.com/temporalio/temporal/common/health"
+	"github.com/temporalio/temporal/common/lifecycle"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/messaging"
@@ -50,6 +52,7 @@ import (
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/persistence/serialization"
 	"github.com/temporalio/temporal/common/primitives"
+	"github.com/temporalio/temporal/common/profiling"
 	"github.com/temporalio/temporal/common/quotas"
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/task"
@@ -68,9 +71,11 @@ type (
 		config                  *Config
 		historyEventNotifier    historyEventNotifier
 		publisher               messaging.Producer
+		lifecyclePublisher      lifecycle.Publisher
 		rateLimiter             quotas.Limiter
 		replicationTaskFetchers ReplicationTaskFetchers
 		queueTaskProcessor      queueTaskProcessor
+		healthChecker           *health.Checker
 	}
 )
 
@@ -97,11 +102,13 @@ var (
 func NewHandler(
 	resource resource.Resource,
 	config *Config,
+	lifecyclePublisher lifecycle.Publisher,
 ) *Handler {
 	handler := &Handler{
-		Resource:        resource,
-		config:          config,
-		tokenSerializer: common.NewProtoTaskTokenSerializer(),
+		Resource:           resource,
+		config:             config,
+		lifecyclePublisher: lifecyclePublisher,
+		tokenSerializer:    common.NewProtoTaskTokenSerializer(),
 		rateLimiter: quotas.NewDynamicRateLimiter(
 			func() float64 {
 				return float64(config.RPS())
@@ -187,6 +194,9 @@ func (h *Handler) Start() {
 	h.historyEventNotifier.Start()
 	h.controller.Start()
 
+	h.healthChecker = resource.NewPersistenceHealthChecker(h.Resource)
+	h.healthChecker.Register("shards", h.controller.checkShardAcquisition)
+
 	h.startWG.Done()
 }
 
@@ -222,6 +232,7 @@ func (h *Handler) CreateEngine(
 		h.GetSDKClient(),
 		h.historyEventNotifier,
 		h.publisher,
+		h.lifecyclePublisher,
 		h.config,
 		h.replicationTaskFetchers,
 		h.GetMatchingRawClient(),
@@ -230,16 +241,16 @@ func (h *Handler) CreateEngine(
 }
 
 // https://github.com/grpc/grpc/blob/master/doc/health-checking.md
-func (h *Handler) Check(context.Context, *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+func (h *Handler) Check(ctx context.Context, request *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
 	h.startWG.Wait()
 	h.GetLogger().Debug("History service health check endpoint (gRPC) reached.")
-	hs := &healthpb.HealthCheckResponse{
-		Status: healthpb.HealthCheckResponse_SERVING,
-	}
-	return hs, nil
+	return h.healthChecker.Check(ctx, request)
 }
-func (h *Handler) Watch(*healthpb.HealthCheckRequest, healthpb.Health_WatchServer) error {
-	return serviceerror.NewUnimplemented("Watch is not implemented.")
+
+// Watch is for health check watch
+func (h *Handler) Watch(request *healthpb.HealthCheckRequest, server healthpb.Health_WatchServer) error {
+	h.startWG.Wait()
+	return h.healthChecker.Watch(request, server)
 }
 
 // RecordActivityTaskHeartbeat - Record Activity Task Heart beat.
@@ -686,6 +697,65 @@ func (h *Handler) DescribeHistoryHost(_ context.Context, _ *historyservice.Descr
 	return resp, nil
 }
 
+// CaptureProfile captures a profile of the process of the history host
+func (h *Handler) CaptureProfile(ctx context.Context, request *historyservice.CaptureProfileRequest) (_ *historyservice.CaptureProfileResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	duration := time.Duration(request.GetDurationInSeconds()) * time.Second
+	if err := profiling.Validate(request.GetProfileType(), duration); err != nil {
+		return nil, serviceerror.NewInvalidArgument(err.Error())
+	}
+	profile, err := profiling.Capture(ctx, request.GetProfileType(), duration)
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return &historyservice.CaptureProfileResponse{
+		HostAddress: h.GetHostInfo().GetAddress(),
+		Profile:     profile,
+	}, nil
+}
+
+// GetHostDiagnostics returns the recent errors and the dynamic config of the process of the history host
+func (h *Handler) GetHostDiagnostics(_ context.Context, _ *historyservice.GetHostDiagnosticsRequest) (_ *historyservice.GetHostDiagnosticsResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	dynamicConfig, err := resource.SnapshotDynamicConfig(h)
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return &historyservice.GetHostDiagnosticsResponse{
+		HostAddress:   h.GetHostInfo().GetAddress(),
+		RecentErrors:  resource.DescribeRecentErrors(h),
+		DynamicConfig: dynamicConfig,
+	}, nil
+}
+
+// DescribeLogLevels returns the log levels of the process of the history host
+func (h *Handler) DescribeLogLevels(_ context.Context, _ *historyservice.DescribeLogLevelsRequest) (_ *historyservice.DescribeLogLevelsResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	logLevels, err := resource.DescribeLogLevels(h)
+	if err != nil {
+		return nil, err
+	}
+	return &historyservice.DescribeLogLevelsResponse{
+		LogLevels: logLevels,
+	}, nil
+}
+
+// UpdateLogLevel changes the log level of a package in the process of the history host until it restarts
+func (h *Handler) UpdateLogLevel(_ context.Context, request *historyservice.UpdateLogLevelRequest) (_ *historyservice.UpdateLogLevelResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	logLevels, err := resource.UpdateLogLevel(h, request.GetPackagePath(), request.GetLevel())
+	if err != nil {
+		return nil, err
+	}
+	return &historyservice.UpdateLogLevelResponse{
+		LogLevels: logLevels,
+	}, nil
+}
+
 // RemoveTask returns information about the internal states of a history host
 func (h *Handler) RemoveTask(_ context.Context, request *historyservice.RemoveTaskRequest) (_ *historyservice.RemoveTaskResponse, retError error) {
 	executionMgr, err := h.GetExecutionManager(int(request.GetShardId()))
@@ -1666,6 +1736,38 @@ func (h *Handler) ReadDLQMessages(ctx context.Context, request *historyservice.R
 	return resp, nil
 }
 
+// DescribeShardQueues reports the ack, read and max read levels together with the failing tasks of
+// every task queue owned by the requested shard
+func (h *Handler) DescribeShardQueues(ctx context.Context, request *historyservice.DescribeShardQueuesRequest) (_ *historyservice.DescribeShardQueuesResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	h.startWG.Wait()
+
+	scope := metrics.HistoryDescribeShardQueuesScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	engine, err := h.controller.getEngineForShard(int(request.GetShardId()))
+	if err != nil {
+		err = h.error(err, scope, "", "")
+		return nil, err
+	}
+
+	resp, err := engine.DescribeShardQueues(ctx, request)
+	if err != nil {
+		err = h.error(err, scope, "", "")
+		return nil, err
+	}
+	resp.HostAddress = h.GetHostInfo().GetAddress()
+
+	return resp, nil
+}
+
 func (h *Handler) PurgeDLQMessages(ctx context.Context, request *historyservice.PurgeDLQMessagesRequest) (_ *historyservice.PurgeDLQMessagesResponse, retError error) {
 	defer log.CapturePanic(h.GetLogger(), &retError)
 
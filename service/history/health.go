@@ -0,0 +1,68 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// checkShardAcquisition reports how many of the shards owned by this host according to the
+// membership ring are acquired, the host is not ready until all of them are
+func (c *shardController) checkShardAcquisition(context.Context) (string, error) {
+	if c.isShuttingDown() {
+		return "", errors.New("shard controller is shutting down")
+	}
+
+	self := c.GetHostInfo().Identity()
+	owned := 0
+	for shardID := 0; shardID < c.config.NumberOfShards; shardID++ {
+		info, err := c.GetHistoryServiceResolver().Lookup(string(rune(shardID)))
+		if err != nil {
+			return "", err
+		}
+		if info.Identity() == self {
+			owned++
+		}
+	}
+
+	acquired := 0
+	c.RLock()
+	for _, item := range c.historyShards {
+		item.RLock()
+		if item.status == historyShardsItemStatusStarted {
+			acquired++
+		}
+		item.RUnlock()
+	}
+	c.RUnlock()
+
+	detail := fmt.Sprintf("%v of %v owned shards acquired", acquired, owned)
+	if acquired < owned {
+		return detail, fmt.Errorf("%v owned shards are not acquired", owned-acquired)
+	}
+	return detail, nil
+}
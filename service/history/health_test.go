@@ -0,0 +1,131 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/resource"
+)
+
+type (
+	shardAcquisitionHealthSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller          *gomock.Controller
+		mockResource        *resource.Test
+		mockServiceResolver *membership.MockServiceResolver
+
+		shardController *shardController
+	}
+)
+
+func TestShardAcquisitionHealthSuite(t *testing.T) {
+	s := new(shardAcquisitionHealthSuite)
+	suite.Run(t, s)
+}
+
+func (s *shardAcquisitionHealthSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+	s.mockResource = resource.NewTest(s.controller, metrics.History)
+	s.mockServiceResolver = s.mockResource.HistoryServiceResolver
+
+	config := NewDynamicConfigForTest()
+	config.NumberOfShards = 3
+	s.shardController = newShardController(s.mockResource, &MockHistoryEngineFactory{}, config)
+}
+
+func (s *shardAcquisitionHealthSuite) TearDownTest() {
+	s.controller.Finish()
+	s.mockResource.Finish(s.T())
+}
+
+func (s *shardAcquisitionHealthSuite) TestAllOwnedShardsAcquired() {
+	s.expectOwnedShards(0, 1)
+	s.setShardStatus(0, historyShardsItemStatusStarted)
+	s.setShardStatus(1, historyShardsItemStatusStarted)
+
+	detail, err := s.shardController.checkShardAcquisition(context.Background())
+	s.NoError(err)
+	s.Equal("2 of 2 owned shards acquired", detail)
+}
+
+func (s *shardAcquisitionHealthSuite) TestOwnedShardNotAcquired() {
+	s.expectOwnedShards(0, 1)
+	s.setShardStatus(0, historyShardsItemStatusStarted)
+	s.setShardStatus(1, historyShardsItemStatusInitialized)
+
+	detail, err := s.shardController.checkShardAcquisition(context.Background())
+	s.Error(err)
+	s.Equal("1 of 2 owned shards acquired", detail)
+}
+
+func (s *shardAcquisitionHealthSuite) TestLookupFailure() {
+	s.mockServiceResolver.EXPECT().Lookup(string(rune(0))).Return(nil, errors.New("ring is not ready"))
+
+	_, err := s.shardController.checkShardAcquisition(context.Background())
+	s.Error(err)
+}
+
+func (s *shardAcquisitionHealthSuite) TestShuttingDown() {
+	s.shardController.PrepareToStop()
+
+	_, err := s.shardController.checkShardAcquisition(context.Background())
+	s.Error(err)
+}
+
+// expectOwnedShards makes the membership ring assign the given shards to this host and the others to another host
+func (s *shardAcquisitionHealthSuite) expectOwnedShards(shardIDs ...int) {
+	owned := make(map[int]bool)
+	for _, shardID := range shardIDs {
+		owned[shardID] = true
+	}
+	otherHost := membership.NewHostInfo("other-host:7234", nil)
+	for shardID := 0; shardID < s.shardController.config.NumberOfShards; shardID++ {
+		host := otherHost
+		if owned[shardID] {
+			host = s.mockResource.GetHostInfo()
+		}
+		s.mockServiceResolver.EXPECT().Lookup(string(rune(shardID))).Return(host, nil)
+	}
+}
+
+func (s *shardAcquisitionHealthSuite) setShardStatus(shardID int, status historyShardsItemStatus) {
+	s.shardController.historyShards[shardID] = &historyShardsItem{
+		shardID: shardID,
+		status:  status,
+	}
+}
@@ -46,9 +46,11 @@ import (
 	sdkclient "go.temporal.io/temporal/client"
 
 	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
+	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/client/matching"
 	"github.com/temporalio/temporal/common"
@@ -58,6 +60,7 @@ import (
 	"github.com/temporalio/temporal/common/convert"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/headers"
+	"github.com/temporalio/temporal/common/lifecycle"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/messaging"
@@ -117,6 +120,7 @@ type (
 		ReadDLQMessages(ctx context.Context, messagesRequest *historyservice.ReadDLQMessagesRequest) (*historyservice.ReadDLQMessagesResponse, error)
 		PurgeDLQMessages(ctx context.Context, messagesRequest *historyservice.PurgeDLQMessagesRequest) error
 		MergeDLQMessages(ctx context.Context, messagesRequest *historyservice.MergeDLQMessagesRequest) (*historyservice.MergeDLQMessagesResponse, error)
+		DescribeShardQueues(ctx context.Context, request *historyservice.DescribeShardQueuesRequest) (*historyservice.DescribeShardQueuesResponse, error)
 		RefreshWorkflowTasks(ctx context.Context, namespaceUUID string, execution executionpb.WorkflowExecution) error
 
 		NotifyNewHistoryEvent(event *historyEventNotification)
@@ -158,6 +162,7 @@ type (
 		rawMatchingClient         matching.Client
 		versionChecker            headers.VersionChecker
 		replicationDLQHandler     replicationDLQHandler
+		lifecyclePublisher        lifecycle.Publisher
 	}
 )
 
@@ -220,6 +225,7 @@ func NewEngineWithShardContext(
 	publicClient sdkclient.Client,
 	historyEventNotifier historyEventNotifier,
 	publisher messaging.Producer,
+	lifecyclePublisher lifecycle.Publisher,
 	config *Config,
 	replicationTaskFetchers ReplicationTaskFetchers,
 	rawMatchingClient matching.Client,
@@ -258,6 +264,7 @@ func NewEngineWithShardContext(
 		matchingClient:     matching,
 		rawMatchingClient:  rawMatchingClient,
 		queueTaskProcessor: queueTaskProcessor,
+		lifecyclePublisher: lifecyclePublisher,
 		versionChecker:     headers.NewVersionChecker(),
 	}
 
@@ -558,6 +565,7 @@ func (e *historyEngineImpl) StartWorkflowExecution(
 		return nil, err
 	}
 	e.overrideStartWorkflowExecutionRequest(namespaceEntry, request, metrics.HistoryStartWorkflowExecutionScope)
+	request.Header = injectWorkflowTrace(ctx, request.Header)
 
 	workflowID := request.GetWorkflowId()
 	// grab the current context as a lock, nothing more
@@ -1322,6 +1330,7 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(
 			}
 			p.ActivityType = scheduledEvent.GetActivityTaskScheduledEventAttributes().ActivityType
 			if p.State == executionpb.PendingActivityState_Scheduled {
+				// scheduled time is in the future for activities with a start delay
 				p.ScheduledTimestamp = ai.ScheduledTime.UnixNano()
 			} else {
 				p.LastStartedTimestamp = ai.StartedTime.UnixNano()
@@ -1381,6 +1390,8 @@ func (e *historyEngineImpl) RecordActivityTaskStarted(
 	}
 
 	response := &historyservice.RecordActivityTaskStartedResponse{}
+	var span *workflowSpan
+	var startedTime time.Time
 	err = e.updateWorkflowExecution(ctx, namespaceID, execution, false,
 		func(context workflowExecutionContext, mutableState mutableState) error {
 			if !mutableState.IsWorkflowExecutionRunning() {
@@ -1438,6 +1449,11 @@ func (e *historyEngineImpl) RecordActivityTaskStarted(
 			response.Attempt = int64(ai.Attempt)
 			response.HeartbeatDetails = ai.Details
 
+			span = newWorkflowSpan(mutableState, "ActivityTask.ScheduleToStart", ai.ScheduledTime).
+				setTag(activityIDSpanTag, ai.ActivityID).
+				setTag(attemptSpanTag, ai.Attempt)
+			startedTime = ai.StartedTime
+
 			response.WorkflowType = mutableState.GetWorkflowType()
 			response.WorkflowNamespace = namespace
 
@@ -1447,6 +1463,7 @@ func (e *historyEngineImpl) RecordActivityTaskStarted(
 	if err != nil {
 		return nil, err
 	}
+	span.recordAt(startedTime)
 
 	return response, err
 }
@@ -1509,6 +1526,7 @@ func (e *historyEngineImpl) RespondActivityTaskCompleted(
 
 	var activityStartedTime time.Time
 	var taskList string
+	var span *workflowSpan
 	err = e.updateWorkflowExecution(ctx, namespaceID, workflowExecution, true,
 		func(context workflowExecutionContext, mutableState mutableState) error {
 			if !mutableState.IsWorkflowExecutionRunning() {
@@ -1536,6 +1554,7 @@ func (e *historyEngineImpl) RespondActivityTaskCompleted(
 				return ErrActivityTaskNotFound
 			}
 
+			span = newActivityAttemptSpan(mutableState, ai, spanOutcomeCompleted)
 			if _, err := mutableState.AddActivityTaskCompletedEvent(scheduleID, ai.StartedID, request); err != nil {
 				// Unable to add ActivityTaskCompleted event to history
 				return serviceerror.NewInternal("Unable to add ActivityTaskCompleted event to history.")
@@ -1544,12 +1563,16 @@ func (e *historyEngineImpl) RespondActivityTaskCompleted(
 			taskList = ai.TaskList
 			return nil
 		})
+	if err == nil {
+		span.record()
+	}
 	if err == nil && !activityStartedTime.IsZero() {
+		workflowTypeTag, activityTypeTag := e.activityTypeTags(namespace, token)
 		scope := e.metricsClient.Scope(metrics.HistoryRespondActivityTaskCompletedScope).
 			Tagged(
 				metrics.NamespaceTag(namespace),
-				metrics.WorkflowTypeTag(token.WorkflowType),
-				metrics.ActivityTypeTag(token.ActivityType),
+				workflowTypeTag,
+				activityTypeTag,
 				metrics.TaskListTag(taskList),
 			)
 		scope.RecordTimer(metrics.ActivityE2ELatency, time.Since(activityStartedTime))
@@ -1583,6 +1606,7 @@ func (e *historyEngineImpl) RespondActivityTaskFailed(
 
 	var activityStartedTime time.Time
 	var taskList string
+	var span *workflowSpan
 	err = e.updateWorkflowExecutionWithAction(ctx, namespaceID, workflowExecution,
 		func(context workflowExecutionContext, mutableState mutableState) (*updateWorkflowAction, error) {
 			if !mutableState.IsWorkflowExecutionRunning() {
@@ -1611,6 +1635,7 @@ func (e *historyEngineImpl) RespondActivityTaskFailed(
 			}
 
 			postActions := &updateWorkflowAction{}
+			span = newActivityAttemptSpan(mutableState, ai, spanOutcomeFailed)
 			ok, err := mutableState.RetryActivity(ai, req.FailedRequest.GetReason(), req.FailedRequest.GetDetails())
 			if err != nil {
 				return nil, err
@@ -1628,12 +1653,16 @@ func (e *historyEngineImpl) RespondActivityTaskFailed(
 			taskList = ai.TaskList
 			return postActions, nil
 		})
+	if err == nil {
+		span.record()
+	}
 	if err == nil && !activityStartedTime.IsZero() {
+		workflowTypeTag, activityTypeTag := e.activityTypeTags(namespace, token)
 		scope := e.metricsClient.Scope(metrics.HistoryRespondActivityTaskFailedScope).
 			Tagged(
 				metrics.NamespaceTag(namespace),
-				metrics.WorkflowTypeTag(token.WorkflowType),
-				metrics.ActivityTypeTag(token.ActivityType),
+				workflowTypeTag,
+				activityTypeTag,
 				metrics.TaskListTag(taskList),
 			)
 		scope.RecordTimer(metrics.ActivityE2ELatency, time.Since(activityStartedTime))
@@ -1667,6 +1696,7 @@ func (e *historyEngineImpl) RespondActivityTaskCanceled(
 
 	var activityStartedTime time.Time
 	var taskList string
+	var span *workflowSpan
 	err = e.updateWorkflowExecution(ctx, namespaceID, workflowExecution, true,
 		func(context workflowExecutionContext, mutableState mutableState) error {
 			if !mutableState.IsWorkflowExecutionRunning() {
@@ -1694,6 +1724,7 @@ func (e *historyEngineImpl) RespondActivityTaskCanceled(
 				return ErrActivityTaskNotFound
 			}
 
+			span = newActivityAttemptSpan(mutableState, ai, spanOutcomeCanceled)
 			if _, err := mutableState.AddActivityTaskCanceledEvent(
 				scheduleID,
 				ai.StartedID,
@@ -1708,12 +1739,16 @@ func (e *historyEngineImpl) RespondActivityTaskCanceled(
 			taskList = ai.TaskList
 			return nil
 		})
+	if err == nil {
+		span.record()
+	}
 	if err == nil && !activityStartedTime.IsZero() {
+		workflowTypeTag, activityTypeTag := e.activityTypeTags(namespace, token)
 		scope := e.metricsClient.Scope(metrics.HistoryClientRespondActivityTaskCanceledScope).
 			Tagged(
 				metrics.NamespaceTag(namespace),
-				metrics.WorkflowTypeTag(token.WorkflowType),
-				metrics.ActivityTypeTag(token.ActivityType),
+				workflowTypeTag,
+				activityTypeTag,
 				metrics.TaskListTag(taskList),
 			)
 		scope.RecordTimer(metrics.ActivityE2ELatency, time.Since(activityStartedTime))
@@ -1721,6 +1756,20 @@ func (e *historyEngineImpl) RespondActivityTaskCanceled(
 	return err
 }
 
+// activityTypeTags returns the workflow and activity type tags of the metrics of an activity task,
+// bounded by the type tag limiter when it is enabled for the namespace
+func (e *historyEngineImpl) activityTypeTags(
+	namespace string,
+	token *tokengenpb.Task,
+) (metrics.Tag, metrics.Tag) {
+
+	if !e.config.TypeTagLimiter.Enabled(namespace) {
+		return metrics.WorkflowTypeTag(token.WorkflowType), metrics.ActivityTypeTag(token.ActivityType)
+	}
+	return e.config.TypeTagLimiter.WorkflowTypeTag(namespace, token.WorkflowType),
+		e.config.TypeTagLimiter.ActivityTypeTag(namespace, token.ActivityType)
+}
+
 // RecordActivityTaskHeartbeat records an hearbeat for a task.
 // This method can be used for two purposes.
 // - For reporting liveness of the activity.
@@ -2019,6 +2068,7 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 		return nil, err
 	}
 	e.overrideStartWorkflowExecutionRequest(namespaceEntry, request, metrics.HistorySignalWorkflowExecutionScope)
+	request.Header = injectWorkflowTrace(ctx, request.Header)
 
 	workflowID := request.GetWorkflowId()
 	// grab the current context as a lock, nothing more
@@ -2340,7 +2390,7 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 	}
 
 	// also load the current run of the workflow, it can be different from the base runID
-	resp, err := e.executionManager.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+	resp, err := persistence.ExecutionManagerWithContext(ctx, e.executionManager).GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
 		NamespaceID: namespaceID,
 		WorkflowID:  request.WorkflowExecution.GetWorkflowId(),
 	})
@@ -3115,6 +3165,30 @@ func (e *historyEngineImpl) MergeDLQMessages(
 	}, nil
 }
 
+func (e *historyEngineImpl) DescribeShardQueues(
+	ctx context.Context,
+	request *historyservice.DescribeShardQueuesRequest,
+) (*historyservice.DescribeShardQueuesResponse, error) {
+
+	var queues []*historygenpb.QueueState
+	queues = append(queues, e.txProcessor.DescribeQueues()...)
+	queues = append(queues, e.timerProcessor.DescribeQueues()...)
+	if e.replicatorProcessor != nil {
+		queues = append(queues, e.replicatorProcessor.describeQueue())
+	}
+
+	for _, queue := range queues {
+		if len(queue.FailingTasks) > maxDescribedFailingTasks {
+			queue.FailingTasks = queue.FailingTasks[:maxDescribedFailingTasks]
+		}
+	}
+
+	return &historyservice.DescribeShardQueuesResponse{
+		ShardId: request.GetShardId(),
+		Queues:  queues,
+	}, nil
+}
+
 func (e *historyEngineImpl) RefreshWorkflowTasks(
 	ctx context.Context,
 	namespaceUUID string,
@@ -3214,7 +3288,7 @@ func (e *historyEngineImpl) loadWorkflow(
 		}
 
 		// workflow not running, need to check current record
-		resp, err := e.shard.GetExecutionManager().GetCurrentExecution(
+		resp, err := persistence.ExecutionManagerWithContext(ctx, e.shard.GetExecutionManager()).GetCurrentExecution(
 			&persistence.GetCurrentExecutionRequest{
 				NamespaceID: namespaceID,
 				WorkflowID:  workflowID,
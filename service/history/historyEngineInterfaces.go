@@ -29,6 +29,7 @@ import (
 
 	"github.com/gogo/protobuf/types"
 
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	"github.com/temporalio/temporal/common"
@@ -60,6 +61,7 @@ type (
 			ctx context.Context,
 			taskInfo *replicationgenpb.ReplicationTaskInfo,
 		) (*replicationgenpb.ReplicationTask, error)
+		describeQueue() *historygenpb.QueueState
 	}
 
 	queueAckMgr interface {
@@ -69,6 +71,9 @@ type (
 		getQueueAckLevel() int64
 		getQueueReadLevel() int64
 		updateQueueAckLevel() error
+		recordQueueTaskFailure(task queueTaskInfo, attempt int, err error)
+		describeQueue() *historygenpb.QueueState
+		emitQueueState()
 	}
 
 	queueTaskInfo interface {
@@ -104,6 +109,7 @@ type (
 	taskExecutor interface {
 		process(taskInfo *taskInfo) (int, error)
 		complete(taskInfo *taskInfo)
+		recordFailure(taskInfo *taskInfo, err error)
 		getTaskFilter() taskFilter
 	}
 
@@ -126,6 +132,9 @@ type (
 		getAckLevel() timerKey
 		getReadLevel() timerKey
 		updateAckLevel() error
+		recordTimerTaskFailure(timerTask *persistenceblobs.TimerTaskInfo, attempt int, err error)
+		describeQueue() *historygenpb.QueueState
+		emitQueueState()
 	}
 
 	historyEventNotifier interface {
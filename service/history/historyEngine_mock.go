@@ -580,6 +580,21 @@ func (mr *MockEngineMockRecorder) MergeDLQMessages(ctx, messagesRequest interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeDLQMessages", reflect.TypeOf((*MockEngine)(nil).MergeDLQMessages), ctx, messagesRequest)
 }
 
+// DescribeShardQueues mocks base method.
+func (m *MockEngine) DescribeShardQueues(ctx context.Context, request *historyservice.DescribeShardQueuesRequest) (*historyservice.DescribeShardQueuesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeShardQueues", ctx, request)
+	ret0, _ := ret[0].(*historyservice.DescribeShardQueuesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeShardQueues indicates an expected call of DescribeShardQueues.
+func (mr *MockEngineMockRecorder) DescribeShardQueues(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeShardQueues", reflect.TypeOf((*MockEngine)(nil).DescribeShardQueues), ctx, request)
+}
+
 // RefreshWorkflowTasks mocks base method.
 func (m *MockEngine) RefreshWorkflowTasks(ctx context.Context, namespaceUUID string, execution execution.WorkflowExecution) error {
 	m.ctrl.T.Helper()
@@ -1755,6 +1755,162 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedSingleActivityScheduledDec
 	s.Equal(int32(5), activity1Attributes.HeartbeatTimeoutSeconds)
 }
 
+func (s *engineSuite) TestRespondDecisionTaskCompletedEagerActivityTasks() {
+
+	we := executionpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tl := "testTaskList"
+	tt := &tokengenpb.Task{
+		WorkflowId: "wId",
+		RunId:      primitives.MustParseUUID(we.GetRunId()),
+		ScheduleId: 2,
+	}
+	taskToken, _ := tt.Marshal()
+	identity := "testIdentity"
+	input := payload.EncodeString("input")
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, payload.EncodeString("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+
+	scheduleActivity := func(activityID string, taskList string) *decisionpb.Decision {
+		return &decisionpb.Decision{
+			DecisionType: decisionpb.DecisionType_ScheduleActivityTask,
+			Attributes: &decisionpb.Decision_ScheduleActivityTaskDecisionAttributes{ScheduleActivityTaskDecisionAttributes: &decisionpb.ScheduleActivityTaskDecisionAttributes{
+				ActivityId:                    activityID,
+				ActivityType:                  &commonpb.ActivityType{Name: "activity_type1"},
+				TaskList:                      &tasklistpb.TaskList{Name: taskList},
+				Input:                         input,
+				ScheduleToCloseTimeoutSeconds: 100,
+				ScheduleToStartTimeoutSeconds: 10,
+				StartToCloseTimeoutSeconds:    50,
+				HeartbeatTimeoutSeconds:       5,
+			}},
+		}
+	}
+	// only the first activity on the task list of the workflow is started eagerly
+	decisions := []*decisionpb.Decision{
+		scheduleActivity("activity1", "otherTaskList"),
+		scheduleActivity("activity2", tl),
+		scheduleActivity("activity3", tl),
+	}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	resp, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &historyservice.RespondDecisionTaskCompletedRequest{
+		NamespaceId: testNamespaceID,
+		CompleteRequest: &workflowservice.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  identity,
+		},
+		MaxEagerActivityTasks: 2,
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	s.Len(resp.GetActivityTasks(), 1)
+	activityTask := resp.GetActivityTasks()[0]
+	s.Equal("activity2", activityTask.GetActivityId())
+	s.Equal(input, activityTask.GetInput())
+	s.Equal(we.GetWorkflowId(), activityTask.GetWorkflowExecution().GetWorkflowId())
+	s.Equal("wType", activityTask.GetWorkflowType().GetName())
+
+	executionBuilder := s.getBuilder(testNamespaceID, we)
+	// scheduled events of the three activities, followed by the started event of the eager one
+	s.Equal(int64(9), executionBuilder.GetExecutionInfo().NextEventID)
+	activityTaskToken, err := s.mockHistoryEngine.tokenSerializer.Deserialize(activityTask.GetTaskToken())
+	s.NoError(err)
+	s.Equal(int64(6), activityTaskToken.GetScheduleId())
+	ai, ok := executionBuilder.GetActivityInfo(6)
+	s.True(ok)
+	s.Equal(int64(8), ai.StartedID)
+	ai, ok = executionBuilder.GetActivityInfo(5)
+	s.True(ok)
+	s.Equal(common.EmptyEventID, ai.StartedID)
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedEagerActivityTasksSizeLimit() {
+
+	we := executionpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tl := "testTaskList"
+	tt := &tokengenpb.Task{
+		WorkflowId: "wId",
+		RunId:      primitives.MustParseUUID(we.GetRunId()),
+		ScheduleId: 2,
+	}
+	taskToken, _ := tt.Marshal()
+	identity := "testIdentity"
+	s.mockHistoryEngine.config.MaxEagerActivityTasksPerDecision = dynamicconfig.GetIntPropertyFilteredByNamespace(2)
+	s.mockHistoryEngine.config.MaxEagerActivityTasksSizePerDecision = dynamicconfig.GetIntPropertyFilteredByNamespace(1024)
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, payload.EncodeString("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+
+	scheduleActivity := func(activityID string, input *commonpb.Payload) *decisionpb.Decision {
+		return &decisionpb.Decision{
+			DecisionType: decisionpb.DecisionType_ScheduleActivityTask,
+			Attributes: &decisionpb.Decision_ScheduleActivityTaskDecisionAttributes{ScheduleActivityTaskDecisionAttributes: &decisionpb.ScheduleActivityTaskDecisionAttributes{
+				ActivityId:                    activityID,
+				ActivityType:                  &commonpb.ActivityType{Name: "activity_type1"},
+				TaskList:                      &tasklistpb.TaskList{Name: tl},
+				Input:                         input,
+				ScheduleToCloseTimeoutSeconds: 100,
+				ScheduleToStartTimeoutSeconds: 10,
+				StartToCloseTimeoutSeconds:    50,
+				HeartbeatTimeoutSeconds:       5,
+			}},
+		}
+	}
+	// the task of the first activity does not fit in the size limit, it is dispatched through matching
+	decisions := []*decisionpb.Decision{
+		scheduleActivity("activity1", payload.EncodeBytes(make([]byte, 2048))),
+		scheduleActivity("activity2", payload.EncodeString("input")),
+	}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	resp, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &historyservice.RespondDecisionTaskCompletedRequest{
+		NamespaceId: testNamespaceID,
+		CompleteRequest: &workflowservice.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  identity,
+		},
+		MaxEagerActivityTasks: 2,
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	s.Len(resp.GetActivityTasks(), 1)
+	s.Equal("activity2", resp.GetActivityTasks()[0].GetActivityId())
+	s.True(resp.GetActivityTasks()[0].Size() <= 1024)
+
+	executionBuilder := s.getBuilder(testNamespaceID, we)
+	ai, ok := executionBuilder.GetActivityInfo(5)
+	s.True(ok)
+	s.Equal(common.EmptyEventID, ai.StartedID)
+	ai, ok = executionBuilder.GetActivityInfo(6)
+	s.True(ok)
+	s.Equal(int64(8), ai.StartedID)
+}
+
 func (s *engineSuite) TestRespondDecisionTaskCompleted_DecisionHeartbeatTimeout() {
 
 	we := executionpb.WorkflowExecution{
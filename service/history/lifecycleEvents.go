@@ -0,0 +1,108 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"fmt"
+	"time"
+
+	eventpb "go.temporal.io/temporal-proto/event"
+	executionpb "go.temporal.io/temporal-proto/execution"
+
+	"github.com/temporalio/temporal/common/lifecycle"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+var (
+	lifecycleEventTypes = map[eventpb.EventType]string{
+		eventpb.EventType_WorkflowExecutionStarted:        lifecycle.EventTypeWorkflowStarted,
+		eventpb.EventType_WorkflowExecutionCompleted:      lifecycle.EventTypeWorkflowClosed,
+		eventpb.EventType_WorkflowExecutionCanceled:       lifecycle.EventTypeWorkflowClosed,
+		eventpb.EventType_WorkflowExecutionTerminated:     lifecycle.EventTypeWorkflowClosed,
+		eventpb.EventType_WorkflowExecutionContinuedAsNew: lifecycle.EventTypeWorkflowClosed,
+		eventpb.EventType_WorkflowExecutionFailed:         lifecycle.EventTypeWorkflowFailed,
+		eventpb.EventType_WorkflowExecutionTimedOut:       lifecycle.EventTypeWorkflowTimedOut,
+		eventpb.EventType_ActivityTaskFailed:              lifecycle.EventTypeActivityFailed,
+		eventpb.EventType_WorkflowExecutionSignaled:       lifecycle.EventTypeSignalReceived,
+	}
+
+	closeEventStatuses = map[eventpb.EventType]executionpb.WorkflowExecutionStatus{
+		eventpb.EventType_WorkflowExecutionCompleted:      executionpb.WorkflowExecutionStatus_Completed,
+		eventpb.EventType_WorkflowExecutionCanceled:       executionpb.WorkflowExecutionStatus_Canceled,
+		eventpb.EventType_WorkflowExecutionTerminated:     executionpb.WorkflowExecutionStatus_Terminated,
+		eventpb.EventType_WorkflowExecutionContinuedAsNew: executionpb.WorkflowExecutionStatus_ContinuedAsNew,
+		eventpb.EventType_WorkflowExecutionFailed:         executionpb.WorkflowExecutionStatus_Failed,
+		eventpb.EventType_WorkflowExecutionTimedOut:       executionpb.WorkflowExecutionStatus_TimedOut,
+	}
+)
+
+// getLifecycleEventType returns the lifecycle event type published for the history event type
+func getLifecycleEventType(
+	eventType eventpb.EventType,
+) (string, bool) {
+
+	lifecycleEventType, ok := lifecycleEventTypes[eventType]
+	return lifecycleEventType, ok
+}
+
+func newLifecycleEvent(
+	namespace string,
+	executionInfo *persistence.WorkflowExecutionInfo,
+	event *eventpb.HistoryEvent,
+) *lifecycle.Event {
+
+	lifecycleEventType, _ := getLifecycleEventType(event.GetEventType())
+	lifecycleEvent := &lifecycle.Event{
+		ID:           fmt.Sprintf("%v/%v", executionInfo.RunID, event.GetEventId()),
+		Type:         lifecycleEventType,
+		Namespace:    namespace,
+		NamespaceID:  executionInfo.NamespaceID,
+		WorkflowID:   executionInfo.WorkflowID,
+		RunID:        executionInfo.RunID,
+		WorkflowType: executionInfo.WorkflowTypeName,
+		EventID:      event.GetEventId(),
+		Timestamp:    time.Unix(0, event.GetTimestamp()).UTC(),
+	}
+	if status, ok := closeEventStatuses[event.GetEventType()]; ok {
+		lifecycleEvent.Status = status.String()
+	}
+
+	switch event.GetEventType() {
+	case eventpb.EventType_WorkflowExecutionFailed:
+		lifecycleEvent.Reason = event.GetWorkflowExecutionFailedEventAttributes().GetReason()
+	case eventpb.EventType_WorkflowExecutionTimedOut:
+		lifecycleEvent.Reason = event.GetWorkflowExecutionTimedOutEventAttributes().GetTimeoutType().String()
+	case eventpb.EventType_ActivityTaskFailed:
+		attributes := event.GetActivityTaskFailedEventAttributes()
+		lifecycleEvent.Reason = attributes.GetReason()
+		lifecycleEvent.ActivityScheduledEventID = attributes.GetScheduledEventId()
+		lifecycleEvent.Identity = attributes.GetIdentity()
+	case eventpb.EventType_WorkflowExecutionSignaled:
+		attributes := event.GetWorkflowExecutionSignaledEventAttributes()
+		lifecycleEvent.SignalName = attributes.GetSignalName()
+		lifecycleEvent.Identity = attributes.GetIdentity()
+	}
+	return lifecycleEvent
+}
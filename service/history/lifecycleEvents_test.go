@@ -0,0 +1,149 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	eventpb "go.temporal.io/temporal-proto/event"
+
+	"github.com/temporalio/temporal/common/lifecycle"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	lifecycleEventsSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		executionInfo *persistence.WorkflowExecutionInfo
+	}
+)
+
+func TestLifecycleEventsSuite(t *testing.T) {
+	s := new(lifecycleEventsSuite)
+	suite.Run(t, s)
+}
+
+func (s *lifecycleEventsSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.executionInfo = &persistence.WorkflowExecutionInfo{
+		NamespaceID:      testNamespaceID,
+		WorkflowID:       "some random workflow ID",
+		RunID:            testRunID,
+		WorkflowTypeName: "some random workflow type",
+	}
+}
+
+func (s *lifecycleEventsSuite) TestGetLifecycleEventType() {
+	eventType, ok := getLifecycleEventType(eventpb.EventType_WorkflowExecutionStarted)
+	s.True(ok)
+	s.Equal(lifecycle.EventTypeWorkflowStarted, eventType)
+
+	eventType, ok = getLifecycleEventType(eventpb.EventType_WorkflowExecutionContinuedAsNew)
+	s.True(ok)
+	s.Equal(lifecycle.EventTypeWorkflowClosed, eventType)
+
+	_, ok = getLifecycleEventType(eventpb.EventType_DecisionTaskScheduled)
+	s.False(ok)
+}
+
+func (s *lifecycleEventsSuite) TestNewLifecycleEvent_WorkflowFailed() {
+	now := time.Now()
+	event := &eventpb.HistoryEvent{
+		EventId:   12,
+		Timestamp: now.UnixNano(),
+		EventType: eventpb.EventType_WorkflowExecutionFailed,
+		Attributes: &eventpb.HistoryEvent_WorkflowExecutionFailedEventAttributes{WorkflowExecutionFailedEventAttributes: &eventpb.WorkflowExecutionFailedEventAttributes{
+			Reason: "some random reason",
+		}},
+	}
+
+	lifecycleEvent := newLifecycleEvent(testNamespace, s.executionInfo, event)
+	s.Equal(testRunID+"/12", lifecycleEvent.ID)
+	s.Equal(lifecycle.EventTypeWorkflowFailed, lifecycleEvent.Type)
+	s.Equal(testNamespace, lifecycleEvent.Namespace)
+	s.Equal(testNamespaceID, lifecycleEvent.NamespaceID)
+	s.Equal(s.executionInfo.WorkflowID, lifecycleEvent.WorkflowID)
+	s.Equal(s.executionInfo.WorkflowTypeName, lifecycleEvent.WorkflowType)
+	s.Equal(int64(12), lifecycleEvent.EventID)
+	s.True(now.Equal(lifecycleEvent.Timestamp))
+	s.Equal("Failed", lifecycleEvent.Status)
+	s.Equal("some random reason", lifecycleEvent.Reason)
+}
+
+func (s *lifecycleEventsSuite) TestNewLifecycleEvent_WorkflowTimedOut() {
+	event := &eventpb.HistoryEvent{
+		EventId:   5,
+		EventType: eventpb.EventType_WorkflowExecutionTimedOut,
+		Attributes: &eventpb.HistoryEvent_WorkflowExecutionTimedOutEventAttributes{WorkflowExecutionTimedOutEventAttributes: &eventpb.WorkflowExecutionTimedOutEventAttributes{
+			TimeoutType: eventpb.TimeoutType_StartToClose,
+		}},
+	}
+
+	lifecycleEvent := newLifecycleEvent(testNamespace, s.executionInfo, event)
+	s.Equal(lifecycle.EventTypeWorkflowTimedOut, lifecycleEvent.Type)
+	s.Equal("TimedOut", lifecycleEvent.Status)
+	s.Equal(eventpb.TimeoutType_StartToClose.String(), lifecycleEvent.Reason)
+}
+
+func (s *lifecycleEventsSuite) TestNewLifecycleEvent_ActivityFailed() {
+	event := &eventpb.HistoryEvent{
+		EventId:   9,
+		EventType: eventpb.EventType_ActivityTaskFailed,
+		Attributes: &eventpb.HistoryEvent_ActivityTaskFailedEventAttributes{ActivityTaskFailedEventAttributes: &eventpb.ActivityTaskFailedEventAttributes{
+			Reason:           "some random reason",
+			ScheduledEventId: 7,
+			Identity:         "some random identity",
+		}},
+	}
+
+	lifecycleEvent := newLifecycleEvent(testNamespace, s.executionInfo, event)
+	s.Equal(lifecycle.EventTypeActivityFailed, lifecycleEvent.Type)
+	s.Empty(lifecycleEvent.Status)
+	s.Equal("some random reason", lifecycleEvent.Reason)
+	s.Equal(int64(7), lifecycleEvent.ActivityScheduledEventID)
+	s.Equal("some random identity", lifecycleEvent.Identity)
+}
+
+func (s *lifecycleEventsSuite) TestNewLifecycleEvent_SignalReceived() {
+	event := &eventpb.HistoryEvent{
+		EventId:   4,
+		EventType: eventpb.EventType_WorkflowExecutionSignaled,
+		Attributes: &eventpb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &eventpb.WorkflowExecutionSignaledEventAttributes{
+			SignalName: "some random signal",
+			Identity:   "some random identity",
+		}},
+	}
+
+	lifecycleEvent := newLifecycleEvent(testNamespace, s.executionInfo, event)
+	s.Equal(lifecycle.EventTypeSignalReceived, lifecycleEvent.Type)
+	s.Equal("some random signal", lifecycleEvent.SignalName)
+	s.Equal("some random identity", lifecycleEvent.Identity)
+}
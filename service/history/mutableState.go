@@ -29,6 +29,7 @@ package history
 import (
 	"time"
 
+	"github.com/opentracing/opentracing-go"
 	commonpb "go.temporal.io/temporal-proto/common"
 	decisionpb "go.temporal.io/temporal-proto/decision"
 	eventpb "go.temporal.io/temporal-proto/event"
@@ -131,6 +132,7 @@ type (
 		GetDecisionInfo(int64) (*decisionInfo, bool)
 		GetNamespaceEntry() *cache.NamespaceCacheEntry
 		GetStartEvent() (*eventpb.HistoryEvent, error)
+		GetSpanContext() opentracing.SpanContext
 		GetCurrentBranchToken() ([]byte, error)
 		GetVersionHistories() *persistence.VersionHistories
 		GetCurrentVersion() int64
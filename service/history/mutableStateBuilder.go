@@ -30,6 +30,7 @@ import (
 	"time"
 
 	"github.com/gogo/protobuf/types"
+	"github.com/opentracing/opentracing-go"
 	"github.com/pborman/uuid"
 	commonpb "go.temporal.io/temporal-proto/common"
 	decisionpb "go.temporal.io/temporal-proto/decision"
@@ -50,6 +51,7 @@ import (
 	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/definition"
+	"github.com/temporalio/temporal/common/lifecycle"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
@@ -153,6 +155,10 @@ type (
 		// wrong. This exist primarily for visibility via CLI
 		checksum checksum.Checksum
 
+		// the span context of the workflow is read from its start event once per mutable state
+		spanContext       opentracing.SpanContext
+		spanContextLoaded bool
+
 		taskGenerator       mutableStateTaskGenerator
 		decisionTaskManager mutableStateDecisionTaskManager
 		queryRegistry       queryRegistry
@@ -1176,6 +1182,26 @@ func (e *mutableStateBuilder) GetStartEvent() (*eventpb.HistoryEvent, error) {
 	return startEvent, nil
 }
 
+// GetSpanContext returns the span context carried in the header of the workflow start event,
+// nil if tracing is disabled or the workflow carries none
+func (e *mutableStateBuilder) GetSpanContext() opentracing.SpanContext {
+	if !opentracing.IsGlobalTracerRegistered() {
+		return nil
+	}
+	if e.spanContextLoaded {
+		return e.spanContext
+	}
+
+	startEvent, err := e.GetStartEvent()
+	if err != nil {
+		return nil
+	}
+	header := startEvent.GetWorkflowExecutionStartedEventAttributes().GetHeader()
+	e.spanContext = extractSpanContext(opentracing.GlobalTracer(), header)
+	e.spanContextLoaded = true
+	return e.spanContext
+}
+
 // DeletePendingChildExecution deletes details about a ChildExecutionInfo.
 func (e *mutableStateBuilder) DeletePendingChildExecution(
 	initiatedEventID int64,
@@ -1651,7 +1677,7 @@ func (e *mutableStateBuilder) addWorkflowExecutionStartedEventForContinueAsNew(
 		TaskStartToCloseTimeoutSeconds:      decisionTimeout,
 		ExecutionStartToCloseTimeoutSeconds: attributes.ExecutionStartToCloseTimeoutSeconds,
 		Input:                               attributes.Input,
-		Header:                              attributes.Header,
+		Header:                              continueWorkflowTrace(previousExecutionState, attributes.Header),
 		RetryPolicy:                         attributes.RetryPolicy,
 		CronSchedule:                        attributes.CronSchedule,
 		Memo:                                attributes.Memo,
@@ -2148,6 +2174,10 @@ func (e *mutableStateBuilder) ReplicateActivityTaskScheduledEvent(
 		targetNamespaceID = primitives.UUIDString(targetNamespaceEntry.GetInfo().Id)
 	}
 
+	// activity with a start delay is scheduled once the delay expires,
+	// so all activity timeouts are counted from that point
+	startDelay := common.GetActivityStartDelay(attributes.GetHeader())
+
 	scheduleEventID := event.GetEventId()
 	scheduleToCloseTimeout := attributes.GetScheduleToCloseTimeoutSeconds()
 
@@ -2155,7 +2185,7 @@ func (e *mutableStateBuilder) ReplicateActivityTaskScheduledEvent(
 		Version:                  event.GetVersion(),
 		ScheduleID:               scheduleEventID,
 		ScheduledEventBatchID:    firstEventID,
-		ScheduledTime:            time.Unix(0, event.GetTimestamp()),
+		ScheduledTime:            time.Unix(0, event.GetTimestamp()).Add(startDelay),
 		StartedID:                common.EmptyEventID,
 		StartedTime:              time.Time{},
 		ActivityID:               attributes.ActivityId,
@@ -3062,16 +3092,21 @@ func (e *mutableStateBuilder) ReplicateTimerStartedEvent(
 	// TODO: Time skew need to be taken in to account.
 	expiryTime, err := types.TimestampProto(time.Unix(0, event.GetTimestamp()).Add(fireTimeout)) // should use the event time, not now
 
+	if err != nil {
+		return nil, err
+	}
+	startedTime, err := types.TimestampProto(time.Unix(0, event.GetTimestamp()))
 	if err != nil {
 		return nil, err
 	}
 
 	ti := &persistenceblobs.TimerInfo{
-		Version:    event.GetVersion(),
-		TimerId:    timerID,
-		ExpiryTime: expiryTime,
-		StartedId:  event.GetEventId(),
-		TaskStatus: timerTaskStatusNone,
+		Version:     event.GetVersion(),
+		TimerId:     timerID,
+		ExpiryTime:  expiryTime,
+		StartedTime: startedTime,
+		StartedId:   event.GetEventId(),
+		TaskStatus:  timerTaskStatusNone,
 	}
 
 	e.pendingTimerInfoIDs[timerID] = ti
@@ -3902,6 +3937,14 @@ func (e *mutableStateBuilder) CloseTransactionAsMutation(
 		return nil, nil, err
 	}
 
+	if err := e.closeTransactionHandleLifecycleEvents(
+		now,
+		transactionPolicy,
+		workflowEventsSeq,
+	); err != nil {
+		return nil, nil, err
+	}
+
 	if len(workflowEventsSeq) > 0 {
 		lastEvents := workflowEventsSeq[len(workflowEventsSeq)-1].Events
 		firstEvent := lastEvents[0]
@@ -3987,6 +4030,14 @@ func (e *mutableStateBuilder) CloseTransactionAsSnapshot(
 		return nil, nil, serviceerror.NewInternal("cannot generate workflow snapshot with buffered events")
 	}
 
+	if err := e.closeTransactionHandleLifecycleEvents(
+		now,
+		transactionPolicy,
+		workflowEventsSeq,
+	); err != nil {
+		return nil, nil, err
+	}
+
 	if len(workflowEventsSeq) > 0 {
 		lastEvents := workflowEventsSeq[len(workflowEventsSeq)-1].Events
 		firstEvent := lastEvents[0]
@@ -4552,6 +4603,44 @@ func (e *mutableStateBuilder) closeTransactionHandleActivityUserTimerTasks(
 	)
 }
 
+// closeTransactionHandleLifecycleEvents schedules the publishing of the lifecycle events
+// of the namespace among the events written by the transaction
+func (e *mutableStateBuilder) closeTransactionHandleLifecycleEvents(
+	now time.Time,
+	transactionPolicy transactionPolicy,
+	workflowEventsSeq []*persistence.WorkflowEvents,
+) error {
+
+	if transactionPolicy == transactionPolicyPassive ||
+		len(workflowEventsSeq) == 0 {
+		return nil
+	}
+
+	namespace := e.GetNamespaceEntry().GetInfo().Name
+	if !e.config.LifecycleEventsEnabled(namespace) {
+		return nil
+	}
+	eventTypes := e.config.LifecycleEventTypes(namespace)
+
+	for _, workflowEvents := range workflowEventsSeq {
+		eventBatchID := workflowEvents.Events[0].GetEventId()
+		for _, event := range workflowEvents.Events {
+			lifecycleEventType, ok := getLifecycleEventType(event.GetEventType())
+			if !ok || !lifecycle.MatchesEventTypes(eventTypes, lifecycleEventType) {
+				continue
+			}
+			if err := e.taskGenerator.generateLifecycleEventTasks(
+				e.unixNanoToTime(now.UnixNano()),
+				event,
+				eventBatchID,
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (e *mutableStateBuilder) checkMutability(
 	actionTag tag.Tag,
 ) error {
@@ -553,6 +553,83 @@ func (s *mutableStateSuite) TestEventReapplied() {
 	s.True(isReapplied)
 }
 
+func (s *mutableStateSuite) TestCloseTransactionHandleLifecycleEvents() {
+	startedEvent := &eventpb.HistoryEvent{
+		EventId:   common.FirstEventID,
+		EventType: eventpb.EventType_WorkflowExecutionStarted,
+	}
+	decisionScheduledEvent := &eventpb.HistoryEvent{
+		EventId:   common.FirstEventID + 1,
+		EventType: eventpb.EventType_DecisionTaskScheduled,
+	}
+	signaledEvent := &eventpb.HistoryEvent{
+		EventId:   common.FirstEventID + 5,
+		EventType: eventpb.EventType_WorkflowExecutionSignaled,
+	}
+	failedEvent := &eventpb.HistoryEvent{
+		EventId:   common.FirstEventID + 6,
+		EventType: eventpb.EventType_WorkflowExecutionFailed,
+	}
+	workflowEventsSeq := []*persistence.WorkflowEvents{
+		{Events: []*eventpb.HistoryEvent{startedEvent, decisionScheduledEvent}},
+		{Events: []*eventpb.HistoryEvent{signaledEvent, failedEvent}},
+	}
+
+	mockTaskGenerator := NewMockmutableStateTaskGenerator(s.controller)
+	s.msBuilder.taskGenerator = mockTaskGenerator
+	now := time.Now()
+
+	// disabled by default
+	err := s.msBuilder.closeTransactionHandleLifecycleEvents(now, transactionPolicyActive, workflowEventsSeq)
+	s.NoError(err)
+
+	s.mockShard.config.LifecycleEventsEnabled = func(namespace string) bool { return true }
+	s.mockShard.config.LifecycleEventTypes = func(namespace string) string { return "WorkflowStarted, SignalReceived" }
+
+	err = s.msBuilder.closeTransactionHandleLifecycleEvents(now, transactionPolicyPassive, workflowEventsSeq)
+	s.NoError(err)
+
+	mockTaskGenerator.EXPECT().generateLifecycleEventTasks(gomock.Any(), startedEvent, startedEvent.GetEventId()).Return(nil).Times(1)
+	mockTaskGenerator.EXPECT().generateLifecycleEventTasks(gomock.Any(), signaledEvent, signaledEvent.GetEventId()).Return(nil).Times(1)
+	err = s.msBuilder.closeTransactionHandleLifecycleEvents(now, transactionPolicyActive, workflowEventsSeq)
+	s.NoError(err)
+}
+
+func (s *mutableStateSuite) TestReplicateActivityTaskScheduledEvent_StartDelay() {
+	s.msBuilder.executionInfo.NamespaceID = testNamespaceID
+	now := time.Now()
+	event := &eventpb.HistoryEvent{
+		EventId:   5,
+		Version:   123,
+		Timestamp: now.UnixNano(),
+		EventType: eventpb.EventType_ActivityTaskScheduled,
+		Attributes: &eventpb.HistoryEvent_ActivityTaskScheduledEventAttributes{ActivityTaskScheduledEventAttributes: &eventpb.ActivityTaskScheduledEventAttributes{
+			ActivityId:                    "some random activity ID",
+			ActivityType:                  &commonpb.ActivityType{Name: "some random activity type"},
+			TaskList:                      &tasklistpb.TaskList{Name: "some random task list"},
+			Header:                        &commonpb.Header{Fields: map[string][]byte{common.ActivityStartDelayHeader: []byte("30")}},
+			ScheduleToCloseTimeoutSeconds: 100,
+			DecisionTaskCompletedEventId:  4,
+		}},
+	}
+
+	// replaying the scheduled event holds the activity for the delay in its header
+	ai, err := s.msBuilder.ReplicateActivityTaskScheduledEvent(4, event)
+	s.NoError(err)
+	s.True(ai.ScheduledTime.Equal(now.Add(30 * time.Second)))
+	s.True(ai.ExpirationTime.Equal(ai.ScheduledTime.Add(100 * time.Second)))
+
+	err = s.msBuilder.taskGenerator.generateActivityTransferTasks(time.Unix(0, event.GetTimestamp()), event)
+	s.NoError(err)
+	s.Empty(s.msBuilder.insertTransferTasks)
+	s.Equal([]persistence.Task{&persistence.ActivityRetryTimerTask{
+		Version:             event.GetVersion(),
+		VisibilityTimestamp: ai.ScheduledTime,
+		EventID:             event.GetEventId(),
+		Attempt:             ai.Attempt,
+	}}, s.msBuilder.insertTimerTasks)
+}
+
 func (s *mutableStateSuite) prepareTransientDecisionCompletionFirstBatchReplicated(version int64, runID string) (*eventpb.HistoryEvent, *eventpb.HistoryEvent) {
 	namespaceID := testNamespaceID
 	execution := executionpb.WorkflowExecution{
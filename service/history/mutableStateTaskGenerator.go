@@ -91,6 +91,11 @@ type (
 		generateWorkflowResetTasks(
 			now time.Time,
 		) error
+		generateLifecycleEventTasks(
+			now time.Time,
+			event *eventpb.HistoryEvent,
+			eventBatchID int64,
+		) error
 
 		// these 2 APIs should only be called when mutable state transaction is being closed
 		generateActivityTimerTasks(
@@ -334,6 +339,11 @@ func (r *mutableStateTaskGeneratorImpl) generateActivityTransferTasks(
 		}
 	}
 
+	if activityInfo.ScheduledTime.After(now) {
+		// activity is not due yet, the retry timer task dispatches it to matching
+		return r.generateActivityRetryTasks(activityScheduleID)
+	}
+
 	r.mutableState.AddTransferTasks(&persistence.ActivityTask{
 		// TaskID is set by shard
 		VisibilityTimestamp: now,
@@ -500,6 +510,23 @@ func (r *mutableStateTaskGeneratorImpl) generateWorkflowResetTasks(
 	return nil
 }
 
+func (r *mutableStateTaskGeneratorImpl) generateLifecycleEventTasks(
+	now time.Time,
+	event *eventpb.HistoryEvent,
+	eventBatchID int64,
+) error {
+
+	r.mutableState.AddTransferTasks(&persistence.LifecycleEventTask{
+		// TaskID is set by shard
+		VisibilityTimestamp: now,
+		EventID:             event.GetEventId(),
+		EventBatchID:        eventBatchID,
+		Version:             event.GetVersion(),
+	})
+
+	return nil
+}
+
 func (r *mutableStateTaskGeneratorImpl) generateActivityTimerTasks(
 	now time.Time,
 ) error {
@@ -241,6 +241,20 @@ func (mr *MockmutableStateTaskGeneratorMockRecorder) generateWorkflowResetTasks(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "generateWorkflowResetTasks", reflect.TypeOf((*MockmutableStateTaskGenerator)(nil).generateWorkflowResetTasks), now)
 }
 
+// generateLifecycleEventTasks mocks base method.
+func (m *MockmutableStateTaskGenerator) generateLifecycleEventTasks(now time.Time, event *event.HistoryEvent, eventBatchID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "generateLifecycleEventTasks", now, event, eventBatchID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// generateLifecycleEventTasks indicates an expected call of generateLifecycleEventTasks.
+func (mr *MockmutableStateTaskGeneratorMockRecorder) generateLifecycleEventTasks(now, event, eventBatchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "generateLifecycleEventTasks", reflect.TypeOf((*MockmutableStateTaskGenerator)(nil).generateLifecycleEventTasks), now, event, eventBatchID)
+}
+
 // generateActivityTimerTasks mocks base method.
 func (m *MockmutableStateTaskGenerator) generateActivityTimerTasks(now time.Time) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,125 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	eventpb "go.temporal.io/temporal-proto/event"
+
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	mutableStateTaskGeneratorSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller         *gomock.Controller
+		mockNamespaceCache *cache.MockNamespaceCache
+		mockMutableState   *MockmutableState
+
+		taskGenerator *mutableStateTaskGeneratorImpl
+	}
+)
+
+func TestMutableStateTaskGeneratorSuite(t *testing.T) {
+	s := new(mutableStateTaskGeneratorSuite)
+	suite.Run(t, s)
+}
+
+func (s *mutableStateTaskGeneratorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+	s.mockNamespaceCache = cache.NewMockNamespaceCache(s.controller)
+	s.mockMutableState = NewMockmutableState(s.controller)
+
+	s.taskGenerator = newMutableStateTaskGenerator(s.mockNamespaceCache, log.NewNoop(), s.mockMutableState)
+}
+
+func (s *mutableStateTaskGeneratorSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *mutableStateTaskGeneratorSuite) TestGenerateActivityTransferTasks_Scheduled() {
+	now := time.Now()
+	event, activityInfo := s.newActivityScheduledEvent(now)
+	s.mockMutableState.EXPECT().GetActivityInfo(activityInfo.ScheduleID).Return(activityInfo, true).AnyTimes()
+	s.mockMutableState.EXPECT().AddTransferTasks(&persistence.ActivityTask{
+		VisibilityTimestamp: now,
+		NamespaceID:         activityInfo.NamespaceID,
+		TaskList:            activityInfo.TaskList,
+		ScheduleID:          activityInfo.ScheduleID,
+		Version:             activityInfo.Version,
+	}).Times(1)
+
+	err := s.taskGenerator.generateActivityTransferTasks(now, event)
+	s.NoError(err)
+}
+
+func (s *mutableStateTaskGeneratorSuite) TestGenerateActivityTransferTasks_Delayed() {
+	now := time.Now()
+	event, activityInfo := s.newActivityScheduledEvent(now.Add(30 * time.Second))
+	s.mockMutableState.EXPECT().GetActivityInfo(activityInfo.ScheduleID).Return(activityInfo, true).AnyTimes()
+	// the delayed activity is dispatched to matching by the retry timer instead of a transfer task
+	s.mockMutableState.EXPECT().AddTimerTasks(&persistence.ActivityRetryTimerTask{
+		Version:             activityInfo.Version,
+		VisibilityTimestamp: activityInfo.ScheduledTime,
+		EventID:             activityInfo.ScheduleID,
+		Attempt:             activityInfo.Attempt,
+	}).Times(1)
+
+	err := s.taskGenerator.generateActivityTransferTasks(now, event)
+	s.NoError(err)
+}
+
+func (s *mutableStateTaskGeneratorSuite) newActivityScheduledEvent(
+	scheduledTime time.Time,
+) (*eventpb.HistoryEvent, *persistence.ActivityInfo) {
+
+	event := &eventpb.HistoryEvent{
+		EventId:   5,
+		Version:   123,
+		EventType: eventpb.EventType_ActivityTaskScheduled,
+		Attributes: &eventpb.HistoryEvent_ActivityTaskScheduledEventAttributes{
+			ActivityTaskScheduledEventAttributes: &eventpb.ActivityTaskScheduledEventAttributes{},
+		},
+	}
+	activityInfo := &persistence.ActivityInfo{
+		Version:       event.GetVersion(),
+		ScheduleID:    event.GetEventId(),
+		ScheduledTime: scheduledTime,
+		NamespaceID:   testNamespaceID,
+		TaskList:      "some random task list",
+	}
+	return event, activityInfo
+}
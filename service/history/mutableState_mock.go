@@ -30,6 +30,7 @@ package history
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	opentracing "github.com/opentracing/opentracing-go"
 	historyservice "github.com/temporalio/temporal/.gen/proto/historyservice"
 	persistenceblobs "github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	cache "github.com/temporalio/temporal/common/cache"
@@ -1043,6 +1044,20 @@ func (mr *MockmutableStateMockRecorder) GetStartEvent() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStartEvent", reflect.TypeOf((*MockmutableState)(nil).GetStartEvent))
 }
 
+// GetSpanContext mocks base method.
+func (m *MockmutableState) GetSpanContext() opentracing.SpanContext {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpanContext")
+	ret0, _ := ret[0].(opentracing.SpanContext)
+	return ret0
+}
+
+// GetSpanContext indicates an expected call of GetSpanContext.
+func (mr *MockmutableStateMockRecorder) GetSpanContext() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpanContext", reflect.TypeOf((*MockmutableState)(nil).GetSpanContext))
+}
+
 // GetCurrentBranchToken mocks base method.
 func (m *MockmutableState) GetCurrentBranchToken() ([]byte, error) {
 	m.ctrl.T.Helper()
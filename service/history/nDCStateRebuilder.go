@@ -236,6 +236,7 @@ func (r *nDCStateRebuilderImpl) getPaginationFn(
 		_, historyBatches, token, size, err := PaginateHistory(
 			r.historyV2Mgr,
 			true,
+			workflowIdentifier.NamespaceID,
 			branchToken,
 			firstEventID,
 			nextEventID,
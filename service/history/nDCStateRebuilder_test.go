@@ -204,6 +204,7 @@ func (s *nDCStateRebuilderSuite) TestPagination() {
 		PageSize:      nDCDefaultPageSize,
 		NextPageToken: nil,
 		ShardID:       &shardId,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       history1,
 		NextPageToken: pageToken,
@@ -216,6 +217,7 @@ func (s *nDCStateRebuilderSuite) TestPagination() {
 		PageSize:      nDCDefaultPageSize,
 		NextPageToken: pageToken,
 		ShardID:       &shardId,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       history2,
 		NextPageToken: nil,
@@ -287,6 +289,7 @@ func (s *nDCStateRebuilderSuite) TestRebuild() {
 		PageSize:      nDCDefaultPageSize,
 		NextPageToken: nil,
 		ShardID:       &shardId,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       history1,
 		NextPageToken: pageToken,
@@ -299,6 +302,7 @@ func (s *nDCStateRebuilderSuite) TestRebuild() {
 		PageSize:      nDCDefaultPageSize,
 		NextPageToken: pageToken,
 		ShardID:       &shardId,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       history2,
 		NextPageToken: nil,
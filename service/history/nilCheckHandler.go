@@ -277,6 +277,38 @@ func (h *NilCheckHandler) DescribeHistoryHost(ctx context.Context, request *hist
 	return resp, err
 }
 
+func (h *NilCheckHandler) CaptureProfile(ctx context.Context, request *historyservice.CaptureProfileRequest) (_ *historyservice.CaptureProfileResponse, retError error) {
+	resp, err := h.parentHandler.CaptureProfile(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.CaptureProfileResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) GetHostDiagnostics(ctx context.Context, request *historyservice.GetHostDiagnosticsRequest) (_ *historyservice.GetHostDiagnosticsResponse, retError error) {
+	resp, err := h.parentHandler.GetHostDiagnostics(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.GetHostDiagnosticsResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) DescribeLogLevels(ctx context.Context, request *historyservice.DescribeLogLevelsRequest) (_ *historyservice.DescribeLogLevelsResponse, retError error) {
+	resp, err := h.parentHandler.DescribeLogLevels(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.DescribeLogLevelsResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) UpdateLogLevel(ctx context.Context, request *historyservice.UpdateLogLevelRequest) (_ *historyservice.UpdateLogLevelResponse, retError error) {
+	resp, err := h.parentHandler.UpdateLogLevel(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.UpdateLogLevelResponse{}
+	}
+	return resp, err
+}
+
 func (h *NilCheckHandler) CloseShard(ctx context.Context, request *historyservice.CloseShardRequest) (_ *historyservice.CloseShardResponse, retError error) {
 	resp, err := h.parentHandler.CloseShard(ctx, request)
 	if resp == nil && err == nil {
@@ -333,6 +365,14 @@ func (h *NilCheckHandler) ReadDLQMessages(ctx context.Context, request *historys
 	return resp, err
 }
 
+func (h *NilCheckHandler) DescribeShardQueues(ctx context.Context, request *historyservice.DescribeShardQueuesRequest) (*historyservice.DescribeShardQueuesResponse, error) {
+	resp, err := h.parentHandler.DescribeShardQueues(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.DescribeShardQueuesResponse{}
+	}
+	return resp, err
+}
+
 func (h *NilCheckHandler) PurgeDLQMessages(ctx context.Context, request *historyservice.PurgeDLQMessagesRequest) (*historyservice.PurgeDLQMessagesResponse, error) {
 	resp, err := h.parentHandler.PurgeDLQMessages(ctx, request)
 	if resp == nil && err == nil {
@@ -29,6 +29,7 @@ import (
 	"sync"
 	"time"
 
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/log"
@@ -46,15 +47,19 @@ type (
 		shard         ShardContext
 		options       *QueueProcessorOptions
 		processor     processor
+		clusterName   string
 		logger        log.Logger
 		metricsClient metrics.Client
 		finishedChan  chan struct{}
+		taskFailures  *queueTaskFailures
 
 		sync.RWMutex
 		outstandingTasks map[int64]bool
-		readLevel        int64
-		ackLevel         int64
-		isReadFinished   bool
+		// visibility time of outstanding tasks, or the time they were read without visibility time
+		outstandingTaskTimes map[int64]time.Time
+		readLevel            int64
+		ackLevel             int64
+		isReadFinished       bool
 	}
 )
 
@@ -62,35 +67,41 @@ const (
 	warnPendingTasks = 2000
 )
 
-func newQueueAckMgr(shard ShardContext, options *QueueProcessorOptions, processor processor, ackLevel int64, logger log.Logger) *queueAckMgrImpl {
+func newQueueAckMgr(shard ShardContext, options *QueueProcessorOptions, processor processor, ackLevel int64, clusterName string, logger log.Logger) *queueAckMgrImpl {
 
 	return &queueAckMgrImpl{
-		isFailover:       false,
-		shard:            shard,
-		options:          options,
-		processor:        processor,
-		outstandingTasks: make(map[int64]bool),
-		readLevel:        ackLevel,
-		ackLevel:         ackLevel,
-		logger:           logger,
-		metricsClient:    shard.GetMetricsClient(),
-		finishedChan:     nil,
+		isFailover:           false,
+		shard:                shard,
+		options:              options,
+		processor:            processor,
+		clusterName:          clusterName,
+		outstandingTasks:     make(map[int64]bool),
+		outstandingTaskTimes: make(map[int64]time.Time),
+		readLevel:            ackLevel,
+		ackLevel:             ackLevel,
+		logger:               logger,
+		metricsClient:        shard.GetMetricsClient(),
+		finishedChan:         nil,
+		taskFailures:         newQueueTaskFailures(),
 	}
 }
 
-func newQueueFailoverAckMgr(shard ShardContext, options *QueueProcessorOptions, processor processor, ackLevel int64, logger log.Logger) *queueAckMgrImpl {
+func newQueueFailoverAckMgr(shard ShardContext, options *QueueProcessorOptions, processor processor, ackLevel int64, clusterName string, logger log.Logger) *queueAckMgrImpl {
 
 	return &queueAckMgrImpl{
-		isFailover:       true,
-		shard:            shard,
-		options:          options,
-		processor:        processor,
-		outstandingTasks: make(map[int64]bool),
-		readLevel:        ackLevel,
-		ackLevel:         ackLevel,
-		logger:           logger,
-		metricsClient:    shard.GetMetricsClient(),
-		finishedChan:     make(chan struct{}, 1),
+		isFailover:           true,
+		shard:                shard,
+		options:              options,
+		processor:            processor,
+		clusterName:          clusterName,
+		outstandingTasks:     make(map[int64]bool),
+		outstandingTaskTimes: make(map[int64]time.Time),
+		readLevel:            ackLevel,
+		ackLevel:             ackLevel,
+		logger:               logger,
+		metricsClient:        shard.GetMetricsClient(),
+		finishedChan:         make(chan struct{}, 1),
+		taskFailures:         newQueueTaskFailures(),
 	}
 }
 
@@ -135,6 +146,7 @@ TaskFilterLoop:
 		a.logger.Debug("Moving read level", tag.TaskID(task.GetTaskId()))
 		a.readLevel = task.GetTaskId()
 		a.outstandingTasks[task.GetTaskId()] = false
+		a.outstandingTaskTimes[task.GetTaskId()] = pendingSince(task, a.shard.GetTimeSource().Now())
 	}
 
 	return tasks, morePage, nil
@@ -146,6 +158,55 @@ func (a *queueAckMgrImpl) completeQueueTask(taskID int64) {
 		a.outstandingTasks[taskID] = true
 	}
 	a.Unlock()
+	a.taskFailures.remove(taskID)
+}
+
+func (a *queueAckMgrImpl) recordQueueTaskFailure(task queueTaskInfo, attempt int, err error) {
+	a.taskFailures.record(task, attempt, err, a.shard.GetTimeSource().Now())
+}
+
+func (a *queueAckMgrImpl) describeQueue() *historygenpb.QueueState {
+	a.RLock()
+	queueType := transferQueueName
+	maxReadLevel := a.shard.GetTransferMaxReadLevel()
+	if a.options.MetricScope == metrics.ReplicatorQueueProcessorScope {
+		// replication tasks are not read up to the transfer max read level, the
+		// replication queue is described up to its own read level
+		queueType = replicationQueueName
+		maxReadLevel = a.readLevel
+	}
+	state := &historygenpb.QueueState{
+		QueueType:    queueType,
+		ClusterName:  a.clusterName,
+		AckLevel:     a.ackLevel,
+		ReadLevel:    a.readLevel,
+		MaxReadLevel: maxReadLevel,
+	}
+	var oldestPendingTaskTime time.Time
+	for taskID, acked := range a.outstandingTasks {
+		if acked {
+			continue
+		}
+		state.InFlightTaskCount++
+		if taskTime := a.outstandingTaskTimes[taskID]; oldestPendingTaskTime.IsZero() || taskTime.Before(oldestPendingTaskTime) {
+			oldestPendingTaskTime = taskTime
+		}
+	}
+	a.RUnlock()
+
+	if !oldestPendingTaskTime.IsZero() {
+		state.OldestPendingTaskTimestamp = oldestPendingTaskTime.UnixNano()
+	}
+	state.FailingTasks = a.taskFailures.describe()
+	return state
+}
+
+// emitQueueState emits the state gauges of the queue, failover queues are not emitted
+func (a *queueAckMgrImpl) emitQueueState() {
+	if a.isFailover {
+		return
+	}
+	emitQueueStateGauges(a.metricsClient, a.options.MetricScope, a.shard.GetShardID(), a.describeQueue(), a.shard.GetTimeSource().Now())
 }
 
 func (a *queueAckMgrImpl) getQueueAckLevel() int64 {
@@ -199,6 +260,7 @@ MoveAckLevelLoop:
 		if acked {
 			ackLevel = current
 			delete(a.outstandingTasks, current)
+			delete(a.outstandingTaskTimes, current)
 			a.logger.Debug("Moving timer ack level to", tag.AckLevel(ackLevel))
 		} else {
 			break MoveAckLevelLoop
@@ -220,6 +282,9 @@ MoveAckLevelLoop:
 	}
 
 	a.Unlock()
+	a.taskFailures.removeAcked(func(failingTask *historygenpb.FailingTask) bool {
+		return failingTask.GetTaskId() <= ackLevel
+	})
 	if err := a.processor.updateAckLevel(ackLevel); err != nil {
 		a.metricsClient.IncCounter(a.options.MetricScope, metrics.AckLevelUpdateFailedCounter)
 		a.logger.Error("Error updating ack level for shard", tag.Error(err), tag.OperationFailed)
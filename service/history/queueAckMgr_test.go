@@ -25,6 +25,7 @@
 package history
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -117,7 +118,7 @@ func (s *queueAckMgrSuite) SetupTest() {
 
 	s.queueAckMgr = newQueueAckMgr(s.mockShard, &QueueProcessorOptions{
 		MetricScope: metrics.ReplicatorQueueProcessorScope,
-	}, s.mockProcessor, 0, s.logger)
+	}, s.mockProcessor, 0, cluster.TestCurrentClusterName, s.logger)
 }
 
 func (s *queueAckMgrSuite) TearDownTest() {
@@ -270,6 +271,52 @@ func (s *queueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	s.Equal(taskID3, s.queueAckMgr.getQueueAckLevel())
 }
 
+func (s *queueAckMgrSuite) TestDescribeReplicationQueue() {
+	readLevel := s.queueAckMgr.readLevel
+	taskID1 := int64(59)
+	taskID2 := int64(60)
+	tasksInput := []queueTaskInfo{
+		&p.ReplicationTaskInfoWrapper{ReplicationTaskInfo: &persistenceblobs.ReplicationTaskInfo{
+			NamespaceId: TestNamespaceId,
+			WorkflowId:  "some random workflow ID",
+			RunId:       uuid.NewRandom(),
+			TaskId:      taskID1,
+		}},
+		&p.ReplicationTaskInfoWrapper{ReplicationTaskInfo: &persistenceblobs.ReplicationTaskInfo{
+			NamespaceId: TestNamespaceId,
+			WorkflowId:  "some random workflow ID",
+			RunId:       uuid.NewRandom(),
+			TaskId:      taskID2,
+		}},
+	}
+	s.mockProcessor.On("readTasks", readLevel).Return(tasksInput, false, nil).Once()
+	_, _, err := s.queueAckMgr.readQueueTasks()
+	s.NoError(err)
+
+	s.queueAckMgr.recordQueueTaskFailure(tasksInput[0], 1, errors.New("some error"))
+	s.queueAckMgr.recordQueueTaskFailure(tasksInput[1], 1, errors.New("some error"))
+
+	state := s.queueAckMgr.describeQueue()
+	s.Equal(replicationQueueName, state.GetQueueType())
+	s.Equal(cluster.TestCurrentClusterName, state.GetClusterName())
+	s.Equal(readLevel, state.GetAckLevel())
+	// the replication queue is described up to its read level, not the transfer max read level
+	s.Equal(taskID2, state.GetReadLevel())
+	s.Equal(taskID2, state.GetMaxReadLevel())
+	s.Equal(int64(2), state.GetInFlightTaskCount())
+	s.Len(state.GetFailingTasks(), 2)
+
+	// failures of the tasks the ack level moves past are removed, even if they were not completed
+	s.queueAckMgr.Lock()
+	s.queueAckMgr.outstandingTasks[taskID1] = true
+	s.queueAckMgr.Unlock()
+	s.mockProcessor.On("updateAckLevel", taskID1).Return(nil).Once()
+	s.NoError(s.queueAckMgr.updateQueueAckLevel())
+	failingTasks := s.queueAckMgr.describeQueue().GetFailingTasks()
+	s.Len(failingTasks, 1)
+	s.Equal(taskID2, failingTasks[0].GetTaskId())
+}
+
 // Tests for failover ack manager
 func (s *queueFailoverAckMgrSuite) SetupSuite() {
 
@@ -306,7 +353,7 @@ func (s *queueFailoverAckMgrSuite) SetupTest() {
 
 	s.queueFailoverAckMgr = newQueueFailoverAckMgr(s.mockShard, &QueueProcessorOptions{
 		MetricScope: metrics.ReplicatorQueueProcessorScope,
-	}, s.mockProcessor, 0, s.logger)
+	}, s.mockProcessor, 0, cluster.TestCurrentClusterName, s.logger)
 }
 
 func (s *queueFailoverAckMgrSuite) TearDownTest() {
@@ -367,6 +414,10 @@ func (s *queueFailoverAckMgrSuite) TestReadQueueTasks() {
 	s.True(s.queueFailoverAckMgr.isReadFinished)
 }
 
+func (s *queueFailoverAckMgrSuite) TestDescribeQueue() {
+	s.Equal(cluster.TestCurrentClusterName, s.queueFailoverAckMgr.describeQueue().GetClusterName())
+}
+
 func (s *queueFailoverAckMgrSuite) TestReadCompleteQueueTasks() {
 	readLevel := s.queueFailoverAckMgr.readLevel
 	// when the ack manager is first initialized, read == ack level
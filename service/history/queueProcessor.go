@@ -207,6 +207,9 @@ func (p *queueProcessorBase) processorPump() {
 	))
 	defer redispatchTimer.Stop()
 
+	queueStateTicker := time.NewTicker(queueStateGaugesInterval)
+	defer queueStateTicker.Stop()
+
 processorPumpLoop:
 	for {
 		select {
@@ -249,6 +252,8 @@ processorPumpLoop:
 				p.options.RedispatchIntervalJitterCoefficient(),
 			))
 			p.redispatchTasks()
+		case <-queueStateTicker.C:
+			p.ackMgr.emitQueueState()
 		}
 	}
 
@@ -350,6 +355,14 @@ func (p *queueProcessorBase) complete(
 	p.ackMgr.completeQueueTask(task.GetTaskId())
 }
 
+func (p *queueProcessorBase) recordFailure(
+	task queueTaskInfo,
+	attempt int,
+	err error,
+) {
+	p.ackMgr.recordQueueTaskFailure(task, attempt, err)
+}
+
 func (p *queueProcessorBase) isPriorityTaskProcessorEnabled() bool {
 	return p.taskProcessor == nil
 }
@@ -0,0 +1,202 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/primitives"
+)
+
+type (
+	// queueTaskFailures keeps track of the tasks of a queue failing to be processed,
+	// a task is removed once it is completed
+	queueTaskFailures struct {
+		sync.Mutex
+		tasks map[int64]*historygenpb.FailingTask
+	}
+)
+
+const (
+	transferQueueName    = "transfer"
+	timerQueueName       = "timer"
+	replicationQueueName = "replication"
+
+	// maxDescribedFailingTasks is the maximum number of failing tasks described per queue, the most attempted first
+	maxDescribedFailingTasks = 100
+	// queueStateGaugesInterval is the interval at which the state gauges of a queue are emitted
+	queueStateGaugesInterval = time.Minute
+)
+
+func newQueueTaskFailures() *queueTaskFailures {
+	return &queueTaskFailures{
+		tasks: make(map[int64]*historygenpb.FailingTask),
+	}
+}
+
+func (f *queueTaskFailures) record(
+	task queueTaskInfo,
+	attempt int,
+	err error,
+	now time.Time,
+) {
+	f.Lock()
+	defer f.Unlock()
+
+	failingTask, ok := f.tasks[task.GetTaskId()]
+	if !ok {
+		visibilityTime, _ := types.TimestampFromProto(task.GetVisibilityTimestamp())
+		failingTask = &historygenpb.FailingTask{
+			TaskId:              task.GetTaskId(),
+			TaskType:            task.GetTaskType(),
+			NamespaceId:         primitives.UUIDString(task.GetNamespaceId()),
+			WorkflowId:          task.GetWorkflowId(),
+			RunId:               primitives.UUIDString(task.GetRunId()),
+			VisibilityTimestamp: visibilityTime.UnixNano(),
+		}
+		f.tasks[task.GetTaskId()] = failingTask
+	}
+	failingTask.Attempt = int32(attempt)
+	failingTask.LastError = err.Error()
+	failingTask.LastFailureTimestamp = now.UnixNano()
+}
+
+func (f *queueTaskFailures) remove(
+	taskID int64,
+) {
+	f.Lock()
+	defer f.Unlock()
+
+	delete(f.tasks, taskID)
+}
+
+// removeAcked removes the failing tasks the ack level of the queue moved past, whether they were
+// completed or discarded
+func (f *queueTaskFailures) removeAcked(
+	isAcked func(failingTask *historygenpb.FailingTask) bool,
+) {
+	f.Lock()
+	defer f.Unlock()
+
+	for taskID, failingTask := range f.tasks {
+		if isAcked(failingTask) {
+			delete(f.tasks, taskID)
+		}
+	}
+}
+
+// describe returns copies of the failing tasks, the most attempted first
+func (f *queueTaskFailures) describe() []*historygenpb.FailingTask {
+	f.Lock()
+	defer f.Unlock()
+
+	failingTasks := make([]*historygenpb.FailingTask, 0, len(f.tasks))
+	for _, failingTask := range f.tasks {
+		copied := *failingTask
+		failingTasks = append(failingTasks, &copied)
+	}
+	sort.Slice(failingTasks, func(i, j int) bool {
+		if failingTasks[i].Attempt != failingTasks[j].Attempt {
+			return failingTasks[i].Attempt > failingTasks[j].Attempt
+		}
+		return failingTasks[i].TaskId < failingTasks[j].TaskId
+	})
+	return failingTasks
+}
+
+// pendingSince returns the visibility time of a task, or now for a task without visibility time
+func pendingSince(
+	task queueTaskInfo,
+	now time.Time,
+) time.Time {
+	visibilityTime, err := types.TimestampFromProto(task.GetVisibilityTimestamp())
+	if err != nil || visibilityTime.UnixNano() <= 0 {
+		return now
+	}
+	return visibilityTime
+}
+
+// emitQueueStateGauges emits the lag, the age of the oldest pending task and the in flight and failing tasks
+// of a queue of a shard, the lag of timer queues is in seconds
+func emitQueueStateGauges(
+	metricsClient metrics.Client,
+	scope int,
+	shardID int,
+	state *historygenpb.QueueState,
+	now time.Time,
+) {
+	metricsScope := metricsClient.Scope(
+		scope,
+		metrics.InstanceTag(strconv.Itoa(shardID)),
+		metrics.TargetClusterTag(state.GetClusterName()),
+	)
+
+	lag := float64(state.GetMaxReadLevel() - state.GetAckLevel())
+	if state.GetQueueType() == timerQueueName {
+		lag = time.Duration(state.GetMaxReadLevel() - state.GetAckLevel()).Seconds()
+	}
+	if lag < 0 {
+		lag = 0
+	}
+	var oldestPendingTaskAge float64
+	if state.GetOldestPendingTaskTimestamp() > 0 {
+		oldestPendingTaskAge = now.Sub(time.Unix(0, state.GetOldestPendingTaskTimestamp())).Seconds()
+	}
+	var maxAttempt int32
+	for _, failingTask := range state.GetFailingTasks() {
+		if failingTask.GetAttempt() > maxAttempt {
+			maxAttempt = failingTask.GetAttempt()
+		}
+	}
+
+	metricsScope.UpdateGauge(metrics.ShardQueueLagGauge, lag)
+	metricsScope.UpdateGauge(metrics.ShardQueueOldestPendingTaskAgeGauge, oldestPendingTaskAge)
+	metricsScope.UpdateGauge(metrics.ShardQueueInFlightTasksGauge, float64(state.GetInFlightTaskCount()))
+	metricsScope.UpdateGauge(metrics.ShardQueueFailingTasksGauge, float64(len(state.GetFailingTasks())))
+	metricsScope.UpdateGauge(metrics.ShardQueueMaxTaskAttemptGauge, float64(maxAttempt))
+}
+
+// sortQueueStates orders the queues of a shard by queue type, the active queue first
+func sortQueueStates(
+	states []*historygenpb.QueueState,
+	currentClusterName string,
+) {
+	sort.SliceStable(states, func(i, j int) bool {
+		if states[i].GetQueueType() != states[j].GetQueueType() {
+			return states[i].GetQueueType() > states[j].GetQueueType()
+		}
+		if (states[i].GetClusterName() == currentClusterName) != (states[j].GetClusterName() == currentClusterName) {
+			return states[i].GetClusterName() == currentClusterName
+		}
+		return states[i].GetClusterName() < states[j].GetClusterName()
+	})
+}
@@ -0,0 +1,131 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+)
+
+type (
+	queueStateSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestQueueStateSuite(t *testing.T) {
+	s := new(queueStateSuite)
+	suite.Run(t, s)
+}
+
+func (s *queueStateSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *queueStateSuite) TestQueueTaskFailures() {
+	failures := newQueueTaskFailures()
+	now := time.Now()
+
+	task1 := &persistenceblobs.TransferTaskInfo{
+		NamespaceId: TestNamespaceId,
+		WorkflowId:  "some random workflow ID",
+		RunId:       uuid.NewRandom(),
+		TaskId:      59,
+		TaskType:    1,
+	}
+	task2 := &persistenceblobs.TransferTaskInfo{
+		NamespaceId: TestNamespaceId,
+		WorkflowId:  "some other random workflow ID",
+		RunId:       uuid.NewRandom(),
+		TaskId:      60,
+		TaskType:    2,
+	}
+
+	failures.record(task1, 1, errors.New("first error"), now)
+	failures.record(task2, 1, errors.New("some error"), now)
+	failures.record(task2, 2, errors.New("second error"), now.Add(time.Second))
+
+	failingTasks := failures.describe()
+	s.Len(failingTasks, 2)
+	s.Equal(task2.TaskId, failingTasks[0].GetTaskId())
+	s.Equal(int32(2), failingTasks[0].GetAttempt())
+	s.Equal("second error", failingTasks[0].GetLastError())
+	s.Equal(now.Add(time.Second).UnixNano(), failingTasks[0].GetLastFailureTimestamp())
+	s.Equal(task2.WorkflowId, failingTasks[0].GetWorkflowId())
+	s.Equal(task1.TaskId, failingTasks[1].GetTaskId())
+	s.Equal(int32(1), failingTasks[1].GetAttempt())
+
+	// described tasks are copies
+	failingTasks[1].Attempt = 10
+	s.Equal(int32(1), failures.describe()[1].GetAttempt())
+
+	failures.remove(task2.TaskId)
+	failingTasks = failures.describe()
+	s.Len(failingTasks, 1)
+	s.Equal(task1.TaskId, failingTasks[0].GetTaskId())
+
+	failures.record(task2, 3, errors.New("third error"), now)
+	failures.removeAcked(func(failingTask *historygenpb.FailingTask) bool {
+		return failingTask.GetTaskId() <= task1.TaskId
+	})
+	failingTasks = failures.describe()
+	s.Len(failingTasks, 1)
+	s.Equal(task2.TaskId, failingTasks[0].GetTaskId())
+}
+
+func (s *queueStateSuite) TestSortQueueStates() {
+	states := []*historygenpb.QueueState{
+		{QueueType: timerQueueName, ClusterName: "standby-b"},
+		{QueueType: replicationQueueName, ClusterName: "active"},
+		{QueueType: transferQueueName, ClusterName: "standby-a"},
+		{QueueType: timerQueueName, ClusterName: "active"},
+		{QueueType: transferQueueName, ClusterName: "active"},
+		{QueueType: timerQueueName, ClusterName: "standby-a"},
+	}
+
+	sortQueueStates(states, "active")
+
+	var sorted [][2]string
+	for _, state := range states {
+		sorted = append(sorted, [2]string{state.GetQueueType(), state.GetClusterName()})
+	}
+	s.Equal([][2]string{
+		{transferQueueName, "active"},
+		{transferQueueName, "standby-a"},
+		{timerQueueName, "active"},
+		{timerQueueName, "standby-a"},
+		{timerQueueName, "standby-b"},
+		{replicationQueueName, "active"},
+	}, sorted)
+}
@@ -173,6 +173,21 @@ func (t *timerQueueTask) Ack() {
 	t.ackMgr.completeTimerTask(timerTask)
 }
 
+func (t *timerQueueTask) HandleErr(
+	err error,
+) error {
+	err = t.queueTaskBase.HandleErr(err)
+	if err == nil || err == ErrTaskRetry {
+		return err
+	}
+
+	timerTask, ok := t.queueTaskInfo.(*persistenceblobs.TimerTaskInfo)
+	if ok {
+		t.ackMgr.recordTimerTaskFailure(timerTask, t.attempt, err)
+	}
+	return err
+}
+
 func (t *timerQueueTask) Nack() {
 	t.queueTaskBase.Nack()
 
@@ -191,6 +206,18 @@ func (t *transferQueueTask) Ack() {
 	t.ackMgr.completeQueueTask(t.GetTaskId())
 }
 
+func (t *transferQueueTask) HandleErr(
+	err error,
+) error {
+	err = t.queueTaskBase.HandleErr(err)
+	if err == nil || err == ErrTaskRetry {
+		return err
+	}
+
+	t.ackMgr.recordQueueTaskFailure(t.queueTaskInfo, t.attempt, err)
+	return err
+}
+
 func (t *transferQueueTask) Nack() {
 	t.queueTaskBase.Nack()
 
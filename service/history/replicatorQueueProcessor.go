@@ -130,7 +130,7 @@ func newReplicatorQueueProcessor(
 		fetchTasksBatchSize:   config.ReplicatorProcessorFetchTasksBatchSize(),
 	}
 
-	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetReplicatorAckLevel(), logger)
+	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetReplicatorAckLevel(), currentClusterName, logger)
 	queueProcessorBase := newQueueProcessorBase(
 		currentClusterName,
 		shard,
@@ -160,6 +160,13 @@ func (p *replicatorQueueProcessorImpl) complete(
 	p.queueProcessorBase.complete(taskInfo.task)
 }
 
+func (p *replicatorQueueProcessorImpl) recordFailure(
+	taskInfo *taskInfo,
+	err error,
+) {
+	p.queueProcessorBase.recordFailure(taskInfo.task, taskInfo.attempt, err)
+}
+
 func (p *replicatorQueueProcessorImpl) process(
 	taskInfo *taskInfo,
 ) (int, error) {
@@ -254,7 +261,7 @@ func GenerateReplicationTask(
 ) (*replicationgenpb.ReplicationTask, string, error) {
 	var err error
 	if history == nil {
-		history, _, err = GetAllHistory(historyV2Mgr, metricsClient, false,
+		history, _, err = GetAllHistory(historyV2Mgr, metricsClient, false, primitives.UUID(task.GetNamespaceId()).String(),
 			task.GetFirstEventId(), task.GetNextEventId(), task.BranchToken, shardID)
 		if err != nil {
 			return nil, "", err
@@ -278,6 +285,7 @@ func GenerateReplicationTask(
 				historyV2Mgr,
 				metricsClient,
 				false,
+				primitives.UUID(task.GetNamespaceId()).String(),
 				common.FirstEventID,
 				common.FirstEventID+1, // [common.FirstEventID to common.FirstEventID+1) will get the first batch
 				task.NewRunBranchToken,
@@ -343,6 +351,7 @@ func GetAllHistory(
 	historyV2Mgr persistence.HistoryManager,
 	metricsClient metrics.Client,
 	byBatch bool,
+	namespaceID string,
 	firstEventID int64,
 	nextEventID int64,
 	branchToken []byte,
@@ -363,7 +372,7 @@ func GetAllHistory(
 
 	for hasMore := true; hasMore; hasMore = len(pageToken) > 0 {
 		pageHistoryEvents, pageHistoryBatches, pageToken, pageHistorySize, err = PaginateHistory(
-			historyV2Mgr, byBatch,
+			historyV2Mgr, byBatch, namespaceID,
 			branchToken, firstEventID, nextEventID,
 			pageToken, defaultHistoryPageSize, shardID,
 		)
@@ -391,6 +400,7 @@ func GetAllHistory(
 func PaginateHistory(
 	historyV2Mgr persistence.HistoryManager,
 	byBatch bool,
+	namespaceID string,
 	branchToken []byte,
 	firstEventID int64,
 	nextEventID int64,
@@ -411,6 +421,7 @@ func PaginateHistory(
 		PageSize:      pageSize,
 		NextPageToken: tokenIn,
 		ShardID:       shardID,
+		NamespaceID:   namespaceID,
 	}
 	if byBatch {
 		response, err := historyV2Mgr.ReadHistoryBranchByBatch(req)
@@ -721,6 +732,7 @@ func (p *replicatorQueueProcessorImpl) generateHistoryReplicationTask(
 			}
 
 			eventsBlob, err := p.getEventsBlob(
+				namespaceID,
 				task.BranchToken,
 				task.GetFirstEventId(),
 				task.GetNextEventId(),
@@ -733,6 +745,7 @@ func (p *replicatorQueueProcessorImpl) generateHistoryReplicationTask(
 			if len(task.NewRunBranchToken) != 0 {
 				// only get the first batch
 				newRunEventsBlob, err = p.getEventsBlob(
+					namespaceID,
 					task.NewRunBranchToken,
 					common.FirstEventID,
 					common.FirstEventID+1,
@@ -762,6 +775,7 @@ func (p *replicatorQueueProcessorImpl) generateHistoryReplicationTask(
 }
 
 func (p *replicatorQueueProcessorImpl) getEventsBlob(
+	namespaceID string,
 	branchToken []byte,
 	firstEventID int64,
 	nextEventID int64,
@@ -776,6 +790,7 @@ func (p *replicatorQueueProcessorImpl) getEventsBlob(
 		PageSize:      1,
 		NextPageToken: pageToken,
 		ShardID:       convert.IntPtr(p.shard.GetShardID()),
+		NamespaceID:   namespaceID,
 	}
 
 	for {
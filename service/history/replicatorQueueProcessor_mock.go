@@ -34,6 +34,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 )
 
@@ -119,3 +120,17 @@ func (mr *MockReplicatorQueueProcessorMockRecorder) notifyNewTask() *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "notifyNewTask", reflect.TypeOf((*MockReplicatorQueueProcessor)(nil).notifyNewTask))
 }
+
+// describeQueue mocks base method
+func (m *MockReplicatorQueueProcessor) describeQueue() *historygenpb.QueueState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "describeQueue")
+	ret0, _ := ret[0].(*historygenpb.QueueState)
+	return ret0
+}
+
+// describeQueue indicates an expected call of describeQueue
+func (mr *MockReplicatorQueueProcessorMockRecorder) describeQueue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "describeQueue", reflect.TypeOf((*MockReplicatorQueueProcessor)(nil).describeQueue))
+}
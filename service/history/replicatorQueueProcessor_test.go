@@ -499,6 +499,7 @@ func (s *replicatorQueueProcessorSuite) TestPaginateHistoryWithShardID() {
 		PageSize:      pageSize,
 		NextPageToken: []byte{},
 		ShardID:       &shardID,
+		NamespaceID:   testNamespaceID,
 	}
 	s.mockHistoryV2Mgr.On("ReadHistoryBranch", req).Return(&persistence.ReadHistoryBranchResponse{
 		HistoryEvents: []*eventpb.HistoryEvent{
@@ -515,6 +516,7 @@ func (s *replicatorQueueProcessorSuite) TestPaginateHistoryWithShardID() {
 	hEvents, bEvents, token, size, err := PaginateHistory(
 		s.mockHistoryV2Mgr,
 		false,
+		testNamespaceID,
 		[]byte("asd"),
 		firstEventID,
 		nextEventID,
@@ -25,23 +25,24 @@
 package history
 
 import (
-	"context"
 	"sync/atomic"
 	"time"
 
-	"go.temporal.io/temporal-proto/serviceerror"
 	"google.golang.org/grpc"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/definition"
+	"github.com/temporalio/temporal/common/health"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
 	espersistence "github.com/temporalio/temporal/common/persistence/elasticsearch"
 	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/common/rpc"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/common/task"
@@ -64,8 +65,11 @@ type Config struct {
 	EmitShardDiffLog                dynamicconfig.BoolPropertyFn
 	MaxAutoResetPoints              dynamicconfig.IntPropertyFnWithNamespaceFilter
 	ThrottledLogRPS                 dynamicconfig.IntPropertyFn
+	RPCRequestLogSampleRate         dynamicconfig.FloatPropertyFn
 	EnableStickyQuery               dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	ShutdownDrainDuration           dynamicconfig.DurationPropertyFn
+	// TypeTagLimiter bounds the workflow and activity type tags of the metrics of a namespace
+	TypeTagLimiter *metrics.TypeTagLimiter
 
 	// HistoryCache settings
 	// Change of these configs require shard restart
@@ -208,6 +212,12 @@ type Config struct {
 	// DecisionHeartbeatTimeout is to timeout behavior of: RespondDecisionTaskComplete with ForceCreateNewDecisionTask == true without any decisions
 	// So that decision will be scheduled to another worker(by clear stickyness)
 	DecisionHeartbeatTimeout dynamicconfig.DurationPropertyFnWithNamespaceFilter
+	// MaxEagerActivityTasksPerDecision is the max number of activity tasks started eagerly for, and returned to,
+	// the worker completing a decision when it asks for it
+	MaxEagerActivityTasksPerDecision dynamicconfig.IntPropertyFnWithNamespaceFilter
+	// MaxEagerActivityTasksSizePerDecision is the max total encoded size of the activity tasks started eagerly, they
+	// are returned in a gRPC response header which is limited in size
+	MaxEagerActivityTasksSizePerDecision dynamicconfig.IntPropertyFnWithNamespaceFilter
 	// The execution timeout a workflow execution defaults to if not specified
 	DefaultExecutionStartToCloseTimeout dynamicconfig.DurationPropertyFnWithNamespaceFilter
 	// Maximum workflow execution timeout permitted by the service
@@ -238,6 +248,10 @@ type Config struct {
 
 	//Crocess DC Replication configuration
 	ReplicationEventsFromCurrentCluster dynamicconfig.BoolPropertyFnWithNamespaceFilter
+
+	// Lifecycle events configuration
+	LifecycleEventsEnabled dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	LifecycleEventTypes    dynamicconfig.StringPropertyFnWithNamespaceFilter
 }
 
 const (
@@ -361,8 +375,15 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		HistoryCountLimitError: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistoryCountLimitError, 200*1024),
 		HistoryCountLimitWarn:  dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistoryCountLimitWarn, 50*1024),
 
-		ThrottledLogRPS:   dc.GetIntProperty(dynamicconfig.HistoryThrottledLogRPS, 4),
-		EnableStickyQuery: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableStickyQuery, true),
+		ThrottledLogRPS:         dc.GetIntProperty(dynamicconfig.HistoryThrottledLogRPS, 4),
+		RPCRequestLogSampleRate: dc.GetFloat64Property(dynamicconfig.RPCRequestLogSampleRate, 0),
+		EnableStickyQuery:       dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableStickyQuery, true),
+		TypeTagLimiter: metrics.NewTypeTagLimiter(
+			dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableTypeTaggedMetrics, false),
+			dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.WorkflowTypeTaggedMetricsAllowlist, ""),
+			dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.ActivityTypeTaggedMetricsAllowlist, ""),
+			dc.GetIntPropertyFilteredByNamespace(dynamicconfig.TypeTaggedMetricsLimit, 20),
+		),
 
 		ValidSearchAttributes:                            dc.GetMapProperty(dynamicconfig.ValidSearchAttributes, definition.GetDefaultIndexedKeys()),
 		SearchAttributesNumberOfKeysLimit:                dc.GetIntPropertyFilteredByNamespace(dynamicconfig.SearchAttributesNumberOfKeysLimit, 100),
@@ -370,6 +391,8 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		SearchAttributesTotalSizeLimit:                   dc.GetIntPropertyFilteredByNamespace(dynamicconfig.SearchAttributesTotalSizeLimit, 40*1024),
 		StickyTTL:                                        dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.StickyTTL, time.Hour*24*365),
 		DecisionHeartbeatTimeout:                         dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.DecisionHeartbeatTimeout, time.Minute*30),
+		MaxEagerActivityTasksPerDecision:                 dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MaxEagerActivityTasksPerDecision, 1),
+		MaxEagerActivityTasksSizePerDecision:             dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MaxEagerActivityTasksSizePerDecision, 4*1024),
 		DefaultExecutionStartToCloseTimeout:              dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.DefaultExecutionStartToCloseTimeout, time.Hour*24*365*10),
 		MaxExecutionStartToCloseTimeout:                  dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.MaxExecutionStartToCloseTimeout, time.Hour*24*365*10),
 		ReplicationTaskFetcherParallelism:                dc.GetIntProperty(dynamicconfig.ReplicationTaskFetcherParallelism, 1),
@@ -390,6 +413,9 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		MutableStateChecksumInvalidateBefore:  dc.GetFloat64Property(dynamicconfig.MutableStateChecksumInvalidateBefore, 0),
 
 		ReplicationEventsFromCurrentCluster: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.ReplicationEventsFromCurrentCluster, false),
+
+		LifecycleEventsEnabled: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.LifecycleEventsEnabled, false),
+		LifecycleEventTypes:    dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.LifecycleEventTypes, ""),
 	}
 
 	return cfg
@@ -409,7 +435,8 @@ type Service struct {
 	params  *resource.BootstrapParams
 	config  *Config
 
-	server *grpc.Server
+	server       *grpc.Server
+	healthServer *health.Server
 }
 
 // NewService builds a new history service
@@ -420,6 +447,10 @@ func NewService(
 		params.PersistenceConfig.NumHistoryShards,
 		params.PersistenceConfig.DefaultStoreType(),
 		params.PersistenceConfig.IsAdvancedVisibilityConfigExist())
+	if params.LifecyclePublisher == nil {
+		// lifecycle event tasks are only generated when there is a publisher to process them
+		serviceConfig.LifecycleEventsEnabled = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false)
+	}
 
 	params.PersistenceConfig.HistoryMaxConns = serviceConfig.HistoryMgrNumConns()
 	params.PersistenceConfig.VisibilityConfig = &config.VisibilityConfig{
@@ -482,17 +513,22 @@ func (s *Service) Start() {
 	logger.Info("elastic search config", tag.ESConfig(s.params.ESConfig))
 	logger.Info("history starting")
 
-	s.handler = NewHandler(s.Resource, s.config)
+	s.handler = NewHandler(s.Resource, s.config, s.params.LifecyclePublisher)
 
 	// must start resource first
 	s.Resource.Start()
 	s.handler.Start()
 
-	s.server = grpc.NewServer(grpc.UnaryInterceptor(interceptor))
+	interceptors := rpc.NewServerInterceptors(s.GetMetricsClient(), logger, s.config.RPCRequestLogSampleRate, s.GetNamespaceCache())
+	opts := append(s.GetInternodeGRPCServerOptions(), grpc.ChainUnaryInterceptor(interceptors...))
+	s.server = grpc.NewServer(opts...)
 	nilCheckHandler := NewNilCheckHandler(s.handler)
 	historyservice.RegisterHistoryServiceServer(s.server, nilCheckHandler)
 	healthpb.RegisterHealthServer(s.server, s.handler)
 
+	s.healthServer = health.NewServer(s.handler.healthChecker, s.GetHealthListener(), logger)
+	s.healthServer.Start()
+
 	listener := s.GetGRPCListener()
 	logger.Info("Starting to serve on history listener")
 	if err := s.server.Serve(listener); err != nil {
@@ -539,18 +575,19 @@ func (s *Service) Stop() {
 	remainingTime = s.sleep(gracePeriod, remainingTime)
 
 	s.server.GracefulStop()
+	s.healthServer.Stop()
 
 	s.handler.Stop()
+	if s.params.LifecyclePublisher != nil {
+		if err := s.params.LifecyclePublisher.Close(); err != nil {
+			s.GetLogger().Warn("Failed to close lifecycle event publisher", tag.ComponentLifecyclePublisher, tag.Error(err))
+		}
+	}
 	s.Resource.Stop()
 
 	s.GetLogger().Info("history stopped")
 }
 
-func interceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	resp, err := handler(ctx, req)
-	return resp, serviceerror.ToStatus(err).Err()
-}
-
 // sleep sleeps for the minimum of desired and available duration
 // returns the remaining available time duration
 func (s *Service) sleep(desired time.Duration, available time.Duration) time.Duration {
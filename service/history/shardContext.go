@@ -838,6 +838,7 @@ func (s *shardContextImpl) AppendHistoryV2Events(
 	request.Encoding = s.getDefaultEncoding(namespaceEntry)
 	request.ShardID = convert.IntPtr(s.shardID)
 	request.TransactionID = transactionID
+	request.NamespaceID = namespaceID
 
 	size := 0
 	defer func() {
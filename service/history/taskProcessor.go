@@ -209,6 +209,9 @@ FilterLoop:
 		err := t.handleTaskError(scope, task, notificationChan, err)
 		if err != nil {
 			task.attempt++
+			if err != ErrTaskRetry {
+				task.processor.recordFailure(task, err)
+			}
 			if task.attempt >= t.config.TimerTaskMaxRetryCount() {
 				scope.RecordTimer(metrics.TaskAttemptTimer, time.Duration(task.attempt))
 				task.logger.Error("Critical error processing task, retrying.",
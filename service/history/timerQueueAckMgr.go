@@ -32,6 +32,7 @@ import (
 
 	"github.com/gogo/protobuf/types"
 
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 
 	"github.com/temporalio/temporal/common/log"
@@ -72,6 +73,7 @@ type (
 		// queue ack manager have no more task to send out and all
 		// tasks sent are finished
 		finishedChan chan struct{}
+		taskFailures *queueTaskFailures
 
 		sync.Mutex
 		// outstanding timer task -> finished (true)
@@ -162,6 +164,7 @@ func newTimerQueueAckMgr(
 		maxQueryLevel:       ackLevel.VisibilityTimestamp,
 		isReadFinished:      false,
 		finishedChan:        nil,
+		taskFailures:        newQueueTaskFailures(),
 		clusterName:         clusterName,
 	}
 
@@ -200,6 +203,7 @@ func newTimerQueueFailoverAckMgr(
 		maxQueryLevel:       maxLevel,
 		isReadFinished:      false,
 		finishedChan:        make(chan struct{}, 1),
+		taskFailures:        newQueueTaskFailures(),
 	}
 
 	return timerQueueAckMgrImpl
@@ -316,9 +320,52 @@ func (t *timerQueueAckMgrImpl) readLookAheadTask() (*persistenceblobs.TimerTaskI
 func (t *timerQueueAckMgrImpl) completeTimerTask(timerTask *persistenceblobs.TimerTaskInfo) {
 	timerKey := timerKeyFromGogoTime(timerTask.GetVisibilityTimestamp(), timerTask.GetTaskId())
 	t.Lock()
-	defer t.Unlock()
-
 	t.outstandingTasks[*timerKey] = true
+	t.Unlock()
+	t.taskFailures.remove(timerTask.GetTaskId())
+}
+
+func (t *timerQueueAckMgrImpl) recordTimerTaskFailure(timerTask *persistenceblobs.TimerTaskInfo, attempt int, err error) {
+	t.taskFailures.record(timerTask, attempt, err, t.timeNow())
+}
+
+func (t *timerQueueAckMgrImpl) describeQueue() *historygenpb.QueueState {
+	maxReadLevel := t.shard.GetTimerMaxReadLevel(t.clusterName)
+
+	t.Lock()
+	state := &historygenpb.QueueState{
+		QueueType:    timerQueueName,
+		ClusterName:  t.clusterName,
+		AckLevel:     t.ackLevel.VisibilityTimestamp.UnixNano(),
+		ReadLevel:    t.readLevel.VisibilityTimestamp.UnixNano(),
+		MaxReadLevel: maxReadLevel.UnixNano(),
+	}
+	var oldestPendingTask *timerKey
+	for key, acked := range t.outstandingTasks {
+		if acked {
+			continue
+		}
+		state.InFlightTaskCount++
+		pendingTask := key
+		if oldestPendingTask == nil || compareTimerIDLess(&pendingTask, oldestPendingTask) {
+			oldestPendingTask = &pendingTask
+		}
+	}
+	t.Unlock()
+
+	if oldestPendingTask != nil {
+		state.OldestPendingTaskTimestamp = oldestPendingTask.VisibilityTimestamp.UnixNano()
+	}
+	state.FailingTasks = t.taskFailures.describe()
+	return state
+}
+
+// emitQueueState emits the state gauges of the queue, failover queues are not emitted
+func (t *timerQueueAckMgrImpl) emitQueueState() {
+	if t.isFailover {
+		return
+	}
+	emitQueueStateGauges(t.metricsClient, t.scope, t.shard.GetShardID(), t.describeQueue(), t.timeNow())
 }
 
 func (t *timerQueueAckMgrImpl) getReadLevel() timerKey {
@@ -388,6 +435,13 @@ MoveAckLevelLoop:
 	}
 
 	t.Unlock()
+	t.taskFailures.removeAcked(func(failingTask *historygenpb.FailingTask) bool {
+		failingTaskKey := timerKey{
+			VisibilityTimestamp: time.Unix(0, failingTask.GetVisibilityTimestamp()),
+			TaskID:              failingTask.GetTaskId(),
+		}
+		return !compareTimerIDLess(&ackLevel, &failingTaskKey)
+	})
 	if err := t.updateTimerAckLevel(ackLevel); err != nil {
 		t.metricsClient.IncCounter(t.scope, metrics.AckLevelUpdateFailedCounter)
 		t.logger.Error("Error updating timer ack level for shard", tag.Error(err))
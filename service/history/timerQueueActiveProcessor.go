@@ -296,6 +296,17 @@ func (t *timerQueueActiveProcessorImpl) complete(
 	t.timerQueueProcessorBase.complete(timerTask)
 }
 
+func (t *timerQueueActiveProcessorImpl) recordFailure(
+	taskInfo *taskInfo,
+	err error,
+) {
+	timerTask, ok := taskInfo.task.(*persistenceblobs.TimerTaskInfo)
+	if !ok {
+		return
+	}
+	t.timerQueueProcessorBase.recordFailure(timerTask, taskInfo.attempt, err)
+}
+
 func (t *timerQueueActiveProcessorImpl) process(
 	taskInfo *taskInfo,
 ) (int, error) {
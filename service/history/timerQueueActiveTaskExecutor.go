@@ -131,6 +131,7 @@ func (t *timerQueueActiveTaskExecutor) executeUserTimerTimeoutTask(
 	timerSequence := t.getTimerSequence(mutableState)
 	referenceTime := t.shard.GetTimeSource().Now()
 	timerFired := false
+	var spans []*workflowSpan
 
 Loop:
 	for _, timerSequenceID := range timerSequence.loadAndSortUserTimers() {
@@ -151,13 +152,18 @@ Loop:
 			return err
 		}
 		timerFired = true
+		spans = append(spans, newTimerSpan(mutableState, timerInfo))
 	}
 
 	if !timerFired {
 		return nil
 	}
 
-	return t.updateWorkflowExecution(weContext, mutableState, timerFired)
+	if err := t.updateWorkflowExecution(weContext, mutableState, timerFired); err != nil {
+		return err
+	}
+	recordWorkflowSpans(spans)
+	return nil
 }
 
 func (t *timerQueueActiveTaskExecutor) executeActivityTimeoutTask(
@@ -184,6 +190,7 @@ func (t *timerQueueActiveTaskExecutor) executeActivityTimeoutTask(
 	referenceTime := t.shard.GetTimeSource().Now()
 	updateMutableState := false
 	scheduleDecision := false
+	var spans []*workflowSpan
 
 	// need to clear activity heartbeat timer task mask for new activity timer task creation
 	// NOTE: LastHeartbeatTimeoutVisibilityInSeconds is for deduping heartbeat timer creation as it's possible
@@ -220,6 +227,7 @@ Loop:
 			break Loop
 		}
 
+		spans = append(spans, newActivityTimeoutSpan(mutableState, activityInfo))
 		if timerSequenceID.timerType != timerTypeScheduleToStart {
 			// schedule to start timeout is not retriable
 			// customer should set larger schedule to start timeout if necessary
@@ -236,7 +244,8 @@ Loop:
 		}
 
 		t.emitTimeoutMetricScopeWithNamespaceTag(
-			mutableState.GetExecutionInfo().NamespaceID,
+			mutableState,
+			activityInfo.ScheduleID,
 			metrics.TimerActiveTaskActivityTimeoutScope,
 			timerSequenceID.timerType,
 		)
@@ -255,7 +264,11 @@ Loop:
 	if !updateMutableState {
 		return nil
 	}
-	return t.updateWorkflowExecution(weContext, mutableState, scheduleDecision)
+	if err := t.updateWorkflowExecution(weContext, mutableState, scheduleDecision); err != nil {
+		return err
+	}
+	recordWorkflowSpans(spans)
+	return nil
 }
 
 func (t *timerQueueActiveTaskExecutor) executeDecisionTimeoutTask(
@@ -294,13 +307,16 @@ func (t *timerQueueActiveTaskExecutor) executeDecisionTimeoutTask(
 	}
 
 	scheduleDecision := false
+	var span *workflowSpan
 	switch timerTypeFromProto(eventpb.TimeoutType(task.TimeoutType)) {
 	case timerTypeStartToClose:
 		t.emitTimeoutMetricScopeWithNamespaceTag(
-			mutableState.GetExecutionInfo().NamespaceID,
+			mutableState,
+			common.EmptyEventID,
 			metrics.TimerActiveTaskDecisionTimeoutScope,
 			timerTypeStartToClose,
 		)
+		span = newDecisionSpan(mutableState, decision, "DecisionTask", decision.StartedTimestamp)
 		if _, err := mutableState.AddDecisionTaskTimedOutEvent(
 			decision.ScheduleID,
 			decision.StartedID,
@@ -316,10 +332,12 @@ func (t *timerQueueActiveTaskExecutor) executeDecisionTimeoutTask(
 		}
 
 		t.emitTimeoutMetricScopeWithNamespaceTag(
-			mutableState.GetExecutionInfo().NamespaceID,
+			mutableState,
+			common.EmptyEventID,
 			metrics.TimerActiveTaskDecisionTimeoutScope,
 			timerTypeScheduleToStart,
 		)
+		span = newDecisionSpan(mutableState, decision, "DecisionTask.ScheduleToStart", decision.ScheduledTimestamp)
 		_, err := mutableState.AddDecisionTaskScheduleToStartTimeoutEvent(scheduleID)
 		if err != nil {
 			return err
@@ -327,7 +345,11 @@ func (t *timerQueueActiveTaskExecutor) executeDecisionTimeoutTask(
 		scheduleDecision = true
 	}
 
-	return t.updateWorkflowExecution(weContext, mutableState, scheduleDecision)
+	if err := t.updateWorkflowExecution(weContext, mutableState, scheduleDecision); err != nil {
+		return err
+	}
+	span.setTag(outcomeSpanTag, spanOutcomeTimedOut).record()
+	return nil
 }
 
 func (t *timerQueueActiveTaskExecutor) executeWorkflowBackoffTimerTask(
@@ -436,10 +458,11 @@ func (t *timerQueueActiveTaskExecutor) executeActivityRetryTimerTask(
 		Name: activityInfo.TaskList,
 	}
 	scheduleToStartTimeout := activityInfo.ScheduleToStartTimeout
+	attempt := activityInfo.Attempt
 
 	release(nil) // release earlier as we don't need the lock anymore
 
-	_, retError = t.shard.GetService().GetMatchingClient().AddActivityTask(context.Background(), &matchingservice.AddActivityTaskRequest{
+	resp, err := t.shard.GetService().GetMatchingClient().AddActivityTask(context.Background(), &matchingservice.AddActivityTaskRequest{
 		NamespaceId:                   targetNamespaceID,
 		SourceNamespaceId:             namespaceID,
 		Execution:                     execution,
@@ -447,8 +470,55 @@ func (t *timerQueueActiveTaskExecutor) executeActivityRetryTimerTask(
 		ScheduleId:                    scheduledID,
 		ScheduleToStartTimeoutSeconds: scheduleToStartTimeout,
 	})
+	if err != nil {
+		return err
+	}
+
+	if resp.GetAffinityOwnerUnavailable() {
+		return t.timeoutAffinityActivity(task, attempt)
+	}
+	return nil
+}
+
+// timeoutAffinityActivity times out a retried activity scheduled to an affinity task list which
+// lost its owner, so that the workflow can schedule the activity to another worker
+func (t *timerQueueActiveTaskExecutor) timeoutAffinityActivity(
+	task *persistenceblobs.TimerTaskInfo,
+	attempt int32,
+) (retError error) {
+
+	weContext, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
+		t.getNamespaceIDAndWorkflowExecution(task),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := loadMutableStateForTimerTask(weContext, task, t.metricsClient, t.logger)
+	if err != nil {
+		return err
+	}
+	if mutableState == nil || !mutableState.IsWorkflowExecutionRunning() {
+		return nil
+	}
+
+	// the activity could have been started or timed out since the task was pushed
+	ai, ok := mutableState.GetActivityInfo(task.GetEventId())
+	if !ok || ai.StartedID != common.EmptyEventID || ai.Attempt != attempt {
+		return nil
+	}
 
-	return retError
+	t.metricsClient.IncCounter(metrics.TimerActiveTaskActivityRetryTimerScope, metrics.AffinityOwnerUnavailableCounter)
+	if _, err := mutableState.AddActivityTaskTimedOutEvent(
+		ai.ScheduleID,
+		ai.StartedID,
+		eventpb.TimeoutType_ScheduleToStart,
+		ai.Details,
+	); err != nil {
+		return err
+	}
+	return t.updateWorkflowExecution(weContext, mutableState, true)
 }
 
 func (t *timerQueueActiveTaskExecutor) executeWorkflowTimeoutTask(
@@ -589,17 +659,43 @@ func (t *timerQueueActiveTaskExecutor) updateWorkflowExecution(
 	return nil
 }
 
+func (t *timerQueueActiveTaskExecutor) getActivityType(
+	mutableState mutableState,
+	scheduleID int64,
+) string {
+
+	if scheduleID == common.EmptyEventID {
+		return ""
+	}
+	scheduledEvent, err := mutableState.GetActivityScheduledEvent(scheduleID)
+	if err != nil {
+		return ""
+	}
+	return scheduledEvent.GetActivityTaskScheduledEventAttributes().GetActivityType().GetName()
+}
+
+// emitTimeoutMetricScopeWithNamespaceTag emits the timeout counter of the workflow, tagged with the
+// workflow type and the type of the activity scheduled by scheduleID, which is empty for decision timeouts.
+// The scheduled event of the activity is only loaded when metrics of the namespace are tagged with types.
 func (t *timerQueueActiveTaskExecutor) emitTimeoutMetricScopeWithNamespaceTag(
-	namespaceID string,
+	mutableState mutableState,
+	scheduleID int64,
 	scope int,
 	timerType timerType,
 ) {
 
-	namespaceEntry, err := t.shard.GetNamespaceCache().GetNamespaceByID(namespaceID)
+	namespaceEntry, err := t.shard.GetNamespaceCache().GetNamespaceByID(mutableState.GetExecutionInfo().NamespaceID)
 	if err != nil {
 		return
 	}
-	metricsScope := t.metricsClient.Scope(scope).Tagged(metrics.NamespaceTag(namespaceEntry.GetInfo().Name))
+	namespace := namespaceEntry.GetInfo().Name
+	metricsScope := t.metricsClient.Scope(scope).Tagged(metrics.NamespaceTag(namespace))
+	if t.config.TypeTagLimiter.Enabled(namespace) {
+		metricsScope = metricsScope.Tagged(
+			t.config.TypeTagLimiter.WorkflowTypeTag(namespace, mutableState.GetWorkflowType().GetName()),
+			t.config.TypeTagLimiter.ActivityTypeTag(namespace, t.getActivityType(mutableState, scheduleID)),
+		)
+	}
 	switch timerType {
 	case timerTypeScheduleToStart:
 		metricsScope.IncCounter(metrics.ScheduleToStartTimeoutCounter)
@@ -36,6 +36,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally"
 	commonpb "go.temporal.io/temporal-proto/common"
+	decisionpb "go.temporal.io/temporal-proto/decision"
 	eventpb "go.temporal.io/temporal-proto/event"
 	executionpb "go.temporal.io/temporal-proto/execution"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
@@ -1124,6 +1125,184 @@ func (s *timerQueueActiveTaskExecutorSuite) TestActivityRetryTimer_Fire() {
 	s.NoError(err)
 }
 
+func (s *timerQueueActiveTaskExecutorSuite) TestActivityRetryTimer_StartDelay_Fire() {
+
+	execution := executionpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                        &commonpb.WorkflowType{Name: workflowType},
+				TaskList:                            &tasklistpb.TaskList{Name: taskListName},
+				ExecutionStartToCloseTimeoutSeconds: 2,
+				TaskStartToCloseTimeoutSeconds:      1,
+			},
+		},
+	)
+	s.Nil(err)
+
+	di := addDecisionTaskScheduledEvent(mutableState)
+	event := addDecisionTaskStartedEvent(mutableState, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(mutableState, di.ScheduleID, di.StartedID, "some random identity")
+
+	timerTimeout := 2 * time.Second
+	scheduledEvent, activityInfo, err := mutableState.AddActivityTaskScheduledEvent(event.GetEventId(), &decisionpb.ScheduleActivityTaskDecisionAttributes{
+		ActivityId:                    "activity",
+		ActivityType:                  &commonpb.ActivityType{Name: "activity type"},
+		TaskList:                      &tasklistpb.TaskList{Name: "tasklist"},
+		Header:                        &commonpb.Header{Fields: map[string][]byte{common.ActivityStartDelayHeader: []byte("10")}},
+		ScheduleToCloseTimeoutSeconds: int32(timerTimeout.Seconds()),
+		ScheduleToStartTimeoutSeconds: int32(timerTimeout.Seconds()),
+		StartToCloseTimeoutSeconds:    int32(timerTimeout.Seconds()),
+	})
+	s.NoError(err)
+	// the delayed activity is held by the retry timer instead of being dispatched to matching right away
+	for _, task := range mutableState.insertTransferTasks {
+		s.NotEqual(persistence.TransferTaskTypeActivityTask, task.GetType())
+	}
+	s.Equal(&persistence.ActivityRetryTimerTask{
+		Version:             activityInfo.Version,
+		VisibilityTimestamp: activityInfo.ScheduledTime,
+		EventID:             activityInfo.ScheduleID,
+		Attempt:             activityInfo.Attempt,
+	}, mutableState.insertTimerTasks[len(mutableState.insertTimerTasks)-1])
+
+	protoTaskTime, err := types.TimestampProto(activityInfo.ScheduledTime)
+	s.NoError(err)
+	timerTask := &persistenceblobs.TimerTaskInfo{
+		Version:             s.version,
+		NamespaceId:         primitives.MustParseUUID(s.namespaceID),
+		WorkflowId:          execution.GetWorkflowId(),
+		RunId:               primitives.MustParseUUID(execution.GetRunId()),
+		TaskId:              int64(100),
+		TaskType:            persistence.TaskTypeActivityRetryTimer,
+		TimeoutType:         0,
+		VisibilityTimestamp: protoTaskTime,
+		EventId:             activityInfo.ScheduleID,
+		ScheduleAttempt:     int64(activityInfo.Attempt),
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, scheduledEvent.GetEventId(), scheduledEvent.GetVersion())
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockMatchingClient.EXPECT().AddActivityTask(
+		gomock.Any(),
+		&matchingservice.AddActivityTaskRequest{
+			NamespaceId:       activityInfo.NamespaceID,
+			SourceNamespaceId: activityInfo.NamespaceID,
+			Execution:         &execution,
+			TaskList: &tasklistpb.TaskList{
+				Name: activityInfo.TaskList,
+			},
+			ScheduleId:                    activityInfo.ScheduleID,
+			ScheduleToStartTimeoutSeconds: activityInfo.ScheduleToStartTimeout,
+		},
+	).Return(&matchingservice.AddActivityTaskResponse{}, nil).Times(1)
+
+	err = s.timerQueueActiveTaskExecutor.execute(timerTask, true)
+	s.NoError(err)
+}
+
+func (s *timerQueueActiveTaskExecutorSuite) TestActivityRetryTimer_AffinityOwnerUnavailable() {
+
+	execution := executionpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                        &commonpb.WorkflowType{Name: workflowType},
+				TaskList:                            &tasklistpb.TaskList{Name: taskListName},
+				ExecutionStartToCloseTimeoutSeconds: 2,
+				TaskStartToCloseTimeoutSeconds:      1,
+			},
+		},
+	)
+	s.Nil(err)
+
+	di := addDecisionTaskScheduledEvent(mutableState)
+	event := addDecisionTaskStartedEvent(mutableState, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(mutableState, di.ScheduleID, di.StartedID, "some random identity")
+
+	tasklist := "tasklist"
+	activityID := "activity"
+	activityType := "activity type"
+	timerTimeout := 2 * time.Second
+	scheduledEvent, activityInfo := addActivityTaskScheduledEventWithRetry(
+		mutableState,
+		event.GetEventId(),
+		activityID,
+		activityType,
+		tasklist,
+		nil,
+		int32(timerTimeout.Seconds()),
+		int32(timerTimeout.Seconds()),
+		int32(timerTimeout.Seconds()),
+		int32(timerTimeout.Seconds()),
+		&commonpb.RetryPolicy{
+			InitialIntervalInSeconds:    1,
+			BackoffCoefficient:          1.2,
+			MaximumIntervalInSeconds:    5,
+			MaximumAttempts:             5,
+			NonRetriableErrorReasons:    []string{"（╯' - ')╯ ┻━┻ "},
+			ExpirationIntervalInSeconds: 999,
+		},
+	)
+	activityInfo.Attempt = 1
+
+	protoTaskTime, err := types.TimestampProto(s.now)
+	s.NoError(err)
+	timerTask := &persistenceblobs.TimerTaskInfo{
+		Version:             s.version,
+		NamespaceId:         primitives.MustParseUUID(s.namespaceID),
+		WorkflowId:          execution.GetWorkflowId(),
+		RunId:               primitives.MustParseUUID(execution.GetRunId()),
+		TaskId:              int64(100),
+		TaskType:            persistence.TaskTypeActivityRetryTimer,
+		TimeoutType:         0,
+		VisibilityTimestamp: protoTaskTime,
+		EventId:             activityInfo.ScheduleID,
+		ScheduleAttempt:     int64(activityInfo.Attempt),
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, scheduledEvent.GetEventId(), scheduledEvent.GetVersion())
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockMatchingClient.EXPECT().AddActivityTask(
+		gomock.Any(),
+		&matchingservice.AddActivityTaskRequest{
+			NamespaceId:       activityInfo.NamespaceID,
+			SourceNamespaceId: activityInfo.NamespaceID,
+			Execution:         &execution,
+			TaskList: &tasklistpb.TaskList{
+				Name: activityInfo.TaskList,
+			},
+			ScheduleId:                    activityInfo.ScheduleID,
+			ScheduleToStartTimeoutSeconds: activityInfo.ScheduleToStartTimeout,
+		},
+	).Return(&matchingservice.AddActivityTaskResponse{AffinityOwnerUnavailable: true}, nil).Times(1)
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	err = s.timerQueueActiveTaskExecutor.execute(timerTask, true)
+	s.NoError(err)
+}
+
 func (s *timerQueueActiveTaskExecutorSuite) TestActivityRetryTimer_Noop() {
 
 	execution := executionpb.WorkflowExecution{
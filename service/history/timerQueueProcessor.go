@@ -33,6 +33,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/client/matching"
 	"github.com/temporalio/temporal/common"
@@ -54,6 +55,7 @@ type (
 		NotifyNewTimers(clusterName string, timerTask []persistence.Task)
 		LockTaskProcessing()
 		UnlockTaskProcessing()
+		DescribeQueues() []*historygenpb.QueueState
 	}
 
 	timeNow                 func() time.Time
@@ -262,6 +264,16 @@ func (t *timerQueueProcessorImpl) UnlockTaskProcessing() {
 	t.taskAllocator.unlock()
 }
 
+// DescribeQueues returns the state of the active queue and of the standby queues of the shard
+func (t *timerQueueProcessorImpl) DescribeQueues() []*historygenpb.QueueState {
+	states := []*historygenpb.QueueState{t.activeTimerProcessor.timerQueueProcessorBase.timerQueueAckMgr.describeQueue()}
+	for _, standbyTimerProcessor := range t.standbyTimerProcessors {
+		states = append(states, standbyTimerProcessor.timerQueueProcessorBase.timerQueueAckMgr.describeQueue())
+	}
+	sortQueueStates(states, t.currentClusterName)
+	return states
+}
+
 func (t *timerQueueProcessorImpl) completeTimersLoop() {
 	timer := time.NewTimer(t.config.TimerProcessorCompleteTimerInterval())
 	defer timer.Stop()
@@ -264,6 +264,9 @@ func (t *timerQueueProcessorBase) internalProcessor() error {
 	))
 	defer redispatchTimer.Stop()
 
+	queueStateTicker := time.NewTicker(queueStateGaugesInterval)
+	defer queueStateTicker.Stop()
+
 	for {
 		// Wait until one of four things occurs:
 		// 1. we get notified of a new message
@@ -343,6 +346,8 @@ func (t *timerQueueProcessorBase) internalProcessor() error {
 				t.config.TimerProcessorRedispatchIntervalJitterCoefficient(),
 			))
 			t.redispatchTasks()
+		case <-queueStateTicker.C:
+			t.timerQueueAckMgr.emitQueueState()
 		}
 	}
 }
@@ -434,6 +439,14 @@ func (t *timerQueueProcessorBase) complete(
 	atomic.AddUint64(&t.timerFiredCount, 1)
 }
 
+func (t *timerQueueProcessorBase) recordFailure(
+	timerTask *persistenceblobs.TimerTaskInfo,
+	attempt int,
+	err error,
+) {
+	t.timerQueueAckMgr.recordTimerTaskFailure(timerTask, attempt, err)
+}
+
 func (t *timerQueueProcessorBase) isPriorityTaskProcessorEnabled() bool {
 	return t.taskProcessor == nil
 }
@@ -32,6 +32,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
 	persistence "github.com/temporalio/temporal/common/persistence"
 )
 
@@ -129,3 +130,17 @@ func (mr *MocktimerQueueProcessorMockRecorder) UnlockTaskProcessing() *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockTaskProcessing", reflect.TypeOf((*MocktimerQueueProcessor)(nil).UnlockTaskProcessing))
 }
+
+// DescribeQueues mocks base method.
+func (m *MocktimerQueueProcessor) DescribeQueues() []*historygenpb.QueueState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeQueues")
+	ret0, _ := ret[0].([]*historygenpb.QueueState)
+	return ret0
+}
+
+// DescribeQueues indicates an expected call of DescribeQueues.
+func (mr *MocktimerQueueProcessorMockRecorder) DescribeQueues() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeQueues", reflect.TypeOf((*MocktimerQueueProcessor)(nil).DescribeQueues))
+}
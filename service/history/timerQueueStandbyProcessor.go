@@ -204,6 +204,17 @@ func (t *timerQueueStandbyProcessorImpl) complete(
 	t.timerQueueProcessorBase.complete(timerTask)
 }
 
+func (t *timerQueueStandbyProcessorImpl) recordFailure(
+	taskInfo *taskInfo,
+	err error,
+) {
+	timerTask, ok := taskInfo.task.(*persistenceblobs.TimerTaskInfo)
+	if !ok {
+		return
+	}
+	t.timerQueueProcessorBase.recordFailure(timerTask, taskInfo.attempt, err)
+}
+
 func (t *timerQueueStandbyProcessorImpl) process(
 	taskInfo *taskInfo,
 ) (int, error) {
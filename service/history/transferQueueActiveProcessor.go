@@ -130,6 +130,7 @@ func newTransferQueueActiveProcessor(
 		options,
 		processor,
 		shard.GetTransferClusterAckLevel(currentClusterName),
+		currentClusterName,
 		logger,
 	)
 
@@ -265,6 +266,7 @@ func newTransferQueueFailoverProcessor(
 		options,
 		processor,
 		minLevel,
+		currentClusterName,
 		logger,
 	)
 
@@ -320,6 +322,14 @@ func (t *transferQueueActiveProcessorImpl) complete(
 	t.queueProcessorBase.complete(taskInfo.task)
 }
 
+func (t *transferQueueActiveProcessorImpl) recordFailure(
+	taskInfo *taskInfo,
+	err error,
+) {
+
+	t.queueProcessorBase.recordFailure(taskInfo.task, taskInfo.attempt, err)
+}
+
 func (t *transferQueueActiveProcessorImpl) process(
 	taskInfo *taskInfo,
 ) (int, error) {
@@ -119,6 +119,8 @@ func (t *transferQueueActiveTaskExecutor) execute(
 		return t.processResetWorkflow(task)
 	case persistence.TransferTaskTypeUpsertWorkflowSearchAttributes:
 		return t.processUpsertWorkflowSearchAttributes(task)
+	case persistence.TransferTaskTypeLifecycleEvent:
+		return t.processLifecycleEvent(task)
 	default:
 		return errUnknownTransferTask
 	}
@@ -153,12 +155,65 @@ func (t *transferQueueActiveTaskExecutor) processActivityTask(
 	if err != nil || !ok {
 		return err
 	}
+	if ai.StartedID != common.EmptyEventID {
+		// activity was started eagerly on decision completion, there is nothing to dispatch
+		return nil
+	}
 
 	timeout := common.MinInt32(ai.ScheduleToStartTimeout, common.MaxTaskTimeout)
+	attempt := ai.Attempt
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
-	return t.pushActivity(task, timeout)
+	err = t.pushActivity(task, timeout)
+	if err == errAffinityOwnerUnavailable {
+		return t.timeoutAffinityActivity(task, attempt)
+	}
+	return err
+}
+
+// timeoutAffinityActivity times out an activity scheduled to an affinity task list which
+// lost its owner, so that the workflow can schedule the activity to another worker
+func (t *transferQueueActiveTaskExecutor) timeoutAffinityActivity(
+	task *persistenceblobs.TransferTaskInfo,
+	attempt int32,
+) (retError error) {
+
+	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
+		t.getNamespaceIDAndWorkflowExecution(task),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	err = t.updateWorkflowExecution(context, true,
+		func(mutableState mutableState) error {
+			if !mutableState.IsWorkflowExecutionRunning() {
+				return serviceerror.NewNotFound("Workflow execution already completed.")
+			}
+
+			ai, ok := mutableState.GetActivityInfo(task.GetScheduleId())
+			if !ok || ai.StartedID != common.EmptyEventID || ai.Attempt != attempt {
+				return serviceerror.NewNotFound("Pending activity not found.")
+			}
+
+			t.metricsClient.IncCounter(metrics.TransferActiveTaskActivityScope, metrics.AffinityOwnerUnavailableCounter)
+			_, err := mutableState.AddActivityTaskTimedOutEvent(
+				ai.ScheduleID,
+				ai.StartedID,
+				eventpb.TimeoutType_ScheduleToStart,
+				ai.Details,
+			)
+			return err
+		})
+
+	if _, ok := err.(*serviceerror.NotFound); ok {
+		// this could happen if this is a duplicate processing of the task,
+		// or the activity has already been started or timed out.
+		return nil
+	}
+	return err
 }
 
 func (t *transferQueueActiveTaskExecutor) processDecisionTask(
@@ -589,12 +644,15 @@ func (t *transferQueueActiveTaskExecutor) processStartChildExecution(
 	}
 
 	attributes := initiatedEvent.GetStartChildWorkflowExecutionInitiatedEventAttributes()
+	span := newWorkflowSpan(mutableState, "ChildWorkflow.Start", t.shard.GetTimeSource().Now()).
+		setTag(childWorkflowIDSpanTag, attributes.WorkflowId)
 	childRunID, err := t.startWorkflowWithRetry(
 		task,
 		namespace,
 		targetNamespace,
 		childInfo,
 		attributes,
+		span,
 	)
 	if err != nil {
 		t.logger.Debug("Failed to start child workflow execution", tag.Error(err))
@@ -718,6 +776,66 @@ func (t *transferQueueActiveTaskExecutor) processRecordWorkflowStartedOrUpsertHe
 	)
 }
 
+func (t *transferQueueActiveTaskExecutor) processLifecycleEvent(
+	task *persistenceblobs.TransferTaskInfo,
+) (retError error) {
+
+	lifecyclePublisher := t.historyService.lifecyclePublisher
+	if lifecyclePublisher == nil {
+		// tasks are only generated with a publisher, these were generated before it was removed
+		t.logger.Warn("Dropping lifecycle event task without a lifecycle event publisher",
+			tag.WorkflowID(task.GetWorkflowId()),
+			tag.WorkflowEventID(task.GetScheduleId()))
+		return nil
+	}
+
+	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
+		t.getNamespaceIDAndWorkflowExecution(task),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := loadMutableStateForTransferTask(context, task, t.metricsClient, t.logger)
+	if err != nil {
+		return err
+	}
+	if mutableState == nil {
+		return nil
+	}
+
+	namespaceID := primitives.UUID(task.GetNamespaceId()).String()
+	branchToken, err := mutableState.GetCurrentBranchToken()
+	if err != nil {
+		return err
+	}
+	event, err := t.shard.GetEventsCache().getEvent(
+		namespaceID,
+		task.GetWorkflowId(),
+		primitives.UUID(task.GetRunId()).String(),
+		task.GetEventBatchId(),
+		task.GetScheduleId(),
+		branchToken,
+	)
+	if err != nil {
+		return err
+	}
+	ok, err := verifyTaskVersion(t.shard, t.logger, task.GetNamespaceId(), event.GetVersion(), task.Version, task)
+	if err != nil || !ok {
+		return err
+	}
+
+	namespace := mutableState.GetNamespaceEntry().GetInfo().Name
+	lifecycleEvent := newLifecycleEvent(namespace, mutableState.GetExecutionInfo(), event)
+
+	// release the context lock since we no longer need mutable state builder and
+	// the rest of logic is publishing the event, which takes time.
+	release(nil)
+
+	return lifecyclePublisher.Publish(lifecycleEvent)
+}
+
 func (t *transferQueueActiveTaskExecutor) processResetWorkflow(
 	task *persistenceblobs.TransferTaskInfo,
 ) (retError error) {
@@ -1190,6 +1308,7 @@ func (t *transferQueueActiveTaskExecutor) startWorkflowWithRetry(
 	targetNamespace string,
 	childInfo *persistence.ChildExecutionInfo,
 	attributes *eventpb.StartChildWorkflowExecutionInitiatedEventAttributes,
+	span *workflowSpan,
 ) (string, error) {
 
 	now := t.shard.GetTimeSource().Now()
@@ -1230,6 +1349,9 @@ func (t *transferQueueActiveTaskExecutor) startWorkflowWithRetry(
 
 	ctx, cancel := context.WithTimeout(context.Background(), transferActiveTaskDefaultTimeout)
 	defer cancel()
+	// the child continues the trace of the parent through the span of the start request
+	ctx, finish := span.startInContext(ctx)
+	defer finish()
 	var response *historyservice.StartWorkflowExecutionResponse
 	var err error
 	op := func() error {
@@ -55,6 +55,7 @@ import (
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/lifecycle"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
@@ -337,6 +338,63 @@ func (s *transferQueueActiveTaskExecutorSuite) TestProcessActivityTask_Duplicati
 	s.Nil(err)
 }
 
+func (s *transferQueueActiveTaskExecutorSuite) TestProcessActivityTask_StartedEagerly() {
+
+	execution := executionpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                        &commonpb.WorkflowType{Name: workflowType},
+				TaskList:                            &tasklistpb.TaskList{Name: taskListName},
+				ExecutionStartToCloseTimeoutSeconds: 2,
+				TaskStartToCloseTimeoutSeconds:      1,
+			},
+		},
+	)
+	s.Nil(err)
+
+	di := addDecisionTaskScheduledEvent(mutableState)
+	event := addDecisionTaskStartedEvent(mutableState, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(mutableState, di.ScheduleID, di.StartedID, "some random identity")
+
+	taskID := int64(59)
+	activityID := "activity-1"
+	activityType := "some random activity type"
+	event, ai := addActivityTaskScheduledEvent(mutableState, event.GetEventId(), activityID, activityType, taskListName, &commonpb.Payload{}, 1, 1, 1, 1)
+
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		Version:           s.version,
+		NamespaceId:       s.GetNamespaceIDBytes(),
+		TargetNamespaceId: primitives.MustParseUUID(s.targetNamespaceID),
+		WorkflowId:        execution.GetWorkflowId(),
+		RunId:             primitives.MustParseUUID(execution.GetRunId()),
+		TaskId:            taskID,
+		TaskList:          taskListName,
+		TaskType:          persistence.TransferTaskTypeActivityTask,
+		ScheduleId:        event.GetEventId(),
+	}
+
+	// the activity is started on decision completion, so matching is not called
+	event = addActivityTaskStartedEvent(mutableState, event.GetEventId(), "")
+	ai.StartedID = event.GetEventId()
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, event.GetEventId(), event.GetVersion())
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+
+	err = s.transferQueueActiveTaskExecutor.execute(transferTask, true)
+	s.Nil(err)
+}
+
 func (s *transferQueueActiveTaskExecutorSuite) TestProcessDecisionTask_FirstDecision() {
 
 	execution := executionpb.WorkflowExecution{
@@ -1797,6 +1855,82 @@ func (s *transferQueueActiveTaskExecutorSuite) TestProcessRecordWorkflowStartedT
 	s.Nil(err)
 }
 
+func (s *transferQueueActiveTaskExecutorSuite) TestProcessLifecycleEvent() {
+
+	execution := executionpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+
+	event, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                        &commonpb.WorkflowType{Name: workflowType},
+				TaskList:                            &tasklistpb.TaskList{Name: taskListName},
+				ExecutionStartToCloseTimeoutSeconds: 2,
+				TaskStartToCloseTimeoutSeconds:      1,
+			},
+		},
+	)
+	s.Nil(err)
+
+	taskID := int64(59)
+	di := addDecisionTaskScheduledEvent(mutableState)
+
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		Version:      s.version,
+		NamespaceId:  s.GetNamespaceIDBytes(),
+		WorkflowId:   execution.GetWorkflowId(),
+		RunId:        primitives.MustParseUUID(execution.GetRunId()),
+		TaskId:       taskID,
+		TaskType:     persistence.TransferTaskTypeLifecycleEvent,
+		ScheduleId:   event.GetEventId(),
+		EventBatchId: event.GetEventId(),
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, di.ScheduleID, di.Version)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+
+	lifecyclePublisher := lifecycle.NewMockPublisher(s.controller)
+	s.transferQueueActiveTaskExecutor.historyService.lifecyclePublisher = lifecyclePublisher
+	lifecyclePublisher.EXPECT().Publish(gomock.Any()).DoAndReturn(func(lifecycleEvent *lifecycle.Event) error {
+		s.Equal(lifecycle.EventTypeWorkflowStarted, lifecycleEvent.Type)
+		s.Equal(s.namespace, lifecycleEvent.Namespace)
+		s.Equal(execution.GetWorkflowId(), lifecycleEvent.WorkflowID)
+		s.Equal(execution.GetRunId(), lifecycleEvent.RunID)
+		s.Equal(workflowType, lifecycleEvent.WorkflowType)
+		s.Equal(event.GetEventId(), lifecycleEvent.EventID)
+		return nil
+	}).Times(1)
+
+	err = s.transferQueueActiveTaskExecutor.execute(transferTask, true)
+	s.Nil(err)
+}
+
+func (s *transferQueueActiveTaskExecutorSuite) TestProcessLifecycleEvent_NoPublisher() {
+
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		Version:      s.version,
+		NamespaceId:  s.GetNamespaceIDBytes(),
+		WorkflowId:   "some random workflow ID",
+		RunId:        primitives.MustParseUUID(uuid.New()),
+		TaskId:       int64(59),
+		TaskType:     persistence.TransferTaskTypeLifecycleEvent,
+		ScheduleId:   common.FirstEventID,
+		EventBatchId: common.FirstEventID,
+	}
+
+	// the task is dropped without loading the workflow
+	err := s.transferQueueActiveTaskExecutor.execute(transferTask, true)
+	s.Nil(err)
+}
+
 func (s *transferQueueActiveTaskExecutorSuite) TestProcessUpsertWorkflowSearchAttributes() {
 
 	execution := executionpb.WorkflowExecution{
@@ -33,6 +33,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/client/matching"
@@ -45,7 +46,8 @@ import (
 )
 
 var (
-	errUnknownTransferTask = errors.New("Unknown transfer task")
+	errUnknownTransferTask      = errors.New("Unknown transfer task")
+	errAffinityOwnerUnavailable = errors.New("affinity task list owner is unavailable")
 )
 
 type (
@@ -55,6 +57,7 @@ type (
 		NotifyNewTask(clusterName string, transferTasks []persistence.Task)
 		LockTaskProcessing()
 		UnlockTaskPrrocessing()
+		DescribeQueues() []*historygenpb.QueueState
 	}
 
 	taskFilter func(task queueTaskInfo) (bool, error)
@@ -277,6 +280,16 @@ func (t *transferQueueProcessorImpl) UnlockTaskPrrocessing() {
 	t.taskAllocator.unlock()
 }
 
+// DescribeQueues returns the state of the active queue and of the standby queues of the shard
+func (t *transferQueueProcessorImpl) DescribeQueues() []*historygenpb.QueueState {
+	states := []*historygenpb.QueueState{t.activeTaskProcessor.queueAckMgr.describeQueue()}
+	for _, standbyTaskProcessor := range t.standbyTaskProcessors {
+		states = append(states, standbyTaskProcessor.queueAckMgr.describeQueue())
+	}
+	sortQueueStates(states, t.currentClusterName)
+	return states
+}
+
 func (t *transferQueueProcessorImpl) completeTransferLoop() {
 	timer := time.NewTimer(t.config.TransferProcessorCompleteTransferInterval())
 	defer timer.Stop()
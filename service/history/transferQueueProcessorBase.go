@@ -158,6 +158,11 @@ func getTransferTaskMetricsScope(
 			return metrics.TransferActiveTaskUpsertWorkflowSearchAttributesScope
 		}
 		return metrics.TransferStandbyTaskUpsertWorkflowSearchAttributesScope
+	case persistence.TransferTaskTypeLifecycleEvent:
+		if isActive {
+			return metrics.TransferActiveTaskLifecycleEventScope
+		}
+		return metrics.TransferStandbyTaskLifecycleEventScope
 	default:
 		if isActive {
 			return metrics.TransferActiveQueueProcessorScope
@@ -32,6 +32,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
 	persistence "github.com/temporalio/temporal/common/persistence"
 )
 
@@ -129,3 +130,17 @@ func (mr *MocktransferQueueProcessorMockRecorder) UnlockTaskPrrocessing() *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockTaskPrrocessing", reflect.TypeOf((*MocktransferQueueProcessor)(nil).UnlockTaskPrrocessing))
 }
+
+// DescribeQueues mocks base method.
+func (m *MocktransferQueueProcessor) DescribeQueues() []*historygenpb.QueueState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeQueues")
+	ret0, _ := ret[0].([]*historygenpb.QueueState)
+	return ret0
+}
+
+// DescribeQueues indicates an expected call of DescribeQueues.
+func (mr *MocktransferQueueProcessorMockRecorder) DescribeQueues() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeQueues", reflect.TypeOf((*MocktransferQueueProcessor)(nil).DescribeQueues))
+}
@@ -132,6 +132,7 @@ func newTransferQueueStandbyProcessor(
 		options,
 		processor,
 		shard.GetTransferClusterAckLevel(clusterName),
+		clusterName,
 		logger,
 	)
 
@@ -189,6 +190,14 @@ func (t *transferQueueStandbyProcessorImpl) complete(
 	t.queueProcessorBase.complete(taskInfo.task)
 }
 
+func (t *transferQueueStandbyProcessorImpl) recordFailure(
+	taskInfo *taskInfo,
+	err error,
+) {
+
+	t.queueProcessorBase.recordFailure(taskInfo.task, taskInfo.attempt, err)
+}
+
 func (t *transferQueueStandbyProcessorImpl) process(
 	taskInfo *taskInfo,
 ) (int, error) {
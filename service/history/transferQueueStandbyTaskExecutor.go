@@ -111,6 +111,9 @@ func (t *transferQueueStandbyTaskExecutor) execute(
 		return nil
 	case persistence.TransferTaskTypeUpsertWorkflowSearchAttributes:
 		return t.processUpsertWorkflowSearchAttributes(transferTask)
+	case persistence.TransferTaskTypeLifecycleEvent:
+		// lifecycle events are only published by the active cluster
+		return nil
 	default:
 		return errUnknownTransferTask
 	}
@@ -525,10 +528,16 @@ func (t *transferQueueStandbyTaskExecutor) pushActivity(
 
 	pushActivityInfo := postActionInfo.(*pushActivityToMatchingInfo)
 	timeout := common.MinInt32(pushActivityInfo.activityScheduleToStartTimeout, common.MaxTaskTimeout)
-	return t.transferQueueTaskExecutorBase.pushActivity(
+	err := t.transferQueueTaskExecutorBase.pushActivity(
 		task.(*persistenceblobs.TransferTaskInfo),
 		timeout,
 	)
+	if err == errAffinityOwnerUnavailable {
+		// standby cluster cannot update mutable state, the activity
+		// will time out once the namespace fails over
+		return nil
+	}
+	return err
 }
 
 func (t *transferQueueStandbyTaskExecutor) pushDecision(
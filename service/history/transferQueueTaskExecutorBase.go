@@ -107,7 +107,7 @@ func (t *transferQueueTaskExecutorBase) pushActivity(
 		t.logger.Fatal("Cannot process non activity task", tag.TaskType(task.GetTaskType()))
 	}
 
-	_, err := t.matchingClient.AddActivityTask(ctx, &m.AddActivityTaskRequest{
+	resp, err := t.matchingClient.AddActivityTask(ctx, &m.AddActivityTaskRequest{
 		NamespaceId:       primitives.UUIDString(task.GetTargetNamespaceId()),
 		SourceNamespaceId: primitives.UUIDString(task.GetNamespaceId()),
 		Execution: &executionpb.WorkflowExecution{
@@ -118,8 +118,14 @@ func (t *transferQueueTaskExecutorBase) pushActivity(
 		ScheduleId:                    task.GetScheduleId(),
 		ScheduleToStartTimeoutSeconds: activityScheduleToStartTimeout,
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	if resp.GetAffinityOwnerUnavailable() {
+		return errAffinityOwnerUnavailable
+	}
+	return nil
 }
 
 func (t *transferQueueTaskExecutorBase) pushDecision(
@@ -789,6 +789,7 @@ func (c *workflowExecutionContextImpl) updateWorkflowExecutionWithNew(
 		if event, err := c.mutableState.GetCompletionEvent(); err == nil {
 			taskList := currentWorkflow.ExecutionInfo.TaskList
 			emitWorkflowCompletionStats(c.metricsClient, namespace, taskList, event)
+			newWorkflowCompletionSpan(c.mutableState, event).recordAt(time.Unix(0, event.GetTimestamp()))
 		}
 	}
 
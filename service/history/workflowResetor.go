@@ -516,6 +516,7 @@ func (w *workflowResetorImpl) replayReceivedSignals(
 			PageSize:      defaultHistoryPageSize,
 			NextPageToken: nextPageToken,
 			ShardID:       &shardId,
+			NamespaceID:   continueMutableState.GetExecutionInfo().NamespaceID,
 		}
 		for {
 			var readResp *persistence.ReadHistoryBranchByBatchResponse
@@ -638,6 +639,7 @@ func (w *workflowResetorImpl) replayHistoryEvents(
 		PageSize:      defaultHistoryPageSize,
 		NextPageToken: nextPageToken,
 		ShardID:       &shardId,
+		NamespaceID:   namespaceID,
 	}
 	var resetMutableState *mutableStateBuilder
 	var lastBatch []*eventpb.HistoryEvent
@@ -914,6 +916,7 @@ func (w *workflowResetorImpl) replicateResetEvent(
 		PageSize:      defaultHistoryPageSize,
 		NextPageToken: nextPageToken,
 		ShardID:       &shardId,
+		NamespaceID:   namespaceID,
 	}
 	for {
 		var readResp *persistence.ReadHistoryBranchByBatchResponse
@@ -264,6 +264,7 @@ func (s *resetorSuite) TestResetWorkflowExecution_NoReplication() {
 		PageSize:      defaultHistoryPageSize,
 		NextPageToken: nil,
 		ShardID:       &s.shardID,
+		NamespaceID:   namespaceID,
 	}
 
 	taskList := &tasklistpb.TaskList{
@@ -943,6 +944,7 @@ func (s *resetorSuite) TestResetWorkflowExecution_NoReplication_WithRequestCance
 		PageSize:      defaultHistoryPageSize,
 		NextPageToken: nil,
 		ShardID:       &s.shardID,
+		NamespaceID:   namespaceID,
 	}
 
 	taskList := &tasklistpb.TaskList{
@@ -1537,6 +1539,7 @@ func (s *resetorSuite) TestResetWorkflowExecution_Replication_WithTerminatingCur
 		PageSize:      defaultHistoryPageSize,
 		NextPageToken: nil,
 		ShardID:       &s.shardID,
+		NamespaceID:   namespaceID,
 	}
 
 	taskList := &tasklistpb.TaskList{
@@ -2238,6 +2241,7 @@ func (s *resetorSuite) TestResetWorkflowExecution_Replication_NotActive() {
 		PageSize:      defaultHistoryPageSize,
 		NextPageToken: nil,
 		ShardID:       &s.shardID,
+		NamespaceID:   namespaceID,
 	}
 
 	taskList := &tasklistpb.TaskList{
@@ -2836,6 +2840,7 @@ func (s *resetorSuite) TestResetWorkflowExecution_Replication_NoTerminatingCurre
 		PageSize:      defaultHistoryPageSize,
 		NextPageToken: nil,
 		ShardID:       &s.shardID,
+		NamespaceID:   namespaceID,
 	}
 
 	taskList := &tasklistpb.TaskList{
@@ -3510,6 +3515,7 @@ func (s *resetorSuite) TestApplyReset() {
 		PageSize:      defaultHistoryPageSize,
 		NextPageToken: nil,
 		ShardID:       &s.shardID,
+		NamespaceID:   namespaceID,
 	}
 
 	taskList := &tasklistpb.TaskList{
@@ -519,6 +519,7 @@ func (r *workflowResetterImpl) reapplyWorkflowEvents(
 	//  after the above change, this API do not have to return the continue as new run ID
 
 	iter := collection.NewPagingIterator(r.getPaginationFn(
+		mutableState.GetExecutionInfo().NamespaceID,
 		firstEventID,
 		nextEventID,
 		branchToken,
@@ -571,6 +572,7 @@ func (r *workflowResetterImpl) reapplyEvents(
 }
 
 func (r *workflowResetterImpl) getPaginationFn(
+	namespaceID string,
 	firstEventID int64,
 	nextEventID int64,
 	branchToken []byte,
@@ -581,6 +583,7 @@ func (r *workflowResetterImpl) getPaginationFn(
 		_, historyBatches, token, _, err := PaginateHistory(
 			r.historyV2Mgr,
 			true,
+			namespaceID,
 			branchToken,
 			firstEventID,
 			nextEventID,
@@ -428,6 +428,7 @@ func (s *workflowResetterSuite) TestReapplyContinueAsNewWorkflowEvents() {
 		PageSize:      nDCDefaultPageSize,
 		NextPageToken: nil,
 		ShardID:       &shardId,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       []*eventpb.History{{Events: baseEvents}},
 		NextPageToken: nil,
@@ -441,6 +442,7 @@ func (s *workflowResetterSuite) TestReapplyContinueAsNewWorkflowEvents() {
 		PageSize:      nDCDefaultPageSize,
 		NextPageToken: nil,
 		ShardID:       &shardId,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       []*eventpb.History{{Events: newEvents}},
 		NextPageToken: nil,
@@ -457,6 +459,9 @@ func (s *workflowResetterSuite) TestReapplyContinueAsNewWorkflowEvents() {
 	_, _ = s.workflowResetter.historyCache.PutIfNotExist(resetContextCacheKey, resetContext)
 
 	mutableState := NewMockmutableState(s.controller)
+	mutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{
+		NamespaceID: s.namespaceID,
+	}).AnyTimes()
 
 	err := s.workflowResetter.reapplyContinueAsNewWorkflowEvents(
 		ctx,
@@ -513,12 +518,16 @@ func (s *workflowResetterSuite) TestReapplyWorkflowEvents() {
 		PageSize:      nDCDefaultPageSize,
 		NextPageToken: nil,
 		ShardID:       &shardId,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       []*eventpb.History{{Events: events}},
 		NextPageToken: nil,
 	}, nil).Once()
 
 	mutableState := NewMockmutableState(s.controller)
+	mutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{
+		NamespaceID: s.namespaceID,
+	}).AnyTimes()
 
 	nextRunID, err := s.workflowResetter.reapplyWorkflowEvents(
 		mutableState,
@@ -617,6 +626,7 @@ func (s *workflowResetterSuite) TestPagination() {
 		PageSize:      nDCDefaultPageSize,
 		NextPageToken: nil,
 		ShardID:       &shardId,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       history1,
 		NextPageToken: pageToken,
@@ -629,13 +639,14 @@ func (s *workflowResetterSuite) TestPagination() {
 		PageSize:      nDCDefaultPageSize,
 		NextPageToken: pageToken,
 		ShardID:       &shardId,
+		NamespaceID:   s.namespaceID,
 	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
 		History:       history2,
 		NextPageToken: nil,
 		Size:          67890,
 	}, nil).Once()
 
-	paginationFn := s.workflowResetter.getPaginationFn(firstEventID, nextEventID, branchToken)
+	paginationFn := s.workflowResetter.getPaginationFn(s.namespaceID, firstEventID, nextEventID, branchToken)
 	iter := collection.NewPagingIterator(paginationFn)
 
 	var result []*eventpb.History
@@ -0,0 +1,329 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+const (
+	// traceHeaderKey is the key of the workflow header field carrying the span context of the
+	// workflow, the field holds the key-value pairs written by the tracer as a JSON object
+	traceHeaderKey = "temporal-trace-context"
+
+	workflowIDSpanTag      = "workflow.id"
+	runIDSpanTag           = "workflow.run_id"
+	workflowTypeSpanTag    = "workflow.type"
+	childWorkflowIDSpanTag = "child_workflow.id"
+	activityIDSpanTag      = "activity.id"
+	timerIDSpanTag         = "timer.id"
+	attemptSpanTag         = "attempt"
+	outcomeSpanTag         = "outcome"
+
+	spanOutcomeCompleted      = "completed"
+	spanOutcomeFailed         = "failed"
+	spanOutcomeCanceled       = "canceled"
+	spanOutcomeTimedOut       = "timed out"
+	spanOutcomeTerminated     = "terminated"
+	spanOutcomeContinuedAsNew = "continued as new"
+)
+
+type (
+	// workflowSpan is a span of the lifecycle of a workflow, it is built while the workflow is
+	// locked and recorded once the update of the workflow is persisted
+	workflowSpan struct {
+		parent    opentracing.SpanContext
+		operation string
+		startTime time.Time
+		tags      opentracing.Tags
+	}
+)
+
+// injectWorkflowTrace adds the span context of the request to the header of a workflow being
+// started, so the spans of the workflow continue the trace of the caller of StartWorkflowExecution.
+// A header already carrying a span context is kept as is.
+func injectWorkflowTrace(
+	ctx context.Context,
+	header *commonpb.Header,
+) *commonpb.Header {
+
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return header
+	}
+	if extractSpanContext(span.Tracer(), header) != nil {
+		return header
+	}
+	return injectSpanContext(span.Tracer(), span.Context(), header)
+}
+
+// continueWorkflowTrace adds the span context of the previous run to the header of a workflow
+// continued as new, unless the header already carries one
+func continueWorkflowTrace(
+	previousExecutionState mutableState,
+	header *commonpb.Header,
+) *commonpb.Header {
+
+	if !opentracing.IsGlobalTracerRegistered() {
+		return header
+	}
+	tracer := opentracing.GlobalTracer()
+	if extractSpanContext(tracer, header) != nil {
+		return header
+	}
+	parent := previousExecutionState.GetSpanContext()
+	if parent == nil {
+		return header
+	}
+	return injectSpanContext(tracer, parent, header)
+}
+
+// extractSpanContext returns the span context carried in the header, nil if it carries none
+func extractSpanContext(
+	tracer opentracing.Tracer,
+	header *commonpb.Header,
+) opentracing.SpanContext {
+
+	data, ok := header.GetFields()[traceHeaderKey]
+	if !ok {
+		return nil
+	}
+	carrier := opentracing.TextMapCarrier{}
+	if err := json.Unmarshal(data, &carrier); err != nil {
+		return nil
+	}
+	spanContext, err := tracer.Extract(opentracing.TextMap, carrier)
+	if err != nil {
+		return nil
+	}
+	return spanContext
+}
+
+// injectSpanContext returns a copy of the header carrying the span context, the header itself if
+// the span context cannot be written
+func injectSpanContext(
+	tracer opentracing.Tracer,
+	spanContext opentracing.SpanContext,
+	header *commonpb.Header,
+) *commonpb.Header {
+
+	carrier := opentracing.TextMapCarrier{}
+	if err := tracer.Inject(spanContext, opentracing.TextMap, carrier); err != nil {
+		return header
+	}
+	data, err := json.Marshal(carrier)
+	if err != nil {
+		return header
+	}
+
+	fields := make(map[string][]byte, len(header.GetFields())+1)
+	for k, v := range header.GetFields() {
+		fields[k] = v
+	}
+	fields[traceHeaderKey] = data
+	return &commonpb.Header{Fields: fields}
+}
+
+// newWorkflowSpan creates a span of the workflow starting at the given time, nil if the workflow
+// is not traced
+func newWorkflowSpan(
+	mutableState mutableState,
+	operation string,
+	startTime time.Time,
+) *workflowSpan {
+
+	parent := mutableState.GetSpanContext()
+	if parent == nil {
+		return nil
+	}
+	executionInfo := mutableState.GetExecutionInfo()
+	return &workflowSpan{
+		parent:    parent,
+		operation: operation,
+		startTime: startTime,
+		tags: opentracing.Tags{
+			workflowIDSpanTag:   executionInfo.WorkflowID,
+			runIDSpanTag:        executionInfo.RunID,
+			workflowTypeSpanTag: executionInfo.WorkflowTypeName,
+		},
+	}
+}
+
+func newActivityAttemptSpan(
+	mutableState mutableState,
+	activityInfo *persistence.ActivityInfo,
+	outcome string,
+) *workflowSpan {
+
+	span := newWorkflowSpan(mutableState, "ActivityTask.Attempt", activityInfo.StartedTime)
+	return span.
+		setTag(activityIDSpanTag, activityInfo.ActivityID).
+		setTag(attemptSpanTag, activityInfo.Attempt).
+		setTag(outcomeSpanTag, outcome)
+}
+
+// newActivityTimeoutSpan creates the span of an activity which timed out, the attempt if the
+// activity started and the wait for a worker otherwise
+func newActivityTimeoutSpan(
+	mutableState mutableState,
+	activityInfo *persistence.ActivityInfo,
+) *workflowSpan {
+
+	if activityInfo.StartedID != common.EmptyEventID {
+		return newActivityAttemptSpan(mutableState, activityInfo, spanOutcomeTimedOut)
+	}
+	span := newWorkflowSpan(mutableState, "ActivityTask.ScheduleToStart", activityInfo.ScheduledTime)
+	return span.
+		setTag(activityIDSpanTag, activityInfo.ActivityID).
+		setTag(attemptSpanTag, activityInfo.Attempt).
+		setTag(outcomeSpanTag, spanOutcomeTimedOut)
+}
+
+// newTimerSpan creates the span of a user timer, timers started before their start time was
+// recorded start at their expiry time
+func newTimerSpan(
+	mutableState mutableState,
+	timerInfo *persistenceblobs.TimerInfo,
+) *workflowSpan {
+
+	startTime, err := types.TimestampFromProto(timerInfo.GetStartedTime())
+	if timerInfo.GetStartedTime() == nil || err != nil {
+		startTime, _ = types.TimestampFromProto(timerInfo.GetExpiryTime())
+	}
+	span := newWorkflowSpan(mutableState, "Timer", startTime)
+	return span.setTag(timerIDSpanTag, timerInfo.GetTimerId())
+}
+
+// newWorkflowCompletionSpan creates the span of a workflow run, from its start to the given
+// completion event
+func newWorkflowCompletionSpan(
+	mutableState mutableState,
+	completionEvent *eventpb.HistoryEvent,
+) *workflowSpan {
+
+	var outcome string
+	switch completionEvent.GetEventType() {
+	case eventpb.EventType_WorkflowExecutionCompleted:
+		outcome = spanOutcomeCompleted
+	case eventpb.EventType_WorkflowExecutionFailed:
+		outcome = spanOutcomeFailed
+	case eventpb.EventType_WorkflowExecutionCanceled:
+		outcome = spanOutcomeCanceled
+	case eventpb.EventType_WorkflowExecutionTimedOut:
+		outcome = spanOutcomeTimedOut
+	case eventpb.EventType_WorkflowExecutionTerminated:
+		outcome = spanOutcomeTerminated
+	case eventpb.EventType_WorkflowExecutionContinuedAsNew:
+		outcome = spanOutcomeContinuedAsNew
+	}
+	span := newWorkflowSpan(mutableState, "Workflow", mutableState.GetExecutionInfo().StartTimestamp)
+	return span.setTag(outcomeSpanTag, outcome)
+}
+
+func newDecisionSpan(
+	mutableState mutableState,
+	decision *decisionInfo,
+	operation string,
+	startTimestamp int64,
+) *workflowSpan {
+
+	span := newWorkflowSpan(mutableState, operation, time.Unix(0, startTimestamp))
+	return span.setTag(attemptSpanTag, decision.Attempt)
+}
+
+func recordWorkflowSpans(
+	spans []*workflowSpan,
+) {
+
+	for _, span := range spans {
+		span.record()
+	}
+}
+
+func (s *workflowSpan) setTag(
+	key string,
+	value interface{},
+) *workflowSpan {
+
+	if s != nil {
+		s.tags[key] = value
+	}
+	return s
+}
+
+// start starts the span, the caller has to finish it
+func (s *workflowSpan) start() opentracing.Span {
+	return opentracing.GlobalTracer().StartSpan(
+		s.operation,
+		opentracing.FollowsFrom(s.parent),
+		opentracing.StartTime(s.startTime),
+		s.tags,
+	)
+}
+
+// startInContext starts the span as the active span of the returned context, the returned function
+// finishes it
+func (s *workflowSpan) startInContext(
+	ctx context.Context,
+) (context.Context, func()) {
+
+	if s == nil {
+		return ctx, func() {}
+	}
+	span := s.start()
+	return opentracing.ContextWithSpan(ctx, span), span.Finish
+}
+
+// record records the span finishing now
+func (s *workflowSpan) record() {
+	s.recordAt(time.Now())
+}
+
+// recordAt records the span finishing at the given time
+func (s *workflowSpan) recordAt(
+	finishTime time.Time,
+) {
+
+	if s == nil {
+		return
+	}
+	span := s.start()
+	if s.tags[outcomeSpanTag] == spanOutcomeFailed || s.tags[outcomeSpanTag] == spanOutcomeTimedOut {
+		ext.Error.Set(span, true)
+	}
+	span.FinishWithOptions(opentracing.FinishOptions{FinishTime: finishTime})
+}
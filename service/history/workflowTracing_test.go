@@ -0,0 +1,179 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	workflowTracingSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller       *gomock.Controller
+		mockMutableState *MockmutableState
+		tracer           *mocktracer.MockTracer
+	}
+)
+
+func TestWorkflowTracingSuite(t *testing.T) {
+	s := new(workflowTracingSuite)
+	suite.Run(t, s)
+}
+
+func (s *workflowTracingSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+	s.mockMutableState = NewMockmutableState(s.controller)
+	s.tracer = mocktracer.New()
+	opentracing.SetGlobalTracer(s.tracer)
+}
+
+func (s *workflowTracingSuite) TearDownTest() {
+	opentracing.SetGlobalTracer(opentracing.NoopTracer{})
+	s.controller.Finish()
+}
+
+func (s *workflowTracingSuite) TestInjectWorkflowTrace() {
+	root := s.tracer.StartSpan("root")
+	ctx := opentracing.ContextWithSpan(context.Background(), root)
+	header := &commonpb.Header{Fields: map[string][]byte{"key": []byte("value")}}
+
+	injected := injectWorkflowTrace(ctx, header)
+	s.Len(injected.GetFields(), 2)
+	s.Equal([]byte("value"), injected.GetFields()["key"])
+	s.Contains(injected.GetFields(), traceHeaderKey)
+	s.Len(header.GetFields(), 1)
+
+	spanContext := extractSpanContext(s.tracer, injected)
+	s.Equal(root.Context(), spanContext)
+}
+
+func (s *workflowTracingSuite) TestInjectWorkflowTrace_KeepsSpanContext() {
+	first := s.tracer.StartSpan("first")
+	header := injectWorkflowTrace(opentracing.ContextWithSpan(context.Background(), first), nil)
+
+	second := s.tracer.StartSpan("second")
+	injected := injectWorkflowTrace(opentracing.ContextWithSpan(context.Background(), second), header)
+	s.Equal(header, injected)
+	s.Equal(first.Context(), extractSpanContext(s.tracer, injected))
+}
+
+func (s *workflowTracingSuite) TestInjectWorkflowTrace_NoSpan() {
+	header := &commonpb.Header{Fields: map[string][]byte{"key": []byte("value")}}
+	s.Equal(header, injectWorkflowTrace(context.Background(), header))
+}
+
+func (s *workflowTracingSuite) TestExtractSpanContext_Corrupted() {
+	header := &commonpb.Header{Fields: map[string][]byte{traceHeaderKey: []byte("not json")}}
+	s.Nil(extractSpanContext(s.tracer, header))
+	s.Nil(extractSpanContext(s.tracer, nil))
+}
+
+func (s *workflowTracingSuite) TestContinueWorkflowTrace() {
+	previous := s.tracer.StartSpan("previous")
+	s.mockMutableState.EXPECT().GetSpanContext().Return(previous.Context()).Times(1)
+
+	header := continueWorkflowTrace(s.mockMutableState, nil)
+	s.Equal(previous.Context(), extractSpanContext(s.tracer, header))
+}
+
+func (s *workflowTracingSuite) TestNewWorkflowSpan() {
+	parent := s.tracer.StartSpan("parent")
+	s.mockMutableState.EXPECT().GetSpanContext().Return(parent.Context()).Times(1)
+	s.mockMutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{
+		WorkflowID:       "workflow-id",
+		RunID:            "run-id",
+		WorkflowTypeName: "workflow-type",
+	}).Times(1)
+
+	startTime := time.Now().Add(-time.Minute)
+	span := newWorkflowSpan(s.mockMutableState, "Timer", startTime).setTag(outcomeSpanTag, spanOutcomeTimedOut)
+	span.record()
+
+	spans := s.tracer.FinishedSpans()
+	s.Len(spans, 1)
+	s.Equal("Timer", spans[0].OperationName)
+	s.Equal(parent.Context().(mocktracer.MockSpanContext).SpanID, spans[0].ParentID)
+	s.Equal(startTime, spans[0].StartTime)
+	s.Equal("workflow-id", spans[0].Tag(workflowIDSpanTag))
+	s.Equal("run-id", spans[0].Tag(runIDSpanTag))
+	s.Equal("workflow-type", spans[0].Tag(workflowTypeSpanTag))
+	s.Equal(true, spans[0].Tag("error"))
+}
+
+func (s *workflowTracingSuite) TestNewWorkflowSpan_NotTraced() {
+	s.mockMutableState.EXPECT().GetSpanContext().Return(nil).Times(1)
+
+	span := newWorkflowSpan(s.mockMutableState, "Timer", time.Now()).setTag(timerIDSpanTag, "timer-id")
+	s.Nil(span)
+	span.record()
+	s.Empty(s.tracer.FinishedSpans())
+}
+
+func (s *workflowTracingSuite) TestGetSpanContext_ReadsStartEventOnce() {
+	root := s.tracer.StartSpan("root")
+	header := injectSpanContext(s.tracer, root.Context(), nil)
+	branchToken := []byte("branch-token")
+	executionInfo := &persistence.WorkflowExecutionInfo{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  "workflow-id",
+		RunID:       "run-id",
+		BranchToken: branchToken,
+	}
+	mockEventsCache := NewMockeventsCache(s.controller)
+	mockEventsCache.EXPECT().getEvent(
+		testNamespaceID, "workflow-id", "run-id", common.FirstEventID, common.FirstEventID, branchToken,
+	).Return(&eventpb.HistoryEvent{
+		EventId:   common.FirstEventID,
+		EventType: eventpb.EventType_WorkflowExecutionStarted,
+		Attributes: &eventpb.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+			WorkflowExecutionStartedEventAttributes: &eventpb.WorkflowExecutionStartedEventAttributes{
+				Header: header,
+			},
+		},
+	}, nil).Times(1)
+	msBuilder := &mutableStateBuilder{
+		executionInfo: executionInfo,
+		eventsCache:   mockEventsCache,
+	}
+
+	s.Equal(root.Context(), msBuilder.GetSpanContext())
+	s.Equal(root.Context(), msBuilder.GetSpanContext())
+}
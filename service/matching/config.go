@@ -29,6 +29,7 @@ import (
 
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
@@ -40,6 +41,7 @@ type (
 		EnableSyncMatch         dynamicconfig.BoolPropertyFnWithTaskListInfoFilters
 		RPS                     dynamicconfig.IntPropertyFn
 		ShutdownDrainDuration   dynamicconfig.DurationPropertyFn
+		EnableTaskListHandoff   dynamicconfig.BoolPropertyFn
 
 		// taskListManager configuration
 		RangeSize                    int64
@@ -54,6 +56,14 @@ type (
 		ForwarderMaxRatePerSecond    dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 		ForwarderMaxChildrenPerNode  dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 
+		// per poller dispatch limits
+		MaxOutstandingTasksPerPoller     dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		MaxOutstandingTasksPerPollerHost dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		PollerOutstandingTaskTTL         dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
+
+		// affinity task list configuration
+		AffinityOwnerTimeout dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
+
 		// Time to hold a poll request before returning an empty response if there are no tasks
 		LongPollExpirationInterval dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
 		MinTaskThrottlingBurstSize dynamicconfig.IntPropertyFnWithTaskListInfoFilters
@@ -64,6 +74,10 @@ type (
 		MaxTaskBatchSize                dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 
 		ThrottledLogRPS dynamicconfig.IntPropertyFn
+		// RPCRequestLogSampleRate is the fraction of successful gRPC requests that are logged
+		RPCRequestLogSampleRate dynamicconfig.FloatPropertyFn
+		// TypeTagLimiter bounds the workflow and activity type tags of the metrics of a namespace
+		TypeTagLimiter *metrics.TypeTagLimiter
 	}
 
 	forwarderConfig struct {
@@ -90,6 +104,12 @@ type (
 		MaxTaskBatchSize                func() int
 		NumWritePartitions              func() int
 		NumReadPartitions               func() int
+		// per poller dispatch limits
+		MaxOutstandingTasksPerPoller     func() int
+		MaxOutstandingTasksPerPollerHost func() int
+		PollerOutstandingTaskTTL         func() time.Duration
+		// affinity task list configuration
+		AffinityOwnerTimeout func() time.Duration
 	}
 )
 
@@ -111,6 +131,7 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		OutstandingTaskAppendsThreshold: dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingOutstandingTaskAppendsThreshold, 250),
 		MaxTaskBatchSize:                dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxTaskBatchSize, 100),
 		ThrottledLogRPS:                 dc.GetIntProperty(dynamicconfig.MatchingThrottledLogRPS, 20),
+		RPCRequestLogSampleRate:         dc.GetFloat64Property(dynamicconfig.RPCRequestLogSampleRate, 0),
 		NumTasklistWritePartitions:      dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingNumTasklistWritePartitions, 1),
 		NumTasklistReadPartitions:       dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingNumTasklistReadPartitions, 1),
 		ForwarderMaxOutstandingPolls:    dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingForwarderMaxOutstandingPolls, 1),
@@ -118,6 +139,19 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		ForwarderMaxRatePerSecond:       dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingForwarderMaxRatePerSecond, 10),
 		ForwarderMaxChildrenPerNode:     dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingForwarderMaxChildrenPerNode, 20),
 		ShutdownDrainDuration:           dc.GetDurationProperty(dynamicconfig.MatchingShutdownDrainDuration, 0),
+		EnableTaskListHandoff:           dc.GetBoolProperty(dynamicconfig.MatchingEnableTaskListHandoff, true),
+
+		MaxOutstandingTasksPerPoller:     dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxOutstandingTasksPerPoller, 0),
+		MaxOutstandingTasksPerPollerHost: dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxOutstandingTasksPerHost, 0),
+		PollerOutstandingTaskTTL:         dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingPollerOutstandingTaskTTL, time.Minute),
+		AffinityOwnerTimeout:             dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingAffinityOwnerTimeout, time.Minute),
+
+		TypeTagLimiter: metrics.NewTypeTagLimiter(
+			dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableTypeTaggedMetrics, false),
+			dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.WorkflowTypeTaggedMetricsAllowlist, ""),
+			dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.ActivityTypeTaggedMetricsAllowlist, ""),
+			dc.GetIntPropertyFilteredByNamespace(dynamicconfig.TypeTaggedMetricsLimit, 20),
+		),
 	}
 }
 
@@ -168,6 +202,18 @@ func newTaskListConfig(id *taskListID, config *Config, namespaceCache cache.Name
 		NumReadPartitions: func() int {
 			return common.MaxInt(1, config.NumTasklistReadPartitions(namespace, taskListName, taskType))
 		},
+		MaxOutstandingTasksPerPoller: func() int {
+			return config.MaxOutstandingTasksPerPoller(namespace, taskListName, taskType)
+		},
+		MaxOutstandingTasksPerPollerHost: func() int {
+			return config.MaxOutstandingTasksPerPollerHost(namespace, taskListName, taskType)
+		},
+		PollerOutstandingTaskTTL: func() time.Duration {
+			return config.PollerOutstandingTaskTTL(namespace, taskListName, taskType)
+		},
+		AffinityOwnerTimeout: func() time.Duration {
+			return config.AffinityOwnerTimeout(namespace, taskListName, taskType)
+		},
 		forwarderConfig: forwarderConfig{
 			ForwarderMaxOutstandingPolls: func() int {
 				return config.ForwarderMaxOutstandingPolls(namespace, taskListName, taskType)
@@ -36,7 +36,8 @@ import (
 
 type handlerContext struct {
 	context.Context
-	scope metrics.Scope
+	namespace string
+	scope     metrics.Scope
 }
 
 var stickyTaskListMetricTag = metrics.TaskListTag("__sticky__")
@@ -49,8 +50,9 @@ func newHandlerContext(
 	metricsScope int,
 ) *handlerContext {
 	return &handlerContext{
-		Context: ctx,
-		scope:   newPerTaskListScope(namespace, taskList.GetName(), taskList.GetKind(), metricsClient, metricsScope),
+		Context:   ctx,
+		namespace: namespace,
+		scope:     newPerTaskListScope(namespace, taskList.GetName(), taskList.GetKind(), metricsClient, metricsScope),
 	}
 }
 
@@ -25,6 +25,7 @@
 package matching
 
 import (
+	"errors"
 	"sync"
 	"sync/atomic"
 
@@ -44,6 +45,7 @@ type (
 		taskType     int32
 		rangeID      int64
 		ackLevel     int64
+		released     bool
 		store        persistence.TaskManager
 		logger       log.Logger
 	}
@@ -53,6 +55,8 @@ type (
 	}
 )
 
+var errTaskListLeaseReleased = errors.New("task list lease is released")
+
 // newTaskListDB returns an instance of an object that represents
 // persistence view of a taskList. All mutations / reads to taskLists
 // wrt persistence go through this object.
@@ -105,6 +109,9 @@ func (db *taskListDB) RenewLease() (taskListState, error) {
 func (db *taskListDB) UpdateState(ackLevel int64) error {
 	db.Lock()
 	defer db.Unlock()
+	if db.released {
+		return errTaskListLeaseReleased
+	}
 	_, err := db.store.UpdateTaskList(&persistence.UpdateTaskListRequest{
 		TaskListInfo: &persistenceblobs.TaskListInfo{
 			NamespaceId: db.namespaceID,
@@ -121,10 +128,44 @@ func (db *taskListDB) UpdateState(ackLevel int64) error {
 	return err
 }
 
+// ReleaseLease persists the given ack level and gives up the lease on the taskList. No
+// further writes are made with the released lease. Losing the lease to another owner
+// before the release is not an error as there is nothing left to release
+func (db *taskListDB) ReleaseLease(ackLevel int64) error {
+	db.Lock()
+	defer db.Unlock()
+	if db.released {
+		return nil
+	}
+	_, err := db.store.UpdateTaskList(&persistence.UpdateTaskListRequest{
+		TaskListInfo: &persistenceblobs.TaskListInfo{
+			NamespaceId: db.namespaceID,
+			Name:        db.taskListName,
+			TaskType:    db.taskType,
+			AckLevel:    ackLevel,
+			Kind:        db.taskListKind,
+		},
+		RangeID: db.rangeID,
+	})
+	switch err.(type) {
+	case nil:
+		db.ackLevel = ackLevel
+	case *persistence.ConditionFailedError:
+		err = nil
+	default:
+		return err
+	}
+	db.released = true
+	return err
+}
+
 // CreateTasks creates a batch of given tasks for this task list
 func (db *taskListDB) CreateTasks(tasks []*persistenceblobs.AllocatedTaskInfo) (*persistence.CreateTasksResponse, error) {
 	db.Lock()
 	defer db.Unlock()
+	if db.released {
+		return nil, errTaskListLeaseReleased
+	}
 	return db.store.CreateTasks(
 		&persistence.CreateTasksRequest{
 			TaskListInfo: &persistence.PersistedTaskListInfo{
@@ -0,0 +1,71 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"math"
+	"sort"
+
+	tasklistpb "go.temporal.io/temporal-proto/tasklist"
+
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist"
+)
+
+// DescribeTaskListLoads returns the status of the task lists loaded on this host with the
+// largest backlogs, at most maxCount of them and the busiest first
+func (e *matchingEngineImpl) DescribeTaskListLoads(maxCount int) []*tasklistgenpb.TaskListLoad {
+	e.taskListsLock.RLock()
+	ids := make([]taskListID, 0, len(e.taskLists))
+	managers := make([]taskListManager, 0, len(e.taskLists))
+	for id, tlMgr := range e.taskLists {
+		ids = append(ids, id)
+		managers = append(managers, tlMgr)
+	}
+	e.taskListsLock.RUnlock()
+
+	// describe the task lists outside of the lock, as String does
+	loads := make([]*tasklistgenpb.TaskListLoad, 0, len(managers))
+	for i, tlMgr := range managers {
+		description := tlMgr.DescribeTaskList(true)
+		loads = append(loads, &tasklistgenpb.TaskListLoad{
+			NamespaceId:  ids[i].namespaceID,
+			Name:         ids[i].name,
+			TaskListType: tasklistpb.TaskListType(ids[i].taskType),
+			PollerCount:  int32(len(description.GetPollers())),
+			Status:       description.GetTaskListStatus(),
+		})
+	}
+	sort.SliceStable(loads, func(i, j int) bool {
+		return loads[i].GetStatus().GetBacklogCountHint() > loads[j].GetStatus().GetBacklogCountHint()
+	})
+
+	if maxCount <= 0 {
+		maxCount = math.MaxInt32
+	}
+	if len(loads) > maxCount {
+		loads = loads[:maxCount]
+	}
+	return loads
+}
@@ -35,8 +35,10 @@ import (
 
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/health"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/profiling"
 	"github.com/temporalio/temporal/common/quotas"
 	"github.com/temporalio/temporal/common/resource"
 )
@@ -51,6 +53,7 @@ type (
 		metricsClient metrics.Client
 		startWG       sync.WaitGroup
 		rateLimiter   quotas.Limiter
+		healthChecker *health.Checker
 	}
 )
 
@@ -81,6 +84,7 @@ func NewHandler(
 			resource.GetMetricsClient(),
 			resource.GetNamespaceCache(),
 			resource.GetMatchingServiceResolver(),
+			resource.GetMembershipMonitor(),
 		),
 	}
 
@@ -92,25 +96,33 @@ func NewHandler(
 
 // Start starts the handler
 func (h *Handler) Start() {
+	h.engine.Start()
+	h.healthChecker = resource.NewPersistenceHealthChecker(h.Resource)
+	h.healthChecker.Register("tasklists", h.engine.CheckTaskListLoad)
 	h.startWG.Done()
 }
 
+// PrepareToStop hands off task lists before the handler stops serving requests
+func (h *Handler) PrepareToStop() {
+	h.engine.PrepareToStop()
+}
+
 // Stop stops the handler
 func (h *Handler) Stop() {
 	h.engine.Stop()
 }
 
 // https://github.com/grpc/grpc/blob/master/doc/health-checking.md
-func (h *Handler) Check(context.Context, *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+func (h *Handler) Check(ctx context.Context, request *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
 	h.startWG.Wait()
 	h.GetLogger().Debug("Matching service health check endpoint (gRPC) reached.")
-	hs := &healthpb.HealthCheckResponse{
-		Status: healthpb.HealthCheckResponse_SERVING,
-	}
-	return hs, nil
+	return h.healthChecker.Check(ctx, request)
 }
-func (h *Handler) Watch(*healthpb.HealthCheckRequest, healthpb.Health_WatchServer) error {
-	return serviceerror.NewUnimplemented("Watch is not implemented.")
+
+// Watch is for health check watch
+func (h *Handler) Watch(request *healthpb.HealthCheckRequest, server healthpb.Health_WatchServer) error {
+	h.startWG.Wait()
+	return h.healthChecker.Watch(request, server)
 }
 
 func (h *Handler) newHandlerContext(
@@ -157,6 +169,10 @@ func (h *Handler) AddActivityTask(
 	if syncMatch {
 		hCtx.scope.RecordTimer(metrics.SyncMatchLatencyPerTaskList, time.Since(startT))
 	}
+	if err == errAffinityOwnerUnavailable {
+		// let history fail the activity instead of retrying the task
+		return &matchingservice.AddActivityTaskResponse{AffinityOwnerUnavailable: true}, nil
+	}
 
 	return &matchingservice.AddActivityTaskResponse{}, hCtx.handleErr(err)
 }
@@ -337,6 +353,27 @@ func (h *Handler) CancelOutstandingPoll(ctx context.Context,
 	return &matchingservice.CancelOutstandingPollResponse{}, hCtx.handleErr(err)
 }
 
+// ReleaseInFlightTask releases the slot held by a task on the poller it was dispatched to
+func (h *Handler) ReleaseInFlightTask(ctx context.Context,
+	request *matchingservice.ReleaseInFlightTaskRequest) (_ *matchingservice.ReleaseInFlightTaskResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := h.newHandlerContext(
+		ctx,
+		request.GetNamespaceId(),
+		request.GetTaskList(),
+		metrics.MatchingReleaseInFlightTaskScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	// Count the request in the RPS, but we still accept it even if RPS is exceeded
+	h.rateLimiter.Allow()
+
+	err := h.engine.ReleaseInFlightTask(hCtx, request)
+	return &matchingservice.ReleaseInFlightTaskResponse{}, hCtx.handleErr(err)
+}
+
 // DescribeTaskList returns information about the target task list, right now this API returns the
 // pollers which polled this task list in last few minutes. If includeTaskListStatus field is true,
 // it will also return status of task list's ackManager (readLevel, ackLevel, backlogCountHint and taskIDBlock).
@@ -388,6 +425,118 @@ func (h *Handler) ListTaskListPartitions(
 	return response, hCtx.handleErr(err)
 }
 
+// CaptureProfile captures a profile of the process of the matching host
+func (h *Handler) CaptureProfile(
+	ctx context.Context,
+	request *matchingservice.CaptureProfileRequest,
+) (_ *matchingservice.CaptureProfileResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := newHandlerContext(
+		ctx,
+		"",
+		nil,
+		h.metricsClient,
+		metrics.MatchingCaptureProfileScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	duration := time.Duration(request.GetDurationInSeconds()) * time.Second
+	if err := profiling.Validate(request.GetProfileType(), duration); err != nil {
+		return nil, hCtx.handleErr(serviceerror.NewInvalidArgument(err.Error()))
+	}
+	profile, err := profiling.Capture(hCtx, request.GetProfileType(), duration)
+	if err != nil {
+		return nil, hCtx.handleErr(err)
+	}
+	return &matchingservice.CaptureProfileResponse{
+		HostAddress: h.GetHostInfo().GetAddress(),
+		Profile:     profile,
+	}, nil
+}
+
+// GetHostDiagnostics returns the recent errors, the dynamic config and the busiest task lists of the matching host
+func (h *Handler) GetHostDiagnostics(
+	ctx context.Context,
+	request *matchingservice.GetHostDiagnosticsRequest,
+) (_ *matchingservice.GetHostDiagnosticsResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := newHandlerContext(
+		ctx,
+		"",
+		nil,
+		h.metricsClient,
+		metrics.MatchingGetHostDiagnosticsScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	dynamicConfig, err := resource.SnapshotDynamicConfig(h)
+	if err != nil {
+		return nil, hCtx.handleErr(err)
+	}
+	return &matchingservice.GetHostDiagnosticsResponse{
+		HostAddress:   h.GetHostInfo().GetAddress(),
+		RecentErrors:  resource.DescribeRecentErrors(h),
+		DynamicConfig: dynamicConfig,
+		TaskLists:     h.engine.DescribeTaskListLoads(int(request.GetMaxTaskLists())),
+	}, nil
+}
+
+// DescribeLogLevels returns the log levels of the process of the matching host
+func (h *Handler) DescribeLogLevels(
+	ctx context.Context,
+	_ *matchingservice.DescribeLogLevelsRequest,
+) (_ *matchingservice.DescribeLogLevelsResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := newHandlerContext(
+		ctx,
+		"",
+		nil,
+		h.metricsClient,
+		metrics.MatchingDescribeLogLevelsScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	logLevels, err := resource.DescribeLogLevels(h)
+	if err != nil {
+		return nil, hCtx.handleErr(err)
+	}
+	return &matchingservice.DescribeLogLevelsResponse{
+		LogLevels: logLevels,
+	}, nil
+}
+
+// UpdateLogLevel changes the log level of a package in the process of the matching host until it restarts
+func (h *Handler) UpdateLogLevel(
+	ctx context.Context,
+	request *matchingservice.UpdateLogLevelRequest,
+) (_ *matchingservice.UpdateLogLevelResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := newHandlerContext(
+		ctx,
+		"",
+		nil,
+		h.metricsClient,
+		metrics.MatchingUpdateLogLevelScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	logLevels, err := resource.UpdateLogLevel(h, request.GetPackagePath(), request.GetLevel())
+	if err != nil {
+		return nil, hCtx.handleErr(err)
+	}
+	return &matchingservice.UpdateLogLevelResponse{
+		LogLevels: logLevels,
+	}, nil
+}
+
 func (h *Handler) namespaceName(id string) string {
 	entry, err := h.GetNamespaceCache().GetNamespaceByID(id)
 	if err != nil {
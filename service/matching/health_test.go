@@ -0,0 +1,54 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+func TestCheckTaskListLoad(t *testing.T) {
+	engine := &matchingEngineImpl{
+		taskLists: map[taskListID]taskListManager{
+			*newTestTaskListID("namespace-id", "tl0", persistence.TaskListTypeDecision): nil,
+			*newTestTaskListID("namespace-id", "tl1", persistence.TaskListTypeActivity): nil,
+		},
+	}
+
+	detail, err := engine.CheckTaskListLoad(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "2 task lists loaded", detail)
+
+	// the host is not ready once it hands off its task lists
+	atomic.StoreInt32(&engine.handingOff, 1)
+	detail, err = engine.CheckTaskListLoad(context.Background())
+	require.Error(t, err)
+	require.Equal(t, "2 task lists loaded", detail)
+}
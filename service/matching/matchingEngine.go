@@ -31,6 +31,7 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/types"
@@ -88,9 +89,21 @@ type (
 		namespaceCache       cache.NamespaceCache
 		versionChecker       headers.VersionChecker
 		keyResolver          membership.ServiceResolver
+		membershipMonitor    membership.Monitor
+		hostInfo             *membership.HostInfo
+		membershipUpdateCh   chan *membership.ChangedEvent
+		shutdownCh           chan struct{}
+		handingOff           int32
 	}
 )
 
+const (
+	matchingEngineMembershipUpdateListenerName = "MatchingEngine"
+
+	// taskListHandoffTimeout is the time budget for the new owner to load a handed off task list
+	taskListHandoffTimeout = 5 * time.Second
+)
+
 var (
 	// EmptyPollForDecisionTaskResponse is the response when there are no decision tasks to hand out
 	emptyPollForDecisionTaskResponse = &matchingservice.PollForDecisionTaskResponse{}
@@ -103,6 +116,11 @@ var (
 	ErrNoTasks    = errors.New("No tasks")
 	errPumpClosed = errors.New("Task list pump closed its channel")
 
+	errAffinityIdentityNotSet   = serviceerror.NewInvalidArgument("Identity is required to poll an affinity task list.")
+	errAffinityKeyOwned         = serviceerror.NewInvalidArgument("Affinity key is owned by another poller.")
+	errAffinityOwnerUnavailable = errors.New("Affinity task list owner is unavailable")
+	errTaskListHandedOff        = serviceerror.NewUnavailable("Task list is handed off to another matching host.")
+
 	pollerIDKey pollerIDCtxKey = "pollerID"
 	identityKey identityCtxKey = "identity"
 )
@@ -118,6 +136,7 @@ func NewEngine(taskManager persistence.TaskManager,
 	metricsClient metrics.Client,
 	namespaceCache cache.NamespaceCache,
 	resolver membership.ServiceResolver,
+	membershipMonitor membership.Monitor,
 ) Engine {
 
 	return &matchingEngineImpl{
@@ -133,20 +152,123 @@ func NewEngine(taskManager persistence.TaskManager,
 		namespaceCache:       namespaceCache,
 		versionChecker:       headers.NewVersionChecker(),
 		keyResolver:          resolver,
+		membershipMonitor:    membershipMonitor,
+		membershipUpdateCh:   make(chan *membership.ChangedEvent, 10),
+		shutdownCh:           make(chan struct{}),
 	}
 }
 
 func (e *matchingEngineImpl) Start() {
-	// As task lists are initialized lazily nothing is done on startup at this point.
+	// As task lists are initialized lazily only the membership listener is started, which
+	// hands off task lists this host no longer owns after a ring change.
+	if e.keyResolver == nil || e.membershipMonitor == nil {
+		return
+	}
+	hostInfo, err := e.membershipMonitor.WhoAmI()
+	if err != nil {
+		e.logger.Error("Unable to resolve host info, task list handoff is disabled", tag.Error(err))
+		return
+	}
+	if err := e.keyResolver.AddListener(matchingEngineMembershipUpdateListenerName, e.membershipUpdateCh); err != nil {
+		e.logger.Error("Error adding listener", tag.Error(err))
+		return
+	}
+	e.hostInfo = hostInfo
+	go e.membershipUpdatePump()
 }
 
 func (e *matchingEngineImpl) Stop() {
+	if e.hostInfo != nil {
+		if err := e.keyResolver.RemoveListener(matchingEngineMembershipUpdateListenerName); err != nil {
+			e.logger.Error("Error removing membership update listener", tag.Error(err), tag.OperationFailed)
+		}
+		close(e.shutdownCh)
+	}
 	// Executes Stop() on each task list outside of lock
 	for _, l := range e.getTaskLists(math.MaxInt32) {
 		l.Stop()
 	}
 }
 
+// PrepareToStop hands off all task lists owned by this host. It is called once the host
+// is evicted from the membership ring, new task lists are not loaded after this point.
+func (e *matchingEngineImpl) PrepareToStop() {
+	if !e.config.EnableTaskListHandoff() || !atomic.CompareAndSwapInt32(&e.handingOff, 0, 1) {
+		return
+	}
+	e.logger.Info("Handing off all task lists")
+	for _, l := range e.getTaskLists(math.MaxInt32) {
+		l.Handoff()
+	}
+}
+
+func (e *matchingEngineImpl) membershipUpdatePump() {
+	for {
+		select {
+		case <-e.shutdownCh:
+			return
+		case <-e.membershipUpdateCh:
+			if e.config.EnableTaskListHandoff() {
+				e.handoffTaskLists()
+			}
+		}
+	}
+}
+
+// handoffTaskLists hands off task lists which are owned by another host after a ring change
+func (e *matchingEngineImpl) handoffTaskLists() {
+	for _, l := range e.getTaskLists(math.MaxInt32) {
+		if tlMgr, ok := l.(*taskListManagerImpl); ok && !e.isOwner(tlMgr.taskListID) {
+			tlMgr.Handoff()
+		}
+	}
+}
+
+// isOwner returns false only when the ring resolves the task list to another host
+func (e *matchingEngineImpl) isOwner(id *taskListID) bool {
+	if e.hostInfo == nil {
+		return true
+	}
+	host, err := e.keyResolver.Lookup(id.name)
+	if err != nil {
+		return true
+	}
+	return host.Identity() == e.hostInfo.Identity()
+}
+
+// notifyNewOwner makes the new owner load a handed off task list, so that it takes over the
+// lease right away instead of on the first poll or task for the task list
+func (e *matchingEngineImpl) notifyNewOwner(id *taskListID, taskListKind tasklistpb.TaskListKind) {
+	if e.matchingClient == nil || e.isOwner(id) {
+		return
+	}
+	taskListType := tasklistpb.TaskListType_Decision
+	if id.taskType == persistence.TaskListTypeActivity {
+		taskListType = tasklistpb.TaskListType_Activity
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), taskListHandoffTimeout)
+		defer cancel()
+		_, err := e.matchingClient.DescribeTaskList(ctx, &matchingservice.DescribeTaskListRequest{
+			NamespaceId: id.namespaceID,
+			DescRequest: &workflowservice.DescribeTaskListRequest{
+				TaskList:     &tasklistpb.TaskList{Name: id.name, Kind: taskListKind},
+				TaskListType: taskListType,
+			},
+		})
+		if err != nil {
+			e.logger.Warn("Failed to notify new owner of handed off task list", tag.WorkflowTaskListName(id.name), tag.Error(err))
+		}
+	}()
+}
+
+// canForwardPoll returns true when a poll failed with errTaskListHandedOff can be served by the new owner. A poll
+// handed off already is not forwarded again, so that it does not bounce between hosts which disagree on the owner
+// while the ring is changing
+func (e *matchingEngineImpl) canForwardPoll(err error, id *taskListID, handedOffFrom string) bool {
+	return err == errTaskListHandedOff && e.matchingClient != nil && handedOffFrom == "" && !e.isOwner(id)
+}
+
 func (e *matchingEngineImpl) getTaskLists(maxCount int) (lists []taskListManager) {
 	e.taskListsLock.RLock()
 	defer e.taskListsLock.RUnlock()
@@ -182,12 +304,21 @@ func (e *matchingEngineImpl) getTaskListManager(taskList *taskListID, taskListKi
 		return result, nil
 	}
 	e.taskListsLock.RUnlock()
+	// A task list owned by another host is not loaded, a stale request would take the lease back
+	// from the new owner
+	if e.config.EnableTaskListHandoff() && !e.isOwner(taskList) {
+		return nil, errTaskListHandedOff
+	}
 	// If it gets here, write lock and check again in case a task list is created between the two locks
 	e.taskListsLock.Lock()
 	if result, ok := e.taskLists[*taskList]; ok {
 		e.taskListsLock.Unlock()
 		return result, nil
 	}
+	if atomic.LoadInt32(&e.handingOff) == 1 {
+		e.taskListsLock.Unlock()
+		return nil, errTaskListHandedOff
+	}
 	e.logger.Info("", tag.LifeCycleStarting, tag.WorkflowTaskListName(taskList.name), tag.WorkflowTaskListType(taskList.taskType))
 	mgr, err := newTaskListManager(e, taskList, taskListKind, e.config)
 	if err != nil {
@@ -341,8 +472,17 @@ pollLoop:
 		taskListKind := request.TaskList.GetKind()
 		task, err := e.getTask(pollerCtx, taskList, nil, taskListKind)
 		if err != nil {
+			if e.canForwardPoll(err, taskList, req.GetHandedOffFrom()) {
+				return e.matchingClient.PollForDecisionTask(hCtx.Context, &matchingservice.PollForDecisionTaskRequest{
+					NamespaceId:   req.GetNamespaceId(),
+					PollerId:      req.GetPollerId(),
+					PollRequest:   req.GetPollRequest(),
+					ForwardedFrom: req.GetForwardedFrom(),
+					HandedOffFrom: e.hostInfo.GetAddress(),
+				})
+			}
 			// TODO: Is empty poll the best reply for errPumpClosed?
-			if err == ErrNoTasks || err == errPumpClosed {
+			if err == ErrNoTasks || err == errPumpClosed || err == errTaskListHandedOff {
 				return emptyPollForDecisionTaskResponse, nil
 			}
 			return nil, err
@@ -383,7 +523,7 @@ pollLoop:
 				BranchToken:               mutableStateResp.CurrentBranchToken,
 				StartedEventId:            common.EmptyEventID,
 			}
-			return e.createPollForDecisionTaskResponse(task, resp, hCtx.scope), nil
+			return e.createPollForDecisionTaskResponse(task, resp, hCtx), nil
 		}
 
 		resp, err := e.recordDecisionTaskStarted(hCtx.Context, request, task)
@@ -396,11 +536,14 @@ pollLoop:
 			default:
 				task.finish(err)
 			}
+			task.releasePollerSlot()
 
 			continue pollLoop
 		}
 		task.finish(nil)
-		return e.createPollForDecisionTaskResponse(task, resp, hCtx.scope), nil
+		// the decision task timeout is not known here, it counts for the outstanding task TTL at most
+		task.startInFlight(resp.GetScheduledEventId(), 0)
+		return e.createPollForDecisionTaskResponse(task, resp, hCtx), nil
 	}
 }
 
@@ -439,8 +582,17 @@ pollLoop:
 		taskListKind := request.TaskList.GetKind()
 		task, err := e.getTask(pollerCtx, taskList, maxDispatch, taskListKind)
 		if err != nil {
+			if e.canForwardPoll(err, taskList, req.GetHandedOffFrom()) {
+				return e.matchingClient.PollForActivityTask(hCtx.Context, &matchingservice.PollForActivityTaskRequest{
+					NamespaceId:   req.GetNamespaceId(),
+					PollerId:      req.GetPollerId(),
+					PollRequest:   req.GetPollRequest(),
+					ForwardedFrom: req.GetForwardedFrom(),
+					HandedOffFrom: e.hostInfo.GetAddress(),
+				})
+			}
 			// TODO: Is empty poll the best reply for errPumpClosed?
-			if err == ErrNoTasks || err == errPumpClosed {
+			if err == ErrNoTasks || err == errPumpClosed || err == errTaskListHandedOff {
 				return emptyPollForActivityTaskResponse, nil
 			}
 			return nil, err
@@ -462,11 +614,14 @@ pollLoop:
 			default:
 				task.finish(err)
 			}
+			task.releasePollerSlot()
 
 			continue pollLoop
 		}
 		task.finish(nil)
-		return e.createPollForActivityTaskResponse(task, resp, hCtx.scope), nil
+		attributes := resp.GetScheduledEvent().GetActivityTaskScheduledEventAttributes()
+		task.startInFlight(task.event.Data.GetScheduleId(), time.Duration(attributes.GetStartToCloseTimeoutSeconds())*time.Second)
+		return e.createPollForActivityTaskResponse(task, resp, hCtx), nil
 	}
 }
 
@@ -571,6 +726,26 @@ func (e *matchingEngineImpl) CancelOutstandingPoll(
 	return nil
 }
 
+// ReleaseInFlightTask releases the slot held by a task on the poller it was dispatched to, once the task is
+// completed, failed or canceled. Nothing is released when the task list partition is not loaded on this host
+func (e *matchingEngineImpl) ReleaseInFlightTask(
+	hCtx *handlerContext,
+	request *matchingservice.ReleaseInFlightTaskRequest,
+) error {
+	taskList, err := newTaskListID(request.GetNamespaceId(), request.TaskList.GetName(), request.GetTaskListType())
+	if err != nil {
+		return err
+	}
+
+	e.taskListsLock.RLock()
+	tlMgr, ok := e.taskLists[*taskList]
+	e.taskListsLock.RUnlock()
+	if ok {
+		tlMgr.ReleaseInFlightTask(request.GetRunId(), request.GetScheduleId())
+	}
+	return nil
+}
+
 func (e *matchingEngineImpl) DescribeTaskList(
 	hCtx *handlerContext,
 	request *matchingservice.DescribeTaskListRequest,
@@ -702,7 +877,7 @@ func (e *matchingEngineImpl) unloadTaskList(id *taskListID) {
 func (e *matchingEngineImpl) createPollForDecisionTaskResponse(
 	task *internalTask,
 	historyResponse *historyservice.RecordDecisionTaskStartedResponse,
-	scope metrics.Scope,
+	hCtx *handlerContext,
 ) *matchingservice.PollForDecisionTaskResponse {
 
 	var serializedToken []byte
@@ -722,11 +897,15 @@ func (e *matchingEngineImpl) createPollForDecisionTaskResponse(
 			RunId:           task.event.Data.GetRunId(),
 			ScheduleId:      historyResponse.GetScheduledEventId(),
 			ScheduleAttempt: historyResponse.GetAttempt(),
+			TaskList:        task.inFlightTaskList(),
 		}
 		serializedToken, _ = e.tokenSerializer.Serialize(taskToken)
 		if task.responseC == nil {
 			ct, _ := types.TimestampFromProto(task.event.Data.CreatedTime)
-			scope.RecordTimer(metrics.AsyncMatchLatencyPerTaskList, time.Since(ct))
+			hCtx.scope.Tagged(
+				e.config.TypeTagLimiter.WorkflowTypeTag(hCtx.namespace, historyResponse.GetWorkflowType().GetName()),
+				e.config.TypeTagLimiter.ActivityTypeTag(hCtx.namespace, ""),
+			).RecordTimer(metrics.AsyncMatchLatencyPerTaskList, time.Since(ct))
 		}
 	}
 
@@ -745,7 +924,7 @@ func (e *matchingEngineImpl) createPollForDecisionTaskResponse(
 func (e *matchingEngineImpl) createPollForActivityTaskResponse(
 	task *internalTask,
 	historyResponse *historyservice.RecordActivityTaskStartedResponse,
-	scope metrics.Scope,
+	hCtx *handlerContext,
 ) *matchingservice.PollForActivityTaskResponse {
 
 	scheduledEvent := historyResponse.ScheduledEvent
@@ -758,7 +937,10 @@ func (e *matchingEngineImpl) createPollForActivityTaskResponse(
 	}
 	if task.responseC == nil {
 		ct, _ := types.TimestampFromProto(task.event.Data.CreatedTime)
-		scope.RecordTimer(metrics.AsyncMatchLatencyPerTaskList, time.Since(ct))
+		hCtx.scope.Tagged(
+			e.config.TypeTagLimiter.WorkflowTypeTag(hCtx.namespace, historyResponse.GetWorkflowType().GetName()),
+			e.config.TypeTagLimiter.ActivityTypeTag(hCtx.namespace, attributes.GetActivityType().GetName()),
+		).RecordTimer(metrics.AsyncMatchLatencyPerTaskList, time.Since(ct))
 	}
 
 	taskToken := &tokengenpb.Task{
@@ -769,6 +951,7 @@ func (e *matchingEngineImpl) createPollForActivityTaskResponse(
 		ScheduleAttempt: historyResponse.GetAttempt(),
 		ActivityId:      attributes.GetActivityId(),
 		ActivityType:    attributes.GetActivityType().GetName(),
+		TaskList:        task.inFlightTaskList(),
 	}
 
 	serializedToken, _ := e.tokenSerializer.Serialize(taskToken)
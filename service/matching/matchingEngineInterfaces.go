@@ -25,13 +25,23 @@
 package matching
 
 import (
+	"context"
+
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist"
 )
 
 type (
 	// Engine exposes interfaces for clients to poll for activity and decision tasks.
 	Engine interface {
+		Start()
 		Stop()
+		// PrepareToStop hands off all task lists owned by this host to their new owners
+		PrepareToStop()
+		// CheckTaskListLoad reports the task lists loaded on this host for its health check
+		CheckTaskListLoad(ctx context.Context) (string, error)
+		// DescribeTaskListLoads returns the status of the task lists loaded on this host, the busiest first
+		DescribeTaskListLoads(maxCount int) []*tasklistgenpb.TaskListLoad
 		AddDecisionTask(hCtx *handlerContext, addRequest *matchingservice.AddDecisionTaskRequest) (syncMatch bool, err error)
 		AddActivityTask(hCtx *handlerContext, addRequest *matchingservice.AddActivityTaskRequest) (syncMatch bool, err error)
 		PollForDecisionTask(hCtx *handlerContext, request *matchingservice.PollForDecisionTaskRequest) (*matchingservice.PollForDecisionTaskResponse, error)
@@ -39,6 +49,7 @@ type (
 		QueryWorkflow(hCtx *handlerContext, request *matchingservice.QueryWorkflowRequest) (*matchingservice.QueryWorkflowResponse, error)
 		RespondQueryTaskCompleted(hCtx *handlerContext, request *matchingservice.RespondQueryTaskCompletedRequest) error
 		CancelOutstandingPoll(hCtx *handlerContext, request *matchingservice.CancelOutstandingPollRequest) error
+		ReleaseInFlightTask(hCtx *handlerContext, request *matchingservice.ReleaseInFlightTaskRequest) error
 		DescribeTaskList(hCtx *handlerContext, request *matchingservice.DescribeTaskListRequest) (*matchingservice.DescribeTaskListResponse, error)
 		ListTaskListPartitions(hCtx *handlerContext, request *matchingservice.ListTaskListPartitionsRequest) (*matchingservice.ListTaskListPartitionsResponse, error)
 	}
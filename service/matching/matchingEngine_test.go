@@ -52,6 +52,7 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
+	"github.com/temporalio/temporal/.gen/proto/matchingservicemock"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
 	"github.com/temporalio/temporal/client/history"
@@ -60,6 +61,7 @@ import (
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/membership"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
 	"github.com/temporalio/temporal/common/payload"
@@ -500,6 +502,75 @@ func (s *matchingEngineSuite) TestTaskWriterShutdown() {
 	tlmImpl.taskWriter.stopped = 1 // reset it back to old value
 }
 
+func (s *matchingEngineSuite) TestGetTaskListManagerNotOwner() {
+	resolver := membership.NewMockServiceResolver(s.controller)
+	s.matchingEngine.keyResolver = resolver
+	s.matchingEngine.hostInfo = membership.NewHostInfo("host1:7935", nil)
+	defer func() { s.matchingEngine.hostInfo = nil }()
+
+	tlID := newTestTaskListID(uuid.New(), "makeToast", persistence.TaskListTypeActivity)
+	tlKind := tasklistpb.TaskListKind_Normal
+
+	// a stale request after a ring change does not take the lease back from the new owner
+	resolver.EXPECT().Lookup(tlID.name).Return(membership.NewHostInfo("host2:7935", nil), nil)
+	_, err := s.matchingEngine.getTaskListManager(tlID, tlKind)
+	s.Equal(errTaskListHandedOff, err)
+	s.Empty(s.matchingEngine.getTaskLists(10))
+	s.Empty(s.taskManager.taskLists)
+
+	resolver.EXPECT().Lookup(tlID.name).Return(membership.NewHostInfo("host1:7935", nil), nil)
+	tlm, err := s.matchingEngine.getTaskListManager(tlID, tlKind)
+	s.NoError(err)
+	s.NotNil(tlm)
+	s.EqualValues(1, s.taskManager.getTaskListManager(tlID).rangeID)
+
+	// a loaded task list is served without a lookup until it is handed off
+	_, err = s.matchingEngine.getTaskListManager(tlID, tlKind)
+	s.NoError(err)
+}
+
+func (s *matchingEngineSuite) TestPollForwardedOnceAfterHandoff() {
+	resolver := membership.NewMockServiceResolver(s.controller)
+	client := matchingservicemock.NewMockMatchingServiceClient(s.controller)
+	s.matchingEngine.keyResolver = resolver
+	s.matchingEngine.matchingClient = client
+	s.matchingEngine.hostInfo = membership.NewHostInfo("host1:7935", nil)
+	defer func() {
+		s.matchingEngine.hostInfo = nil
+		s.matchingEngine.matchingClient = nil
+	}()
+
+	namespaceID := uuid.New()
+	tlID := newTestTaskListID(namespaceID, "makeToast", persistence.TaskListTypeActivity)
+	resolver.EXPECT().Lookup(tlID.name).Return(membership.NewHostInfo("host2:7935", nil), nil).AnyTimes()
+	request := &matchingservice.PollForActivityTaskRequest{
+		NamespaceId: namespaceID,
+		PollerId:    "poller1",
+		PollRequest: &workflowservice.PollForActivityTaskRequest{
+			TaskList: &tasklistpb.TaskList{Name: "makeToast"},
+			Identity: "selfDrivingToaster",
+		},
+	}
+
+	// the poll is forwarded to the new owner, marked as handed off by this host
+	forwardedResp := &matchingservice.PollForActivityTaskResponse{ActivityId: "activity1"}
+	client.EXPECT().PollForActivityTask(gomock.Any(), &matchingservice.PollForActivityTaskRequest{
+		NamespaceId:   namespaceID,
+		PollerId:      "poller1",
+		PollRequest:   request.PollRequest,
+		HandedOffFrom: "host1:7935",
+	}).Return(forwardedResp, nil).Times(1)
+	resp, err := s.matchingEngine.PollForActivityTask(s.handlerContext, request)
+	s.NoError(err)
+	s.Equal(forwardedResp, resp)
+
+	// a poll handed off by another host is not forwarded again
+	request.HandedOffFrom = "host2:7935"
+	resp, err = s.matchingEngine.PollForActivityTask(s.handlerContext, request)
+	s.NoError(err)
+	s.Equal(emptyPollForActivityTaskResponse, resp)
+}
+
 func (s *matchingEngineSuite) TestAddThenConsumeActivities() {
 	s.matchingEngine.config.LongPollExpirationInterval = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(10 * time.Millisecond)
 
@@ -1645,6 +1716,34 @@ func (s *matchingEngineSuite) TestTaskExpiryAndCompletion() {
 	}
 }
 
+func (s *matchingEngineSuite) TestDescribeTaskListLoads() {
+	namespaceID := primitives.UUID(uuid.NewRandom()).String()
+	idleID := newTestTaskListID(namespaceID, "idle", persistence.TaskListTypeActivity)
+	busyID := newTestTaskListID(namespaceID, "busy", persistence.TaskListTypeDecision)
+	_, err := s.matchingEngine.getTaskListManager(idleID, tasklistpb.TaskListKind_Normal)
+	s.NoError(err)
+	busy, err := s.matchingEngine.getTaskListManager(busyID, tasklistpb.TaskListKind_Normal)
+	s.NoError(err)
+
+	ackManager := &busy.(*taskListManagerImpl).taskAckManager
+	readLevel := ackManager.getReadLevel()
+	ackManager.addTask(readLevel + 1)
+	ackManager.addTask(readLevel + 2)
+
+	loads := s.matchingEngine.DescribeTaskListLoads(10)
+	s.Len(loads, 2)
+	s.Equal(namespaceID, loads[0].GetNamespaceId())
+	s.Equal("busy", loads[0].GetName())
+	s.Equal(tasklistpb.TaskListType_Decision, loads[0].GetTaskListType())
+	s.Equal(int64(2), loads[0].GetStatus().GetBacklogCountHint())
+	s.Equal("idle", loads[1].GetName())
+	s.Equal(tasklistpb.TaskListType_Activity, loads[1].GetTaskListType())
+
+	loads = s.matchingEngine.DescribeTaskListLoads(1)
+	s.Len(loads, 1)
+	s.Equal("busy", loads[0].GetName())
+}
+
 func (s *matchingEngineSuite) setupRecordActivityTaskStartedMock(tlName string) {
 	activityTypeName := "activity1"
 	activityID := "activityId1"
@@ -109,6 +109,14 @@ func (h *NilCheckHandler) CancelOutstandingPoll(ctx context.Context, request *ma
 	return resp, err
 }
 
+func (h *NilCheckHandler) ReleaseInFlightTask(ctx context.Context, request *matchingservice.ReleaseInFlightTaskRequest) (*matchingservice.ReleaseInFlightTaskResponse, error) {
+	resp, err := h.parentHandler.ReleaseInFlightTask(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.ReleaseInFlightTaskResponse{}
+	}
+	return resp, err
+}
+
 func (h *NilCheckHandler) DescribeTaskList(ctx context.Context, request *matchingservice.DescribeTaskListRequest) (*matchingservice.DescribeTaskListResponse, error) {
 	resp, err := h.parentHandler.DescribeTaskList(ctx, request)
 	if resp == nil && err == nil {
@@ -124,3 +132,35 @@ func (h *NilCheckHandler) ListTaskListPartitions(ctx context.Context, request *m
 	}
 	return resp, err
 }
+
+func (h *NilCheckHandler) CaptureProfile(ctx context.Context, request *matchingservice.CaptureProfileRequest) (*matchingservice.CaptureProfileResponse, error) {
+	resp, err := h.parentHandler.CaptureProfile(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.CaptureProfileResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) GetHostDiagnostics(ctx context.Context, request *matchingservice.GetHostDiagnosticsRequest) (*matchingservice.GetHostDiagnosticsResponse, error) {
+	resp, err := h.parentHandler.GetHostDiagnostics(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.GetHostDiagnosticsResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) DescribeLogLevels(ctx context.Context, request *matchingservice.DescribeLogLevelsRequest) (*matchingservice.DescribeLogLevelsResponse, error) {
+	resp, err := h.parentHandler.DescribeLogLevels(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.DescribeLogLevelsResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) UpdateLogLevel(ctx context.Context, request *matchingservice.UpdateLogLevelRequest) (*matchingservice.UpdateLogLevelResponse, error) {
+	resp, err := h.parentHandler.UpdateLogLevel(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.UpdateLogLevelResponse{}
+	}
+	return resp, err
+}
@@ -25,10 +25,13 @@
 package matching
 
 import (
+	"strings"
+	"sync"
 	"time"
 
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist"
 	"github.com/temporalio/temporal/common/cache"
 )
 
@@ -36,6 +39,9 @@ const (
 	pollerHistoryInitSize    = 0
 	pollerHistoryInitMaxSize = 1000
 	pollerHistoryTTL         = 5 * time.Minute
+	// pollerCapacityRetryInterval is how often a poller, which is at its limits,
+	// checks if one of its tasks completed or timed out
+	pollerCapacityRetryInterval = 100 * time.Millisecond
 )
 
 type (
@@ -44,12 +50,52 @@ type (
 	pollerInfo struct {
 		ratePerSecond float64
 	}
+
+	// pollerLimits are the per poller identity and per poller host caps on the
+	// number of tasks in flight, zero means unlimited
+	pollerLimits struct {
+		maxTasksPerPoller     int
+		maxTasksPerPollerHost int
+		outstandingTaskTTL    time.Duration
+	}
+
+	// inFlightTaskKey identifies a dispatched activity or decision task by its run and schedule ID
+	inFlightTaskKey struct {
+		runID      string
+		scheduleID int64
+	}
+
+	inFlightTask struct {
+		identity pollerIdentity
+		expiry   time.Time
+	}
+
+	// pollerSlot is a slot of the limits of a poller reserved for a task dispatched to it.
+	// It is either started, when the task is marked as started in history, or released
+	pollerSlot struct {
+		pollers  *pollerHistory
+		identity pollerIdentity
+		taskList string // name of the task list partition which dispatched the task
+		ttl      time.Duration
+	}
 )
 
 type pollerHistory struct {
 	// poller ID -> pollerInfo
 	// pollers map[pollerID]pollerInfo
 	history cache.Cache
+
+	// A task is in flight from the time it is started until frontend reports it completed,
+	// failed or canceled, or until it times out. Every task list partition keeps its own
+	// history, so the limits apply per partition. A poll reserves a slot before it waits
+	// for a task, so that concurrent polls of the same poller can not exceed the limits
+	sync.Mutex
+	inFlightTasks        map[inFlightTaskKey]inFlightTask
+	nextExpiry           time.Time
+	identityTasks        map[pollerIdentity]int
+	hostTasks            map[string]int
+	identityReservations map[pollerIdentity]int
+	hostReservations     map[string]int
 }
 
 func newPollerHistory() *pollerHistory {
@@ -60,7 +106,12 @@ func newPollerHistory() *pollerHistory {
 	}
 
 	return &pollerHistory{
-		history: cache.New(pollerHistoryInitMaxSize, opts),
+		history:              cache.New(pollerHistoryInitMaxSize, opts),
+		inFlightTasks:        make(map[inFlightTaskKey]inFlightTask),
+		identityTasks:        make(map[pollerIdentity]int),
+		hostTasks:            make(map[string]int),
+		identityReservations: make(map[pollerIdentity]int),
+		hostReservations:     make(map[string]int),
 	}
 }
 
@@ -92,3 +143,148 @@ func (pollers *pollerHistory) getAllPollerInfo() []*tasklistpb.PollerInfo {
 
 	return result
 }
+
+// reserveTask reserves a slot for a task to be dispatched to the given poller. It returns false
+// when the tasks in flight and the slots reserved by the ongoing polls of the poller, or of its
+// host, are at the limits. A reserved slot must be either started or released
+func (pollers *pollerHistory) reserveTask(id pollerIdentity, limits pollerLimits, now time.Time) bool {
+	pollers.Lock()
+	defer pollers.Unlock()
+
+	pollers.expireTasksLocked(now)
+	host := pollerHost(id)
+	if limits.maxTasksPerPoller > 0 &&
+		pollers.identityTasks[id]+pollers.identityReservations[id] >= limits.maxTasksPerPoller {
+		return false
+	}
+	if limits.maxTasksPerPollerHost > 0 &&
+		pollers.hostTasks[host]+pollers.hostReservations[host] >= limits.maxTasksPerPollerHost {
+		return false
+	}
+
+	pollers.identityReservations[id]++
+	pollers.hostReservations[host]++
+	return true
+}
+
+// startTask turns the slot reserved by the given poller into a task in flight, which holds
+// the slot until it completes or expiry passes
+func (pollers *pollerHistory) startTask(id pollerIdentity, key inFlightTaskKey, expiry time.Time) {
+	pollers.Lock()
+	defer pollers.Unlock()
+
+	pollers.releaseReservationLocked(id)
+	if task, ok := pollers.inFlightTasks[key]; ok {
+		// the task timed out in history and was dispatched again before it expired here
+		pollers.removeTaskLocked(key, task)
+	}
+	pollers.inFlightTasks[key] = inFlightTask{identity: id, expiry: expiry}
+	pollers.identityTasks[id]++
+	pollers.hostTasks[pollerHost(id)]++
+	if pollers.nextExpiry.IsZero() || expiry.Before(pollers.nextExpiry) {
+		pollers.nextExpiry = expiry
+	}
+}
+
+// releaseReservation releases the slot reserved by the given poller, when no task was started for it
+func (pollers *pollerHistory) releaseReservation(id pollerIdentity) {
+	pollers.Lock()
+	defer pollers.Unlock()
+
+	pollers.releaseReservationLocked(id)
+}
+
+// completeTask releases the slot held by a task in flight, once it is completed, failed or
+// canceled. It returns false when the task is not in flight
+func (pollers *pollerHistory) completeTask(key inFlightTaskKey) bool {
+	pollers.Lock()
+	defer pollers.Unlock()
+
+	task, ok := pollers.inFlightTasks[key]
+	if ok {
+		pollers.removeTaskLocked(key, task)
+	}
+	return ok
+}
+
+func (pollers *pollerHistory) releaseReservationLocked(id pollerIdentity) {
+	if pollers.identityReservations[id]--; pollers.identityReservations[id] <= 0 {
+		delete(pollers.identityReservations, id)
+	}
+	host := pollerHost(id)
+	if pollers.hostReservations[host]--; pollers.hostReservations[host] <= 0 {
+		delete(pollers.hostReservations, host)
+	}
+}
+
+func (pollers *pollerHistory) removeTaskLocked(key inFlightTaskKey, task inFlightTask) {
+	delete(pollers.inFlightTasks, key)
+	if pollers.identityTasks[task.identity]--; pollers.identityTasks[task.identity] <= 0 {
+		delete(pollers.identityTasks, task.identity)
+	}
+	host := pollerHost(task.identity)
+	if pollers.hostTasks[host]--; pollers.hostTasks[host] <= 0 {
+		delete(pollers.hostTasks, host)
+	}
+}
+
+// expireTasksLocked drops the tasks in flight which timed out, or whose completion was not reported
+func (pollers *pollerHistory) expireTasksLocked(now time.Time) {
+	if pollers.nextExpiry.IsZero() || now.Before(pollers.nextExpiry) {
+		return
+	}
+
+	pollers.nextExpiry = time.Time{}
+	for key, task := range pollers.inFlightTasks {
+		if !now.Before(task.expiry) {
+			pollers.removeTaskLocked(key, task)
+		} else if pollers.nextExpiry.IsZero() || task.expiry.Before(pollers.nextExpiry) {
+			pollers.nextExpiry = task.expiry
+		}
+	}
+}
+
+func (pollers *pollerHistory) getAllPollerLoad(now time.Time) []*tasklistgenpb.PollerLoadInfo {
+	pollers.Lock()
+	defer pollers.Unlock()
+
+	pollers.expireTasksLocked(now)
+	var result []*tasklistgenpb.PollerLoadInfo
+	for id, tasks := range pollers.identityTasks {
+		host := pollerHost(id)
+		result = append(result, &tasklistgenpb.PollerLoadInfo{
+			Identity:             string(id),
+			Host:                 host,
+			OutstandingTasks:     int32(tasks),
+			OutstandingHostTasks: int32(pollers.hostTasks[host]),
+		})
+	}
+	return result
+}
+
+// start marks the task with the given run and schedule ID as in flight on the poller. It holds the
+// slot until it is reported completed or the timeout passes, a task without a timeout holds it for
+// the outstanding task TTL of the task list
+func (slot *pollerSlot) start(runID []byte, scheduleID int64, timeout time.Duration, now time.Time) {
+	if timeout <= 0 {
+		timeout = slot.ttl
+	}
+	key := inFlightTaskKey{runID: string(runID), scheduleID: scheduleID}
+	slot.pollers.startTask(slot.identity, key, now.Add(timeout))
+}
+
+// release releases the slot when the task it was reserved for is not started
+func (slot *pollerSlot) release() {
+	slot.pollers.releaseReservation(slot.identity)
+}
+
+// pollerHost returns the host part of a poller identity. The SDKs use <pid>@<host>@<tasklist>
+// or <pid>@<host> as the default identity of a worker, so the host is the second field. An
+// identity without a host is treated as a host of its own
+func pollerHost(id pollerIdentity) string {
+	fields := strings.SplitN(string(id), "@", 3)
+	if len(fields) < 2 || fields[1] == "" {
+		return string(id)
+	}
+	return fields[1]
+}
@@ -0,0 +1,240 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollerHistoryReserveTask_Unlimited(t *testing.T) {
+	pollers := newPollerHistory()
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		startTask(t, pollers, "1@host0", int64(i), now.Add(time.Minute))
+	}
+	require.True(t, pollers.reserveTask("1@host0", pollerLimits{}, now))
+}
+
+func TestPollerHistoryReserveTask_PerPoller(t *testing.T) {
+	pollers := newPollerHistory()
+	limits := pollerLimits{maxTasksPerPoller: 2}
+	now := time.Now()
+
+	startTask(t, pollers, "1@host0", 1, now.Add(time.Minute))
+	require.True(t, pollers.reserveTask("1@host0", limits, now))
+	pollers.startTask("1@host0", inFlightTaskKey{runID: "run", scheduleID: 2}, now.Add(2*time.Minute))
+	require.False(t, pollers.reserveTask("1@host0", limits, now))
+	require.True(t, pollers.reserveTask("2@host0", limits, now))
+	pollers.releaseReservation("2@host0")
+
+	// the first task timed out
+	require.True(t, pollers.reserveTask("1@host0", limits, now.Add(time.Minute)))
+}
+
+func TestPollerHistoryReserveTask_PerHost(t *testing.T) {
+	pollers := newPollerHistory()
+	limits := pollerLimits{maxTasksPerPollerHost: 2}
+	now := time.Now()
+
+	startTask(t, pollers, "1@host0", 1, now.Add(time.Minute))
+	startTask(t, pollers, "2@host0", 2, now.Add(time.Minute))
+	startTask(t, pollers, "noHostIdentity", 3, now.Add(time.Minute))
+	require.False(t, pollers.reserveTask("3@host0", limits, now))
+	require.True(t, pollers.reserveTask("1@host1", limits, now))
+	require.True(t, pollers.reserveTask("noHostIdentity", limits, now))
+	pollers.startTask("noHostIdentity", inFlightTaskKey{runID: "run", scheduleID: 4}, now.Add(time.Minute))
+	require.False(t, pollers.reserveTask("noHostIdentity", limits, now))
+}
+
+func TestPollerHistoryReserveTask_PerHostSDKIdentity(t *testing.T) {
+	pollers := newPollerHistory()
+	limits := pollerLimits{maxTasksPerPollerHost: 2}
+	now := time.Now()
+
+	// workers on different hosts polling the same task list
+	startTask(t, pollers, "1234@host0@orders", 1, now.Add(time.Minute))
+	startTask(t, pollers, "5678@host0@orders", 2, now.Add(time.Minute))
+	require.False(t, pollers.reserveTask("9012@host0@orders", limits, now))
+	require.True(t, pollers.reserveTask("1234@host1@orders", limits, now))
+	require.True(t, pollers.reserveTask("4321@host2@orders", limits, now))
+}
+
+func TestPollerHost(t *testing.T) {
+	require.Equal(t, "host0", pollerHost("1234@host0@orders"))
+	require.Equal(t, "host0", pollerHost("1234@host0"))
+	require.Equal(t, "host0", pollerHost("1234@host0@orders@v2"))
+	require.Equal(t, "noHostIdentity", pollerHost("noHostIdentity"))
+	require.Equal(t, "1234@", pollerHost("1234@"))
+}
+
+func TestPollerHistoryReserveTask_Release(t *testing.T) {
+	pollers := newPollerHistory()
+	limits := pollerLimits{maxTasksPerPoller: 1}
+	now := time.Now()
+
+	require.True(t, pollers.reserveTask("1@host0", limits, now))
+	// the slot is held by the ongoing poll
+	require.False(t, pollers.reserveTask("1@host0", limits, now))
+	pollers.releaseReservation("1@host0")
+	require.True(t, pollers.reserveTask("1@host0", limits, now))
+	pollers.startTask("1@host0", inFlightTaskKey{runID: "run", scheduleID: 1}, now.Add(time.Minute))
+	require.False(t, pollers.reserveTask("1@host0", limits, now))
+	require.Empty(t, pollers.identityReservations)
+	require.Empty(t, pollers.hostReservations)
+}
+
+func TestPollerHistoryCompleteTask(t *testing.T) {
+	pollers := newPollerHistory()
+	limits := pollerLimits{maxTasksPerPoller: 1, maxTasksPerPollerHost: 1}
+	now := time.Now()
+	key := inFlightTaskKey{runID: "run", scheduleID: 5}
+
+	require.True(t, pollers.reserveTask("1@host0", limits, now))
+	pollers.startTask("1@host0", key, now.Add(time.Hour))
+	require.False(t, pollers.reserveTask("1@host0", limits, now))
+	require.False(t, pollers.reserveTask("2@host0", limits, now))
+
+	require.True(t, pollers.completeTask(key))
+	require.False(t, pollers.completeTask(key))
+	require.False(t, pollers.completeTask(inFlightTaskKey{runID: "run", scheduleID: 6}))
+	require.Empty(t, pollers.identityTasks)
+	require.Empty(t, pollers.hostTasks)
+	require.True(t, pollers.reserveTask("2@host0", limits, now))
+}
+
+func TestPollerHistoryStartTask_Redispatched(t *testing.T) {
+	pollers := newPollerHistory()
+	now := time.Now()
+	key := inFlightTaskKey{runID: "run", scheduleID: 5}
+
+	// the task timed out in history and was dispatched again to another poller
+	startTask(t, pollers, "1@host0", 5, now.Add(time.Hour))
+	require.True(t, pollers.reserveTask("2@host1", pollerLimits{}, now))
+	pollers.startTask("2@host1", key, now.Add(time.Hour))
+
+	loads := pollers.getAllPollerLoad(now)
+	require.Len(t, loads, 1)
+	require.Equal(t, "2@host1", loads[0].GetIdentity())
+	require.Equal(t, int32(1), loads[0].GetOutstandingTasks())
+}
+
+func TestPollerSlot(t *testing.T) {
+	pollers := newPollerHistory()
+	limits := pollerLimits{maxTasksPerPoller: 1}
+	now := time.Now()
+	slot := &pollerSlot{pollers: pollers, identity: "1@host0", taskList: "tl", ttl: time.Minute}
+
+	require.True(t, pollers.reserveTask("1@host0", limits, now))
+	slot.release()
+	require.True(t, pollers.reserveTask("1@host0", limits, now))
+	// without a timeout the task holds the slot for the ttl
+	slot.start([]byte("run"), 1, 0, now)
+	require.False(t, pollers.reserveTask("1@host0", limits, now.Add(59*time.Second)))
+	require.True(t, pollers.reserveTask("1@host0", limits, now.Add(time.Minute)))
+	slot.start([]byte("run"), 2, 10*time.Second, now)
+	require.False(t, pollers.reserveTask("1@host0", limits, now.Add(9*time.Second)))
+	require.True(t, pollers.reserveTask("1@host0", limits, now.Add(10*time.Second)))
+}
+
+func TestPollerHistoryReserveTask_Concurrent(t *testing.T) {
+	pollers := newPollerHistory()
+	limits := pollerLimits{maxTasksPerPoller: 3, maxTasksPerPollerHost: 5}
+	now := time.Now()
+
+	var lock sync.Mutex
+	started := make(map[pollerIdentity]int)
+	var wg sync.WaitGroup
+	begin := make(chan struct{})
+	for i := 0; i < 50; i++ {
+		id := pollerIdentity("1@host0")
+		if i%2 == 1 {
+			id = "2@host0"
+		}
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-begin
+			for j := 0; j < 10; j++ {
+				if !pollers.reserveTask(id, limits, now) {
+					continue
+				}
+				// every other poll returns without a task
+				if j%2 == 0 {
+					pollers.releaseReservation(id)
+					continue
+				}
+				pollers.startTask(id, inFlightTaskKey{runID: "run", scheduleID: int64(i*10 + j)}, now.Add(time.Minute))
+				lock.Lock()
+				started[id]++
+				lock.Unlock()
+			}
+		}()
+	}
+	close(begin)
+	wg.Wait()
+
+	require.Empty(t, pollers.identityReservations)
+	require.Empty(t, pollers.hostReservations)
+	// fill the remaining slots, if any
+	for _, id := range []pollerIdentity{"1@host0", "2@host0"} {
+		for pollers.reserveTask(id, limits, now) {
+			pollers.startTask(id, inFlightTaskKey{runID: "run", scheduleID: int64(1000 + started[id])}, now.Add(time.Minute))
+			started[id]++
+		}
+	}
+	require.Equal(t, 5, started["1@host0"]+started["2@host0"])
+	require.True(t, started["1@host0"] <= 3)
+	require.True(t, started["2@host0"] <= 3)
+	for _, load := range pollers.getAllPollerLoad(now) {
+		require.Equal(t, int32(started[pollerIdentity(load.GetIdentity())]), load.GetOutstandingTasks())
+		require.Equal(t, int32(5), load.GetOutstandingHostTasks())
+	}
+}
+
+func TestPollerHistoryGetAllPollerLoad(t *testing.T) {
+	pollers := newPollerHistory()
+	now := time.Now()
+
+	startTask(t, pollers, "1@host0@orders", 1, now.Add(-time.Second))
+	startTask(t, pollers, "2@host0@orders", 2, now.Add(time.Minute))
+	startTask(t, pollers, "2@host0@orders", 3, now.Add(time.Minute))
+
+	loads := pollers.getAllPollerLoad(now)
+	require.Len(t, loads, 1)
+	require.Equal(t, "2@host0@orders", loads[0].GetIdentity())
+	require.Equal(t, "host0", loads[0].GetHost())
+	require.Equal(t, int32(2), loads[0].GetOutstandingTasks())
+	require.Equal(t, int32(2), loads[0].GetOutstandingHostTasks())
+}
+
+func startTask(t *testing.T, pollers *pollerHistory, id pollerIdentity, scheduleID int64, expiry time.Time) {
+	require.True(t, pollers.reserveTask(id, pollerLimits{}, time.Now()))
+	pollers.startTask(id, inFlightTaskKey{runID: "run", scheduleID: scheduleID}, expiry)
+}
@@ -25,21 +25,21 @@
 package matching
 
 import (
-	"context"
 	"sync/atomic"
 	"time"
 
-	"go.temporal.io/temporal-proto/serviceerror"
 	"google.golang.org/grpc"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/health"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
 	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/common/rpc"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
@@ -51,7 +51,8 @@ type Service struct {
 	handler *Handler
 	config  *Config
 
-	server *grpc.Server
+	server       *grpc.Server
+	healthServer *health.Server
 }
 
 // NewService builds a new matching service
@@ -99,11 +100,16 @@ func (s *Service) Start() {
 	s.Resource.Start()
 	s.handler.Start()
 
-	s.server = grpc.NewServer(grpc.UnaryInterceptor(interceptor))
+	interceptors := rpc.NewServerInterceptors(s.GetMetricsClient(), logger, s.config.RPCRequestLogSampleRate, s.GetNamespaceCache())
+	opts := append(s.GetInternodeGRPCServerOptions(), grpc.ChainUnaryInterceptor(interceptors...))
+	s.server = grpc.NewServer(opts...)
 	nilCheckHandler := NewNilCheckHandler(s.handler)
 	matchingservice.RegisterMatchingServiceServer(s.server, nilCheckHandler)
 	healthpb.RegisterHealthServer(s.server, s.handler)
 
+	s.healthServer = health.NewServer(s.handler.healthChecker, s.GetHealthListener(), logger)
+	s.healthServer.Start()
+
 	listener := s.GetGRPCListener()
 	logger.Info("Starting to serve on matching listener")
 	if err := s.server.Serve(listener); err != nil {
@@ -123,15 +129,16 @@ func (s *Service) Stop() {
 	s.GetLogger().Info("ShutdownHandler: Waiting for others to discover I am unhealthy")
 	time.Sleep(s.config.ShutdownDrainDuration())
 
+	// hand off task lists to their new owners, polls still outstanding on this host are
+	// forwarded to the new owners which allows the server to stop without losing polls
+	s.GetLogger().Info("ShutdownHandler: Handing off task lists")
+	s.handler.PrepareToStop()
+
 	s.server.GracefulStop()
+	s.healthServer.Stop()
 
 	s.handler.Stop()
 	s.Resource.Stop()
 
 	s.GetLogger().Info("matching stopped")
 }
-
-func interceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	resp, err := handler(ctx, req)
-	return resp, serviceerror.ToStatus(err).Err()
-}
@@ -25,6 +25,8 @@
 package matching
 
 import (
+	"time"
+
 	executionpb "go.temporal.io/temporal-proto/execution"
 
 	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
@@ -62,6 +64,7 @@ type (
 		forwardedFrom    string     // name of the child partition this task is forwarded from (empty if not forwarded)
 		responseC        chan error // non-nil only where there is a caller waiting for response (sync-match)
 		backlogCountHint int64
+		pollerSlot       *pollerSlot // non-nil when the task is dispatched to a poller which counts its tasks in flight
 	}
 )
 
@@ -152,6 +155,30 @@ func (task *internalTask) pollForActivityResponse() *matchingservice.PollForActi
 	return nil
 }
 
+// startInFlight counts the task against the limits of the poller it is dispatched to, until it is reported
+// completed or the timeout passes. Should be called once the task is marked as started in history, with the
+// schedule ID of its task token
+func (task *internalTask) startInFlight(scheduleID int64, timeout time.Duration) {
+	if task.pollerSlot != nil {
+		task.pollerSlot.start(task.event.Data.GetRunId(), scheduleID, timeout, time.Now())
+	}
+}
+
+// releasePollerSlot releases the slot reserved for the task on its poller, when the task could not be started
+func (task *internalTask) releasePollerSlot() {
+	if task.pollerSlot != nil {
+		task.pollerSlot.release()
+	}
+}
+
+// inFlightTaskList returns the name of the task list partition counting the task in flight, if any
+func (task *internalTask) inFlightTaskList() string {
+	if task.pollerSlot != nil {
+		return task.pollerSlot.taskList
+	}
+	return ""
+}
+
 // finish marks a task as finished. Should be called after a poller picks up a task
 // and marks it as started. If the task is unable to marked as started, then this
 // method should be called with a non-nil error argument.
@@ -40,6 +40,7 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/cache"
@@ -70,6 +71,9 @@ type (
 	taskListManager interface {
 		Start() error
 		Stop()
+		// Handoff stops the task list after flushing its ack level and unblocking all
+		// outstanding polls, so that the new owner can take over the task list immediately
+		Handoff()
 		// AddTask adds a task to the task list. This method will first attempt a synchronous
 		// match with a poller. When that fails, task will be written to database and later
 		// asynchronously matched with a poller
@@ -85,6 +89,8 @@ type (
 		// if dispatched to local poller then nil and nil is returned.
 		DispatchQueryTask(ctx context.Context, taskID string, request *matchingservice.QueryWorkflowRequest) (*matchingservice.QueryWorkflowResponse, error)
 		CancelPoller(pollerID string)
+		// ReleaseInFlightTask releases the slot held on its poller by a task which completed, failed or was canceled
+		ReleaseInFlightTask(runID []byte, scheduleID int64)
 		GetAllPollerInfo() []*tasklistpb.PollerInfo
 		// DescribeTaskList returns information about the target task list
 		DescribeTaskList(includeTaskListStatus bool) *matchingservice.DescribeTaskListResponse
@@ -117,10 +123,19 @@ type (
 		// prevent tasks being dispatched to zombie pollers.
 		outstandingPollsLock sync.Mutex
 		outstandingPollsMap  map[string]context.CancelFunc
+		// affinity task lists are bound to the single poller owning the affinity key,
+		// the owner is considered gone when it has no outstanding poll and has not
+		// polled for AffinityOwnerTimeout
+		affinityLock          sync.Mutex
+		affinityOwner         pollerIdentity
+		affinityOwnerPolls    int
+		affinityOwnerLastSeen time.Time
+		createTime            time.Time
 
 		shutdownCh chan struct{}  // Delivers stop to the pump that populates taskBuffer
 		startWG    sync.WaitGroup // ensures that background processes do not start until setup is ready
 		stopped    int32
+		handedOff  int32
 	}
 )
 
@@ -162,6 +177,7 @@ func newTaskListManager(
 		config:              taskListConfig,
 		pollerHistory:       newPollerHistory(),
 		outstandingPollsMap: make(map[string]context.CancelFunc),
+		createTime:          time.Now(),
 	}
 
 	tlMgr.namespaceValue.Store("")
@@ -219,6 +235,35 @@ func (c *taskListManagerImpl) Stop() {
 	c.logger.Info("", tag.LifeCycleStopped)
 }
 
+// Handoff stops the task list on this host when it is no longer the owner of the task list.
+// Ack level is persisted and the lease released before stopping so the new owner does not
+// redeliver tasks which are already acked, and outstanding polls are unblocked with
+// errTaskListHandedOff to be forwarded to the new owner.
+func (c *taskListManagerImpl) Handoff() {
+	if !atomic.CompareAndSwapInt32(&c.handedOff, 0, 1) {
+		return
+	}
+
+	ackLevel := c.taskAckManager.getAckLevel()
+	if err := c.db.ReleaseLease(ackLevel); err != nil {
+		c.logger.Warn("Failed to release task list lease on handoff", tag.Error(err))
+	}
+	c.taskGC.RunNow(ackLevel)
+	c.Stop()
+
+	c.outstandingPollsLock.Lock()
+	for _, cancel := range c.outstandingPollsMap {
+		cancel()
+	}
+	c.outstandingPollsLock.Unlock()
+	c.engine.notifyNewOwner(c.taskListID, c.taskListKind)
+	c.logger.Info("Task list handed off", tag.AckLevel(c.taskAckManager.getAckLevel()))
+}
+
+func (c *taskListManagerImpl) isHandedOff() bool {
+	return atomic.LoadInt32(&c.handedOff) == 1
+}
+
 // AddTask adds a task to the task list. This method will first attempt a synchronous
 // match with a poller. When there are no pollers or if ratelimit is exceeded, task will
 // be written to database and later asynchronously matched with a poller
@@ -239,6 +284,10 @@ func (c *taskListManagerImpl) AddTask(ctx context.Context, params addTaskParams)
 			return r, err
 		}
 
+		if c.taskListID.IsAffinity() && !c.isAffinityOwnerAvailable() {
+			return nil, errAffinityOwnerUnavailable
+		}
+
 		syncMatch, err = c.trySyncMatch(ctx, params)
 		if syncMatch {
 			return &persistence.CreateTasksResponse{}, err
@@ -287,6 +336,9 @@ func (c *taskListManagerImpl) GetTask(
 ) (*internalTask, error) {
 	task, err := c.getTask(ctx, maxDispatchPerSecond)
 	if err != nil {
+		if err == ErrNoTasks && c.isHandedOff() {
+			return nil, errTaskListHandedOff
+		}
 		return nil, err
 	}
 	task.namespace = c.namespace()
@@ -316,9 +368,27 @@ func (c *taskListManagerImpl) getTask(ctx context.Context, maxDispatchPerSecond
 		}()
 	}
 
-	identity, ok := ctx.Value(identityKey).(string)
-	if ok && identity != "" {
+	identity, _ := ctx.Value(identityKey).(string)
+	if c.taskListID.IsAffinity() {
+		release, err := c.acquireAffinityPoll(pollerIdentity(identity))
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	dispatched := false
+	if identity != "" {
 		c.pollerHistory.updatePollerInfo(pollerIdentity(identity), maxDispatchPerSecond)
+		// hold the poll until the poller is within its outstanding task limits
+		if err := c.waitForPollerCapacity(childCtx, pollerIdentity(identity)); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if !dispatched {
+				c.pollerHistory.releaseReservation(pollerIdentity(identity))
+			}
+		}()
 	}
 
 	namespaceEntry, err := c.namespaceCache.GetNamespaceByID(c.taskListID.namespaceID)
@@ -337,7 +407,102 @@ func (c *taskListManagerImpl) getTask(ctx context.Context, maxDispatchPerSecond
 		return c.matcher.PollForQuery(childCtx)
 	}
 
-	return c.matcher.Poll(childCtx)
+	task, err := c.matcher.Poll(childCtx)
+	if err != nil {
+		return nil, err
+	}
+	// the engine starts the slot once it marks the task as started in history, or releases it. Tasks
+	// started by a parent partition count against the limits of the poller there
+	if identity != "" && !task.isQuery() && !task.isStarted() {
+		task.pollerSlot = &pollerSlot{
+			pollers:  c.pollerHistory,
+			identity: pollerIdentity(identity),
+			taskList: c.taskListID.name,
+			ttl:      c.config.PollerOutstandingTaskTTL(),
+		}
+		dispatched = true
+	}
+	return task, nil
+}
+
+// waitForPollerCapacity blocks until a slot for a task to be dispatched to the given poller is
+// reserved without exceeding the per poller limits. Returns ErrNoTasks when context deadline is exceeded
+func (c *taskListManagerImpl) waitForPollerCapacity(ctx context.Context, id pollerIdentity) error {
+	throttled := false
+	for {
+		if c.pollerHistory.reserveTask(id, c.pollerLimits(), time.Now()) {
+			return nil
+		}
+		if !throttled {
+			throttled = true
+			c.metricScope().IncCounter(metrics.PollerThrottlePerTaskListCounter)
+		}
+
+		timer := time.NewTimer(pollerCapacityRetryInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ErrNoTasks
+		}
+	}
+}
+
+// acquireAffinityPoll binds an affinity task list to the given poller, unless it is owned by
+// another poller which is still alive. The returned function must be called when the poll completes
+func (c *taskListManagerImpl) acquireAffinityPoll(id pollerIdentity) (func(), error) {
+	if id == "" {
+		return nil, errAffinityIdentityNotSet
+	}
+
+	c.affinityLock.Lock()
+	defer c.affinityLock.Unlock()
+
+	now := time.Now()
+	if c.affinityOwner != id {
+		if c.affinityOwner != "" && c.isAffinityOwnerAliveLocked(now) {
+			return nil, errAffinityKeyOwned
+		}
+		c.logger.Info("Affinity task list bound to poller.", tag.PollerIdentity(string(id)))
+		c.affinityOwner = id
+		c.affinityOwnerPolls = 0
+	}
+	c.affinityOwnerPolls++
+	c.affinityOwnerLastSeen = now
+
+	return func() {
+		c.affinityLock.Lock()
+		defer c.affinityLock.Unlock()
+		if c.affinityOwner == id {
+			c.affinityOwnerPolls--
+			c.affinityOwnerLastSeen = time.Now()
+		}
+	}, nil
+}
+
+// isAffinityOwnerAvailable returns false when the owner of an affinity task list is gone
+func (c *taskListManagerImpl) isAffinityOwnerAvailable() bool {
+	c.affinityLock.Lock()
+	defer c.affinityLock.Unlock()
+
+	now := time.Now()
+	if c.affinityOwner == "" {
+		// the owner is unknown until it polls after the task list is loaded
+		return now.Sub(c.createTime) < c.config.AffinityOwnerTimeout()
+	}
+	return c.isAffinityOwnerAliveLocked(now)
+}
+
+func (c *taskListManagerImpl) isAffinityOwnerAliveLocked(now time.Time) bool {
+	return c.affinityOwnerPolls > 0 || now.Sub(c.affinityOwnerLastSeen) < c.config.AffinityOwnerTimeout()
+}
+
+func (c *taskListManagerImpl) pollerLimits() pollerLimits {
+	return pollerLimits{
+		maxTasksPerPoller:     c.config.MaxOutstandingTasksPerPoller(),
+		maxTasksPerPollerHost: c.config.MaxOutstandingTasksPerPollerHost(),
+		outstandingTaskTTL:    c.config.PollerOutstandingTaskTTL(),
+	}
 }
 
 // GetAllPollerInfo returns all pollers that polled from this tasklist in last few minutes
@@ -355,11 +520,25 @@ func (c *taskListManagerImpl) CancelPoller(pollerID string) {
 	}
 }
 
+// ReleaseInFlightTask releases the slot held on its poller by a task which completed, failed or was canceled
+func (c *taskListManagerImpl) ReleaseInFlightTask(runID []byte, scheduleID int64) {
+	c.pollerHistory.completeTask(inFlightTaskKey{runID: string(runID), scheduleID: scheduleID})
+}
+
 // DescribeTaskList returns information about the target tasklist, right now this API returns the
-// pollers which polled this tasklist in last few minutes and status of tasklist's ackManager
-// (readLevel, ackLevel, backlogCountHint and taskIDBlock).
+// pollers which polled this tasklist in last few minutes, their outstanding tasks and limits and
+// status of tasklist's ackManager (readLevel, ackLevel, backlogCountHint and taskIDBlock).
 func (c *taskListManagerImpl) DescribeTaskList(includeTaskListStatus bool) *matchingservice.DescribeTaskListResponse {
-	response := &matchingservice.DescribeTaskListResponse{Pollers: c.GetAllPollerInfo()}
+	limits := c.pollerLimits()
+	response := &matchingservice.DescribeTaskListResponse{
+		Pollers:     c.GetAllPollerInfo(),
+		PollerLoads: c.pollerHistory.getAllPollerLoad(time.Now()),
+		PollerLimits: &tasklistgenpb.PollerLimits{
+			MaxOutstandingTasksPerPoller:     int32(limits.maxTasksPerPoller),
+			MaxOutstandingTasksPerPollerHost: int32(limits.maxTasksPerPollerHost),
+			OutstandingTaskTTLSeconds:        int64(limits.outstandingTaskTTL.Seconds()),
+		},
+	}
 	if !includeTaskListStatus {
 		return response
 	}
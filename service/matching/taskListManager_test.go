@@ -207,6 +207,8 @@ func TestDescribeTaskList(t *testing.T) {
 	descResp := tlm.DescribeTaskList(includeTaskStatus)
 	require.Equal(t, 0, len(descResp.GetPollers()))
 	require.Nil(t, descResp.GetTaskListStatus())
+	require.Equal(t, int32(0), descResp.GetPollerLimits().GetMaxOutstandingTasksPerPoller())
+	require.Equal(t, int64(60), descResp.GetPollerLimits().GetOutstandingTaskTTLSeconds())
 
 	includeTaskStatus = true
 	taskListStatus := tlm.DescribeTaskList(includeTaskStatus).GetTaskListStatus()
@@ -285,3 +287,144 @@ func TestCheckIdleTaskList(t *testing.T) {
 	tlm.Stop()
 	require.Equal(t, int32(1), tlm.stopped)
 }
+
+func TestAffinityOwner(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	cfg := NewConfig(dynamicconfig.NewNopCollection())
+	cfg.AffinityOwnerTimeout = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(time.Minute)
+	tlm := createTestTaskListManagerWithConfig(controller, cfg)
+
+	// owner is unknown right after the task list is loaded
+	require.True(t, tlm.isAffinityOwnerAvailable())
+
+	_, err := tlm.acquireAffinityPoll("")
+	require.Equal(t, errAffinityIdentityNotSet, err)
+
+	release, err := tlm.acquireAffinityPoll("owner")
+	require.NoError(t, err)
+	_, err = tlm.acquireAffinityPoll("other")
+	require.Equal(t, errAffinityKeyOwned, err)
+	release()
+	require.True(t, tlm.isAffinityOwnerAvailable())
+
+	// owner has not polled within the timeout
+	tlm.affinityOwnerLastSeen = time.Now().Add(-2 * time.Minute)
+	require.False(t, tlm.isAffinityOwnerAvailable())
+	release, err = tlm.acquireAffinityPoll("other")
+	require.NoError(t, err)
+	require.Equal(t, pollerIdentity("other"), tlm.affinityOwner)
+	release()
+}
+
+func TestGetTaskReleasesPollerCapacity(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	cfg := NewConfig(dynamicconfig.NewNopCollection())
+	cfg.MaxOutstandingTasksPerPoller = dynamicconfig.GetIntPropertyFilteredByTaskListInfo(1)
+	tlm := createTestTaskListManagerWithConfig(controller, cfg)
+	require.NoError(t, tlm.Start())
+	defer tlm.Stop()
+
+	// concurrent polls of the poller share one slot, which each poll releases when it returns without a task
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), identityKey, "1@host0"), 50*time.Millisecond)
+			defer cancel()
+			_, err := tlm.GetTask(ctx, nil)
+			require.Equal(t, ErrNoTasks, err)
+		}()
+	}
+	wg.Wait()
+
+	tlm.pollerHistory.Lock()
+	defer tlm.pollerHistory.Unlock()
+	require.Empty(t, tlm.pollerHistory.identityReservations)
+	require.Empty(t, tlm.pollerHistory.identityTasks)
+}
+
+func TestReleaseInFlightTask(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	cfg := NewConfig(dynamicconfig.NewNopCollection())
+	cfg.MaxOutstandingTasksPerPoller = dynamicconfig.GetIntPropertyFilteredByTaskListInfo(1)
+	tlm := createTestTaskListManagerWithConfig(controller, cfg)
+	limits := tlm.pollerLimits()
+	now := time.Now()
+
+	require.True(t, tlm.pollerHistory.reserveTask("1@host0", limits, now))
+	slot := &pollerSlot{pollers: tlm.pollerHistory, identity: "1@host0", taskList: tlm.taskListID.name, ttl: time.Minute}
+	slot.start([]byte("run"), 5, time.Hour, now)
+	require.False(t, tlm.pollerHistory.reserveTask("1@host0", limits, now))
+
+	tlm.ReleaseInFlightTask([]byte("run"), 6)
+	require.False(t, tlm.pollerHistory.reserveTask("1@host0", limits, now))
+	tlm.ReleaseInFlightTask([]byte("run"), 5)
+	require.True(t, tlm.pollerHistory.reserveTask("1@host0", limits, now))
+}
+
+func TestHandoff(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	tlm := createTestTaskListManager(controller)
+	require.NoError(t, tlm.Start())
+
+	errCh := make(chan error, 1)
+	go func() {
+		ctx := context.WithValue(context.Background(), pollerIDKey, "poller")
+		_, err := tlm.GetTask(ctx, nil)
+		errCh <- err
+	}()
+	require.Eventually(t, func() bool {
+		tlm.outstandingPollsLock.Lock()
+		defer tlm.outstandingPollsLock.Unlock()
+		return len(tlm.outstandingPollsMap) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	tlm.taskAckManager.setAckLevel(5)
+	tlm.Handoff()
+	require.Equal(t, errTaskListHandedOff, <-errCh)
+	require.Equal(t, int32(1), tlm.stopped)
+	require.True(t, tlm.isHandedOff())
+
+	// ack level is persisted and no further writes are made with the released lease
+	tm := tlm.db.store.(*testTaskManager)
+	require.Equal(t, int64(5), tm.getTaskListManager(tlm.taskListID).ackLevel)
+	require.Equal(t, errTaskListLeaseReleased, tlm.db.UpdateState(6))
+	_, err := tlm.db.CreateTasks(nil)
+	require.Equal(t, errTaskListLeaseReleased, err)
+
+	// handoff is a no-op once the task list is handed off
+	tlm.Handoff()
+}
+
+func TestHandoffLeaseLost(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	tlm := createTestTaskListManager(controller)
+	require.NoError(t, tlm.Start())
+
+	// the new owner has already taken the lease
+	tm := tlm.db.store.(*testTaskManager)
+	_, err := tm.LeaseTaskList(&persistence.LeaseTaskListRequest{
+		NamespaceID: tlm.db.namespaceID,
+		TaskList:    tlm.db.taskListName,
+		TaskType:    tlm.db.taskType,
+	})
+	require.NoError(t, err)
+
+	tlm.taskAckManager.setAckLevel(5)
+	tlm.Handoff()
+	require.Equal(t, int32(1), tlm.stopped)
+	require.True(t, tlm.isHandedOff())
+	require.Equal(t, int64(0), tm.getTaskListManager(tlm.taskListID).ackLevel)
+	require.NoError(t, tlm.db.ReleaseLease(6))
+}
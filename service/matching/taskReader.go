@@ -158,7 +158,7 @@ getTasksPumpLoop:
 		case <-updateAckTimer.C:
 			{
 				err := tr.persistAckLevel()
-				if err != nil {
+				if err != nil && err != errTaskListLeaseReleased {
 					if _, ok := err.(*persistence.ConditionFailedError); ok {
 						// This indicates the task list may have moved to another host.
 						tr.tlMgr.Stop()
@@ -30,6 +30,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/persistence"
 )
 
@@ -42,9 +43,10 @@ type (
 	}
 	// qualifiedTaskListName refers to the fully qualified task list name
 	qualifiedTaskListName struct {
-		name      string // internal name of the tasks list
-		baseName  string // original name of the task list as specified by user
-		partition int    // partitionID of task list
+		name        string // internal name of the tasks list
+		baseName    string // original name of the task list as specified by user
+		partition   int    // partitionID of task list
+		affinityKey string // affinity key of an affinity task list
 	}
 )
 
@@ -68,6 +70,13 @@ const (
 // optimization to allow for partitioned task lists to dispatch tasks with low latency when
 // throughput is low - See https://github.com/temporalio/temporal/issues/2098
 //
+// Affinity task lists have an internal name of the form
+//
+//     /__temporal_affinity/[affinity-key]
+//
+// An affinity task list is never partitioned and is bound to the single
+// poller which owns the affinity key, see taskListManagerImpl.acquireAffinityPoll.
+//
 // Returns error if the given name is non-compliant with the required format
 // for task list names
 func newTaskListName(name string) (qualifiedTaskListName, error) {
@@ -86,6 +95,11 @@ func (tn *qualifiedTaskListName) IsRoot() bool {
 	return tn.partition == 0
 }
 
+// IsAffinity returns true if this task list is an affinity task list
+func (tn *qualifiedTaskListName) IsAffinity() bool {
+	return tn.affinityKey != ""
+}
+
 // GetRoot returns the root name for a task list
 func (tn *qualifiedTaskListName) GetRoot() string {
 	return tn.baseName
@@ -111,6 +125,14 @@ func (tn *qualifiedTaskListName) mkName(partition int) string {
 }
 
 func (tn *qualifiedTaskListName) init() error {
+	if strings.HasPrefix(tn.name, common.TaskListAffinityPrefix) {
+		tn.affinityKey = tn.name[len(common.TaskListAffinityPrefix):]
+		if tn.affinityKey == "" {
+			return fmt.Errorf("invalid affinity task list name %v", tn.name)
+		}
+		return nil
+	}
+
 	if !strings.HasPrefix(tn.name, taskListPartitionPrefix) {
 		return nil
 	}
@@ -47,6 +47,8 @@ func TestValidTaskListNames(t *testing.T) {
 		{"/__temporal_sys/list0/1", "list0", 1},
 		{"/__temporal_sys//list0//41", "/list0/", 41},
 		{"/__temporal_sys//__temporal_sys/sys/0/41", "/__temporal_sys/sys/0", 41},
+		{"/__temporal_affinity/host0", "/__temporal_affinity/host0", 0},
+		{"/__temporal_affinity/host0/1", "/__temporal_affinity/host0/1", 0},
 	}
 
 	for _, tc := range testCases {
@@ -62,6 +64,21 @@ func TestValidTaskListNames(t *testing.T) {
 	}
 }
 
+func TestAffinityTaskListNames(t *testing.T) {
+	tn, err := newTaskListName("/__temporal_affinity/host0")
+	require.NoError(t, err)
+	require.True(t, tn.IsAffinity())
+	require.True(t, tn.IsRoot())
+	require.Equal(t, "host0", tn.affinityKey)
+
+	tn, err = newTaskListName("list0")
+	require.NoError(t, err)
+	require.False(t, tn.IsAffinity())
+
+	_, err = newTaskListName("/__temporal_affinity/")
+	require.Error(t, err)
+}
+
 func TestTaskListParentName(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -157,6 +157,20 @@ func newAdminShardManagementCommands() []cli.Command {
 				AdminRemoveTask(c)
 			},
 		},
+		{
+			Name:    "describe-queues",
+			Aliases: []string{"dq"},
+			Usage:   "describe the levels and the failing tasks of the transfer, timer and replication queues of a shard",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardID,
+					Usage: "shardID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDescribeShardQueues(c)
+			},
+		},
 	}
 }
 
@@ -209,6 +223,43 @@ func newAdminHistoryHostCommands() []cli.Command {
 	}
 }
 
+func newAdminHostCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "profile",
+			Aliases: []string{"prof"},
+			Usage:   "Capture a profile of the process of a host and write it to a file readable with go tool pprof",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagProfileService,
+					Usage: "Service of the host. (Options: frontend, history, matching)",
+				},
+				cli.StringFlag{
+					Name:  FlagProfileHost,
+					Usage: "Host address(IP:PORT) as listed by admin cluster describe, required for history and matching hosts. A frontend profile is captured on the frontend host serving the request when not set",
+				},
+				cli.StringFlag{
+					Name:  FlagProfileType,
+					Value: "cpu",
+					Usage: "Profile type. (Options: cpu, heap, goroutine)",
+				},
+				cli.IntFlag{
+					Name:  FlagProfileDuration,
+					Value: 30,
+					Usage: "Duration of a cpu profile in seconds",
+				},
+				cli.StringFlag{
+					Name:  FlagOutputFilenameWithAlias,
+					Usage: "Profile file, <service>.<type>.<time>.pb.gz by default",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminCaptureProfile(c)
+			},
+		},
+	}
+}
+
 func newAdminNamespaceCommands() []cli.Command {
 	return []cli.Command{
 		{
@@ -255,6 +306,36 @@ func newAdminNamespaceCommands() []cli.Command {
 				AdminGetNamespaceIDOrName(c)
 			},
 		},
+		{
+			Name:    "describe_quotas",
+			Aliases: []string{"dq"},
+			Usage:   "Describe the RPS and visibility quotas of the namespace in the cluster",
+			Action: func(c *cli.Context) {
+				AdminDescribeNamespaceQuotas(c)
+			},
+		},
+		{
+			Name:    "update_quotas",
+			Aliases: []string{"uq"},
+			Usage:   "Update the RPS and visibility quotas of the namespace in the cluster, the quotas are not replicated",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagNamespaceRPS,
+					Usage: "RPS limit of the namespace per frontend host, 0 to use the dynamic config",
+				},
+				cli.IntFlag{
+					Name:  FlagNamespaceGlobalRPS,
+					Usage: "RPS limit of the namespace for the whole cluster, 0 to use the dynamic config",
+				},
+				cli.IntFlag{
+					Name:  FlagVisibilityListQPS,
+					Usage: "QPS limit of the namespace for listing workflows when visibility sampling is enabled, 0 to use the dynamic config",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminUpdateNamespaceQuotas(c)
+			},
+		},
 	}
 }
 
@@ -572,7 +653,7 @@ func newAdminTaskListCommands() []cli.Command {
 		{
 			Name:    "describe",
 			Aliases: []string{"desc"},
-			Usage:   "Describe pollers and status information of tasklist",
+			Usage:   "Describe pollers, status information and poller loads of tasklist",
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  FlagTaskListWithAlias,
@@ -624,6 +705,86 @@ func newAdminClusterCommands() []cli.Command {
 				AdminDescribeCluster(c)
 			},
 		},
+		{
+			Name:    "describe-log-levels",
+			Aliases: []string{"dll"},
+			Usage:   "Describe the log levels of frontend, history and matching hosts",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagLogService,
+					Usage: "Service of the hosts (Options: frontend, history, matching), all of them if not set",
+				},
+				cli.StringFlag{
+					Name:  FlagLogHost,
+					Usage: "Address of the host, all hosts of the service if not set",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDescribeLogLevels(c)
+			},
+		},
+		{
+			Name:    "diagnose",
+			Aliases: []string{"diag"},
+			Usage:   "Collect membership, shard distribution, recent errors, dynamic config, busiest task lists and optionally DLQ sizes of all hosts into a zip archive",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagOutputFilenameWithAlias,
+					Usage: "Archive file, temporal-diagnostics.<time>.zip by default",
+				},
+				cli.IntFlag{
+					Name:  FlagNumberOfShards,
+					Usage: "Number of history shards, taken from the history hosts by default",
+				},
+				cli.IntFlag{
+					Name:  FlagMaxTaskLists,
+					Value: defaultDiagnoseMaxTaskLists,
+					Usage: "Number of busiest task lists collected",
+				},
+				cli.BoolFlag{
+					Name:  FlagCountDLQ,
+					Usage: "Count the messages of the namespace DLQ and of the replication DLQs of every shard, which reads them",
+				},
+				cli.Int64Flag{
+					Name:  FlagMaxMessageCountWithAlias,
+					Value: defaultDiagnoseMaxDLQMessage,
+					Usage: "Maximum number of messages counted in each DLQ with --" + FlagCountDLQ,
+				},
+				cli.StringSliceFlag{
+					Name:  FlagSourceClusters,
+					Usage: "Source cluster of the replication DLQs counted with --" + FlagCountDLQ + ", can be repeated",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDiagnoseCluster(c)
+			},
+		},
+		{
+			Name:    "update-log-level",
+			Aliases: []string{"ull"},
+			Usage:   "Change the log level of a package on frontend, history and matching hosts until they restart",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagLogService,
+					Usage: "Service of the hosts (Options: frontend, history, matching), all of them if not set",
+				},
+				cli.StringFlag{
+					Name:  FlagLogHost,
+					Usage: "Address of the host, all hosts of the service if not set",
+				},
+				cli.StringFlag{
+					Name:  FlagLogPackage,
+					Usage: "Package whose log level changes along with its subpackages, e.g. service/matching, the default log level if not set",
+				},
+				cli.StringFlag{
+					Name:  FlagLogLevel,
+					Usage: "Log level, the level of the package is removed if not set. (Options: debug, info, warn, error, fatal)",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminUpdateLogLevel(c)
+			},
+		},
 	}
 }
 
@@ -77,6 +77,47 @@ func AdminDescribeCluster(c *cli.Context) {
 	prettyPrintJSONObject(response)
 }
 
+// AdminDescribeLogLevels is used to dump the log levels of frontend, history and matching hosts
+func AdminDescribeLogLevels(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.DescribeLogLevels(ctx, &adminservice.DescribeLogLevelsRequest{
+		Service:     c.String(FlagLogService),
+		HostAddress: c.String(FlagLogHost),
+	})
+	if err != nil {
+		ErrorAndExit("Operation DescribeLogLevels failed.", err)
+	}
+
+	prettyPrintJSONObject(response)
+}
+
+// AdminUpdateLogLevel is used to change the log level of a package on frontend, history and matching hosts
+func AdminUpdateLogLevel(c *cli.Context) {
+	packagePath := c.String(FlagLogPackage)
+	level := c.String(FlagLogLevel)
+	if packagePath == "" && level == "" {
+		ErrorAndExit("Either package or level is required.", nil)
+	}
+	adminClient := cFactory.AdminClient(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.UpdateLogLevel(ctx, &adminservice.UpdateLogLevelRequest{
+		Service:     c.String(FlagLogService),
+		HostAddress: c.String(FlagLogHost),
+		PackagePath: packagePath,
+		Level:       level,
+	})
+	if err != nil {
+		ErrorAndExit("Operation UpdateLogLevel failed.", err)
+	}
+
+	prettyPrintJSONObject(response)
+}
+
 func intValTypeToString(valType int) string {
 	switch valType {
 	case 0:
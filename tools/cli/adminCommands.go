@@ -28,15 +28,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"strconv"
 	"time"
 
 	"github.com/gocql/gocql"
+	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli"
 	eventpb "go.temporal.io/temporal-proto/event"
 	executionpb "go.temporal.io/temporal-proto/execution"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	historygenpb "github.com/temporalio/temporal/.gen/proto/history"
+	namespacegenpb "github.com/temporalio/temporal/.gen/proto/namespace"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/auth"
@@ -395,6 +399,96 @@ func AdminRemoveTask(c *cli.Context) {
 	}
 }
 
+// AdminDescribeShardQueues describes the queues of a shard
+func AdminDescribeShardQueues(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	sid := getRequiredIntOption(c, FlagShardID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.DescribeShardQueues(ctx, &adminservice.DescribeShardQueuesRequest{
+		ShardId: int32(sid),
+	})
+	if err != nil {
+		ErrorAndExit("Describe shard queues has failed", err)
+	}
+
+	fmt.Printf("Shard %v owned by %v\n", resp.GetShardId(), resp.GetHostAddress())
+	printQueueStates(resp.GetQueues())
+	for _, queue := range resp.GetQueues() {
+		if len(queue.GetFailingTasks()) == 0 {
+			continue
+		}
+		fmt.Printf("\nFailing tasks of %v queue of cluster %v:\n", queue.GetQueueType(), queue.GetClusterName())
+		printFailingTasks(queue.GetFailingTasks())
+	}
+}
+
+func printQueueStates(queues []*historygenpb.QueueState) {
+	now := time.Now()
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Queue", "Cluster", "Ack Level", "Read Level", "Max Read Level", "Lag", "Oldest Pending Age", "In Flight", "Failing"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue,
+		tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, queue := range queues {
+		var ackLevel, readLevel, maxReadLevel, lag string
+		if queue.GetQueueType() == "timer" {
+			ackLevel = convertTime(queue.GetAckLevel(), false)
+			readLevel = convertTime(queue.GetReadLevel(), false)
+			maxReadLevel = convertTime(queue.GetMaxReadLevel(), false)
+			lag = time.Duration(queue.GetMaxReadLevel() - queue.GetAckLevel()).String()
+		} else {
+			ackLevel = strconv.FormatInt(queue.GetAckLevel(), 10)
+			readLevel = strconv.FormatInt(queue.GetReadLevel(), 10)
+			maxReadLevel = strconv.FormatInt(queue.GetMaxReadLevel(), 10)
+			lag = strconv.FormatInt(queue.GetMaxReadLevel()-queue.GetAckLevel(), 10)
+		}
+		oldestPendingAge := "-"
+		if queue.GetOldestPendingTaskTimestamp() > 0 {
+			oldestPendingAge = now.Sub(time.Unix(0, queue.GetOldestPendingTaskTimestamp())).Round(time.Millisecond).String()
+		}
+		table.Append([]string{
+			queue.GetQueueType(),
+			queue.GetClusterName(),
+			ackLevel,
+			readLevel,
+			maxReadLevel,
+			lag,
+			oldestPendingAge,
+			strconv.Itoa(int(queue.GetInFlightTaskCount())),
+			strconv.Itoa(len(queue.GetFailingTasks())),
+		})
+	}
+	table.Render()
+}
+
+func printFailingTasks(failingTasks []*historygenpb.FailingTask) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"TaskId", "Type", "NamespaceId", "WorkflowId", "RunId", "Attempt", "Last Failure", "Last Error"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue,
+		tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, failingTask := range failingTasks {
+		table.Append([]string{
+			strconv.FormatInt(failingTask.GetTaskId(), 10),
+			strconv.Itoa(int(failingTask.GetTaskType())),
+			failingTask.GetNamespaceId(),
+			failingTask.GetWorkflowId(),
+			failingTask.GetRunId(),
+			strconv.Itoa(int(failingTask.GetAttempt())),
+			convertTime(failingTask.GetLastFailureTimestamp(), false),
+			failingTask.GetLastError(),
+		})
+	}
+	table.Render()
+}
+
 // AdminShardManagement describes history host
 func AdminShardManagement(c *cli.Context) {
 	adminClient := cFactory.AdminClient(c)
@@ -475,3 +569,59 @@ func AdminRefreshWorkflowTasks(c *cli.Context) {
 		fmt.Println("Refresh workflow task succeeded.")
 	}
 }
+
+// AdminDescribeNamespaceQuotas describes the quotas of a namespace in the cluster
+func AdminDescribeNamespaceQuotas(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.DescribeNamespaceQuotas(ctx, &adminservice.DescribeNamespaceQuotasRequest{
+		Namespace: namespace,
+	})
+	if err != nil {
+		ErrorAndExit("Operation DescribeNamespaceQuotas failed.", err)
+	}
+
+	prettyPrintJSONObject(response.GetQuotas())
+}
+
+// AdminUpdateNamespaceQuotas updates the quotas of a namespace in the cluster, quotas without a flag are kept
+func AdminUpdateNamespaceQuotas(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	describeResponse, err := adminClient.DescribeNamespaceQuotas(ctx, &adminservice.DescribeNamespaceQuotasRequest{
+		Namespace: namespace,
+	})
+	if err != nil {
+		ErrorAndExit("Operation DescribeNamespaceQuotas failed.", err)
+	}
+
+	quotas := describeResponse.GetQuotas()
+	if quotas == nil {
+		quotas = &namespacegenpb.NamespaceQuotas{}
+	}
+	if c.IsSet(FlagNamespaceRPS) {
+		quotas.Rps = int32(c.Int(FlagNamespaceRPS))
+	}
+	if c.IsSet(FlagNamespaceGlobalRPS) {
+		quotas.GlobalRps = int32(c.Int(FlagNamespaceGlobalRPS))
+	}
+	if c.IsSet(FlagVisibilityListQPS) {
+		quotas.VisibilityListQps = int32(c.Int(FlagVisibilityListQPS))
+	}
+
+	response, err := adminClient.UpdateNamespaceQuotas(ctx, &adminservice.UpdateNamespaceQuotasRequest{
+		Namespace: namespace,
+		Quotas:    quotas,
+	})
+	if err != nil {
+		ErrorAndExit("Operation UpdateNamespaceQuotas failed.", err)
+	}
+
+	prettyPrintJSONObject(response.GetQuotas())
+}
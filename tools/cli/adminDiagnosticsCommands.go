@@ -0,0 +1,385 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/urfave/cli"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/codec"
+)
+
+type (
+	// diagnosticsBundle is the archive written by admin cluster diagnose, collection goes on when
+	// a step fails and the failures are written to the archive along with everything collected
+	diagnosticsBundle struct {
+		archive  *zip.Writer
+		failures []diagnosticsFailure
+	}
+
+	diagnosticsFailure struct {
+		Step  string `json:"step"`
+		Error string `json:"error"`
+	}
+
+	shardDistribution struct {
+		NumberOfShards  int            `json:"numberOfShards"`
+		ShardsPerHost   map[string]int `json:"shardsPerHost"`
+		UnownedShards   []int32        `json:"unownedShards,omitempty"`
+		DuplicateShards []int32        `json:"duplicateShards,omitempty"`
+	}
+
+	hostTaskListLoad struct {
+		HostAddress      string  `json:"hostAddress"`
+		NamespaceID      string  `json:"namespaceId"`
+		Name             string  `json:"name"`
+		TaskListType     string  `json:"taskListType"`
+		PollerCount      int32   `json:"pollerCount"`
+		BacklogCountHint int64   `json:"backlogCountHint"`
+		ReadLevel        int64   `json:"readLevel"`
+		AckLevel         int64   `json:"ackLevel"`
+		RatePerSecond    float64 `json:"ratePerSecond"`
+	}
+
+	hostLogEntry struct {
+		HostAddress string          `json:"hostAddress"`
+		Time        time.Time       `json:"time"`
+		Service     string          `json:"service"`
+		Level       string          `json:"level"`
+		Message     string          `json:"message"`
+		Fields      json.RawMessage `json:"fields,omitempty"`
+	}
+
+	dlqSize struct {
+		Type          string `json:"type"`
+		SourceCluster string `json:"sourceCluster,omitempty"`
+		ShardID       int32  `json:"shardId,omitempty"`
+		Count         int64  `json:"count"`
+		// Truncated is true when counting stopped at the maximum message count
+		Truncated bool `json:"truncated,omitempty"`
+	}
+)
+
+const (
+	defaultDiagnoseMaxTaskLists  = 20
+	defaultDiagnoseMaxDLQMessage = 1000
+)
+
+// AdminDiagnoseCluster collects the state of every host of the cluster into a zip archive for incident reports
+func AdminDiagnoseCluster(c *cli.Context) {
+	filename := c.String(FlagOutputFilename)
+	if filename == "" {
+		filename = fmt.Sprintf("temporal-diagnostics.%v.zip", time.Now().UTC().Format("20060102T150405Z"))
+	}
+	file, err := os.Create(filename)
+	if err != nil {
+		ErrorAndExit("Failed to create the diagnostics archive.", err)
+	}
+	defer file.Close()
+
+	bundle := &diagnosticsBundle{archive: zip.NewWriter(file)}
+	hostCount := collectDiagnostics(c, bundle)
+	bundle.add("failures.json", bundle.failures)
+	if err := bundle.archive.Close(); err != nil {
+		ErrorAndExit("Failed to write the diagnostics archive.", err)
+	}
+
+	fmt.Printf("Diagnostics of %v hosts written to %v\n", hostCount, filename)
+	for _, failure := range bundle.failures {
+		fmt.Printf("Failed to collect %v: %v\n", failure.Step, failure.Error)
+	}
+}
+
+func collectDiagnostics(c *cli.Context, bundle *diagnosticsBundle) int {
+	adminClient := cFactory.AdminClient(c)
+	maxTaskLists := c.Int(FlagMaxTaskLists)
+
+	ctx, cancel := newContext(c)
+	cluster, err := adminClient.DescribeCluster(ctx, &adminservice.DescribeClusterRequest{})
+	cancel()
+	if err != nil {
+		// without the membership rings there is no host to collect from
+		ErrorAndExit("Operation DescribeCluster failed.", err)
+	}
+	bundle.add("cluster.json", cluster)
+
+	hosts := make(map[string][]string)
+	hostCount := 0
+	for _, ring := range cluster.GetMembershipInfo().GetRings() {
+		for _, member := range ring.GetMembers() {
+			hosts[ring.GetRole()] = append(hosts[ring.GetRole()], member.GetIdentity())
+			hostCount++
+		}
+	}
+
+	var recentErrors []hostLogEntry
+	var taskLists []hostTaskListLoad
+	collectHost := func(service string, hostAddress string) {
+		ctx, cancel := newContext(c)
+		defer cancel()
+		response, err := adminClient.GetHostDiagnostics(ctx, &adminservice.GetHostDiagnosticsRequest{
+			Service:      service,
+			HostAddress:  hostAddress,
+			MaxTaskLists: int32(maxTaskLists),
+		})
+		if err != nil {
+			bundle.fail(fmt.Sprintf("diagnostics of %v host %v", service, hostAddress), err)
+			return
+		}
+		if hostAddress == "" {
+			hostAddress = response.GetHostAddress()
+		}
+		dir := hostDir(service, hostAddress)
+		if len(response.GetDynamicConfig()) > 0 {
+			bundle.addRaw(dir+"/dynamicconfig.json", response.GetDynamicConfig())
+		}
+		response.DynamicConfig = nil
+		bundle.add(dir+"/diagnostics.json", response)
+
+		recentErrors = append(recentErrors, toHostLogEntries(hostAddress, response.GetRecentErrors())...)
+		taskLists = append(taskLists, toHostTaskListLoads(hostAddress, response)...)
+	}
+
+	// frontend diagnostics are only returned by the frontend host serving the request
+	collectHost(common.FrontendServiceName, "")
+
+	var historyHosts []*adminservice.DescribeHistoryHostResponse
+	for _, hostAddress := range hosts[common.HistoryServiceName] {
+		ctx, cancel := newContext(c)
+		response, err := adminClient.DescribeHistoryHost(ctx, &adminservice.DescribeHistoryHostRequest{
+			HostAddress: hostAddress,
+		})
+		cancel()
+		if err != nil {
+			bundle.fail(fmt.Sprintf("description of history host %v", hostAddress), err)
+		} else {
+			bundle.add(hostDir(common.HistoryServiceName, hostAddress)+"/describe.json", response)
+			if response.GetAddress() == "" {
+				response.Address = hostAddress
+			}
+			historyHosts = append(historyHosts, response)
+		}
+		collectHost(common.HistoryServiceName, hostAddress)
+	}
+	distribution := newShardDistribution(historyHosts, c.Int(FlagNumberOfShards))
+	bundle.add("shards.json", distribution)
+
+	for _, hostAddress := range hosts[common.MatchingServiceName] {
+		collectHost(common.MatchingServiceName, hostAddress)
+	}
+
+	sort.Slice(recentErrors, func(i, j int) bool {
+		return recentErrors[i].Time.Before(recentErrors[j].Time)
+	})
+	bundle.add("errors.json", recentErrors)
+
+	sort.SliceStable(taskLists, func(i, j int) bool {
+		return taskLists[i].BacklogCountHint > taskLists[j].BacklogCountHint
+	})
+	if len(taskLists) > maxTaskLists {
+		taskLists = taskLists[:maxTaskLists]
+	}
+	bundle.add("tasklists.json", taskLists)
+
+	// counting reads the DLQ messages of every shard, which loads the cluster, so it is only done on request
+	if c.Bool(FlagCountDLQ) {
+		bundle.add("dlq.json", collectDLQSizes(c, bundle, distribution.NumberOfShards))
+	}
+	return hostCount
+}
+
+// collectDLQSizes counts the messages of the namespace DLQ and of the replication DLQs of the
+// given source clusters, counting stops at the maximum message count of each DLQ
+func collectDLQSizes(c *cli.Context, bundle *diagnosticsBundle, numberOfShards int) []dlqSize {
+	adminClient := cFactory.AdminClient(c)
+	maxCount := c.Int64(FlagMaxMessageCount)
+
+	count := func(request *adminservice.ReadDLQMessagesRequest) (int64, bool, error) {
+		var total int64
+		for {
+			ctx, cancel := newContext(c)
+			response, err := adminClient.ReadDLQMessages(ctx, request)
+			cancel()
+			if err != nil {
+				return total, false, err
+			}
+			total += int64(len(response.GetReplicationTasks()))
+			if total >= maxCount {
+				return maxCount, len(response.GetNextPageToken()) > 0 || total > maxCount, nil
+			}
+			if len(response.GetNextPageToken()) == 0 {
+				return total, false, nil
+			}
+			request.NextPageToken = response.GetNextPageToken()
+		}
+	}
+
+	var sizes []dlqSize
+	total, truncated, err := count(&adminservice.ReadDLQMessagesRequest{
+		Type:            commongenpb.DLQType_Namespace,
+		MaximumPageSize: defaultPageSize,
+	})
+	if err != nil {
+		bundle.fail("size of the namespace DLQ", err)
+	} else {
+		sizes = append(sizes, dlqSize{Type: "namespace", Count: total, Truncated: truncated})
+	}
+
+	for _, sourceCluster := range c.StringSlice(FlagSourceClusters) {
+		for shardID := 0; shardID < numberOfShards; shardID++ {
+			total, truncated, err := count(&adminservice.ReadDLQMessagesRequest{
+				Type:            commongenpb.DLQType_Replication,
+				ShardId:         int32(shardID),
+				SourceCluster:   sourceCluster,
+				MaximumPageSize: defaultPageSize,
+			})
+			if err != nil {
+				bundle.fail(fmt.Sprintf("size of the replication DLQ of shard %v from %v", shardID, sourceCluster), err)
+				continue
+			}
+			// most shards have an empty DLQ, only the others are listed
+			if total > 0 {
+				sizes = append(sizes, dlqSize{
+					Type:          "history",
+					SourceCluster: sourceCluster,
+					ShardID:       int32(shardID),
+					Count:         total,
+					Truncated:     truncated,
+				})
+			}
+		}
+	}
+	return sizes
+}
+
+// newShardDistribution computes the shards owned by each history host, numberOfShards is
+// taken from the history hosts when not set
+func newShardDistribution(hosts []*adminservice.DescribeHistoryHostResponse, numberOfShards int) *shardDistribution {
+	distribution := &shardDistribution{
+		NumberOfShards: numberOfShards,
+		ShardsPerHost:  make(map[string]int),
+	}
+	owners := make(map[int32]int)
+	for _, host := range hosts {
+		if numberOfShards == 0 && int(host.GetNumberOfShards()) > distribution.NumberOfShards {
+			distribution.NumberOfShards = int(host.GetNumberOfShards())
+		}
+		distribution.ShardsPerHost[host.GetAddress()] = len(host.GetShardIds())
+		for _, shardID := range host.GetShardIds() {
+			owners[shardID]++
+		}
+	}
+	for shardID := int32(0); shardID < int32(distribution.NumberOfShards); shardID++ {
+		switch {
+		case owners[shardID] == 0:
+			distribution.UnownedShards = append(distribution.UnownedShards, shardID)
+		case owners[shardID] > 1:
+			distribution.DuplicateShards = append(distribution.DuplicateShards, shardID)
+		}
+	}
+	return distribution
+}
+
+func toHostLogEntries(hostAddress string, entries []*commongenpb.LogEntry) []hostLogEntry {
+	var hostEntries []hostLogEntry
+	for _, entry := range entries {
+		hostEntry := hostLogEntry{
+			HostAddress: hostAddress,
+			Time:        time.Unix(0, entry.GetTimestamp()).UTC(),
+			Service:     entry.GetService(),
+			Level:       entry.GetLevel(),
+			Message:     entry.GetMessage(),
+		}
+		if json.Valid([]byte(entry.GetFields())) {
+			hostEntry.Fields = json.RawMessage(entry.GetFields())
+		}
+		hostEntries = append(hostEntries, hostEntry)
+	}
+	return hostEntries
+}
+
+func toHostTaskListLoads(hostAddress string, response *adminservice.GetHostDiagnosticsResponse) []hostTaskListLoad {
+	var loads []hostTaskListLoad
+	for _, taskList := range response.GetTaskLists() {
+		loads = append(loads, hostTaskListLoad{
+			HostAddress:      hostAddress,
+			NamespaceID:      taskList.GetNamespaceId(),
+			Name:             taskList.GetName(),
+			TaskListType:     taskList.GetTaskListType().String(),
+			PollerCount:      taskList.GetPollerCount(),
+			BacklogCountHint: taskList.GetStatus().GetBacklogCountHint(),
+			ReadLevel:        taskList.GetStatus().GetReadLevel(),
+			AckLevel:         taskList.GetStatus().GetAckLevel(),
+			RatePerSecond:    taskList.GetStatus().GetRatePerSecond(),
+		})
+	}
+	return loads
+}
+
+// hostDir returns the directory of the files of a host in the archive
+func hostDir(service string, hostAddress string) string {
+	return service + "/" + strings.NewReplacer(":", "_", "/", "_").Replace(hostAddress)
+}
+
+// add writes o as indented JSON to the archive
+func (b *diagnosticsBundle) add(name string, o interface{}) {
+	var data []byte
+	var err error
+	if pb, ok := o.(proto.Message); ok {
+		data, err = codec.NewJSONPBIndentEncoder("  ").Encode(pb)
+	} else {
+		data, err = json.MarshalIndent(o, "", "  ")
+	}
+	if err != nil {
+		b.fail(name, err)
+		return
+	}
+	b.addRaw(name, data)
+}
+
+func (b *diagnosticsBundle) addRaw(name string, data []byte) {
+	writer, err := b.archive.Create(name)
+	if err == nil {
+		_, err = writer.Write(data)
+	}
+	if err != nil {
+		b.fail(name, err)
+	}
+}
+
+func (b *diagnosticsBundle) fail(step string, err error) {
+	b.failures = append(b.failures, diagnosticsFailure{Step: step, Error: err.Error()})
+}
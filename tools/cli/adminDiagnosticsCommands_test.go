@@ -0,0 +1,54 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/bmizerany/assert"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+)
+
+func TestAdminDiagnoseCluster_newShardDistribution(t *testing.T) {
+	hosts := []*adminservice.DescribeHistoryHostResponse{
+		{Address: "10.0.0.1:7234", NumberOfShards: 4, ShardIds: []int32{0, 1}},
+		{Address: "10.0.0.2:7234", NumberOfShards: 4, ShardIds: []int32{1}},
+	}
+
+	distribution := newShardDistribution(hosts, 0)
+	assert.Equal(t, 4, distribution.NumberOfShards)
+	assert.Equal(t, map[string]int{"10.0.0.1:7234": 2, "10.0.0.2:7234": 1}, distribution.ShardsPerHost)
+	assert.Equal(t, []int32{2, 3}, distribution.UnownedShards)
+	assert.Equal(t, []int32{1}, distribution.DuplicateShards)
+
+	distribution = newShardDistribution(hosts, 2)
+	assert.Equal(t, 2, distribution.NumberOfShards)
+	assert.Equal(t, 0, len(distribution.UnownedShards))
+}
+
+func TestAdminDiagnoseCluster_hostDir(t *testing.T) {
+	assert.Equal(t, "history/10.0.0.1_7234", hostDir("history", "10.0.0.1:7234"))
+}
@@ -0,0 +1,65 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+)
+
+// AdminCaptureProfile captures a profile of a host and writes it to a file
+func AdminCaptureProfile(c *cli.Context) {
+	service := getRequiredOption(c, FlagProfileService)
+	profileType := c.String(FlagProfileType)
+	duration := c.Int(FlagProfileDuration)
+	filename := c.String(FlagOutputFilename)
+	if filename == "" {
+		filename = fmt.Sprintf("%v.%v.%v.pb.gz", service, profileType, time.Now().UTC().Format("20060102T150405Z"))
+	}
+	adminClient := cFactory.AdminClient(c)
+
+	// the call lasts for the duration of the profile on top of the usual timeout
+	ctx, cancel := newContextWithTimeout(c, defaultContextTimeout+time.Duration(duration)*time.Second)
+	defer cancel()
+	response, err := adminClient.CaptureProfile(ctx, &adminservice.CaptureProfileRequest{
+		Service:           service,
+		HostAddress:       c.String(FlagProfileHost),
+		ProfileType:       profileType,
+		DurationInSeconds: int32(duration),
+	})
+	if err != nil {
+		ErrorAndExit("Operation CaptureProfile failed.", err)
+	}
+
+	if err := ioutil.WriteFile(filename, response.GetProfile(), 0644); err != nil {
+		ErrorAndExit("Failed to write the profile.", err)
+	}
+	fmt.Printf("Profile of host %v written to %v, read it with: go tool pprof %v\n", response.GetHostAddress(), filename, filename)
+}
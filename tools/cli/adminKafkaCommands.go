@@ -492,10 +492,10 @@ func doRereplicate(shardID int, namespaceID, wid, rid string, minID, maxID int64
 	}
 
 	histV2 := cassandra.NewHistoryV2PersistenceFromSession(session, loggerimpl.NewNopLogger())
-	historyV2Mgr := persistence.NewHistoryV2ManagerImpl(histV2, loggerimpl.NewNopLogger(), dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit))
+	historyV2Mgr := persistence.NewHistoryV2ManagerImpl(histV2, nil, loggerimpl.NewNopLogger(), dynamicconfig.GetIntPropertyFn(common.DefaultTransactionSizeLimit))
 
 	exeM, _ := cassandra.NewWorkflowExecutionPersistence(shardID, session, loggerimpl.NewNopLogger())
-	exeMgr := persistence.NewExecutionManagerImpl(exeM, loggerimpl.NewNopLogger())
+	exeMgr := persistence.NewExecutionManagerImpl(exeM, nil, loggerimpl.NewNopLogger())
 
 	for {
 		fmt.Printf("Start rereplicate for wid: %v, rid:%v \n", wid, rid)
@@ -528,7 +528,7 @@ func doRereplicate(shardID int, namespaceID, wid, rid string, minID, maxID int64
 			BranchToken:         exeInfo.BranchToken,
 		}
 
-		_, historyBatches, err := history.GetAllHistory(historyV2Mgr, nil, true,
+		_, historyBatches, err := history.GetAllHistory(historyV2Mgr, nil, true, namespaceID,
 			minID, maxID, exeInfo.BranchToken, convert.IntPtr(shardID))
 
 		if err != nil {
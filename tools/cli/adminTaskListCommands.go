@@ -33,12 +33,14 @@ import (
 	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
-	"go.temporal.io/temporal-proto/workflowservice"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist"
 )
 
 // AdminDescribeTaskList displays poller and status information of task list.
 func AdminDescribeTaskList(c *cli.Context) {
-	frontendClient := cFactory.FrontendClient(c)
+	adminClient := cFactory.AdminClient(c)
 	namespace := getRequiredGlobalOption(c, FlagNamespace)
 	taskList := getRequiredOption(c, FlagTaskList)
 	taskListType := tasklistpb.TaskListType_Decision
@@ -48,14 +50,14 @@ func AdminDescribeTaskList(c *cli.Context) {
 
 	ctx, cancel := newContext(c)
 	defer cancel()
-	request := &workflowservice.DescribeTaskListRequest{
+	request := &adminservice.DescribeTaskListRequest{
 		Namespace:             namespace,
 		TaskList:              &tasklistpb.TaskList{Name: taskList},
 		TaskListType:          taskListType,
 		IncludeTaskListStatus: true,
 	}
 
-	response, err := frontendClient.DescribeTaskList(ctx, request)
+	response, err := adminClient.DescribeTaskList(ctx, request)
 	if err != nil {
 		ErrorAndExit("Operation DescribeTaskList failed.", err)
 	}
@@ -72,6 +74,8 @@ func AdminDescribeTaskList(c *cli.Context) {
 		ErrorAndExit(colorMagenta("No poller for tasklist: "+taskList), nil)
 	}
 	printPollerInfo(pollers, taskListType)
+	fmt.Printf("\n")
+	printPollerLoads(response.GetPollerLoads(), response.GetPollerLimits())
 }
 
 func printTaskListStatus(taskListStatus *tasklistpb.TaskListStatus) {
@@ -107,3 +111,23 @@ func printPollerInfo(pollers []*tasklistpb.PollerInfo, taskListType tasklistpb.T
 	}
 	table.Render()
 }
+
+func printPollerLoads(loads []*tasklistgenpb.PollerLoadInfo, limits *tasklistgenpb.PollerLimits) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Poller Identity", "Host", "Outstanding Tasks", "Outstanding Host Tasks"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, load := range loads {
+		table.Append([]string{load.GetIdentity(),
+			load.GetHost(),
+			strconv.Itoa(int(load.GetOutstandingTasks())),
+			strconv.Itoa(int(load.GetOutstandingHostTasks()))})
+	}
+	table.Render()
+	fmt.Printf("Max outstanding tasks per poller: %v, per poller host: %v, outstanding task TTL: %vs\n",
+		limits.GetMaxOutstandingTasksPerPoller(),
+		limits.GetMaxOutstandingTasksPerPollerHost(),
+		limits.GetOutstandingTaskTTLSeconds())
+}
@@ -69,6 +69,36 @@ func NewCliApp() *cli.App {
 			Usage:  "automatically confirm all prompts",
 			Hidden: true,
 		},
+		cli.BoolFlag{
+			Name:   FlagTLS,
+			Usage:  "connect to frontend with TLS, implied by the other tls options",
+			EnvVar: "TEMPORAL_CLI_TLS",
+		},
+		cli.StringFlag{
+			Name:   FlagTLSCertPath,
+			Usage:  "path to the client certificate presented to frontend for mutual TLS",
+			EnvVar: "TEMPORAL_CLI_TLS_CERT",
+		},
+		cli.StringFlag{
+			Name:   FlagTLSKeyPath,
+			Usage:  "path to the private key of the client certificate",
+			EnvVar: "TEMPORAL_CLI_TLS_KEY",
+		},
+		cli.StringFlag{
+			Name:   FlagTLSCaPath,
+			Usage:  "path to the CA certificates verifying the frontend certificate, system CAs are used when not set",
+			EnvVar: "TEMPORAL_CLI_TLS_CA",
+		},
+		cli.StringFlag{
+			Name:   FlagTLSServerName,
+			Usage:  "override the host name used to verify the frontend certificate",
+			EnvVar: "TEMPORAL_CLI_TLS_SERVER_NAME",
+		},
+		cli.BoolFlag{
+			Name:   FlagTLSDisableHostVerification,
+			Usage:  "skip verification of the frontend certificate",
+			EnvVar: "TEMPORAL_CLI_TLS_DISABLE_HOST_VERIFICATION",
+		},
 	}
 	app.Commands = []cli.Command{
 		{
@@ -154,6 +184,12 @@ func NewCliApp() *cli.App {
 					Usage:       "Run admin operations on database",
 					Subcommands: newDBCommands(),
 				},
+				{
+					Name:        "host",
+					Aliases:     []string{"h"},
+					Usage:       "Run admin operation on a frontend, history or matching host",
+					Subcommands: newAdminHostCommands(),
+				},
 			},
 		},
 		{
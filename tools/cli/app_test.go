@@ -50,6 +50,7 @@ import (
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/.gen/proto/adminservicemock"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist"
 	"github.com/temporalio/temporal/common/payload"
 )
 
@@ -564,6 +565,27 @@ func (s *cliAppSuite) TestDescribeTaskList_Activity() {
 	s.sdkClient.AssertExpectations(s.T())
 }
 
+func (s *cliAppSuite) TestAdminDescribeTaskList() {
+	resp := &adminservice.DescribeTaskListResponse{
+		Pollers:        describeTaskListResponse.Pollers,
+		TaskListStatus: &tasklistpb.TaskListStatus{TaskIdBlock: &tasklistpb.TaskIdBlock{}},
+		PollerLoads: []*tasklistgenpb.PollerLoadInfo{
+			{
+				Identity:             "tester",
+				OutstandingTasks:     1,
+				OutstandingHostTasks: 1,
+			},
+		},
+		PollerLimits: &tasklistgenpb.PollerLimits{
+			MaxOutstandingTasksPerPoller: 1,
+			OutstandingTaskTTLSeconds:    60,
+		},
+	}
+	s.serverAdminClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).Return(resp, nil)
+	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "admin", "tasklist", "describe", "-tl", "test-taskList", "-tlt", "activity"})
+	s.Nil(err)
+}
+
 func (s *cliAppSuite) TestObserveWorkflow() {
 	s.sdkClient.On("GetWorkflowHistory", mock.Anything, "wid", "", mock.Anything, mock.Anything).Return(historyEventIterator()).Once()
 	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "workflow", "observe", "-w", "wid"})
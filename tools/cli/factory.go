@@ -30,8 +30,10 @@ import (
 	sdkclient "go.temporal.io/temporal/client"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/common/auth"
 	"github.com/temporalio/temporal/common/rpc"
 )
 
@@ -60,14 +62,14 @@ func NewClientFactory() ClientFactory {
 
 // FrontendClient builds a frontend client
 func (b *clientFactory) FrontendClient(c *cli.Context) workflowservice.WorkflowServiceClient {
-	connection := b.createGRPCConnection(c.GlobalString(FlagAddress))
+	connection := b.createGRPCConnection(c, c.GlobalString(FlagAddress))
 
 	return workflowservice.NewWorkflowServiceClient(connection)
 }
 
 // AdminClient builds an admin client (based on server side thrift interface)
 func (b *clientFactory) AdminClient(c *cli.Context) adminservice.AdminServiceClient {
-	connection := b.createGRPCConnection(c.GlobalString(FlagAddress))
+	connection := b.createGRPCConnection(c, c.GlobalString(FlagAddress))
 
 	return adminservice.NewAdminServiceClient(connection)
 }
@@ -80,8 +82,9 @@ func (b *clientFactory) SDKClient(c *cli.Context, namespace string) sdkclient.Cl
 	}
 
 	sdkClient, err := sdkclient.NewClient(sdkclient.Options{
-		HostPort:  hostPort,
-		Namespace: namespace,
+		HostPort:   hostPort,
+		Namespace:  namespace,
+		GRPCDialer: rpc.NewSDKGRPCDialer(b.createTransportCredentials(c)),
 	})
 	if err != nil {
 		b.logger.Fatal("Failed to create SDK client", zap.Error(err))
@@ -90,12 +93,12 @@ func (b *clientFactory) SDKClient(c *cli.Context, namespace string) sdkclient.Cl
 	return sdkClient
 }
 
-func (b *clientFactory) createGRPCConnection(hostPort string) *grpc.ClientConn {
+func (b *clientFactory) createGRPCConnection(c *cli.Context, hostPort string) *grpc.ClientConn {
 	if hostPort == "" {
 		hostPort = localHostPort
 	}
 
-	connection, err := rpc.Dial(hostPort)
+	connection, err := rpc.Dial(hostPort, b.createTransportCredentials(c))
 	if err != nil {
 		b.logger.Fatal("Failed to create connection", zap.Error(err))
 		return nil
@@ -103,3 +106,27 @@ func (b *clientFactory) createGRPCConnection(hostPort string) *grpc.ClientConn {
 
 	return connection
 }
+
+// createTransportCredentials returns the TLS credentials configured by the tls flags,
+// or nil for a plaintext connection
+func (b *clientFactory) createTransportCredentials(c *cli.Context) credentials.TransportCredentials {
+	config := &auth.ClientTLS{
+		CertFile:                c.GlobalString(FlagTLSCertPath),
+		KeyFile:                 c.GlobalString(FlagTLSKeyPath),
+		ServerName:              c.GlobalString(FlagTLSServerName),
+		DisableHostVerification: c.GlobalBool(FlagTLSDisableHostVerification),
+	}
+	if caPath := c.GlobalString(FlagTLSCaPath); caPath != "" {
+		config.RootCAFiles = []string{caPath}
+	}
+	if !c.GlobalBool(FlagTLS) && config.CertFile == "" && len(config.RootCAFiles) == 0 &&
+		config.ServerName == "" && !config.DisableHostVerification {
+		return nil
+	}
+
+	transportCredentials, err := rpc.NewClientCredentials(config)
+	if err != nil {
+		b.logger.Fatal("Failed to create TLS config", zap.Error(err))
+	}
+	return transportCredentials
+}
@@ -156,6 +156,9 @@ const (
 	FlagIsGlobalNamespaceWithAlias        = FlagIsGlobalNamespace + ", gd"
 	FlagNamespaceData                     = "namespace_data"
 	FlagNamespaceDataWithAlias            = FlagNamespaceData + ", dmd"
+	FlagNamespaceRPS                      = "namespace_rps"
+	FlagNamespaceGlobalRPS                = "namespace_global_rps"
+	FlagVisibilityListQPS                 = "visibility_list_qps"
 	FlagEventID                           = "event_id"
 	FlagEventIDWithAlias                  = FlagEventID + ", eid"
 	FlagActivityID                        = "activity_id"
@@ -223,6 +226,23 @@ const (
 	FlagUpperShardBound                   = "upper_shard_bound"
 	FlagInputDirectory                    = "input_directory"
 	FlagAutoConfirm                       = "auto_confirm"
+	FlagTLS                               = "tls"
+	FlagTLSCertPath                       = "tls_cert_path"
+	FlagTLSKeyPath                        = "tls_key_path"
+	FlagTLSCaPath                         = "tls_ca_path"
+	FlagTLSServerName                     = "tls_server_name"
+	FlagTLSDisableHostVerification        = "tls_disable_host_verification"
+	FlagLogService                        = "log_service"
+	FlagLogHost                           = "log_host"
+	FlagLogPackage                        = "log_package"
+	FlagLogLevel                          = "log_level"
+	FlagProfileService                    = "service"
+	FlagProfileHost                       = "host"
+	FlagProfileType                       = "type"
+	FlagProfileDuration                   = "duration"
+	FlagMaxTaskLists                      = "max_task_lists"
+	FlagCountDLQ                          = "count_dlq"
+	FlagSourceClusters                    = "source_cluster"
 )
 
 var flagsForExecution = []cli.Flag{